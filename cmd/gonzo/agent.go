@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/control-theory/gonzo/internal/agent"
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent host:port",
+	Short: "Forward enriched log entries to a central gonzo",
+	Long: `Run log ingestion and parsing without a terminal and forward the entries to
+a gonzo started with --aggregator-listen, which shows the entries of every
+agent in one TUI, tagged with the agent's name in the gonzo.agent attribute.
+
+Entries are queued while the aggregator is unreachable and sent once it is
+back. Use --tls-ca to verify the aggregator and --tls-cert and --tls-key to
+present a client certificate when it requires mutual TLS.`,
+	Example: `  # On each node, forward its Kubernetes logs
+  gonzo agent aggregator.example.com:7403 --k8s-enabled --name "$(hostname)"
+
+  # Forward a file over mutual TLS
+  gonzo agent aggregator.example.com:7403 -f /var/log/app.log --follow \
+    --tls-ca ca.pem --tls-cert agent.pem --tls-key agent-key.pem
+
+  # On the central machine, run the TUI on the entries of every agent
+  gonzo --aggregator-listen :7403 --aggregator-tls-cert server.pem \
+    --aggregator-tls-key server-key.pem --aggregator-client-ca ca.pem`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAgent,
+}
+
+// agentTLS returns the TLS config of the agent's connection, or nil for
+// plain text when no TLS flag is set
+func agentTLS() (*tls.Config, error) {
+	if cfg.AgentTLSCA == "" && cfg.AgentTLSCert == "" && cfg.AgentTLSKey == "" {
+		return nil, nil
+	}
+	return agent.ClientTLS(cfg.AgentTLSCA, cfg.AgentTLSCert, cfg.AgentTLSKey)
+}
+
+// runAgent runs the configured log inputs headless and forwards the entries
+// to an aggregator until the input ends or gonzo is interrupted
+func runAgent(cmd *cobra.Command, args []string) error {
+	// Agent status goes to stderr; there is no TUI to disturb
+	log.SetOutput(os.Stderr)
+	klog.SetOutput(io.Discard)
+	klog.LogToStderr(false)
+
+	tlsConfig, err := agentTLS()
+	if err != nil {
+		return err
+	}
+	if tlsConfig == nil {
+		log.Printf("Warning: forwarding to %s without TLS", args[0])
+	}
+	name := cfg.AgentName
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+
+	forwarder := agent.NewForwarder(args[0], name, tlsConfig)
+	if err := forwarder.Start(); err != nil {
+		return err
+	}
+	defer forwarder.Stop()
+	log.Printf("Forwarding entries to %s as %s", args[0], name)
+
+	configDir := os.Getenv("HOME") + "/.config/gonzo"
+	model := newProcessingModel(configDir)
+
+	// Entries leave the host, so they are redacted for export
+	sink := func(entry *tui.LogEntry) {
+		data, err := json.Marshal(redactEntry(model.exportRedactor, entry))
+		if err != nil {
+			log.Printf("Warning: could not encode log entry: %v", err)
+			return
+		}
+		forwarder.Send(data)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := model.runHeadless(ctx, cancel, sink, nil); err != nil {
+		return fmt.Errorf("agent input failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/control-theory/gonzo/internal/agent"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// agentAttribute names the agent an aggregated entry came from
+const agentAttribute = "gonzo.agent"
+
+// startAggregator accepts gonzo agents on --aggregator-listen. It reports
+// false when the listener could not be started.
+func (m *simpleTuiModel) startAggregator() bool {
+	var server *agent.Server
+	if cfg.AggregatorTLSCert != "" || cfg.AggregatorTLSKey != "" {
+		tlsConfig, err := agent.ServerTLS(cfg.AggregatorTLSCert, cfg.AggregatorTLSKey, cfg.AggregatorClientCA)
+		if err != nil {
+			log.Printf("Error starting aggregator: %v", err)
+			return false
+		}
+		server = agent.NewServer(tlsConfig)
+	} else {
+		if cfg.AggregatorClientCA != "" {
+			log.Printf("Error starting aggregator: --aggregator-client-ca needs --aggregator-tls-cert and --aggregator-tls-key")
+			return false
+		}
+		server = agent.NewServer(nil)
+	}
+	if err := server.Start(cfg.AggregatorListen); err != nil {
+		log.Printf("Error starting aggregator: %v", err)
+		return false
+	}
+	log.Printf("Accepting gonzo agents on %s", server.Addr())
+	m.aggregator = server
+	return true
+}
+
+// readAggregatorAsync passes the entries of the agents on, tagged with the
+// agent they came from
+func (m *simpleTuiModel) readAggregatorAsync() {
+	defer close(m.inputChan)
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			m.aggregator.Stop()
+			return
+		case received := <-m.aggregator.Entries():
+			var entry tui.LogEntry
+			if err := json.Unmarshal(received.Data, &entry); err != nil {
+				log.Printf("Warning: skipping invalid entry from agent %s: %v", received.Agent, err)
+				continue
+			}
+			if entry.Attributes == nil {
+				entry.Attributes = make(map[string]string)
+			}
+			entry.Attributes[agentAttribute] = received.Agent
+			entry.Seq = 0
+			data, err := json.Marshal(entry)
+			if err != nil {
+				continue
+			}
+			if !m.sendLine(sourceAgent, inputLine{text: string(data)}) {
+				m.aggregator.Stop()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/report"
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze [FILE...]",
+	Short: "Summarize log files without the TUI",
+	Long: `Read the given files or file globs, or stdin when none are given, to the end
+and print a summary of them: volumes, a timeline of entries and errors, the
+top and new patterns, the services and patterns with the most errors, and
+anomalies. Entries are counted at their own timestamps when they have one.
+
+--report selects the output: text (the default), markdown or json print the
+summary to stdout, and any other value is a file to write it to, as JSON if it
+ends in .json and as Markdown otherwise.
+
+With --max-errors, gonzo exits with an error status when the input has more
+error entries than that, to fail a CI job or alert from cron.`,
+	Example: `  # Summarize a log file
+  gonzo analyze app.log
+
+  # Summarize yesterday's logs as JSON for a script
+  gonzo analyze "/var/log/app-2026-10-13*.log" --report json | jq .error_rate
+
+  # Summarize piped logs into a file
+  kubectl logs deployment/checkout | gonzo analyze --report checkout.md
+
+  # Fail a CI job when the test run logged any errors
+  gonzo analyze test-output.log --max-errors 0`,
+	RunE: runAnalyze,
+}
+
+// Report outputs of gonzo analyze that print to stdout
+const (
+	analyzeText     = "text"
+	analyzeMarkdown = "markdown"
+	analyzeJSON     = "json"
+)
+
+// analyzeMaxErrors is the most error entries gonzo analyze accepts, -1 for
+// no limit
+var analyzeMaxErrors int64
+
+// runAnalyze reads the configured inputs to the end without a terminal and
+// prints or writes their summary
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	// Status goes to stderr, so stdout stays the report
+	log.SetOutput(os.Stderr)
+	klog.SetOutput(io.Discard)
+	klog.LogToStderr(false)
+
+	// The summary is written once the input ends, not by --report's collector
+	output := cfg.Report
+	switch strings.ToLower(output) {
+	case "", analyzeText:
+		output = analyzeText
+	case "md", analyzeMarkdown:
+		output = analyzeMarkdown
+	case analyzeJSON:
+		output = analyzeJSON
+	}
+	cfg.Report = ""
+	cfg.Files = append(cfg.Files, args...)
+	cfg.Follow = false
+
+	// Files are read far faster than they were written, so the timeline and
+	// spikes follow the logs' own timestamps
+	collector := report.New()
+	sink := func(entry *tui.LogEntry) {
+		at := entry.OrigTimestamp
+		if at.IsZero() {
+			at = entry.Timestamp
+		}
+		collector.Observe(tui.EntryRecord(*entry), tui.ServiceName(*entry), at)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	configDir := os.Getenv("HOME") + "/.config/gonzo"
+	model := newProcessingModel(configDir)
+	if err := model.runHeadless(ctx, cancel, sink, nil); err != nil {
+		return err
+	}
+
+	end := collector.Latest()
+	if end.IsZero() {
+		end = time.Now()
+	}
+	summary := collector.Summary(end)
+	if err := writeAnalysis(output, summary); err != nil {
+		return err
+	}
+	if analyzeMaxErrors >= 0 && summary.Errors > analyzeMaxErrors {
+		// Usage help would only bury the report
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d error entries, more than --max-errors %d", summary.Errors, analyzeMaxErrors)
+	}
+	return nil
+}
+
+// writeAnalysis prints the summary to stdout in the output format, or
+// writes it to the file output names
+func writeAnalysis(output string, summary report.Summary) error {
+	switch output {
+	case analyzeText:
+		_, err := fmt.Print(summary.Text())
+		return err
+	case analyzeMarkdown:
+		_, err := fmt.Print(summary.Markdown())
+		return err
+	case analyzeJSON:
+		data, err := summary.JSON()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return report.WriteFile(output, summary)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/capture"
+	"github.com/control-theory/gonzo/internal/filterexpr"
+	"github.com/control-theory/gonzo/internal/report"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// Query API limits
+const (
+	apiDefaultLimit = 100
+	apiTimeout      = 30 * time.Second
+)
+
+// queryAPI serves the buffered entries of gonzo serve, already redacted for
+// export, and the session's analytics over HTTP (--api-listen)
+type queryAPI struct {
+	server    *entryServer
+	analytics *report.Collector
+	http      *http.Server
+}
+
+// apiEntry is a buffered entry as returned by the query API
+type apiEntry struct {
+	Seq int64 `json:"seq"`
+	capture.Entry
+}
+
+// apiEntriesResponse is the body of /api/v1/entries
+type apiEntriesResponse struct {
+	Entries   []apiEntry `json:"entries"`
+	Matched   int        `json:"matched"`   // Buffered entries matching the query
+	Truncated bool       `json:"truncated"` // Matched exceeds the limit; the newest are returned
+	Buffered  int        `json:"buffered"`
+}
+
+// startQueryAPI serves the query API on addr
+func startQueryAPI(addr string, server *entryServer) (*queryAPI, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the query API on %s: %w", addr, err)
+	}
+
+	api := &queryAPI{server: server, analytics: report.New()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/entries", api.handleEntries)
+	mux.HandleFunc("GET /api/v1/analytics", api.handleAnalytics)
+	api.http = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: apiTimeout,
+		WriteTimeout:      apiTimeout,
+	}
+	go func() {
+		if err := api.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Warning: query API stopped: %v", err)
+		}
+	}()
+	log.Printf("Serving the query API on http://%s/api/v1/", listener.Addr())
+	return api, nil
+}
+
+// close stops serving the query API
+func (a *queryAPI) close() {
+	a.http.Close()
+}
+
+// observe counts an entry towards the analytics
+func (a *queryAPI) observe(entry *tui.LogEntry) {
+	a.analytics.Observe(tui.EntryRecord(*entry), tui.ServiceName(*entry), time.Now())
+}
+
+// parseAPITime reads a time parameter, either RFC 3339 or a duration back
+// from now such as 15m
+func parseAPITime(name, value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if ago, err := time.ParseDuration(value); err == nil {
+		return now.Add(-ago), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: expected an RFC 3339 time or a duration such as 15m", name, value)
+	}
+	return t, nil
+}
+
+// handleEntries returns the newest buffered entries matching a filter
+// expression and time range:
+//
+//	GET /api/v1/entries?filter=severity=ERROR&since=15m&until=2026-01-02T15:04:05Z&limit=100
+func (a *queryAPI) handleEntries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	now := time.Now()
+
+	var filter *filterexpr.Expr
+	if source := strings.TrimSpace(query.Get("filter")); source != "" {
+		expr, err := filterexpr.Parse(source)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid filter: %w", err))
+			return
+		}
+		filter = expr
+	}
+	since, err := parseAPITime("since", query.Get("since"), now)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	until, err := parseAPITime("until", query.Get("until"), now)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit := apiDefaultLimit
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q: expected a positive number", value))
+			return
+		}
+	}
+
+	buffered := a.server.snapshot()
+	var matched []tui.LogEntry
+	for _, entry := range buffered {
+		// Time ranges apply to the log's own timestamp when it has one
+		timestamp := entry.OrigTimestamp
+		if timestamp.IsZero() {
+			timestamp = entry.Timestamp
+		}
+		if !since.IsZero() && timestamp.Before(since) {
+			continue
+		}
+		if !until.IsZero() && timestamp.After(until) {
+			continue
+		}
+		if filter != nil && !filter.Match(tui.EntryRecord(entry)) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+
+	response := apiEntriesResponse{
+		Entries:   []apiEntry{},
+		Matched:   len(matched),
+		Truncated: len(matched) > limit,
+		Buffered:  len(buffered),
+	}
+	for _, entry := range matched[max(0, len(matched)-limit):] {
+		response.Entries = append(response.Entries, apiEntry{Seq: entry.Seq, Entry: newCaptureEntry(&entry, nil)})
+	}
+	writeAPIResponse(w, response)
+}
+
+// handleAnalytics returns the session summary: volumes, top and new
+// patterns, error leaders and spikes
+//
+//	GET /api/v1/analytics
+func (a *queryAPI) handleAnalytics(w http.ResponseWriter, r *http.Request) {
+	writeAPIResponse(w, a.analytics.Summary(time.Now()))
+}
+
+// writeAPIResponse writes a JSON response body
+func writeAPIResponse(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Warning: failed to write query API response: %v", err)
+	}
+}
+
+// writeAPIError writes an error as {"error": "..."}
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -3,27 +3,41 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/control-theory/gonzo/internal/agent"
+	"github.com/control-theory/gonzo/internal/ai"
 	"github.com/control-theory/gonzo/internal/analyzer"
+	"github.com/control-theory/gonzo/internal/backpressure"
+	"github.com/control-theory/gonzo/internal/capture"
+	"github.com/control-theory/gonzo/internal/execpipe"
 	"github.com/control-theory/gonzo/internal/filereader"
-	"github.com/control-theory/gonzo/internal/formats"
+	"github.com/control-theory/gonzo/internal/filterexpr"
 	"github.com/control-theory/gonzo/internal/k8s"
+	"github.com/control-theory/gonzo/internal/linelimit"
+	"github.com/control-theory/gonzo/internal/loki"
 	"github.com/control-theory/gonzo/internal/memory"
-	"github.com/control-theory/gonzo/internal/otlplog"
+	"github.com/control-theory/gonzo/internal/metrics"
+	"github.com/control-theory/gonzo/internal/notify"
+	"github.com/control-theory/gonzo/internal/otlpexport"
 	"github.com/control-theory/gonzo/internal/otlpreceiver"
+	"github.com/control-theory/gonzo/internal/redact"
+	"github.com/control-theory/gonzo/internal/report"
+	"github.com/control-theory/gonzo/internal/spill"
+	"github.com/control-theory/gonzo/internal/timerange"
 	"github.com/control-theory/gonzo/internal/tui"
 	versioncheck "github.com/control-theory/gonzo/internal/version"
 	"github.com/control-theory/gonzo/internal/vmlogs"
+	"github.com/control-theory/gonzo/internal/webui"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
-	"k8s.io/klog/v2"
 )
 
 // runApp initializes and runs the application
@@ -34,14 +48,9 @@ func runApp(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Redirect log output to discard to avoid messing up the TUI
-	// All log.Printf calls will be silently discarded
-	log.SetOutput(io.Discard)
-
-	// Suppress klog output from Kubernetes client-go
-	// This prevents errors like "request.go:752" from appearing in the TUI
-	klog.SetOutput(io.Discard)
-	klog.LogToStderr(false)
+	// Keep log output, including client-go's, off the screen
+	diagLog, closeDiagLog := startDiagLog()
+	defer closeDiagLog()
 
 	// Start version checking in background (if not disabled)
 	var versionChecker *versioncheck.Checker
@@ -58,75 +67,171 @@ func runApp(cmd *cobra.Command, args []string) error {
 		log.Printf("Warning: Failed to load skin '%s': %v (using default)", cfg.Skin, err)
 	}
 
-	// Initialize format detector and converter with custom format if specified
-	var formatDetector *otlplog.FormatDetector
-	var logConverter *otlplog.LogConverter
-	var customParser *formats.Parser
-
-	if cfg.Format != "" {
-		// Check if it's a built-in format
-		switch strings.ToLower(cfg.Format) {
-		case "otlp", "json", "text":
-			// Built-in format
-			formatDetector = otlplog.NewFormatDetectorWithFormat(cfg.Format)
-			logConverter = otlplog.NewLogConverter()
-		default:
-			// Try to load custom format
-			format, err := formats.LoadFormatByName(cfg.Format, configDir)
-			if err != nil {
-				log.Printf("Warning: Failed to load custom format '%s': %v (using auto-detect)", cfg.Format, err)
-				formatDetector = otlplog.NewFormatDetector()
-				logConverter = otlplog.NewLogConverter()
-			} else {
-				// Create parser for the custom format
-				customParser, err = formats.NewParser(format)
-				if err != nil {
-					log.Printf("Warning: Failed to create parser for format '%s': %v (using auto-detect)", cfg.Format, err)
-					formatDetector = otlplog.NewFormatDetector()
-					logConverter = otlplog.NewLogConverter()
-				} else {
-					formatDetector = otlplog.NewFormatDetectorWithFormat(cfg.Format)
-					logConverter = otlplog.NewLogConverterWithFormat(cfg.Format, customParser)
-					log.Printf("Using custom format: %s", cfg.Format)
-				}
-			}
-		}
-	} else {
-		// Auto-detect format
-		formatDetector = otlplog.NewFormatDetector()
-		logConverter = otlplog.NewLogConverter()
-	}
+	tuiModel := newProcessingModel(configDir)
 
-	textAnalyzer := analyzer.NewTextAnalyzerWithStopWords(cfg.StopWords)
-	otlpAnalyzer := analyzer.NewOTLPAnalyzer()
-	freqMemory := memory.NewFrequencyMemory(cfg.MemorySize)
+	// Plain output mode prints entries to stdout without the dashboard
+	if cfg.NoTUI || cfg.Output != "" {
+		return runPlain(tuiModel)
+	}
 
 	// Initialize TUI model with components
-	dashboard := tui.NewDashboardModel(cfg.LogBuffer, cfg.UpdateInterval, cfg.AIModel, textAnalyzer.GetStopWords(), cfg.ReverseScrollWheel, cfg.UseLogTime)
+	aiClient := ai.NewClient(ai.Config{
+		Provider:   cfg.AIProvider,
+		BaseURL:    cfg.AIBaseURL,
+		APIKey:     cfg.AIAPIKey,
+		Model:      cfg.AIModel,
+		APIVersion: cfg.AIAPIVersion,
+	})
+	dashboard := tui.NewDashboardModel(cfg.LogBuffer, cfg.UpdateInterval, aiClient, tuiModel.textAnalyzer.GetStopWords(), cfg.ReverseScrollWheel, cfg.UseLogTime)
 	if versionChecker != nil {
 		dashboard.SetVersionChecker(versionChecker)
 	}
 
-	tuiModel := &simpleTuiModel{
-		formatDetector: formatDetector,
-		logConverter:   logConverter,
-		customParser:   customParser,
-		textAnalyzer:   textAnalyzer,
-		otlpAnalyzer:   otlpAnalyzer,
-		freqMemory:     freqMemory,
-		dashboard:      dashboard,
-		updateInterval: cfg.UpdateInterval,
-		testMode:       cfg.TestMode,
-		versionChecker: versionChecker,
+	dashboard.SetDiagLog(diagLog)
+	dashboard.SetTimeDisplay(cfg.UTC, cfg.TimeFormat, cfg.DateTimeFormat)
+	dashboard.SetTimestampMode(cfg.TimestampMode)
+	dashboard.SetShowLineNumbers(cfg.LineNumbers)
+	dashboard.SetWordAnalyzer(tuiModel.textAnalyzer)
+	dashboard.SetExportRedactor(tuiModel.exportRedactor)
+	if err := dashboard.SetSourceBadges(cfg.SourceBadges, loadSourceBadges()); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	dashboard.SetChartsHeight(cfg.ChartsHeight)
+	if err := dashboard.SetHiddenPanels(cfg.HidePanels); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := dashboard.SetStatusLineTemplate(cfg.StatusLine); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	if err := dashboard.SetBufferBudget(int64(cfg.LogBufferMB)*1024*1024, cfg.LogBufferPolicy); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Keep the entries evicted from the buffer on disk for scrollback
+	if cfg.LogSpill {
+		store, err := spill.Open(cfg.LogSpillDir, int64(cfg.LogSpillMB)*1024*1024)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		} else {
+			defer store.Close()
+			dashboard.SetSpill(store)
+		}
+	}
+	if cfg.AIPromptFile != "" {
+		prompt, err := os.ReadFile(cfg.AIPromptFile)
+		if err != nil {
+			log.Printf("Warning: failed to read AI prompt file: %v", err)
+		} else {
+			dashboard.SetAIBatchPrompt(string(prompt))
+		}
+	}
+
+	// Show the guided tour on first run, or whenever it is asked for
+	if cfg.Tutorial || (!cfg.TestMode && markFirstRun(configDir)) {
+		dashboard.SetShowTutorial(true)
+	}
+
+	// Load user-defined highlight rules, skipping invalid ones
+	if len(cfg.Highlights) > 0 {
+		rules, errs := parseHighlightRules(cfg.Highlights)
+		for _, err := range errs {
+			log.Printf("Warning: %v", err)
+		}
+		dashboard.SetHighlightRules(rules)
+	}
+
+	// Load alert rules, skipping invalid ones
+	if len(cfg.Alerts) > 0 {
+		rules, errs := parseAlertRules(cfg.Alerts, cfg.AlertWebhook)
+		for _, err := range errs {
+			log.Printf("Warning: %v", err)
+		}
+		dashboard.SetAlertRules(rules)
+	}
+
+	// Load SLOs, skipping invalid ones
+	if len(cfg.SLOs) > 0 {
+		objectives, errs := parseObjectives(cfg.SLOs)
+		for _, err := range errs {
+			log.Printf("Warning: %v", err)
+		}
+		dashboard.SetObjectives(objectives)
+	}
+
+	// Count the watches in the watches pane; invalid ones are reported when
+	// the notifier starts
+	if len(cfg.Watches) > 0 {
+		watches, _ := parseWatches(cfg.Watches, cfg.NotifyWebhook)
+		dashboard.SetWatches(watches)
+	}
+
+	// The severity floor key (V) starts from the ingest floor
+	if tuiModel.minSeverity != "" {
+		dashboard.SetSeverityFloor(tuiModel.minSeverity)
+	}
+	if err := dashboard.SetFilterExpression(cfg.ViewFilter); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Pick up the view where the last session left it
+	if cfg.Resume {
+		session, err := loadSession(configDir)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		} else {
+			for _, err := range dashboard.RestoreSession(session) {
+				log.Printf("Warning: %v", err)
+			}
+		}
 	}
 
+	if tuiModel.metricSet != nil {
+		dashboard.SetMetricSet(tuiModel.metricSet)
+	}
+
+	dashboard.SetLatencyTracking(cfg.LatencyField, cfg.LatencyWindow)
+	dashboard.SetNewPatternWindow(cfg.NewPatternWindow)
+	dashboard.SetOutlierThreshold(cfg.OutlierThreshold)
+	dashboard.SetSessionKeys(cfg.SessionKeys)
+
+	// Load the URL templates opened from the selected entry, skipping invalid ones
+	if len(cfg.Links) > 0 {
+		var links []tui.LinkTemplate
+		for _, spec := range cfg.Links {
+			link, err := tui.ParseLinkTemplate(spec)
+			if err != nil {
+				log.Printf("Warning: %v", err)
+				continue
+			}
+			links = append(links, link)
+		}
+		dashboard.SetLinkTemplates(links)
+	}
+
+	tuiModel.dashboard = dashboard
+	tuiModel.updateInterval = cfg.UpdateInterval
+	tuiModel.batchSize = max(1, cfg.IngestBatchSize)
+	tuiModel.batchInterval = cfg.IngestBatchInterval
+	tuiModel.parserWorkers = parserWorkers()
+	refresh := refreshRate()
+	tuiModel.redraw = newRedrawLimiter(refresh)
+	logCPULimit(tuiModel.parserWorkers, refresh)
+	tuiModel.testMode = cfg.TestMode
+	tuiModel.versionChecker = versionChecker
+	tuiModel.configDir = configDir
+	tuiModel.liveConfig = cfg
+	tuiModel.summary = newSessionSummary()
+
+	// A panic restores the terminal and saves the buffer instead of losing it
+	guard := newCrashGuard(tuiModel)
+
 	var p *tea.Program
 	if cfg.TestMode {
 		// Test mode - no TTY requirements
-		p = tea.NewProgram(tuiModel, tea.WithInput(nil), tea.WithOutput(os.Stdout))
+		p = tea.NewProgram(guard, tea.WithInput(nil), tea.WithOutput(os.Stdout))
 	} else {
 		// Normal mode with manual screen management
-		p = tea.NewProgram(tuiModel, tea.WithAltScreen(), tea.WithMouseCellMotion())
+		p = tea.NewProgram(guard, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithReportFocus(), tea.WithFPS(max(60, refresh)))
 	}
 
 	// No manual cleanup needed - Bubble Tea handles it
@@ -136,19 +241,93 @@ func runApp(cmd *cobra.Command, args []string) error {
 	tuiModel.ctx = ctx
 	tuiModel.cancelFunc = cancel
 
-	if _, err := p.Run(); err != nil {
+	// Apply changes to the config file's live settings while running
+	if !cfg.TestMode {
+		watchConfig(ctx, p, cmd)
+	}
+
+	_, err := p.Run()
+	guard.reportCrash()
+	// SIGINT, or Ctrl+C without a terminal, closes the dashboard like q
+	if errors.Is(err, tea.ErrInterrupted) {
+		err = nil
+	}
+	// The demo leaves the last real session to --resume
+	if !cfg.TestMode && !cfg.Demo {
+		if err := saveSession(configDir, tuiModel.dashboard.Session()); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	// Stop the inputs before flushing the outputs they feed
+	tuiModel.stopSources()
+	tuiModel.stopOTLPExport()
+	tuiModel.stopLoki()
+	tuiModel.stopExecPipes()
+	tuiModel.stopNotifier()
+	tuiModel.stopReport()
+	tuiModel.stopCapture()
+	tuiModel.stopWebUI()
+	if err != nil {
 		if strings.Contains(err.Error(), "TTY") || strings.Contains(err.Error(), "/dev/tty") {
 			return fmt.Errorf("TUI requires a real terminal. Try --test-mode for non-interactive testing")
 		}
 		return fmt.Errorf("error running TUI: %w", err)
 	}
 
+	tuiModel.printSummary()
 	return nil
 }
 
+// tutorialMarker is created in the config directory once the guided tour
+// has been shown
+const tutorialMarker = ".tutorial-shown"
+
+// markFirstRun reports whether the dashboard has never been opened before,
+// recording that it now has
+func markFirstRun(configDir string) bool {
+	marker := filepath.Join(configDir, tutorialMarker)
+	if _, err := os.Stat(marker); err == nil {
+		return false
+	}
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		log.Printf("Warning: failed to create config directory: %v", err)
+		return false
+	}
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		log.Printf("Warning: failed to record first run: %v", err)
+		return false
+	}
+	return true
+}
+
+// newProcessingModel creates the parsing and analysis pipeline for the
+// configured log format, without a dashboard attached
+func newProcessingModel(configDir string) *simpleTuiModel {
+	textAnalyzer := loadTextAnalyzer()
+	redactor, exportRedactor := loadRedactors()
+	otlpAnalyzer := analyzer.NewOTLPAnalyzer()
+	freqMemory := memory.NewFrequencyMemory(cfg.MemorySize)
+
+	return &simpleTuiModel{
+		format:         loadLineFormat(cfg.Format, configDir),
+		sourceFormats:  loadSourceFormats(configDir),
+		textAnalyzer:   textAnalyzer,
+		otlpAnalyzer:   otlpAnalyzer,
+		freqMemory:     freqMemory,
+		metricSet:      loadMetricSet(),
+		timeRange:      loadTimeRange(),
+		minSeverity:    loadSeverityFloor(),
+		redactor:       redactor,
+		exportRedactor: exportRedactor,
+		sources:        newSourceStats(),
+		unparsed:       &quarantine{},
+	}
+}
+
 // Message types for bubbletea
 type (
-	logLineMsg  string
+	logBatchMsg []inputLine
 	snapshotMsg *memory.FrequencySnapshot
 	finishedMsg struct{}
 	tickMsg     struct {
@@ -159,19 +338,81 @@ type (
 
 // simpleTuiModel is the main TUI model that handles everything internally
 type simpleTuiModel struct {
-	formatDetector *otlplog.FormatDetector
-	logConverter   *otlplog.LogConverter
-	customParser   *formats.Parser
+	format         *lineFormat            // Of the inputs without a --source-format
+	sourceFormats  map[string]*lineFormat // By the SOURCE of --source-format
 	textAnalyzer   *analyzer.TextAnalyzer
 	otlpAnalyzer   *analyzer.OTLPAnalyzer
 	freqMemory     *memory.FrequencyMemory
 	dashboard      *tui.DashboardModel
 	updateInterval time.Duration
+	batchSize      int            // Most input lines per dashboard update
+	batchInterval  time.Duration  // Longest a line waits for its batch
+	parserWorkers  int            // Goroutines parsing a batch's lines
+	redraw         *redrawLimiter // Caps dashboard redraws (--refresh)
 	testMode       bool
 	ctx            context.Context
 	cancelFunc     context.CancelFunc
 	versionChecker *versioncheck.Checker
 
+	// The config file's live settings as last applied, and where skins are
+	// found, for reloading the file while running
+	liveConfig Config
+	configDir  string
+
+	// Receives processed entries instead of the dashboard in plain output mode
+	entrySink func(*tui.LogEntry)
+
+	// Entries of the batch being processed, handed to the dashboard together
+	pendingEntries []*tui.LogEntry
+
+	// Metric rules and the Prometheus exporter (--metrics-listen)
+	metricSet *metrics.Set
+	exporter  *metrics.Exporter
+
+	// Entries outside --since and --until, or below --min-severity, are
+	// dropped at ingest
+	timeRange   timerange.Range
+	minSeverity string
+
+	// Redaction of ingested entries (--redact, --redact-attr), and of
+	// exported and forwarded ones, which adds --export-redact and
+	// --export-deny-attr
+	redactor       *redact.Redactor
+	exportRedactor *redact.Redactor
+
+	// Forwards logs and metric rules to an OTLP endpoint (--otlp-export-endpoint)
+	otlpExporter *otlpexport.Exporter
+
+	// Entries matching lokiFilter are pushed to Loki (--loki-url)
+	lokiPusher *loki.Pusher
+	lokiFilter *filterexpr.Expr
+
+	// Commands entries are piped into (--exec)
+	execPipes []*execpipe.Pipe
+
+	// Posts the matches of --watch expressions to webhooks
+	notifier *notify.Notifier
+
+	// Session summary written on exit and every interval (--report)
+	report     *report.Collector
+	reportDone chan struct{}
+
+	// Every ingested entry, appended to --capture-file
+	capture *capture.Writer
+
+	// Read-only browser view of the session (--web-listen)
+	web    *webui.Server
+	webSeq int64
+
+	// What each input source delivered, for the sources modal, and the
+	// lines that failed to parse, for the unparsed view
+	sources  *sourceStats
+	unparsed *quarantine
+
+	// Counts for the summary printed once the dashboard closes, nil when
+	// running headless
+	summary *sessionSummary
+
 	// Internal state
 	finished       bool
 	logCount       int
@@ -181,9 +422,10 @@ type simpleTuiModel struct {
 	hasStdinData   bool                // Whether stdin has data available
 
 	// File reading support
-	fileReader   *filereader.FileReader // File reader for file input mode
-	inputChan    chan string            // Unified input channel (from stdin or files)
-	hasFileInput bool                   // Whether we're reading from files
+	fileReader   *filereader.FileReader         // File reader for file input mode
+	inputChan    chan inputLine                 // Unified input channel (from stdin or files)
+	inputQueue   *backpressure.Queue[inputLine] // Sends on inputChan under --backpressure
+	hasFileInput bool                           // Whether we're reading from files
 
 	// OTLP receiver support
 	otlpReceiver *otlpreceiver.Receiver // OTLP receiver for network input
@@ -196,6 +438,20 @@ type simpleTuiModel struct {
 	// Kubernetes receiver support
 	k8sReceiver *k8s.KubernetesLogSource // Kubernetes log source for streaming pod logs
 	hasK8sInput bool                     // Whether we're receiving Kubernetes logs
+	rollouts    chan k8s.Rollout         // Deployment rollouts to mark, nil when not watched
+
+	// Entries streamed from a gonzo server when attached
+	hasAttachInput bool
+
+	// Entries read back from a capture file by gonzo replay
+	hasReplayInput bool
+
+	// Entries forwarded by gonzo agents (--aggregator-listen)
+	aggregator         *agent.Server
+	hasAggregatorInput bool
+
+	// Synthetic logs of made-up services (--demo)
+	hasDemoInput bool
 
 	// JSON accumulation for multi-line OTLP support
 	jsonBuffer   strings.Builder // Buffer for accumulating multi-line JSON
@@ -211,20 +467,100 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 	// Initialize frequency reset timer
 	m.lastFreqReset = time.Now()
 
+	// Start reading from the configured log input
+	m.startInputSources()
+	if m.fileReader != nil {
+		m.dashboard.SetOlderEntries(m.readOlderEntries)
+	}
+	m.dashboard.SetDroppedCounter(m.droppedLines)
+	m.dashboard.SetDroppedBySource(m.droppedBySource)
+	m.dashboard.SetQueueDepths(m.queueDepths)
+	m.addSources()
+	m.dashboard.SetSourceStats(m.sourceStatistics)
+	m.dashboard.SetUnparsed(m.unparsed.snapshot)
+	m.startMetricsServer()
+	m.startPprof()
+	m.startOTLPExport()
+	m.startLoki()
+	m.startExecPipes()
+	m.startNotifier()
+	m.startReport()
+	m.startCapture()
+	m.startWebUI()
+
+	// Start the dashboard
+	dashboardCmd := m.dashboard.Init()
+
+	var cmds []tea.Cmd
+	cmds = append(cmds, dashboardCmd)
+	cmds = append(cmds, m.periodicUpdate())
+
+	// Start checking for input data if we have any input source
+	if m.hasInput() {
+		cmds = append(cmds, m.checkInputChannel())
+	}
+	if m.rollouts != nil {
+		cmds = append(cmds, m.waitForRollout())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// startInputSources starts the first configured log input: a gonzo server
+// when attached, gonzo agents when aggregating, the demo, Kubernetes,
+// Victoria Logs, OTLP, files, or piped stdin as the fallback
+func (m *simpleTuiModel) startInputSources() {
+	// An attached TUI shows a gonzo server's entries instead of local inputs
+	if attachConn != nil {
+		m.hasAttachInput = true
+		m.openInput()
+		go m.readAttachAsync()
+		return
+	}
+
+	// A replay shows the entries of a capture file instead of local inputs
+	if replayPath != "" {
+		reader, err := capture.Open(replayPath)
+		if err != nil {
+			log.Printf("Error: %v", err)
+			return
+		}
+		m.hasReplayInput = true
+		m.openInput()
+		go m.readReplayAsync(reader)
+		return
+	}
+
+	// An aggregator shows the entries forwarded by gonzo agents
+	if cfg.AggregatorListen != "" && m.startAggregator() {
+		m.hasAggregatorInput = true
+		m.openInput()
+		go m.readAggregatorAsync()
+		return
+	}
+
+	// The demo shows synthetic logs instead of local inputs
+	if cfg.Demo {
+		m.startDemo()
+		return
+	}
+
 	// Check if Kubernetes receiver is enabled
 	if cfg.K8sEnabled {
 		// Kubernetes input mode
 		m.hasK8sInput = true
-		m.inputChan = make(chan string, 100)
+		m.openInput()
 
 		// Create kubernetes config
 		k8sConfig := &k8s.Config{
-			Kubeconfig: cfg.K8sKubeconfig,
-			Context:    cfg.K8sContext,
-			Namespaces: cfg.K8sNamespaces,
-			Selector:   cfg.K8sSelector,
-			Since:      cfg.K8sSince,
-			TailLines:  cfg.K8sTailLines,
+			Kubeconfig:  cfg.K8sKubeconfig,
+			Context:     cfg.K8sContext,
+			Namespaces:  cfg.K8sNamespaces,
+			Selector:    cfg.K8sSelector,
+			Since:       cfg.K8sSince,
+			SinceTime:   m.timeRange.Since,
+			TailLines:   cfg.K8sTailLines,
+			MaxLineSize: maxLineSize(),
 		}
 
 		// Create and start Kubernetes log source
@@ -241,7 +577,10 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 				m.hasK8sInput = false
 			} else {
 				// Wire K8s source to the dashboard for namespace/pod listing
-				m.dashboard.SetK8sSource(k8sSource)
+				if m.dashboard != nil {
+					m.dashboard.SetK8sSource(k8sSource)
+				}
+				m.startRolloutMarkers(k8sSource)
 				// Start reading from Kubernetes receiver in the background
 				go m.readK8sAsync()
 			}
@@ -252,12 +591,16 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 	if !m.hasK8sInput && cfg.VmlogsURL != "" {
 		// Victoria Logs input mode
 		m.hasVmlogsInput = true
-		m.inputChan = make(chan string, 100)
+		m.openInput()
 
 		// Create and start Victoria Logs receiver
 		params := make(map[string]string)
-		// Add any default parameters here if needed
+		// Replay the history back to --since before tailing
+		if !m.timeRange.Since.IsZero() {
+			params["start_offset"] = fmt.Sprintf("%ds", int64(time.Since(m.timeRange.Since).Seconds())+1)
+		}
 		m.vmlogsReceiver = vmlogs.NewReceiver(cfg.VmlogsURL, cfg.VmlogsUser, cfg.VmlogsPassword, cfg.VmlogsQuery, params)
+		m.vmlogsReceiver.SetMaxLineSize(maxLineSize())
 		if err := m.vmlogsReceiver.Start(); err != nil {
 			log.Printf("Error starting Victoria Logs receiver: %v", err)
 			// Fall back to other input methods if Victoria Logs fails
@@ -272,11 +615,15 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 	if !m.hasK8sInput && !m.hasVmlogsInput && cfg.OTLPEnabled {
 		// OTLP input mode
 		m.hasOTLPInput = true
-		m.inputChan = make(chan string, 100)
+		m.openInput()
 
 		// Create and start OTLP receiver
 		m.otlpReceiver = otlpreceiver.NewReceiver(cfg.OTLPGRPCPort, cfg.OTLPHTTPPort)
-		if err := m.otlpReceiver.Start(); err != nil {
+		err := configureOTLPReceiver(m.otlpReceiver)
+		if err == nil {
+			err = m.otlpReceiver.Start()
+		}
+		if err != nil {
 			log.Printf("Error starting OTLP receiver: %v", err)
 			// Fall back to other input methods if OTLP fails
 			m.hasOTLPInput = false
@@ -290,7 +637,7 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 	if !m.hasK8sInput && !m.hasVmlogsInput && !m.hasOTLPInput && len(cfg.Files) > 0 {
 		// File input mode
 		m.hasFileInput = true
-		m.inputChan = make(chan string, 100)
+		m.openInput()
 
 		// Create file reader
 		var err error
@@ -300,6 +647,12 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 			// Fall back to stdin if file reading fails
 			m.hasFileInput = false
 		} else {
+			if !m.timeRange.IsZero() {
+				m.fileReader.SetTimeRange(m.timeRange)
+			}
+			m.loadCheckpoints()
+			m.setFileTail()
+			m.fileReader.SetMaxLineSize(maxLineSize())
 			// Start file reading in the background
 			go m.readFilesAsync()
 		}
@@ -312,26 +665,58 @@ func (m *simpleTuiModel) Init() tea.Cmd {
 		if (stat.Mode() & os.ModeCharDevice) == 0 {
 			// stdin is a pipe or file, we have data
 			m.hasStdinData = true
-			m.inputChan = make(chan string, 100)
+			m.openInput()
 
 			// Start goroutine to read stdin without blocking
 			go m.readStdinAsync()
 		}
 	}
+}
 
-	// Start the dashboard
-	dashboardCmd := m.dashboard.Init()
-
-	var cmds []tea.Cmd
-	cmds = append(cmds, dashboardCmd)
-	cmds = append(cmds, m.periodicUpdate())
+// hasInput reports whether any log input source is active
+func (m *simpleTuiModel) hasInput() bool {
+	return m.hasStdinData || m.hasFileInput || m.hasOTLPInput || m.hasVmlogsInput || m.hasK8sInput || m.hasAttachInput || m.hasReplayInput || m.hasAggregatorInput || m.hasDemoInput
+}
 
-	// Start checking for input data if we have any input source
-	if m.hasStdinData || m.hasFileInput || m.hasOTLPInput || m.hasVmlogsInput || m.hasK8sInput {
-		cmds = append(cmds, m.checkInputChannel())
+// runHeadless processes the configured log input without a dashboard,
+// passing each entry to sink and calling idle whenever the input is drained.
+// It returns when the input ends or ctx is cancelled.
+func (m *simpleTuiModel) runHeadless(ctx context.Context, cancel context.CancelFunc, sink func(*tui.LogEntry), idle func()) error {
+	m.ctx = ctx
+	m.cancelFunc = cancel
+	m.severityCounts = &tui.SeverityCounts{}
+	m.entrySink = sink
+
+	m.startInputSources()
+	m.startMetricsServer()
+	m.startPprof()
+	m.startOTLPExport()
+	m.startLoki()
+	m.startExecPipes()
+	m.startNotifier()
+	m.startReport()
+	m.startCapture()
+	m.startWebUI()
+	defer m.stopFileReader()
+	defer m.stopOTLPExport()
+	defer m.stopLoki()
+	defer m.stopExecPipes()
+	defer m.stopNotifier()
+	defer m.stopReport()
+	defer m.stopCapture()
+	defer m.stopWebUI()
+	if !m.hasInput() {
+		return fmt.Errorf("no log input: pipe logs to stdin or use --file, --otlp-enabled, --vmlogs-url or --k8s-enabled")
 	}
 
-	return tea.Batch(cmds...)
+	for line := range m.inputChan {
+		m.processInputLine(line)
+		// Notify whenever the input is idle so followed sources stay live
+		if len(m.inputChan) == 0 && idle != nil {
+			idle()
+		}
+	}
+	return nil
 }
 
 // readK8sAsync reads from the Kubernetes log source
@@ -356,12 +741,8 @@ func (m *simpleTuiModel) readK8sAsync() {
 				// Kubernetes receiver finished
 				return
 			}
-			if line != "" {
-				select {
-				case m.inputChan <- line:
-				case <-m.ctx.Done():
-					return
-				}
+			if line != "" && !m.sendLine(sourceK8s, inputLine{text: line}) {
+				return
 			}
 		}
 	}
@@ -389,12 +770,8 @@ func (m *simpleTuiModel) readVmlogsAsync() {
 				// Victoria Logs receiver finished
 				return
 			}
-			if line != "" {
-				select {
-				case m.inputChan <- line:
-				case <-m.ctx.Done():
-					return
-				}
+			if line != "" && !m.sendLine(sourceVmlogs, inputLine{text: line}) {
+				return
 			}
 		}
 	}
@@ -422,12 +799,8 @@ func (m *simpleTuiModel) readOTLPAsync() {
 				// OTLP receiver finished
 				return
 			}
-			if line != "" {
-				select {
-				case m.inputChan <- line:
-				case <-m.ctx.Done():
-					return
-				}
+			if line != "" && !m.sendLine(sourceOTLP, inputLine{text: line}) {
+				return
 			}
 		}
 	}
@@ -443,6 +816,7 @@ func (m *simpleTuiModel) readFilesAsync() {
 
 	// Start the file reader and get the channel
 	fileLineChan := m.fileReader.Start()
+	merged := len(m.fileReader.GetFilePaths()) > 1
 
 	// Forward lines from file reader to input channel
 	for {
@@ -455,12 +829,13 @@ func (m *simpleTuiModel) readFilesAsync() {
 				// File reader finished
 				return
 			}
-			if line != "" {
-				select {
-				case m.inputChan <- line:
-				case <-m.ctx.Done():
-					return
-				}
+			// Entries of merged files are told apart by their file
+			input := inputLine{text: line.Text}
+			if merged {
+				input.file = line.Path
+			}
+			if line.Text != "" && !m.sendLine(sourceFile, input) {
+				return
 			}
 		}
 	}
@@ -472,10 +847,10 @@ func (m *simpleTuiModel) readStdinAsync() {
 
 	scanner := bufio.NewScanner(os.Stdin)
 
-	// Set larger buffer size (1MB) to handle long OTLP JSON lines
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	// Cut lines longer than --max-line-size rather than stop at them
+	lineLimit := maxLineSize()
+	linelimit.Buffer(scanner, lineLimit)
+	scanner.Split(linelimit.Split(lineLimit))
 
 	// Channel to receive scan results
 	scanChan := make(chan bool, 1)
@@ -493,56 +868,93 @@ func (m *simpleTuiModel) readStdinAsync() {
 		case hasLine := <-scanChan:
 			if !hasLine {
 				// EOF or error - exit gracefully
-				break
+				return
 			}
 
 			line := scanner.Text()
-			if line != "" {
-				select {
-				case m.inputChan <- line:
-				case <-m.ctx.Done():
-					return
-				}
+			if line != "" && !m.sendLine(sourceStdin, inputLine{text: line}) {
+				return
 			}
 		}
 	}
 }
 
-// checkInputChannel checks for data from the unified input channel
+// checkInputChannel collects lines from the unified input channel into a
+// batch, delivered once it holds batchSize lines or batchInterval has passed
+// since its first line, so a busy input costs one update per batch
 func (m *simpleTuiModel) checkInputChannel() tea.Cmd {
 	return func() tea.Msg {
-		select {
-		case line, ok := <-m.inputChan:
-			if !ok {
-				// Channel closed, input is done
-				return finishedMsg{}
+		var batch []inputLine
+		for len(batch) == 0 {
+			select {
+			case line, ok := <-m.inputChan:
+				if !ok {
+					// Channel closed, input is done
+					return finishedMsg{}
+				}
+				if line.text != "" {
+					batch = append(batch, line)
+				}
+			case <-time.After(50 * time.Millisecond):
+				// No data available right now, check again soon
+				// This timeout ensures the UI remains responsive
 			}
-			if line != "" {
-				return logLineMsg(line)
+		}
+
+		deadline := time.NewTimer(m.batchInterval)
+		defer deadline.Stop()
+		for len(batch) < m.batchSize {
+			select {
+			case line, ok := <-m.inputChan:
+				if !ok {
+					// Deliver what was read; the next check finds the end
+					return logBatchMsg(batch)
+				}
+				if line.text != "" {
+					batch = append(batch, line)
+				}
+			case <-deadline.C:
+				return logBatchMsg(batch)
 			}
-			// Empty line, continue checking
-			return m.checkInputChannel()()
-		case <-time.After(50 * time.Millisecond):
-			// No data available right now, check again soon
-			// This timeout ensures the UI remains responsive
-			return m.checkInputChannel()()
 		}
+		return logBatchMsg(batch)
+	}
+}
+
+// intervalUpdate sends the dashboard the snapshot and counts of the update
+// interval that ended, and starts counting the next
+func (m *simpleTuiModel) intervalUpdate() tea.Cmd {
+	// Send periodic snapshot with current count (even if 0)
+	snapshot := m.freqMemory.GetSnapshot()
+
+	// No automatic reset - only manual reset via 'r' key now
+	shouldReset := false
+
+	updateMsg := tui.UpdateMsg{
+		Snapshot:         snapshot,
+		SeverityCount:    m.severityCounts,
+		LineCount:        m.logCount,  // Keep for backward compatibility
+		ForceCountUpdate: true,        // Always update count history, even with 0
+		ResetDrain3:      shouldReset, // Reset drain3 when frequency memory resets
 	}
+
+	// Reset severity counts for next interval
+	m.severityCounts = &tui.SeverityCounts{}
+	m.logCount = 0
+	newDashboard, cmd := m.dashboard.Update(updateMsg)
+	m.dashboard = newDashboard.(*tui.DashboardModel)
+	return cmd
 }
 
 // Update handles messages and updates the model
 func (m *simpleTuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	m.redraw.observe(msg)
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Check for quit keys and cancel context
-		if msg.String() == "q" || msg.String() == "ctrl+c" {
-			if m.cancelFunc != nil {
-				m.cancelFunc()
-			}
-		}
-		// Always forward to dashboard first - let it decide whether to quit
+		// Forward to dashboard - let it decide whether to quit. The inputs
+		// are stopped once the program has ended.
 		newDashboard, cmd := m.dashboard.Update(msg)
 		m.dashboard = newDashboard.(*tui.DashboardModel)
 		cmds = append(cmds, cmd)
@@ -577,11 +989,21 @@ func (m *simpleTuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.dashboard = newDashboard.(*tui.DashboardModel)
 		cmds = append(cmds, cmd)
 
-	case logLineMsg:
-		m.processLogLine(string(msg))
+	case redrawMsg:
+		// The frame held back by the redraw limit is drawn now
+
+	case configReloadMsg:
+		m.applyConfigReload(msg)
+
+	case rolloutMsg:
+		m.dashboard.AddMarker("deploy "+k8s.Rollout(msg).String(), msg.Time)
+		cmds = append(cmds, m.waitForRollout())
+
+	case logBatchMsg:
+		m.processBatch(msg)
 
 		// Continue checking for more data if we have input sources
-		if (m.hasStdinData || m.hasFileInput || m.hasOTLPInput || m.hasVmlogsInput || m.hasK8sInput) && !m.finished {
+		if m.hasInput() && !m.finished {
 			cmds = append(cmds, m.checkInputChannel())
 		}
 
@@ -623,29 +1045,7 @@ func (m *simpleTuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil // Ignore this tick
 		}
 
-		// Send periodic snapshot with current count (even if 0)
-		snapshot := m.freqMemory.GetSnapshot()
-
-		// No automatic reset - only manual reset via 'r' key now
-		shouldReset := false
-
-		updateMsg := tui.UpdateMsg{
-			Snapshot:         snapshot,
-			SeverityCount:    m.severityCounts,
-			LineCount:        m.logCount,  // Keep for backward compatibility
-			ForceCountUpdate: true,        // Always update count history, even with 0
-			ResetDrain3:      shouldReset, // Reset drain3 when frequency memory resets
-		}
-
-		// Reset severity counts for next interval
-		m.severityCounts = &tui.SeverityCounts{}
-		m.logCount = 0
-		newDashboard, cmd := m.dashboard.Update(updateMsg)
-		m.dashboard = newDashboard.(*tui.DashboardModel)
-		cmds = append(cmds, cmd)
-
-		// Always reset log count for counts chart tracking
-		m.logCount = 0
+		cmds = append(cmds, m.intervalUpdate())
 
 		// Always schedule next update to keep dashboard refreshing
 		cmds = append(cmds, m.periodicUpdate())
@@ -657,6 +1057,7 @@ func (m *simpleTuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	cmds = append(cmds, m.redraw.schedule())
 	return m, tea.Batch(cmds...)
 }
 
@@ -679,7 +1080,13 @@ func (m *simpleTuiModel) View() string {
 		return result
 	}
 
-	return m.dashboard.View()
+	// Between frames the last one stays on screen
+	if frame, held := m.redraw.held(); held {
+		return frame
+	}
+	frame := m.dashboard.View()
+	m.redraw.drawn(frame)
+	return frame
 }
 
 // periodicUpdate schedules periodic updates to the dashboard
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+// attachDialTimeout bounds how long attach waits for the server
+const attachDialTimeout = 5 * time.Second
+
+// attachConn is the connection to a gonzo server when the TUI is attached
+var attachConn net.Conn
+
+var attachCmd = &cobra.Command{
+	Use:   "attach host:port",
+	Short: "Open the TUI on the log stream of a running gonzo serve",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := net.DialTimeout("tcp", args[0], attachDialTimeout)
+		if err != nil {
+			return fmt.Errorf("could not connect to gonzo server at %s: %w", args[0], err)
+		}
+		defer conn.Close()
+
+		attachConn = conn
+		return runApp(cmd, nil)
+	},
+}
+
+// readAttachAsync reads JSON entries streamed by a gonzo server
+func (m *simpleTuiModel) readAttachAsync() {
+	defer close(m.inputChan)
+
+	// Closing the connection unblocks the scanner on quit
+	go func() {
+		<-m.ctx.Done()
+		attachConn.Close()
+	}()
+
+	scanner := bufio.NewScanner(attachConn)
+	const maxEntrySize = 4 * 1024 * 1024 // 4MB
+	scanner.Buffer(make([]byte, 64*1024), maxEntrySize)
+
+	for scanner.Scan() {
+		if !m.sendLine(sourceAttach, inputLine{text: scanner.Text()}) {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil && m.ctx.Err() == nil {
+		log.Printf("Connection to gonzo server lost: %v", err)
+	}
+}
+
+// processRemoteEntry adds an entry received from a gonzo server. The server
+// has already parsed and enriched it, so only the frequency analysis runs here.
+func (m *simpleTuiModel) processRemoteEntry(line string) {
+	var entry tui.LogEntry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		log.Printf("Warning: skipping invalid entry from server: %v", err)
+		return
+	}
+	m.logCount++
+	entry.Message, entry.RawLine = m.redactor.Text(entry.Message), m.redactor.Text(entry.RawLine)
+	m.processSingleLogEntry(m.textAnalyzer.AnalyzeLine(entry.Message), entry.Attributes, &entry)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log"
+
+	"github.com/control-theory/gonzo/internal/backpressure"
+)
+
+// openInput creates the unified input channel and the queue the sources
+// send on it through, under the --backpressure policy
+func (m *simpleTuiModel) openInput() {
+	m.inputChan = make(chan inputLine, inputChannelSize())
+	queue, err := backpressure.New(m.inputChan, cfg.Backpressure)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		queue, _ = backpressure.New(m.inputChan, backpressure.Block)
+	}
+	m.inputQueue = queue
+}
+
+// sendLine queues a line from source under the backpressure policy,
+// reporting false when gonzo is shutting down
+func (m *simpleTuiModel) sendLine(source string, line inputLine) bool {
+	line.source = source
+	return m.inputQueue.Send(m.ctx, source, line)
+}
+
+// droppedBySource returns the lines each source dropped: those the
+// backpressure policy discarded and the records the OTLP receiver could not
+// queue
+func (m *simpleTuiModel) droppedBySource() map[string]int64 {
+	counts := make(map[string]int64)
+	if m.inputQueue != nil {
+		counts = m.inputQueue.DroppedBySource()
+	}
+	if m.otlpReceiver != nil {
+		if dropped := m.otlpReceiver.Dropped(); dropped > 0 {
+			counts[sourceOTLP] += dropped
+		}
+	}
+	return counts
+}
+
+// droppedLines returns how many lines the sources dropped in total
+func (m *simpleTuiModel) droppedLines() int64 {
+	var total int64
+	if m.inputQueue != nil {
+		total = m.inputQueue.Dropped()
+	}
+	if m.otlpReceiver != nil {
+		total += m.otlpReceiver.Dropped()
+	}
+	return total
+}
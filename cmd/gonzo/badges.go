@@ -0,0 +1,21 @@
+package main
+
+import (
+	"log"
+
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// loadSourceBadges parses the --source-badge labels, skipping invalid ones
+func loadSourceBadges() map[string]tui.SourceBadge {
+	badges := make(map[string]tui.SourceBadge)
+	for _, spec := range cfg.SourceBadgeLabels {
+		source, badge, err := tui.ParseSourceBadge(spec)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		badges[source] = badge
+	}
+	return badges
+}
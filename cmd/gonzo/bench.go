@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/synth"
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+// sourceBench labels the lines gonzo bench generates
+const sourceBench = "bench"
+
+// benchLatencySamples is how many line latencies gonzo bench keeps for the
+// percentiles, sampled evenly from all of them
+const benchLatencySamples = 100000
+
+// Traffic gonzo bench generates
+var (
+	benchRate        int
+	benchDuration    time.Duration
+	benchFormats     []string
+	benchServices    int
+	benchPatterns    int
+	benchCardinality int
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure the ingest pipeline under synthetic log traffic",
+	Long: `Generate log lines of made-up services at --rate lines per second for
+--duration and feed them through the pipeline the dashboard ingests with:
+the input queue under --backpressure, the --parser-workers, the ingest
+batches and the --log-buffer. Nothing is drawn, so the numbers are what the
+hardware sustains before rendering.
+
+The lines take the --formats in turn, with --services services, --patterns
+message patterns and --cardinality distinct user_id values. The root flags
+apply, so buffer and worker settings can be compared run by run:
+
+  throughput   lines and entries ingested per second
+  latency      from when a line was due to when its batch was processed;
+               it grows when the pipeline falls behind --rate
+  dropped      lines the backpressure policy discarded
+  buffer       entries held and their estimated memory, with the heap`,
+	Example: `  # Check the pipeline keeps up with 50,000 lines a second
+  gonzo bench --rate 50000
+
+  # Find the most a mix of formats can take, as fast as possible
+  gonzo bench --rate 0 --formats json,logfmt,text,otlp --duration 30s
+
+  # Size a buffer of a million entries with high-cardinality attributes
+  gonzo bench --log-buffer 1000000 --cardinality 100000 --duration 1m
+
+  # Compare dropping lines to blocking the source
+  gonzo bench --rate 200000 --backpressure drop-oldest`,
+	Args: cobra.NoArgs,
+	RunE: runBench,
+}
+
+// benchRun is what gonzo bench measured
+type benchRun struct {
+	generated  int64
+	processed  int64
+	started    time.Time
+	generating time.Duration   // Until the last line was sent
+	elapsed    time.Duration   // Until the last line was processed
+	latencies  []time.Duration // Sampled, see benchLatencySamples
+	seen       int64           // Latencies measured
+	maxDelay   time.Duration
+	sampler    *rand.Rand
+}
+
+// observe records the latency of a processed line, keeping a uniform
+// sample of them
+func (r *benchRun) observe(latency time.Duration) {
+	r.seen++
+	r.maxDelay = max(r.maxDelay, latency)
+	if len(r.latencies) < benchLatencySamples {
+		r.latencies = append(r.latencies, latency)
+	} else if i := r.sampler.Int64N(r.seen); i < benchLatencySamples {
+		r.latencies[i] = latency
+	}
+}
+
+// percentile returns the latency below which a fraction q of the sampled
+// ones are, once sorted
+func (r *benchRun) percentile(q float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	return r.latencies[min(len(r.latencies)-1, int(q*float64(len(r.latencies))))]
+}
+
+// runBench generates the traffic, runs it through the ingest pipeline and
+// prints what it measured
+func runBench(cmd *cobra.Command, args []string) error {
+	// Status goes to stderr, so stdout stays the results
+	log.SetOutput(os.Stderr)
+	klog.SetOutput(io.Discard)
+	klog.LogToStderr(false)
+
+	gen, err := synth.New(synth.Config{
+		Formats:     benchFormats,
+		Services:    benchServices,
+		Patterns:    benchPatterns,
+		Cardinality: benchCardinality,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid --formats: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// The model is set up as runApp sets it up, without the inputs and the
+	// outputs
+	configDir := os.Getenv("HOME") + "/.config/gonzo"
+	m := newProcessingModel(configDir)
+	m.ctx = ctx
+	m.cancelFunc = cancel
+	m.severityCounts = &tui.SeverityCounts{}
+	m.summary = newSessionSummary()
+	m.batchSize = max(1, cfg.IngestBatchSize)
+	m.batchInterval = cfg.IngestBatchInterval
+	m.parserWorkers = parserWorkers()
+	m.dashboard = tui.NewDashboardModel(cfg.LogBuffer, cfg.UpdateInterval, nil, m.textAnalyzer.GetStopWords(), false, cfg.UseLogTime)
+	if err := m.dashboard.SetBufferBudget(int64(cfg.LogBufferMB)*1024*1024, cfg.LogBufferPolicy); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	m.openInput()
+
+	rate := "as fast as possible"
+	if benchRate > 0 {
+		rate = fmt.Sprintf("%d lines/s", benchRate)
+	}
+	log.Printf("Generating %s for %s...", rate, benchDuration)
+
+	run := &benchRun{started: time.Now(), sampler: rand.New(rand.NewPCG(1, 2))}
+	go m.generateBench(gen, run)
+	m.consumeBench(run)
+	run.elapsed = time.Since(run.started)
+
+	fmt.Print(m.benchResults(run))
+	return nil
+}
+
+// generateBench sends generated lines into the input queue at --rate for
+// --duration, then closes the input. Each line is stamped with when it was
+// due, so a pipeline falling behind shows in the latency.
+func (m *simpleTuiModel) generateBench(gen *synth.Generator, run *benchRun) {
+	defer close(m.inputChan)
+	defer func() { run.generating = time.Since(run.started) }()
+
+	interval := time.Duration(0)
+	if benchRate > 0 {
+		interval = time.Second / time.Duration(benchRate)
+	}
+	deadline := run.started.Add(benchDuration)
+	var seq uint64
+	for m.ctx.Err() == nil {
+		now := time.Now()
+		if now.After(deadline) {
+			return
+		}
+
+		// Without a rate, lines are sent as fast as the queue takes them
+		due := seq + 1
+		if interval > 0 {
+			due = uint64(now.Sub(run.started) / interval)
+		}
+		for ; seq < due; seq++ {
+			at := now
+			if interval > 0 {
+				at = run.started.Add(time.Duration(seq) * interval)
+			}
+			if !m.sendLine(sourceBench, inputLine{text: gen.Line(seq, at), sent: at}) {
+				return
+			}
+			run.generated++
+		}
+		if interval > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+// consumeBench processes the input in batches as the dashboard does, with
+// an interval update every --update-interval, until the input closes
+func (m *simpleTuiModel) consumeBench(run *benchRun) {
+	next := m.checkInputChannel()
+	lastUpdate := time.Now()
+	for {
+		batch, ok := next().(logBatchMsg)
+		if !ok {
+			m.intervalUpdate()
+			return
+		}
+		m.processBatch(batch)
+
+		done := time.Now()
+		run.processed += int64(len(batch))
+		for _, line := range batch {
+			run.observe(done.Sub(line.sent))
+		}
+		if done.Sub(lastUpdate) >= cfg.UpdateInterval {
+			m.intervalUpdate()
+			lastUpdate = done
+		}
+	}
+}
+
+// roundLatency rounds a latency to the precision worth printing
+func roundLatency(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond)
+}
+
+// benchResults renders what gonzo bench measured
+func (m *simpleTuiModel) benchResults(run *benchRun) string {
+	seconds := max(run.elapsed.Seconds(), 0.001)
+	target := "as fast as possible"
+	if benchRate > 0 {
+		target = fmt.Sprintf("target %d/s", benchRate)
+	}
+	formats := benchFormats
+	if len(formats) == 0 {
+		formats = []string{synth.FormatJSON}
+	}
+
+	slices.Sort(run.latencies)
+	entries, bufferBytes := m.dashboard.BufferUsage()
+	runtime.GC()
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Generated   %s in %s (%.0f/s, %s), formats %s\n",
+		plural(run.generated, "line", "lines"), run.generating.Round(time.Millisecond), float64(run.generated)/max(run.generating.Seconds(), 0.001), target, strings.Join(formats, ","))
+	fmt.Fprintf(&b, "Throughput  %.0f lines/s, %.0f entries/s (%s, %s in %s)\n",
+		float64(run.processed)/seconds, float64(m.summary.entries)/seconds, plural(run.processed, "line", "lines"), plural(m.summary.entries, "entry", "entries"), run.elapsed.Round(time.Millisecond))
+	fmt.Fprintf(&b, "Latency     p50 %s, p90 %s, p99 %s, max %s\n",
+		roundLatency(run.percentile(0.5)), roundLatency(run.percentile(0.9)), roundLatency(run.percentile(0.99)), roundLatency(run.maxDelay))
+	fmt.Fprintf(&b, "Dropped     %s (%s backpressure)\n", plural(m.droppedLines(), "line", "lines"), m.inputQueue.Policy())
+	fmt.Fprintf(&b, "Buffer      %d of %d entries, %.1f MB estimated; heap %.1f MB after %d GC cycles\n",
+		entries, cfg.LogBuffer, float64(bufferBytes)/(1<<20), float64(mem.HeapAlloc)/(1<<20), mem.NumGC)
+	fmt.Fprintf(&b, "Pipeline    %s, batches of up to %d lines or %s, queue of %d lines, %s\n",
+		plural(int64(m.parserWorkers), "parser worker", "parser workers"), m.batchSize, m.batchInterval, cap(m.inputChan), plural(int64(cpus()), "CPU", "CPUs"))
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"os"
+
+	"github.com/control-theory/gonzo/internal/capture"
+	"github.com/control-theory/gonzo/internal/redact"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// startCapture opens --capture-file, if set
+func (m *simpleTuiModel) startCapture() {
+	if cfg.CaptureFile == "" {
+		return
+	}
+	writer, err := capture.NewWriter(capture.Config{
+		Path:    cfg.CaptureFile,
+		MaxSize: int64(cfg.CaptureMaxSize) * 1024 * 1024,
+		MaxAge:  cfg.CaptureMaxAge,
+	})
+	if err != nil {
+		log.Printf("Warning: capture disabled: %v", err)
+		return
+	}
+	m.capture = writer
+}
+
+// stopCapture flushes the capture file before gonzo exits. The dashboard has
+// closed or was never shown by then, so failures go to stderr.
+func (m *simpleTuiModel) stopCapture() {
+	if m.capture == nil {
+		return
+	}
+	if err := m.capture.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (%d entries not captured)\n", err, m.capture.Dropped())
+	}
+	m.capture = nil
+}
+
+// captureEntry appends a processed entry to the capture file, before any of
+// the dashboard's filters apply
+func (m *simpleTuiModel) captureEntry(entry *tui.LogEntry) {
+	if m.capture == nil {
+		return
+	}
+	m.capture.Write(newCaptureEntry(entry, m.exportRedactor))
+}
+
+// newCaptureEntry converts an entry to the JSON written to the capture file
+// and to exec commands, redacted for export
+func newCaptureEntry(entry *tui.LogEntry, r *redact.Redactor) capture.Entry {
+	captured := capture.Entry{
+		Timestamp:  entry.Timestamp,
+		Severity:   entry.Severity,
+		Message:    entry.Message,
+		Attributes: maps.Clone(entry.Attributes),
+		RawLine:    entry.RawLine,
+		Source:     entry.Source,
+	}
+	captured.Message, captured.RawLine = r.Entry(captured.Message, captured.RawLine, captured.Attributes)
+	if !entry.OrigTimestamp.IsZero() {
+		orig := entry.OrigTimestamp
+		captured.OrigTimestamp = &orig
+	}
+	return captured
+}
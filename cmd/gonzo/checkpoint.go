@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log"
+
+	"github.com/control-theory/gonzo/internal/filereader"
+)
+
+// loadCheckpoints makes the file reader resume from --checkpoint-file, if
+// set. Without a usable checkpoint file the files are read as usual.
+func (m *simpleTuiModel) loadCheckpoints() {
+	if cfg.CheckpointFile == "" {
+		return
+	}
+	checkpoints, err := filereader.LoadCheckpoints(cfg.CheckpointFile)
+	if err != nil {
+		log.Printf("Warning: checkpoints disabled: %v", err)
+		return
+	}
+	m.fileReader.SetCheckpoints(checkpoints, cfg.FromBeginning)
+}
+
+// stopFileReader stops reading files before gonzo exits, saving their
+// checkpoints
+func (m *simpleTuiModel) stopFileReader() {
+	if m.fileReader != nil {
+		m.fileReader.Stop()
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/control-theory/gonzo/internal/capture"
+)
+
+// crashGuard runs the dashboard, recording the first panic in its Update,
+// View or commands. The panic goes on to bubbletea, which restores the
+// terminal; the buffer is dumped once the program has ended.
+type crashGuard struct {
+	model *simpleTuiModel
+
+	mu    sync.Mutex
+	value any    // The first panic, nil until one happens
+	stack []byte // Where it happened
+}
+
+// newCrashGuard wraps the dashboard's model
+func newCrashGuard(model *simpleTuiModel) *crashGuard {
+	return &crashGuard{model: model}
+}
+
+// Init starts the dashboard
+func (g *crashGuard) Init() tea.Cmd {
+	return g.guard(g.model.Init())
+}
+
+// Update hands a message to the dashboard
+func (g *crashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.recover()
+	_, cmd := g.model.Update(msg)
+	return g, g.guard(cmd)
+}
+
+// View renders the dashboard
+func (g *crashGuard) View() string {
+	defer g.recover()
+	return g.model.View()
+}
+
+// recover records a panic and panics again, so bubbletea restores the
+// terminal and ends the program
+func (g *crashGuard) recover() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	g.mu.Lock()
+	if g.value == nil {
+		g.value, g.stack = r, debug.Stack()
+	}
+	g.mu.Unlock()
+	panic(r)
+}
+
+// guard records panics in a command and in the commands of a batch it
+// returns. Bubbletea runs sequences itself, so their panics restore the
+// terminal without a crash file.
+func (g *crashGuard) guard(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer g.recover()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i := range batch {
+				batch[i] = g.guard(batch[i])
+			}
+		}
+		return msg
+	}
+}
+
+// crashed returns the panic the dashboard ended with, if any
+func (g *crashGuard) crashed() (any, []byte) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value, g.stack
+}
+
+// crashDir is where crash files go: the config directory, or the temporary
+// one when it cannot be created
+func crashDir(configDir string) string {
+	dir := filepath.Join(configDir, "crashes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return os.TempDir()
+	}
+	return dir
+}
+
+// writeCrashFiles writes the panic and its stack trace to a report, and the
+// entries in the buffer to a capture file next to it that gonzo replay
+// reads. Call it once the program has ended, so nothing changes the buffer.
+func (g *crashGuard) writeCrashFiles(value any, stack []byte) (string, string, error) {
+	now := time.Now()
+	base := filepath.Join(crashDir(g.model.configDir), "crash-"+now.Format("20060102-150405"))
+	reportPath, dumpPath := base+".txt", base+".jsonl.gz"
+
+	entries := g.model.dashboard.BufferedEntries()
+	writer, err := capture.NewWriter(capture.Config{Path: dumpPath})
+	if err != nil {
+		return "", "", err
+	}
+	for i := range entries {
+		writer.Write(newCaptureEntry(&entries[i], g.model.exportRedactor))
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	report := fmt.Sprintf("gonzo %s crashed at %s\n\npanic: %v\n\n%d buffered entries saved to %s\n\n%s",
+		version, now.Format(time.RFC3339), value, len(entries), dumpPath, stack)
+	if err := os.WriteFile(reportPath, []byte(report), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return reportPath, dumpPath, nil
+}
+
+// reportCrash writes the crash files if the dashboard panicked and tells
+// where they are
+func (g *crashGuard) reportCrash() {
+	value, stack := g.crashed()
+	if value == nil {
+		return
+	}
+	reportPath, dumpPath, err := g.writeCrashFiles(value, stack)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gonzo crashed and could not save its buffer: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "gonzo crashed. The stack trace is in %s\nThe buffered entries are in %s; view them with: gonzo replay %s\n",
+		reportPath, dumpPath, dumpPath)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/k8s"
+	"github.com/control-theory/gonzo/internal/synth"
+)
+
+// Pace of the demo's traffic: a steady stream that swells and ebbs, with an
+// incident now and then where a rollout makes one service fail
+const (
+	demoRate          = 40 // Lines per second on average
+	demoTick          = 100 * time.Millisecond
+	demoWave          = time.Minute // Period of the swell
+	demoFirstIncident = 30 * time.Second
+	demoIncidentEvery = 2 * time.Minute
+	demoIncidentFor   = 20 * time.Second
+	demoIncidentRate  = 15 // Error lines per second of the failing service
+)
+
+// startDemo starts generating the demo's logs
+func (m *simpleTuiModel) startDemo() {
+	gen, err := synth.New(synth.Config{
+		// Mostly JSON, with OTLP records as a collector would forward
+		Formats:     []string{synth.FormatJSON, synth.FormatJSON, synth.FormatJSON, synth.FormatOTLP},
+		Services:    8,
+		Patterns:    60,
+		Cardinality: 200,
+		Seed:        uint64(time.Now().UnixNano()),
+		Kubernetes:  true,
+		RequestIDs:  true,
+	})
+	if err != nil {
+		log.Printf("Error starting the demo: %v", err)
+		return
+	}
+	m.hasDemoInput = true
+	m.openInput()
+	if m.dashboard != nil && cfg.K8sRolloutMarkers {
+		m.rollouts = make(chan k8s.Rollout, rolloutBuffer)
+	}
+	go m.readDemoAsync(gen)
+}
+
+// readDemoAsync sends the demo's lines until gonzo exits
+func (m *simpleTuiModel) readDemoAsync(gen *synth.Generator) {
+	defer close(m.inputChan)
+
+	ticker := time.NewTicker(demoTick)
+	defer ticker.Stop()
+
+	started := time.Now()
+	nextIncident := started.Add(demoFirstIncident)
+	failing := -1
+	var incidentEnds time.Time
+	var seq uint64
+	var lines, errorLines float64 // Lines due, carried over between ticks
+	send := func(line string) bool {
+		seq++
+		return m.sendLine(sourceDemo, inputLine{text: line})
+	}
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			// A rollout starts each incident; the service recovers after it
+			if failing < 0 && now.After(nextIncident) {
+				failing = rand.IntN(len(gen.Workloads()))
+				incidentEnds = now.Add(demoIncidentFor)
+				m.demoRollout(gen.Rollout(failing), now)
+			} else if failing >= 0 && now.After(incidentEnds) {
+				failing = -1
+				nextIncident = now.Add(demoIncidentEvery)
+			}
+
+			elapsed := now.Sub(started).Seconds()
+			swell := 1 + 0.5*math.Sin(2*math.Pi*elapsed/demoWave.Seconds())
+			for lines += demoRate * demoTick.Seconds() * swell * (0.5 + rand.Float64()); lines >= 1; lines-- {
+				// Lines are spread over the tick, as they were logged
+				at := now.Add(-time.Duration(rand.Int64N(int64(demoTick))))
+				if !send(gen.Line(seq, at)) {
+					return
+				}
+			}
+			if failing < 0 {
+				continue
+			}
+			for errorLines += demoIncidentRate * demoTick.Seconds() * (0.5 + rand.Float64()); errorLines >= 1; errorLines-- {
+				if !send(gen.ErrorLine(seq, now, failing)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// demoRollout marks a rollout of the demo on the counts chart
+func (m *simpleTuiModel) demoRollout(w synth.Workload, at time.Time) {
+	if m.rollouts == nil {
+		return
+	}
+	rollout := k8s.Rollout{
+		Time:      at,
+		Namespace: w.Namespace,
+		Name:      w.Service,
+		Revision:  strconv.Itoa(w.Revision),
+		Images:    []string{fmt.Sprintf("%s:v1.%d.0", w.Service, w.Revision)},
+	}
+	select {
+	case m.rollouts <- rollout:
+	default:
+	}
+}
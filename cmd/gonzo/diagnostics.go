@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+
+	"github.com/control-theory/gonzo/internal/diaglog"
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"k8s.io/klog/v2"
+)
+
+// diagLogCapacity is how many of gonzo's own log messages the dashboard keeps
+const diagLogCapacity = 1000
+
+// startDiagLog routes the standard logger and the Kubernetes client's log to
+// a ring buffer shown in the gonzo logs modal, and to --log-file, so they do
+// not write over the dashboard. The returned function closes the file.
+func startDiagLog() (*diaglog.Log, func()) {
+	level, levelErr := diaglog.ParseLevel(cfg.LogLevel)
+
+	var file io.Writer
+	closeFile := func() {}
+	var fileErr error
+	if cfg.LogFile != "" {
+		f, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			fileErr = err
+		} else {
+			file = f
+			closeFile = func() { f.Close() }
+		}
+	}
+
+	logs := diaglog.New(diagLogCapacity, level, file)
+	log.SetFlags(0)
+	log.SetOutput(logs)
+
+	// klog writes a message once for every severity up to its own, and the
+	// INFO output receives them all
+	klog.SetOutput(io.Discard)
+	klog.SetOutputBySeverity("INFO", logs.Klog())
+	klog.LogToStderr(false)
+
+	if levelErr != nil {
+		log.Printf("Warning: %v", levelErr)
+	}
+	if fileErr != nil {
+		log.Printf("Warning: failed to open log file: %v", fileErr)
+	}
+	return logs, closeFile
+}
+
+// startPprof serves the Go profiler on --pprof, under /debug/pprof/
+func (m *simpleTuiModel) startPprof() {
+	if cfg.Pprof == "" {
+		return
+	}
+
+	listener, err := net.Listen("tcp", cfg.Pprof)
+	if err != nil {
+		log.Printf("Warning: failed to listen for pprof on %s: %v", cfg.Pprof, err)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	go func() {
+		if err := http.Serve(listener, mux); err != nil && !errors.Is(err, net.ErrClosed) {
+			log.Printf("Warning: pprof server stopped: %v", err)
+		}
+	}()
+	log.Printf("Serving pprof on http://%s/debug/pprof/", listener.Addr())
+}
+
+// queueDepths reports how full the channels between the sources and the
+// dashboard are, for the diagnostics in the statistics modal
+func (m *simpleTuiModel) queueDepths() []tui.QueueDepth {
+	var depths []tui.QueueDepth
+	if m.inputChan != nil {
+		depths = append(depths, tui.QueueDepth{Name: "Input", Len: len(m.inputChan), Cap: cap(m.inputChan)})
+	}
+	if m.otlpReceiver != nil {
+		ch := m.otlpReceiver.GetLineChan()
+		depths = append(depths, tui.QueueDepth{Name: "OTLP", Len: len(ch), Cap: cap(ch)})
+	}
+	if m.k8sReceiver != nil {
+		ch := m.k8sReceiver.GetLineChan()
+		depths = append(depths, tui.QueueDepth{Name: "Kubernetes", Len: len(ch), Cap: cap(ch)})
+	}
+	if m.vmlogsReceiver != nil {
+		ch := m.vmlogsReceiver.GetLineChan()
+		depths = append(depths, tui.QueueDepth{Name: "Victoria Logs", Len: len(ch), Cap: cap(ch)})
+	}
+	return depths
+}
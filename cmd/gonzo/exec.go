@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/control-theory/gonzo/internal/execpipe"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// startExecPipes starts the --exec commands
+func (m *simpleTuiModel) startExecPipes() {
+	for _, spec := range cfg.Exec {
+		pipe, err := execpipe.Parse(spec)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		pipe.Start()
+		m.execPipes = append(m.execPipes, pipe)
+	}
+}
+
+// stopExecPipes writes what is still queued and waits for the commands to
+// exit
+func (m *simpleTuiModel) stopExecPipes() {
+	for _, pipe := range m.execPipes {
+		pipe.Stop()
+		if dropped := pipe.Dropped(); dropped > 0 {
+			log.Printf("Warning: exec %q dropped %d entries because the command fell behind or exited", pipe.Command, dropped)
+		}
+	}
+	m.execPipes = nil
+}
+
+// pipeEntry sends a processed entry as a JSON line to every command whose
+// filter it matches
+func (m *simpleTuiModel) pipeEntry(entry *tui.LogEntry) {
+	if len(m.execPipes) == 0 {
+		return
+	}
+	rec := tui.EntryRecord(*entry)
+	var line []byte
+	for _, pipe := range m.execPipes {
+		if !pipe.Matches(rec) {
+			continue
+		}
+		if line == nil {
+			data, err := json.Marshal(newCaptureEntry(entry, m.exportRedactor))
+			if err != nil {
+				return
+			}
+			line = append(data, '\n')
+		}
+		pipe.Send(line)
+	}
+}
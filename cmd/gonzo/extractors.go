@@ -1,8 +1,14 @@
 package main
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,21 +35,23 @@ func extractAllLogEntriesFromOTLPBatch(logsData *logspb.LogsData) []*tui.LogEntr
 	// Process all resource logs
 	for _, resourceLog := range logsData.ResourceLogs {
 		// Extract resource attributes first
-		resourceAttributes := make(map[string]string)
+		var resource otlpAttributes
 		if resourceLog.Resource != nil {
-			for _, attr := range resourceLog.Resource.Attributes {
-				if attr.Key != "" && attr.Value != nil {
-					resourceAttributes[attr.Key] = extractStringFromAnyValue(attr.Value)
-				}
-			}
+			resource = newOTLPAttributes(resourceLog.Resource.Attributes)
 		}
 
 		// Process all scope logs within each resource
 		for _, scopeLog := range resourceLog.ScopeLogs {
+			var scope otlpAttributes
+			if scopeLog.Scope != nil {
+				scope = newScopeAttributes(scopeLog.Scope)
+			}
+
 			// Process all log records within each scope
 			for _, logRecord := range scopeLog.LogRecords {
-				entry := extractLogEntryFromOTLPRecordWithResource(logRecord, resourceAttributes)
+				entry := extractLogEntryFromOTLPRecordWithResource(logRecord, resource, scope)
 				if entry != nil {
+					tagSeverityNumber(entry, logRecord.SeverityNumber)
 					allEntries = append(allEntries, entry)
 				}
 			}
@@ -53,8 +61,59 @@ func extractAllLogEntriesFromOTLPBatch(logsData *logspb.LogsData) []*tui.LogEntr
 	return allEntries
 }
 
-// extractLogEntryFromOTLPRecordWithResource extracts a LogEntry from OTLP record with resource attributes
-func extractLogEntryFromOTLPRecordWithResource(record *logspb.LogRecord, resourceAttributes map[string]string) *tui.LogEntry {
+// otlpAttributes are the attributes of an OTLP resource or scope, shared by
+// its records
+type otlpAttributes struct {
+	values map[string]string
+	keys   []string // Sorted
+}
+
+// newOTLPAttributes converts the attributes of a resource or scope
+func newOTLPAttributes(attrs []*commonpb.KeyValue) otlpAttributes {
+	a := otlpAttributes{values: make(map[string]string, len(attrs))}
+	for _, attr := range attrs {
+		if attr.Key != "" && attr.Value != nil {
+			a.values[attr.Key] = extractStringFromAnyValue(attr.Value)
+		}
+	}
+	a.keys = slices.Sorted(maps.Keys(a.values))
+	return a
+}
+
+// newScopeAttributes converts the attributes of an instrumentation scope,
+// adding its name and version as otel.scope.name and otel.scope.version
+func newScopeAttributes(scope *commonpb.InstrumentationScope) otlpAttributes {
+	a := newOTLPAttributes(scope.Attributes)
+	if scope.Name == "" {
+		return a
+	}
+	a.values[tui.ScopeNameAttribute] = scope.Name
+	if scope.Version != "" {
+		a.values[tui.ScopeVersionAttribute] = scope.Version
+	}
+	a.keys = slices.Sorted(maps.Keys(a.values))
+	return a
+}
+
+// keptKeys returns the keys of a resource or scope whose values an entry
+// kept, sharing the slice unless some were overridden
+func (a otlpAttributes) keptKeys(attributes map[string]string) []string {
+	for i, key := range a.keys {
+		if attributes[key] != a.values[key] {
+			kept := slices.Clone(a.keys[:i])
+			for _, key := range a.keys[i+1:] {
+				if attributes[key] == a.values[key] {
+					kept = append(kept, key)
+				}
+			}
+			return kept
+		}
+	}
+	return a.keys
+}
+
+// extractLogEntryFromOTLPRecordWithResource extracts a LogEntry from OTLP record with resource and scope attributes
+func extractLogEntryFromOTLPRecordWithResource(record *logspb.LogRecord, resource, scope otlpAttributes) *tui.LogEntry {
 	// Use receive time for all processing
 	receiveTime := time.Now()
 
@@ -68,16 +127,21 @@ func extractLogEntryFromOTLPRecordWithResource(record *logspb.LogRecord, resourc
 	}
 
 	// Extract severity with proper fallback priority:
-	// 1. Use SeverityText if present
-	// 2. Use SeverityNumber if specified (not UNSPECIFIED)
-	// 3. Fall back to parsing message text for severity keywords
+	// 1. Use SeverityNumber if it is one of OTLP's levels, 1 to 24
+	// 2. Use SeverityText if present
+	// 3. Use any other SeverityNumber if specified (not UNSPECIFIED)
+	// 4. Fall back to parsing message text for severity keywords
 	var severity string
 
-	if record.SeverityText != "" {
-		// Priority 1: Use SeverityText if present
+	if isOTLPSeverityNumber(record.SeverityNumber) {
+		// Priority 1: The number is the normalized level, the text only
+		// its original name, such as WARN3 or "notice"
+		severity = severityNumberToString(record.SeverityNumber)
+	} else if record.SeverityText != "" {
+		// Priority 2: Use SeverityText if present
 		severity = record.SeverityText
 	} else if record.SeverityNumber != logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED {
-		// Priority 2: Use SeverityNumber if it's specified
+		// Priority 3: Use SeverityNumber if it's specified
 		severity = severityNumberToString(record.SeverityNumber)
 	} else {
 		// Priority 3: Fall back to parsing message text
@@ -88,11 +152,14 @@ func extractLogEntryFromOTLPRecordWithResource(record *logspb.LogRecord, resourc
 	// Extract message from body
 	message := extractMessageFromBody(record.Body)
 
-	// Merge resource attributes with record attributes (record attributes take precedence)
+	// Merge resource, scope and record attributes (record attributes take precedence)
 	attributes := make(map[string]string)
 
-	// First add resource attributes
-	for key, value := range resourceAttributes {
+	// First add resource attributes, then scope attributes
+	for key, value := range resource.values {
+		attributes[key] = value
+	}
+	for key, value := range scope.values {
 		attributes[key] = value
 	}
 
@@ -102,21 +169,30 @@ func extractLogEntryFromOTLPRecordWithResource(record *logspb.LogRecord, resourc
 			attributes[attr.Key] = extractStringFromAnyValue(attr.Value)
 		}
 	}
+	addTraceContext(attributes, record)
 
-	return &tui.LogEntry{
+	entry := &tui.LogEntry{
 		Timestamp:     receiveTime,
 		OrigTimestamp: origTimestamp,
 		Severity:      normalizeSeverity(severity),
 		Message:       message,
 		RawLine:       message,
 		Attributes:    attributes,
+		ResourceKeys:  resource.keptKeys(attributes),
+		ScopeKeys:     scope.keptKeys(attributes),
 	}
+	// The message summarizes a structured body, kept whole as the raw line
+	if isStructuredValue(record.Body) {
+		entry.RawLine = anyValueJSON(record.Body)
+		entry.StructuredBody = true
+	}
+	return entry
 }
 
 // extractLogEntryFromOTLPRecord extracts a LogEntry from a single OTLP record
 func extractLogEntryFromOTLPRecord(record *logspb.LogRecord) *tui.LogEntry {
 	// Use the new function with empty resource attributes for backwards compatibility
-	return extractLogEntryFromOTLPRecordWithResource(record, make(map[string]string))
+	return extractLogEntryFromOTLPRecordWithResource(record, otlpAttributes{}, otlpAttributes{})
 }
 
 // createFallbackLogEntry creates a basic LogEntry for unparseable lines
@@ -187,23 +263,75 @@ func normalizeSeverity(severity string) string {
 	}
 }
 
-// severityNumberToString converts OTLP severity number to string
+// severityNumberToString converts OTLP severity number to string. Each
+// level spans four numbers, e.g. WARN to WARN4 are 13 to 16.
 func severityNumberToString(severityNumber logspb.SeverityNumber) string {
-	switch severityNumber {
-	case logspb.SeverityNumber_SEVERITY_NUMBER_TRACE:
-		return "TRACE"
-	case logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG:
-		return "DEBUG"
-	case logspb.SeverityNumber_SEVERITY_NUMBER_INFO:
+	switch {
+	case !isOTLPSeverityNumber(severityNumber):
 		return "INFO"
-	case logspb.SeverityNumber_SEVERITY_NUMBER_WARN:
-		return "WARN"
-	case logspb.SeverityNumber_SEVERITY_NUMBER_ERROR:
-		return "ERROR"
-	case logspb.SeverityNumber_SEVERITY_NUMBER_FATAL:
+	case severityNumber >= logspb.SeverityNumber_SEVERITY_NUMBER_FATAL:
 		return "FATAL"
-	default:
+	case severityNumber >= logspb.SeverityNumber_SEVERITY_NUMBER_ERROR:
+		return "ERROR"
+	case severityNumber >= logspb.SeverityNumber_SEVERITY_NUMBER_WARN:
+		return "WARN"
+	case severityNumber >= logspb.SeverityNumber_SEVERITY_NUMBER_INFO:
 		return "INFO"
+	case severityNumber >= logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG:
+		return "DEBUG"
+	default:
+		return "TRACE"
+	}
+}
+
+// isOTLPSeverityNumber reports whether a severity number is one of OTLP's
+// levels rather than, say, a pino level of 30
+func isOTLPSeverityNumber(severityNumber logspb.SeverityNumber) bool {
+	return severityNumber >= logspb.SeverityNumber_SEVERITY_NUMBER_TRACE &&
+		severityNumber <= logspb.SeverityNumber_SEVERITY_NUMBER_FATAL4
+}
+
+// severityNumberAttribute holds the precise severity number of an OTLP
+// record, which the display levels round, for filters such as
+// severityNumber>=17
+const severityNumberAttribute = "severityNumber"
+
+// tagSeverityNumber keeps the severity number of an OTLP record as an
+// attribute
+func tagSeverityNumber(entry *tui.LogEntry, severityNumber logspb.SeverityNumber) {
+	if entry == nil || !isOTLPSeverityNumber(severityNumber) {
+		return
+	}
+	if entry.Attributes == nil {
+		entry.Attributes = make(map[string]string)
+	}
+	entry.Attributes[severityNumberAttribute] = strconv.Itoa(int(severityNumber))
+}
+
+// Attributes holding the trace context of an OTLP record, as hex
+const (
+	traceIDAttribute = "trace_id"
+	spanIDAttribute  = "span_id"
+)
+
+// addTraceContext adds the trace and span IDs and trace flags of an OTLP
+// record to its attributes, unless they already have them
+func addTraceContext(attributes map[string]string, record *logspb.LogRecord) {
+	context := map[string]string{}
+	if len(record.TraceId) > 0 {
+		context[traceIDAttribute] = hex.EncodeToString(record.TraceId)
+	}
+	if len(record.SpanId) > 0 {
+		context[spanIDAttribute] = hex.EncodeToString(record.SpanId)
+	}
+	// Only the low byte holds W3C trace flags, e.g. 01 for sampled
+	if len(record.TraceId) > 0 || record.Flags != 0 {
+		context[tui.TraceFlagsAttribute] = fmt.Sprintf("%02x", record.Flags&uint32(logspb.LogRecordFlags_LOG_RECORD_FLAGS_TRACE_FLAGS_MASK))
+	}
+	for key, value := range context {
+		if _, ok := attributes[key]; !ok {
+			attributes[key] = value
+		}
 	}
 }
 
@@ -226,11 +354,118 @@ func extractMessageFromBody(body *commonpb.AnyValue) string {
 		return fmt.Sprintf("%.2f", v.DoubleValue)
 	case *commonpb.AnyValue_BoolValue:
 		return fmt.Sprintf("%t", v.BoolValue)
+	case *commonpb.AnyValue_KvlistValue:
+		return summarizeKeyValues(v.KvlistValue)
+	case *commonpb.AnyValue_ArrayValue, *commonpb.AnyValue_BytesValue:
+		return summarizeValue(body)
 	default:
 		return fmt.Sprintf("%v", body)
 	}
 }
 
+// bodyMessageKeys are the keys of a key/value body holding its message,
+// which its summary starts with
+var bodyMessageKeys = []string{"message", "msg", "body"}
+
+// summarizeKeyValues renders a key/value body on one line, its message
+// followed by the other pairs as key=value
+func summarizeKeyValues(kvlist *commonpb.KeyValueList) string {
+	if kvlist == nil {
+		return ""
+	}
+	var message string
+	var pairs []string
+	for _, kv := range kvlist.Values {
+		if _, ok := kv.Value.GetValue().(*commonpb.AnyValue_StringValue); ok && message == "" && slices.Contains(bodyMessageKeys, strings.ToLower(kv.Key)) {
+			message = extractMessageFromBody(kv.Value)
+			continue
+		}
+		pairs = append(pairs, kv.Key+"="+summarizeValue(kv.Value))
+	}
+	if message != "" {
+		pairs = append([]string{message}, pairs...)
+	}
+	return strings.Join(pairs, " ")
+}
+
+// summarizeValue renders a value within a one-line summary: strings
+// quoted when they hold spaces, arrays in brackets and key/values in braces
+func summarizeValue(value *commonpb.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		if v.StringValue == "" || strings.ContainsAny(v.StringValue, " \t\r\n\"") {
+			return strconv.Quote(v.StringValue)
+		}
+		return v.StringValue
+	case *commonpb.AnyValue_ArrayValue:
+		items := make([]string, 0, len(v.ArrayValue.GetValues()))
+		for _, item := range v.ArrayValue.GetValues() {
+			items = append(items, summarizeValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *commonpb.AnyValue_KvlistValue:
+		pairs := make([]string, 0, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			pairs = append(pairs, kv.Key+"="+summarizeValue(kv.Value))
+		}
+		return "{" + strings.Join(pairs, " ") + "}"
+	case *commonpb.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	case nil:
+		return "null"
+	}
+	return extractStringFromAnyValue(value)
+}
+
+// isStructuredValue reports whether a value is a list of key/values or an
+// array, whose structure a one-line rendering loses
+func isStructuredValue(value *commonpb.AnyValue) bool {
+	switch value.GetValue().(type) {
+	case *commonpb.AnyValue_KvlistValue, *commonpb.AnyValue_ArrayValue:
+		return true
+	}
+	return false
+}
+
+// anyValueJSON encodes an OTLP value as the plain JSON it holds, e.g.
+// {"user":"bob","ids":[1,2]}
+func anyValueJSON(value *commonpb.AnyValue) string {
+	data, err := json.Marshal(anyValueData(value))
+	if err != nil {
+		return summarizeValue(value)
+	}
+	return string(data)
+}
+
+// anyValueData converts an OTLP value to the Go value it holds
+func anyValueData(value *commonpb.AnyValue) any {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_IntValue:
+		return v.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return v.DoubleValue
+	case *commonpb.AnyValue_BoolValue:
+		return v.BoolValue
+	case *commonpb.AnyValue_BytesValue:
+		return v.BytesValue
+	case *commonpb.AnyValue_ArrayValue:
+		items := make([]any, 0, len(v.ArrayValue.GetValues()))
+		for _, item := range v.ArrayValue.GetValues() {
+			items = append(items, anyValueData(item))
+		}
+		return items
+	case *commonpb.AnyValue_KvlistValue:
+		fields := make(map[string]any, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			fields[kv.Key] = anyValueData(kv.Value)
+		}
+		return fields
+	}
+	return nil
+}
+
 // extractStringFromAnyValue extracts string representation from OTLP AnyValue
 func extractStringFromAnyValue(value *commonpb.AnyValue) string {
 	if value == nil {
@@ -246,6 +481,10 @@ func extractStringFromAnyValue(value *commonpb.AnyValue) string {
 		return fmt.Sprintf("%.2f", v.DoubleValue)
 	case *commonpb.AnyValue_BoolValue:
 		return fmt.Sprintf("%t", v.BoolValue)
+	case *commonpb.AnyValue_KvlistValue, *commonpb.AnyValue_ArrayValue:
+		return anyValueJSON(value)
+	case *commonpb.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
 	default:
 		return fmt.Sprintf("%v", v)
 	}
@@ -312,6 +551,46 @@ func extractSeverityFromText(message string) string {
 			return severity
 		}
 	}
+	// No explicit level: guess from the content when enabled
+	if cfg.InferSeverity {
+		if inferred := inferSeverityFromText(message); inferred != "" {
+			return inferred
+		}
+	}
 	// Default to INFO if no severity found in text
 	return "INFO"
 }
+
+// Heuristics for lines without an explicit level. HTTP status codes are
+// matched after a request line (`"GET / HTTP/1.1" 503`) or a status field.
+var (
+	httpStatusRegex   = regexp.MustCompile(`(?i)(?:HTTP/\d(?:\.\d)?"?\s+|\b(?:status|status_code|code)["']?\s*[=:]\s*["']?)([1-5]\d{2})\b`)
+	fatalKeywordRegex = regexp.MustCompile(`(?i)\b(?:panic|panicked|segmentation fault|out of memory|oom-?killed)\b`)
+	errorKeywordRegex = regexp.MustCompile(`(?i)exceptions?\b|\b(?:errors|traceback|failed|failure|err[=:]|stack ?trace)`)
+	warnKeywordRegex  = regexp.MustCompile(`(?i)\b(?:warnings|deprecated|retrying|retry attempt)\b`)
+)
+
+// inferSeverityFromText guesses the severity of an unstructured line from
+// HTTP status codes and error keywords, returning "" when nothing matches
+func inferSeverityFromText(message string) string {
+	if matches := httpStatusRegex.FindStringSubmatch(message); len(matches) > 1 {
+		switch matches[1][0] {
+		case '5':
+			return "ERROR"
+		case '4':
+			return "WARN"
+		default:
+			return "INFO"
+		}
+	}
+
+	switch {
+	case fatalKeywordRegex.MatchString(message):
+		return "FATAL"
+	case errorKeywordRegex.MatchString(message):
+		return "ERROR"
+	case warnKeywordRegex.MatchString(message):
+		return "WARN"
+	}
+	return ""
+}
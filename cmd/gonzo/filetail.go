@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/linelimit"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// byteUnits are the suffixes --tail-bytes takes, longest first
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"G", 1 << 30},
+	{"M", 1 << 20},
+	{"K", 1 << 10},
+	{"B", 1},
+}
+
+// parseByteSize parses a size such as 512MB, 2G or 1048576
+func parseByteSize(s string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(text, u.suffix) {
+			text, unit = strings.TrimSpace(strings.TrimSuffix(text, u.suffix)), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q: use a number of bytes or e.g. 512MB, 2GB", s)
+	}
+	return int64(n * float64(unit)), nil
+}
+
+// setFileTail starts the files at --tail-bytes or --tail-lines from their
+// end, if set
+func (m *simpleTuiModel) setFileTail() {
+	var tailBytes int64
+	if cfg.TailBytes != "" {
+		size, err := parseByteSize(cfg.TailBytes)
+		if err != nil {
+			log.Printf("Warning: --tail-bytes ignored: %v", err)
+		}
+		tailBytes = size
+	}
+	if tailBytes > 0 || cfg.TailLines > 0 {
+		m.fileReader.SetTail(tailBytes, cfg.TailLines)
+	}
+}
+
+// readOlderEntries parses the lines of the files from before where their
+// tail started, up to n lines of each, for the dashboard to show when
+// scrolling past the first entry. They were never received, so they are
+// parsed and filtered but not counted, captured or forwarded.
+func (m *simpleTuiModel) readOlderEntries(n int) []tui.LogEntry {
+	lines := m.fileReader.ReadOlder(n)
+	merged := len(m.fileReader.GetFilePaths()) > 1
+
+	var entries []tui.LogEntry
+	for _, line := range lines {
+		if line.Text == "" || isOTLPSignalLog(line.Text) {
+			continue
+		}
+		text, size := linelimit.Unmark(line.Text)
+		job := parseJob{text: text, old: true, size: size}
+		if merged {
+			job.file = line.Path
+		}
+		for _, p := range m.parse(job) {
+			entry := p.entry
+			if entry == nil || !m.inTimeRange(entry) || !tui.SeverityAtLeast(entry.Severity, m.minSeverity) {
+				continue
+			}
+			if m.redactor.Attributes(entry.Attributes) {
+				entry.RawLine = ""
+			}
+			if entry.Source == "" {
+				entry.Source = m.sourceName()
+			}
+			entries = append(entries, *entry)
+		}
+	}
+
+	// Each file's page is in order, but they overlap in time
+	if merged {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Timestamp.Before(entries[j].Timestamp)
+		})
+	}
+	return entries
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/formats"
+	"github.com/control-theory/gonzo/internal/otlplog"
+)
+
+// formatAuto asks for the format of each line to be detected
+const formatAuto = "auto"
+
+// lineFormat parses the lines of one format: detected line by line, one of
+// the built-in formats or a custom one
+type lineFormat struct {
+	name      string // Lowercase, "" when detected
+	detector  *otlplog.FormatDetector
+	converter *otlplog.LogConverter
+	custom    *formats.Parser
+}
+
+// loadLineFormat loads a format by name: auto or "" to detect it, otlp,
+// json, text, or a custom format. A custom format that does not load is
+// detected instead.
+func loadLineFormat(name, configDir string) *lineFormat {
+	switch strings.ToLower(name) {
+	case "", formatAuto:
+		return &lineFormat{detector: otlplog.NewFormatDetector(), converter: otlplog.NewLogConverter()}
+	case "otlp", "json", "text":
+		// Built-in format
+		return &lineFormat{
+			name:      strings.ToLower(name),
+			detector:  otlplog.NewFormatDetectorWithFormat(name),
+			converter: otlplog.NewLogConverter(),
+		}
+	}
+
+	// Try to load custom format
+	format, err := formats.LoadFormatByName(name, configDir)
+	if err != nil {
+		log.Printf("Warning: Failed to load custom format '%s': %v (using auto-detect)", name, err)
+		return loadLineFormat("", configDir)
+	}
+	// Create parser for the custom format
+	customParser, err := formats.NewParser(format)
+	if err != nil {
+		log.Printf("Warning: Failed to create parser for format '%s': %v (using auto-detect)", name, err)
+		return loadLineFormat("", configDir)
+	}
+	log.Printf("Using custom format: %s", name)
+	return &lineFormat{
+		name:      strings.ToLower(name),
+		detector:  otlplog.NewFormatDetectorWithFormat(name),
+		converter: otlplog.NewLogConverterWithFormat(name, customParser),
+		custom:    customParser,
+	}
+}
+
+// joinsJSON reports whether lines opening a JSON object are joined with the
+// lines after them until it closes. Plain text may open braces it never
+// closes.
+func (f *lineFormat) joinsJSON() bool {
+	return f.name != "text"
+}
+
+// loadSourceFormats loads the formats of --source-format, written as
+// SOURCE=FORMAT, by source, skipping invalid ones
+func loadSourceFormats(configDir string) map[string]*lineFormat {
+	if len(cfg.SourceFormats) == 0 {
+		return nil
+	}
+	sourceFormats := make(map[string]*lineFormat)
+	for _, spec := range cfg.SourceFormats {
+		source, name, ok := strings.Cut(spec, "=")
+		source, name = strings.TrimSpace(source), strings.TrimSpace(name)
+		if !ok || source == "" || name == "" {
+			log.Printf("Warning: invalid source format %q: expected SOURCE=FORMAT", spec)
+			continue
+		}
+		sourceFormats[source] = loadLineFormat(name, configDir)
+	}
+	return sourceFormats
+}
+
+// formatOf returns the format of the lines of an input, or of a file when
+// file is set: the file's --source-format, by path or name, or else the
+// input's, or else --format
+func (m *simpleTuiModel) formatOf(file string) *lineFormat {
+	if len(m.sourceFormats) == 0 {
+		return m.format
+	}
+	// One file is read as the file input, without its path on each line
+	if file == "" && m.fileReader != nil && len(m.fileReader.GetFilePaths()) == 1 {
+		file = m.fileReader.GetFilePaths()[0]
+	}
+	if file != "" {
+		if f, ok := m.sourceFormats[file]; ok {
+			return f
+		}
+		if f, ok := m.sourceFormats[filepath.Base(file)]; ok {
+			return f
+		}
+	}
+	if f, ok := m.sourceFormats[m.sourceName()]; ok {
+		return f
+	}
+	return m.format
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var k8sCmd = &cobra.Command{
+	Use:   "k8s",
+	Short: "Stream the logs of Kubernetes pods into the TUI",
+	Long: `Open the TUI on the logs of the pods in the current Kubernetes context.
+This is the same as gonzo --k8s-enabled, with shorter flags for the
+namespaces, label selector and context; the --k8s-* flags work as well.`,
+	Example: `  # Stream every pod of the prod namespace labelled app=checkout
+  gonzo k8s -n prod -l app=checkout
+
+  # Stream two namespaces of another cluster
+  gonzo k8s -n default -n payments --context staging`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg.K8sEnabled = true
+		if cmd.Flags().Changed("namespace") {
+			cfg.K8sNamespaces, _ = cmd.Flags().GetStringSlice("namespace")
+		}
+		if cmd.Flags().Changed("selector") {
+			cfg.K8sSelector, _ = cmd.Flags().GetString("selector")
+		}
+		if cmd.Flags().Changed("context") {
+			cfg.K8sContext, _ = cmd.Flags().GetString("context")
+		}
+		return runApp(cmd, nil)
+	},
+}
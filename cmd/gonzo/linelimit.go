@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/linelimit"
+)
+
+// originalSizeAttribute holds the size in bytes of a line cut to
+// --max-line-size
+const originalSizeAttribute = "gonzo.original_size"
+
+// maxLineSize returns the longest line the inputs keep whole, from
+// --max-line-size
+func maxLineSize() int {
+	if cfg.MaxLineSize == "" {
+		return linelimit.Default
+	}
+	size, err := parseByteSize(cfg.MaxLineSize)
+	if err == nil && (size < 1024 || size > math.MaxInt32) {
+		err = strconv.ErrRange
+	}
+	if err != nil {
+		log.Printf("Warning: --max-line-size ignored, must be from 1KB to 2GB: %v", err)
+		return linelimit.Default
+	}
+	return int(size)
+}
+
+// lineSize returns the size a line had before it was cut, if it was
+func lineSize(text string) int {
+	if _, size := linelimit.Unmark(text); size > 0 {
+		return int(size)
+	}
+	return len(text)
+}
+
+// tagCutEntry marks an entry parsed from a line cut to --max-line-size with
+// the size the line had, and its message as cut short
+func tagCutEntry(p parsedEntry, size int64) {
+	if p.entry == nil {
+		return
+	}
+	if p.entry.Attributes == nil {
+		p.entry.Attributes = make(map[string]string)
+	}
+	for _, attributes := range []map[string]string{p.attributes, p.entry.Attributes} {
+		if attributes != nil {
+			attributes[originalSizeAttribute] = strconv.FormatInt(size, 10)
+		}
+	}
+	if !strings.HasSuffix(p.entry.Message, "…") {
+		p.entry.Message += "…"
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"log"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+	"github.com/control-theory/gonzo/internal/loki"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// startLoki starts pushing the entries matching --loki-filter to --loki-url,
+// if set
+func (m *simpleTuiModel) startLoki() {
+	if cfg.LokiURL == "" {
+		return
+	}
+
+	var labels []loki.Label
+	for _, spec := range cfg.LokiLabels {
+		label, err := loki.ParseLabel(spec)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		labels = append(labels, label)
+	}
+	headers := parseHeaders("Loki", cfg.LokiHeaders)
+	if cfg.LokiTenant != "" {
+		headers["X-Scope-OrgID"] = cfg.LokiTenant
+	}
+
+	if cfg.LokiFilter != "" {
+		filter, err := filterexpr.Parse(cfg.LokiFilter)
+		if err != nil {
+			log.Printf("Warning: Loki push disabled: invalid filter: %v", err)
+			return
+		}
+		m.lokiFilter = filter
+	}
+
+	pusher, err := loki.NewPusher(loki.Config{
+		URL:     cfg.LokiURL,
+		Labels:  labels,
+		Static:  map[string]string{"job": "gonzo"},
+		Headers: headers,
+	})
+	if err != nil {
+		log.Printf("Warning: Loki push disabled: %v", err)
+		return
+	}
+	pusher.Start()
+	m.lokiPusher = pusher
+}
+
+// stopLoki sends what is still queued before gonzo exits
+func (m *simpleTuiModel) stopLoki() {
+	if m.lokiPusher == nil {
+		return
+	}
+	m.lokiPusher.Stop()
+	if dropped := m.lokiPusher.Dropped(); dropped > 0 {
+		log.Printf("Warning: Loki push dropped %d entries because the server fell behind", dropped)
+	}
+}
+
+// pushToLoki queues a processed entry for Loki if it matches the filter
+func (m *simpleTuiModel) pushToLoki(entry *tui.LogEntry) {
+	if m.lokiPusher == nil {
+		return
+	}
+	rec := tui.EntryRecord(*entry)
+	if m.lokiFilter != nil && !m.lokiFilter.Match(rec) {
+		return
+	}
+	timestamp := entry.OrigTimestamp
+	if timestamp.IsZero() {
+		timestamp = entry.Timestamp
+	}
+	// The entry is encoded on another goroutine, so it gets its own attributes
+	entry = redactEntry(m.exportRedactor, entry)
+	m.lokiPusher.Add(loki.Entry{
+		Time:       timestamp,
+		Severity:   rec.Severity,
+		Service:    tui.ServiceName(*entry),
+		Message:    entry.Message,
+		Attributes: entry.Attributes,
+	})
+}
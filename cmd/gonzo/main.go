@@ -7,6 +7,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/control-theory/gonzo/internal/ai"
+	"github.com/control-theory/gonzo/internal/backpressure"
+	"github.com/control-theory/gonzo/internal/notify"
+	"github.com/control-theory/gonzo/internal/redact"
+	"github.com/control-theory/gonzo/internal/synth"
+	"github.com/control-theory/gonzo/internal/tui"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -29,14 +36,48 @@ type Config struct {
 	MemorySize           int           `mapstructure:"memory-size"`
 	UpdateInterval       time.Duration `mapstructure:"update-interval"`
 	LogBuffer            int           `mapstructure:"log-buffer"`
+	LogBufferMB          int           `mapstructure:"log-buffer-mb"`
+	LogBufferPolicy      string        `mapstructure:"log-buffer-policy"`
+	LogSpill             bool          `mapstructure:"log-spill"`
+	LogSpillDir          string        `mapstructure:"log-spill-dir"`
+	LogSpillMB           int           `mapstructure:"log-spill-mb"`
+	IngestBatchSize      int           `mapstructure:"ingest-batch-size"`
+	IngestBatchInterval  time.Duration `mapstructure:"ingest-batch-interval"`
+	Backpressure         string        `mapstructure:"backpressure"`
+	InputChannelSize     int           `mapstructure:"input-channel-size"`
+	ParserWorkers        int           `mapstructure:"parser-workers"`
+	Refresh              int           `mapstructure:"refresh"`
 	TestMode             bool          `mapstructure:"test-mode"`
 	ConfigFile           string        `mapstructure:"config"`
 	AIModel              string        `mapstructure:"ai-model"`
+	AIProvider           string        `mapstructure:"ai-provider"`
+	AIBaseURL            string        `mapstructure:"ai-base-url"`
+	AIAPIKey             string        `mapstructure:"ai-api-key"`
+	AIAPIVersion         string        `mapstructure:"ai-api-version"`
+	AIPromptFile         string        `mapstructure:"ai-prompt-file"`
 	Files                []string      `mapstructure:"files"`
 	Follow               bool          `mapstructure:"follow"`
+	CheckpointFile       string        `mapstructure:"checkpoint-file"`
+	FromBeginning        bool          `mapstructure:"from-beginning"`
+	TailBytes            string        `mapstructure:"tail-bytes"`
+	TailLines            int           `mapstructure:"tail-lines"`
+	MaxLineSize          string        `mapstructure:"max-line-size"`
 	OTLPEnabled          bool          `mapstructure:"otlp-enabled"`
 	OTLPGRPCPort         int           `mapstructure:"otlp-grpc-port"`
 	OTLPHTTPPort         int           `mapstructure:"otlp-http-port"`
+	OTLPToken            string        `mapstructure:"otlp-token"`
+	OTLPBasicAuth        string        `mapstructure:"otlp-basic-auth"`
+	OTLPAllow            []string      `mapstructure:"otlp-allow"`
+	OTLPTLSCert          string        `mapstructure:"otlp-tls-cert"`
+	OTLPTLSKey           string        `mapstructure:"otlp-tls-key"`
+	OTLPClientCA         string        `mapstructure:"otlp-client-ca"`
+	OTLPTLSMinVersion    string        `mapstructure:"otlp-tls-min-version"`
+	OTLPGRPCKeepalive    time.Duration `mapstructure:"otlp-grpc-keepalive"`
+	OTLPGRPCKeepaliveTTL time.Duration `mapstructure:"otlp-grpc-keepalive-timeout"`
+	OTLPGRPCMinPing      time.Duration `mapstructure:"otlp-grpc-min-ping-interval"`
+	OTLPGRPCMaxStreams   uint32        `mapstructure:"otlp-grpc-max-streams"`
+	OTLPGRPCWindow       string        `mapstructure:"otlp-grpc-window-size"`
+	OTLPGRPCConnWindow   string        `mapstructure:"otlp-grpc-conn-window-size"`
 	VmlogsURL            string        `mapstructure:"vmlogs-url"`
 	VmlogsUser           string        `mapstructure:"vmlogs-user"`
 	VmlogsPassword       string        `mapstructure:"vmlogs-password"`
@@ -48,12 +89,88 @@ type Config struct {
 	K8sSelector          string        `mapstructure:"k8s-selector"`
 	K8sSince             int64         `mapstructure:"k8s-since"`
 	K8sTailLines         int64         `mapstructure:"k8s-tail-lines"`
+	K8sRolloutMarkers    bool          `mapstructure:"k8s-rollout-markers"`
+	Since                string        `mapstructure:"since"`
+	Until                string        `mapstructure:"until"`
+	MinSeverity          string        `mapstructure:"min-severity"`
 	Skin                 string        `mapstructure:"skin"`
 	StopWords            []string      `mapstructure:"stop-words"`
+	StopWordsFiles       []string      `mapstructure:"stop-words-file"`
+	NoDefaultStopWords   bool          `mapstructure:"no-default-stop-words"`
+	MinWordLength        int           `mapstructure:"min-word-length"`
+	CollapseTokens       []string      `mapstructure:"collapse-tokens"`
 	Format               string        `mapstructure:"format"`
+	SourceFormats        []string      `mapstructure:"source-format"`
 	DisableVersionCheck  bool          `mapstructure:"disable-version-check"`
 	ReverseScrollWheel   bool          `mapstructure:"reverse-scroll-wheel"`
 	UseLogTime           bool          `mapstructure:"use-log-time"`
+	Highlights           []string      `mapstructure:"highlight"`
+	ViewFilter           string        `mapstructure:"view-filter"`
+	Resume               bool          `mapstructure:"resume"`
+	Demo                 bool          `mapstructure:"demo"`
+	UTC                  bool          `mapstructure:"utc"`
+	TimeFormat           string        `mapstructure:"time-format"`
+	DateTimeFormat       string        `mapstructure:"date-time-format"`
+	TimestampMode        string        `mapstructure:"timestamp-mode"`
+	NoTUI                bool          `mapstructure:"no-tui"`
+	Output               string        `mapstructure:"output"`
+	Filter               string        `mapstructure:"filter"`
+	ServeListen          string        `mapstructure:"serve-listen"`
+	ServeAPIListen       string        `mapstructure:"serve-api-listen"`
+	AgentName            string        `mapstructure:"agent-name"`
+	AgentTLSCA           string        `mapstructure:"agent-tls-ca"`
+	AgentTLSCert         string        `mapstructure:"agent-tls-cert"`
+	AgentTLSKey          string        `mapstructure:"agent-tls-key"`
+	AggregatorListen     string        `mapstructure:"aggregator-listen"`
+	AggregatorTLSCert    string        `mapstructure:"aggregator-tls-cert"`
+	AggregatorTLSKey     string        `mapstructure:"aggregator-tls-key"`
+	AggregatorClientCA   string        `mapstructure:"aggregator-client-ca"`
+	InferSeverity        bool          `mapstructure:"infer-severity"`
+	LineNumbers          bool          `mapstructure:"line-numbers"`
+	SourceBadges         string        `mapstructure:"source-badges"`
+	SourceBadgeLabels    []string      `mapstructure:"source-badge"`
+	HidePanels           []string      `mapstructure:"hide-panels"`
+	ChartsHeight         int           `mapstructure:"charts-height"`
+	StatusLine           string        `mapstructure:"status-line"`
+	Tutorial             bool          `mapstructure:"tutorial"`
+	Alerts               []string      `mapstructure:"alert"`
+	AlertWebhook         string        `mapstructure:"alert-webhook"`
+	SLOs                 []string      `mapstructure:"slo"`
+	Metrics              []string      `mapstructure:"metric"`
+	MetricsListen        string        `mapstructure:"metrics-listen"`
+	Pprof                string        `mapstructure:"pprof"`
+	LogFile              string        `mapstructure:"log-file"`
+	LogLevel             string        `mapstructure:"log-level"`
+	OTLPExportEndpoint   string        `mapstructure:"otlp-export-endpoint"`
+	OTLPExportSignals    []string      `mapstructure:"otlp-export-signals"`
+	OTLPExportHeaders    []string      `mapstructure:"otlp-export-header"`
+	OTLPExportInterval   time.Duration `mapstructure:"otlp-export-interval"`
+	Report               string        `mapstructure:"report"`
+	ReportInterval       time.Duration `mapstructure:"report-interval"`
+	LokiURL              string        `mapstructure:"loki-url"`
+	LokiFilter           string        `mapstructure:"loki-filter"`
+	LokiLabels           []string      `mapstructure:"loki-label"`
+	LokiHeaders          []string      `mapstructure:"loki-header"`
+	LokiTenant           string        `mapstructure:"loki-tenant"`
+	Exec                 []string      `mapstructure:"exec"`
+	Watches              []string      `mapstructure:"watch"`
+	NotifyWebhook        string        `mapstructure:"notify-webhook"`
+	NotifyInterval       time.Duration `mapstructure:"notify-interval"`
+	NotifySamples        int           `mapstructure:"notify-samples"`
+	CaptureFile          string        `mapstructure:"capture-file"`
+	CaptureMaxSize       int           `mapstructure:"capture-max-size"`
+	CaptureMaxAge        time.Duration `mapstructure:"capture-max-age"`
+	Redact               []string      `mapstructure:"redact"`
+	RedactAttributes     []string      `mapstructure:"redact-attr"`
+	ExportRedact         []string      `mapstructure:"export-redact"`
+	ExportDenyAttributes []string      `mapstructure:"export-deny-attr"`
+	LatencyField         string        `mapstructure:"latency-field"`
+	LatencyWindow        time.Duration `mapstructure:"latency-window"`
+	NewPatternWindow     time.Duration `mapstructure:"new-pattern-window"`
+	OutlierThreshold     float64       `mapstructure:"outlier-threshold"`
+	SessionKeys          []string      `mapstructure:"session-key"`
+	Links                []string      `mapstructure:"link"`
+	WebListen            string        `mapstructure:"web-listen"`
 }
 
 var (
@@ -98,10 +215,11 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
   export OPENAI_API_KEY=sk-your-key-here
   gonzo -f application.log --ai-model="gpt-4"
   
-  # With local AI server (auto-selects available model)
-  export OPENAI_API_BASE="http://127.0.0.1:1234/v1"
-  export OPENAI_API_KEY="local-key"
-  gonzo -f logs.json --follow
+  # With local AI server (auto-selects available model, no API key needed)
+  gonzo -f logs.json --follow --ai-base-url="http://127.0.0.1:11434/v1"
+
+  # With Azure OpenAI (the model is the deployment name)
+  gonzo -f logs.json --ai-provider=azure --ai-base-url="https://my-resource.openai.azure.com" --ai-model=gpt-4o
   
   # With OTLP listener (both gRPC and HTTP)
   gonzo --otlp-enabled
@@ -130,9 +248,57 @@ Supports OTLP (OpenTelemetry) format natively, with automatic detection of JSON,
   # Using a custom log format
   gonzo --format=nodejs -f app.log
 
+  # Highlight matching text or whole rows
+  gonzo -f app.log --highlight "deadline exceeded=bg:red" --highlight "req-[0-9a-f]+=cyan"
+
+  # Alert when payments logs more than 50 errors a minute
+  gonzo -f app.log --follow --alert "payments: severity=ERROR k8s.namespace=payments > 50/min => banner,desktop"
+
+  # Watch the error budget of checkout's 1% error-rate SLO
+  gonzo -f app.log --follow --slo "checkout: severity=ERROR,FATAL < 1% over 30m where service.name=checkout"
+
+  # Track request latency from a duration attribute in the metrics pane
+  gonzo -f app.log --follow --metric "latency = histogram(duration) where service.name=api"
+
+  # Tap a stream while forwarding it to an OpenTelemetry Collector
+  gonzo -f app.log --follow --otlp-export-endpoint=http://localhost:4318
+
+  # Promote the errors of an ad-hoc tail into a persisted Loki stream
+  gonzo -f app.log --follow --loki-url=http://localhost:3100 --loki-filter='severity=ERROR' --loki-label=namespace=k8s.namespace
+
+  # Send a desktop notification for every panic
+  gonzo -f app.log --follow --exec='panic => jq -r .message | xargs -L1 notify-send gonzo'
+
+  # Post panics to a Slack channel, at most once a minute
+  gonzo -f app.log --follow --watch='panics: panic => https://hooks.slack.com/services/T000/B000/XXXX'
+
+  # Keep every entry of a live session, beyond the ring buffer
+  gonzo -f app.log --follow --capture-file=session.jsonl.gz
+
+  # Watch a canary, rewriting a summary report every minute
+  gonzo -f app.log --follow --no-tui --report=canary.md --report-interval=1m
+
+  # Open the selected entry's trace in Jaeger with b
+  gonzo -f app.log --link 'jaeger: trace_id=https://jaeger.example.com/trace/{value}'
+
+  # Keep a multi-hour session within 512MB, evicting the oldest entries
+  gonzo -f app.log --follow --log-buffer 1000000 --log-buffer-mb 512
+
+  # Scroll back through every entry of the session, not only those in memory
+  gonzo -f app.log --follow --log-spill
+
+  # Let teammates follow the session from a browser
+  gonzo -f app.log --follow --web-listen=127.0.0.1:7402
+
+  # Try the dashboard without a log source
+  gonzo --demo
+
   # Use built-in formats explicitly
   gonzo --format=json -f structured.log
-  gonzo --format=text -f plain.log`,
+  gonzo --format=text -f plain.log
+
+  # Print lines to stdout without the dashboard
+  gonzo -f app.log --follow -o logfmt --filter "error|timeout"`,
 		RunE: runApp,
 	}
 
@@ -158,14 +324,48 @@ func init() {
 	rootCmd.Flags().IntP("memory-size", "m", 10000, "Maximum number of entries to keep in memory")
 	rootCmd.Flags().DurationP("update-interval", "u", 1*time.Second, "Dashboard update interval")
 	rootCmd.Flags().IntP("log-buffer", "b", 1000, "Maximum log buffer size")
+	rootCmd.Flags().Int("log-buffer-mb", 0, "Also cap the log buffer's estimated memory at this many MB (default: 0, no cap)")
+	rootCmd.Flags().Bool("log-spill", false, "Keep entries evicted from the log buffer on disk, and page them back in when scrolling past the oldest entry")
+	rootCmd.Flags().String("log-spill-dir", "", "Directory for the --log-spill files, deleted on exit (default: the system's temporary directory)")
+	rootCmd.Flags().Int("log-spill-mb", 1024, "Disk space for --log-spill in MB; the oldest entries are deleted beyond it (0 for no limit)")
+	rootCmd.Flags().String("log-buffer-policy", tui.BufferPolicyDropOldest, "What a full log buffer does: drop-oldest evicts the oldest entries, stop discards new ones")
+	rootCmd.Flags().Int("ingest-batch-size", 500, "Most input lines the dashboard takes in one update")
+	rootCmd.Flags().Duration("ingest-batch-interval", 50*time.Millisecond, "Longest a line waits for its batch to fill before the dashboard takes it")
+	rootCmd.Flags().String("backpressure", backpressure.Block, "What a source does when gonzo falls behind: block waits, drop-oldest and drop-newest discard and count lines")
+	rootCmd.Flags().Int("input-channel-size", 0, "Lines the sources can queue for processing (default: 0, 128 per CPU up to 4096)")
+	rootCmd.Flags().Int("parser-workers", 0, "Goroutines parsing input lines (default: 0, one per CPU up to 8)")
+	rootCmd.Flags().Int("refresh", 30, "Most dashboard redraws per second, fewer while idle or unfocused (0 redraws on every update)")
 	rootCmd.Flags().BoolP("test-mode", "t", false, "Run in test mode (works without TTY)")
 	rootCmd.Flags().BoolP("version", "v", false, "Print version information")
 	rootCmd.Flags().String("ai-model", "", "AI model to use for log analysis (auto-selects best available if not specified)")
-	rootCmd.Flags().StringSliceP("file", "f", []string{}, "Files or file globs to read logs from (can specify multiple)")
+	rootCmd.Flags().String("ai-provider", ai.ProviderAuto, "AI provider: auto, openai, ollama, azure or compatible (vLLM, LM Studio, LocalAI)")
+	rootCmd.Flags().String("ai-base-url", "", "AI API base URL, e.g. http://localhost:11434/v1 for a local Ollama (default: $OPENAI_API_BASE or OpenAI)")
+	rootCmd.Flags().String("ai-api-key", "", "AI API key (default: $OPENAI_API_KEY; not needed for local servers)")
+	rootCmd.Flags().String("ai-api-version", "", "Azure OpenAI API version (default: 2024-06-01)")
+	rootCmd.Flags().String("ai-prompt-file", "", "File with the prompt template for AI analysis of a selection or the filtered view")
+	rootCmd.Flags().StringSliceP("file", "f", []string{}, "Files or file globs to read logs from, merged in timestamp order (can specify multiple)")
 	rootCmd.Flags().Bool("follow", false, "Follow log files like 'tail -f' (watch for new lines in real-time)")
+	rootCmd.Flags().String("checkpoint-file", "", "Save how far each --file has been read to this file, and resume from there when run again")
+	rootCmd.Flags().Bool("from-beginning", false, "Read the --file files from their start, ignoring the --checkpoint-file offsets")
+	rootCmd.Flags().String("tail-bytes", "", "Start each --file this far from its end, e.g. 200MB, reading what comes before when scrolling past it (default: the whole file)")
+	rootCmd.Flags().Int("tail-lines", 0, "Start each --file this many lines from its end, reading what comes before when scrolling past it (default: the whole file)")
+	rootCmd.Flags().String("max-line-size", "256KB", "Longest input line kept whole; longer lines are cut to it and tagged with their original size")
 	rootCmd.Flags().Bool("otlp-enabled", false, "Enable OTLP listener to receive logs via OpenTelemetry protocol (gRPC and HTTP)")
 	rootCmd.Flags().Int("otlp-grpc-port", 4317, "Port for OTLP gRPC listener (default: 4317)")
 	rootCmd.Flags().Int("otlp-http-port", 4318, "Port for OTLP HTTP listener (default: 4318)")
+	rootCmd.Flags().String("otlp-token", "", "Bearer token OTLP clients must send (can also use GONZO_OTLP_TOKEN env var)")
+	rootCmd.Flags().String("otlp-basic-auth", "", "USER:PASSWORD OTLP clients must send with basic auth (can also use GONZO_OTLP_BASIC_AUTH env var)")
+	rootCmd.Flags().StringSlice("otlp-allow", []string{}, "IP addresses or CIDR ranges allowed to connect to the OTLP listener, e.g. 10.0.0.0/8 (default: any)")
+	rootCmd.Flags().String("otlp-tls-cert", "", "Certificate the OTLP gRPC and HTTP listeners present to clients (default: plain text)")
+	rootCmd.Flags().String("otlp-tls-key", "", "Key of --otlp-tls-cert")
+	rootCmd.Flags().String("otlp-client-ca", "", "Require OTLP clients to present a certificate signed by this CA (mutual TLS)")
+	rootCmd.Flags().String("otlp-tls-min-version", "1.2", "Oldest TLS version OTLP clients may use: 1.2 or 1.3")
+	rootCmd.Flags().Duration("otlp-grpc-keepalive", 0, "Ping OTLP gRPC clients idle this long (default: gRPC's 2h)")
+	rootCmd.Flags().Duration("otlp-grpc-keepalive-timeout", 0, "Close OTLP gRPC connections not answering a ping within this (default: gRPC's 20s)")
+	rootCmd.Flags().Duration("otlp-grpc-min-ping-interval", 10*time.Second, "Shortest interval OTLP gRPC clients may send keepalive pings at without being disconnected")
+	rootCmd.Flags().Uint32("otlp-grpc-max-streams", 0, "Concurrent streams per OTLP gRPC connection (default: unlimited)")
+	rootCmd.Flags().String("otlp-grpc-window-size", "", "Initial flow control window of each OTLP gRPC stream, e.g. 1MB (default: sized dynamically)")
+	rootCmd.Flags().String("otlp-grpc-conn-window-size", "", "Initial flow control window of each OTLP gRPC connection, e.g. 4MB (default: sized dynamically)")
 	rootCmd.Flags().String("vmlogs-url", "", "Victoria Logs URL endpoint for streaming logs (e.g., http://localhost:9428)")
 	rootCmd.Flags().String("vmlogs-user", "", "Victoria Logs basic auth username (can also use GONZO_VMLOGS_USER env var)")
 	rootCmd.Flags().String("vmlogs-password", "", "Victoria Logs basic auth password (can also use GONZO_VMLOGS_PASSWORD env var)")
@@ -177,24 +377,128 @@ func init() {
 	rootCmd.Flags().String("k8s-selector", "", "Label selector to filter pods (e.g., 'app=myapp,env=prod')")
 	rootCmd.Flags().Int64("k8s-since", 0, "Only show logs newer than this many seconds (default: 0 = all)")
 	rootCmd.Flags().Int64("k8s-tail-lines", 10, "Lines of recent logs to show initially per pod (default: 10, use -1 for all)")
+	rootCmd.Flags().Bool("k8s-rollout-markers", true, "Mark Deployment rollouts in the watched namespaces on the counts chart")
+	rootCmd.Flags().String("since", "", "Only ingest entries logged at or after this time: a duration back such as 30m or 2d, or a time such as 2026-01-02T15:04:05Z, '2026-01-02 15:04' or 15:04")
+	rootCmd.Flags().String("until", "", "Only ingest entries logged at or before this time, in the same forms as --since")
+	rootCmd.Flags().String("min-severity", "", "Only ingest entries at or above this severity: trace, debug, info, warn, error or fatal (default: all)")
 	rootCmd.Flags().StringP("skin", "s", "default", "Color scheme/skin to use (default, or name of a skin file in ~/.config/gonzo/skins/)")
 	rootCmd.Flags().StringSlice("stop-words", []string{}, "Additional stop words to filter out from analysis (adds to built-in list)")
+	rootCmd.Flags().StringSlice("stop-words-file", []string{}, "Files of additional stop words, separated by spaces, commas or lines (# starts a comment)")
+	rootCmd.Flags().Bool("no-default-stop-words", false, "Drop the built-in English stop words, keeping only --stop-words and --stop-words-file")
+	rootCmd.Flags().Int("min-word-length", 3, "Shortest word counted in the word frequency analysis")
+	rootCmd.Flags().StringSlice("collapse-tokens", []string{}, "Count these tokens as one placeholder each in the word frequency analysis: numbers (<num>), uuids (<uuid>), hex (<hex>)")
 	rootCmd.Flags().String("format", "", "Log format to use (auto-detect if not specified). Can be: otlp, json, text, or a custom format name from ~/.config/gonzo/formats/")
+	rootCmd.Flags().StringArray("source-format", []string{}, "Log format of one source as SOURCE=FORMAT, overriding --format: SOURCE is a file path or name, or stdin, file, otlp, k8s or vmlogs; FORMAT is auto or a --format (can specify multiple)")
 	rootCmd.Flags().Bool("disable-version-check", false, "Disable automatic version checking on startup")
 	rootCmd.Flags().Bool("reverse-scroll-wheel", false, "Reverse scroll wheel direction (natural scrolling)")
 	rootCmd.Flags().Bool("use-log-time", false, "Use original log timestamps instead of receive time for heatmap and display (falls back to receive time if log has no timestamp)")
+	rootCmd.Flags().StringArray("highlight", []string{}, "Highlight rules as PATTERN=COLOR, or PATTERN=bg:COLOR to color the whole row (can specify multiple)")
+	rootCmd.Flags().String("view-filter", "", "Filter expression applied to the log view, as entered with F (e.g. 'severity=ERROR since=10m')")
+	rootCmd.Flags().Bool("resume", false, "Restore the filters, columns, scroll position, Kubernetes selection and pinned entries of the last session")
+	rootCmd.Flags().Bool("demo", false, "Explore the dashboard on synthetic logs of made-up services in Kubernetes, without a log source")
+	rootCmd.Flags().Bool("utc", false, "Display timestamps in UTC instead of local time")
+	rootCmd.Flags().String("time-format", tui.DefaultTimeFormat, "Go time layout for log timestamps from today")
+	rootCmd.Flags().String("date-time-format", tui.DefaultDateTimeFormat, "Go time layout for log timestamps older than today")
+	rootCmd.Flags().String("timestamp-mode", tui.TimestampModeAbsolute, "Log timestamp display: absolute, relative (\"2.3s ago\") or delta (\"+120ms\" from previous entry)")
+	rootCmd.Flags().Bool("no-tui", false, "Print processed log lines to stdout instead of running the dashboard")
+	rootCmd.Flags().StringP("output", "o", "", "Plain output format: raw, json or logfmt (implies --no-tui)")
+	rootCmd.Flags().String("filter", "", "Only print lines matching this regex in plain output mode")
+	rootCmd.Flags().Bool("line-numbers", false, "Show entry sequence numbers in the log list (toggle with #, jump with :)")
+	rootCmd.Flags().String("source-badges", "auto", "Badge naming each entry's source in the log list: auto (once two sources are seen), always or never")
+	rootCmd.Flags().StringArray("source-badge", []string{}, "Badge label and color of a source, as SOURCE=LABEL[:COLOR], e.g. k8s=⎈:#326ce5 (can specify multiple)")
+	rootCmd.Flags().StringSlice("hide-panels", []string{}, "Dashboard panels to hide: words, attributes, patterns, counts, pinned, metrics, slo, watches, http, new-patterns")
+	rootCmd.Flags().Int("charts-height", 0, "Maximum lines per chart row (0 sizes charts to their content; adjust at runtime with [ and ])")
+	rootCmd.Flags().String("status-line", "", "Status bar template, e.g. \"{rate} • buf {buffer_pct} • dropped {dropped}\" (variables: "+strings.Join(tui.StatusLineVariables(), ", ")+")")
+	rootCmd.Flags().Bool("tutorial", false, "Show the guided tour of the dashboard (shown automatically on first run; press t in help to reopen)")
+	rootCmd.Flags().StringArray("alert", []string{}, "Alert rules as [NAME:] FILTER > COUNT/WINDOW [=> banner,bell,desktop,webhook[=URL]] (can specify multiple)")
+	rootCmd.Flags().String("alert-webhook", "", "URL that alert rules with the webhook action POST to")
+	rootCmd.Flags().StringArray("slo", []string{}, "SLOs as [NAME:] FILTER < TARGET% over WINDOW [where SCOPE], FILTER selecting the bad entries (can specify multiple)")
+	rootCmd.Flags().StringArray("metric", []string{}, "Metric rules as NAME = count|histogram(FIELD)|gauge(FIELD) [where FILTER], shown in the metrics pane (can specify multiple)")
+	rootCmd.Flags().String("metrics-listen", "", "Serve Prometheus metrics on this address at /metrics, e.g. 127.0.0.1:9464 (default: disabled)")
+	rootCmd.Flags().String("pprof", "", "Serve the Go profiler on this address at /debug/pprof/, e.g. 127.0.0.1:6060 (default: disabled)")
+	rootCmd.Flags().String("log-file", "", "Also append gonzo's own log messages, shown with L in the dashboard, to this file")
+	rootCmd.Flags().String("log-level", "info", "Least severe of gonzo's own log messages kept: debug, info, warn or error")
+	rootCmd.Flags().String("otlp-export-endpoint", "", "Forward logs and metric rules to this OTLP/HTTP endpoint, e.g. http://localhost:4318 (default: disabled)")
+	rootCmd.Flags().StringSlice("otlp-export-signals", []string{"logs", "metrics"}, "Signals forwarded to the OTLP export endpoint: logs, metrics")
+	rootCmd.Flags().StringArray("otlp-export-header", []string{}, "Header sent with OTLP export requests as KEY=VALUE (can specify multiple)")
+	rootCmd.Flags().Duration("otlp-export-interval", 10*time.Second, "How often metric rules are forwarded to the OTLP export endpoint")
+	rootCmd.Flags().String("report", "", "Write a summary of the session (volumes, top and new patterns, error leaders, anomalies) to this file on exit; JSON if it ends in .json, Markdown otherwise")
+	rootCmd.Flags().Duration("report-interval", 0, "Also rewrite the --report summary at this interval (default: only on exit)")
+	rootCmd.Flags().String("loki-url", "", "Push entries to this Loki server, e.g. http://localhost:3100")
+	rootCmd.Flags().String("loki-filter", "", "Filter expression selecting the entries pushed to Loki (default: every entry)")
+	rootCmd.Flags().StringArray("loki-label", []string{}, "Loki stream label read from an attribute, as NAME=ATTRIBUTE or ATTRIBUTE (can specify multiple)")
+	rootCmd.Flags().StringArray("loki-header", []string{}, "Header sent with Loki push requests as KEY=VALUE (can specify multiple)")
+	rootCmd.Flags().String("loki-tenant", "", "Loki tenant sent as X-Scope-OrgID")
+	rootCmd.Flags().StringArray("exec", []string{}, "Pipe entries as JSON lines into a shell command, as [FILTER =>] COMMAND; restarted if it exits (can specify multiple)")
+	rootCmd.Flags().StringArray("watch", []string{}, "Count entries matching a filter in the watches pane, and post them to a webhook (Slack, Discord or generic JSON) if given, as [NAME:] FILTER [=> URL] (can specify multiple)")
+	rootCmd.Flags().String("notify-webhook", "", "Webhook URL for --watch expressions without their own")
+	rootCmd.Flags().Duration("notify-interval", notify.DefaultInterval, "Shortest time between two messages of a watch; matches in between are counted into the next one")
+	rootCmd.Flags().Int("notify-samples", notify.DefaultSamples, "Sample lines included in each watch message")
+	rootCmd.Flags().String("capture-file", "", "Append every ingested entry, before filtering, to this gzip-compressed JSON lines file")
+	rootCmd.Flags().Int("capture-max-size", 100, "Rotate the capture file when it reaches this many MB (0 disables)")
+	rootCmd.Flags().Duration("capture-max-age", time.Hour, "Rotate the capture file when it is this old (0 disables)")
+	rootCmd.Flags().StringArray("redact", []string{}, "Replace matches in every ingested entry, as PATTERN [=> REPLACEMENT] where PATTERN is a regexp or one of "+strings.Join(redact.Presets(), ", ")+" (can specify multiple)")
+	rootCmd.Flags().StringSlice("redact-attr", []string{}, "Replace the values of these attributes in every ingested entry, as key globs such as *token*")
+	rootCmd.Flags().StringArray("export-redact", []string{}, "Replace matches in exported and forwarded entries too, as PATTERN [=> REPLACEMENT] (can specify multiple)")
+	rootCmd.Flags().StringSlice("export-deny-attr", []string{}, "Remove these attributes from exported and forwarded entries, as key globs such as k8s.*")
+	rootCmd.Flags().String("latency-field", "", "Attribute holding request durations for the latency percentiles (default: detect duration/latency attributes and \"took 153ms\" in messages)")
+	rootCmd.Flags().Duration("latency-window", tui.DefaultLatencyWindow, "Rolling window the latency percentiles in the statistics modal cover")
+	rootCmd.Flags().Duration("new-pattern-window", tui.DefaultNewPatternWindow, "How long a message pattern stays in the new patterns pane after it is first seen")
+	rootCmd.Flags().Float64("outlier-threshold", tui.DefaultOutlierThreshold, "Modified z-score above which a numeric attribute value marks its entry as an outlier (0 disables)")
+	rootCmd.Flags().StringSlice("session-key", tui.DefaultSessionKeys, "Correlation attributes the sessions view (G) groups entries by, in order of preference")
+	rootCmd.Flags().StringArray("link", []string{}, "URL template opened in the browser from the selected entry (b), as [NAME:] ATTRIBUTE=URL with {value} placeholders (can specify multiple)")
+	rootCmd.Flags().String("aggregator-listen", "", "Show the entries forwarded by gonzo agents on this address, e.g. :7403, instead of local inputs")
+	rootCmd.Flags().String("aggregator-tls-cert", "", "Certificate the aggregator presents to agents (default: plain text)")
+	rootCmd.Flags().String("aggregator-tls-key", "", "Key of --aggregator-tls-cert")
+	rootCmd.Flags().String("aggregator-client-ca", "", "Require agents to present a certificate signed by this CA (mutual TLS)")
+	rootCmd.Flags().String("web-listen", "", "Serve a read-only web UI with a live tail and filter box on this address, e.g. 127.0.0.1:7402 (default: disabled)")
+	rootCmd.Flags().Bool("infer-severity", true, "Infer the severity of lines without a level from keywords (panic, exception, failed) and HTTP status codes")
 
 	// Bind flags to viper
 	viper.BindPFlag("memory-size", rootCmd.Flags().Lookup("memory-size"))
 	viper.BindPFlag("update-interval", rootCmd.Flags().Lookup("update-interval"))
 	viper.BindPFlag("log-buffer", rootCmd.Flags().Lookup("log-buffer"))
+	viper.BindPFlag("log-buffer-mb", rootCmd.Flags().Lookup("log-buffer-mb"))
+	viper.BindPFlag("log-buffer-policy", rootCmd.Flags().Lookup("log-buffer-policy"))
+	viper.BindPFlag("log-spill", rootCmd.Flags().Lookup("log-spill"))
+	viper.BindPFlag("log-spill-dir", rootCmd.Flags().Lookup("log-spill-dir"))
+	viper.BindPFlag("log-spill-mb", rootCmd.Flags().Lookup("log-spill-mb"))
+	viper.BindPFlag("ingest-batch-size", rootCmd.Flags().Lookup("ingest-batch-size"))
+	viper.BindPFlag("ingest-batch-interval", rootCmd.Flags().Lookup("ingest-batch-interval"))
+	viper.BindPFlag("backpressure", rootCmd.Flags().Lookup("backpressure"))
+	viper.BindPFlag("input-channel-size", rootCmd.Flags().Lookup("input-channel-size"))
+	viper.BindPFlag("parser-workers", rootCmd.Flags().Lookup("parser-workers"))
+	viper.BindPFlag("refresh", rootCmd.Flags().Lookup("refresh"))
 	viper.BindPFlag("test-mode", rootCmd.Flags().Lookup("test-mode"))
 	viper.BindPFlag("ai-model", rootCmd.Flags().Lookup("ai-model"))
+	viper.BindPFlag("ai-provider", rootCmd.Flags().Lookup("ai-provider"))
+	viper.BindPFlag("ai-base-url", rootCmd.Flags().Lookup("ai-base-url"))
+	viper.BindPFlag("ai-api-key", rootCmd.Flags().Lookup("ai-api-key"))
+	viper.BindPFlag("ai-api-version", rootCmd.Flags().Lookup("ai-api-version"))
+	viper.BindPFlag("ai-prompt-file", rootCmd.Flags().Lookup("ai-prompt-file"))
 	viper.BindPFlag("files", rootCmd.Flags().Lookup("file"))
 	viper.BindPFlag("follow", rootCmd.Flags().Lookup("follow"))
+	viper.BindPFlag("checkpoint-file", rootCmd.Flags().Lookup("checkpoint-file"))
+	viper.BindPFlag("from-beginning", rootCmd.Flags().Lookup("from-beginning"))
+	viper.BindPFlag("tail-bytes", rootCmd.Flags().Lookup("tail-bytes"))
+	viper.BindPFlag("tail-lines", rootCmd.Flags().Lookup("tail-lines"))
+	viper.BindPFlag("max-line-size", rootCmd.Flags().Lookup("max-line-size"))
 	viper.BindPFlag("otlp-enabled", rootCmd.Flags().Lookup("otlp-enabled"))
 	viper.BindPFlag("otlp-grpc-port", rootCmd.Flags().Lookup("otlp-grpc-port"))
 	viper.BindPFlag("otlp-http-port", rootCmd.Flags().Lookup("otlp-http-port"))
+	viper.BindPFlag("otlp-token", rootCmd.Flags().Lookup("otlp-token"))
+	viper.BindPFlag("otlp-basic-auth", rootCmd.Flags().Lookup("otlp-basic-auth"))
+	viper.BindPFlag("otlp-allow", rootCmd.Flags().Lookup("otlp-allow"))
+	viper.BindPFlag("otlp-tls-cert", rootCmd.Flags().Lookup("otlp-tls-cert"))
+	viper.BindPFlag("otlp-tls-key", rootCmd.Flags().Lookup("otlp-tls-key"))
+	viper.BindPFlag("otlp-client-ca", rootCmd.Flags().Lookup("otlp-client-ca"))
+	viper.BindPFlag("otlp-tls-min-version", rootCmd.Flags().Lookup("otlp-tls-min-version"))
+	viper.BindPFlag("otlp-grpc-keepalive", rootCmd.Flags().Lookup("otlp-grpc-keepalive"))
+	viper.BindPFlag("otlp-grpc-keepalive-timeout", rootCmd.Flags().Lookup("otlp-grpc-keepalive-timeout"))
+	viper.BindPFlag("otlp-grpc-min-ping-interval", rootCmd.Flags().Lookup("otlp-grpc-min-ping-interval"))
+	viper.BindPFlag("otlp-grpc-max-streams", rootCmd.Flags().Lookup("otlp-grpc-max-streams"))
+	viper.BindPFlag("otlp-grpc-window-size", rootCmd.Flags().Lookup("otlp-grpc-window-size"))
+	viper.BindPFlag("otlp-grpc-conn-window-size", rootCmd.Flags().Lookup("otlp-grpc-conn-window-size"))
 	viper.BindPFlag("vmlogs-url", rootCmd.Flags().Lookup("vmlogs-url"))
 	viper.BindPFlag("vmlogs-user", rootCmd.Flags().Lookup("vmlogs-user"))
 	viper.BindPFlag("vmlogs-password", rootCmd.Flags().Lookup("vmlogs-password"))
@@ -206,15 +510,131 @@ func init() {
 	viper.BindPFlag("k8s-selector", rootCmd.Flags().Lookup("k8s-selector"))
 	viper.BindPFlag("k8s-since", rootCmd.Flags().Lookup("k8s-since"))
 	viper.BindPFlag("k8s-tail-lines", rootCmd.Flags().Lookup("k8s-tail-lines"))
+	viper.BindPFlag("k8s-rollout-markers", rootCmd.Flags().Lookup("k8s-rollout-markers"))
+	viper.BindPFlag("since", rootCmd.Flags().Lookup("since"))
+	viper.BindPFlag("until", rootCmd.Flags().Lookup("until"))
+	viper.BindPFlag("min-severity", rootCmd.Flags().Lookup("min-severity"))
 	viper.BindPFlag("skin", rootCmd.Flags().Lookup("skin"))
 	viper.BindPFlag("stop-words", rootCmd.Flags().Lookup("stop-words"))
+	viper.BindPFlag("stop-words-file", rootCmd.Flags().Lookup("stop-words-file"))
+	viper.BindPFlag("no-default-stop-words", rootCmd.Flags().Lookup("no-default-stop-words"))
+	viper.BindPFlag("min-word-length", rootCmd.Flags().Lookup("min-word-length"))
+	viper.BindPFlag("collapse-tokens", rootCmd.Flags().Lookup("collapse-tokens"))
 	viper.BindPFlag("format", rootCmd.Flags().Lookup("format"))
+	viper.BindPFlag("source-format", rootCmd.Flags().Lookup("source-format"))
 	viper.BindPFlag("disable-version-check", rootCmd.Flags().Lookup("disable-version-check"))
 	viper.BindPFlag("reverse-scroll-wheel", rootCmd.Flags().Lookup("reverse-scroll-wheel"))
 	viper.BindPFlag("use-log-time", rootCmd.Flags().Lookup("use-log-time"))
+	viper.BindPFlag("highlight", rootCmd.Flags().Lookup("highlight"))
+	viper.BindPFlag("view-filter", rootCmd.Flags().Lookup("view-filter"))
+	viper.BindPFlag("resume", rootCmd.Flags().Lookup("resume"))
+	viper.BindPFlag("demo", rootCmd.Flags().Lookup("demo"))
+	viper.BindPFlag("utc", rootCmd.Flags().Lookup("utc"))
+	viper.BindPFlag("time-format", rootCmd.Flags().Lookup("time-format"))
+	viper.BindPFlag("date-time-format", rootCmd.Flags().Lookup("date-time-format"))
+	viper.BindPFlag("timestamp-mode", rootCmd.Flags().Lookup("timestamp-mode"))
+	viper.BindPFlag("no-tui", rootCmd.Flags().Lookup("no-tui"))
+	viper.BindPFlag("output", rootCmd.Flags().Lookup("output"))
+	viper.BindPFlag("filter", rootCmd.Flags().Lookup("filter"))
+	viper.BindPFlag("infer-severity", rootCmd.Flags().Lookup("infer-severity"))
+	viper.BindPFlag("line-numbers", rootCmd.Flags().Lookup("line-numbers"))
+	viper.BindPFlag("source-badges", rootCmd.Flags().Lookup("source-badges"))
+	viper.BindPFlag("source-badge", rootCmd.Flags().Lookup("source-badge"))
+	viper.BindPFlag("hide-panels", rootCmd.Flags().Lookup("hide-panels"))
+	viper.BindPFlag("charts-height", rootCmd.Flags().Lookup("charts-height"))
+	viper.BindPFlag("status-line", rootCmd.Flags().Lookup("status-line"))
+	viper.BindPFlag("tutorial", rootCmd.Flags().Lookup("tutorial"))
+	viper.BindPFlag("alert", rootCmd.Flags().Lookup("alert"))
+	viper.BindPFlag("alert-webhook", rootCmd.Flags().Lookup("alert-webhook"))
+	viper.BindPFlag("slo", rootCmd.Flags().Lookup("slo"))
+	viper.BindPFlag("metric", rootCmd.Flags().Lookup("metric"))
+	viper.BindPFlag("metrics-listen", rootCmd.Flags().Lookup("metrics-listen"))
+	viper.BindPFlag("pprof", rootCmd.Flags().Lookup("pprof"))
+	viper.BindPFlag("log-file", rootCmd.Flags().Lookup("log-file"))
+	viper.BindPFlag("log-level", rootCmd.Flags().Lookup("log-level"))
+	viper.BindPFlag("otlp-export-endpoint", rootCmd.Flags().Lookup("otlp-export-endpoint"))
+	viper.BindPFlag("otlp-export-signals", rootCmd.Flags().Lookup("otlp-export-signals"))
+	viper.BindPFlag("otlp-export-header", rootCmd.Flags().Lookup("otlp-export-header"))
+	viper.BindPFlag("otlp-export-interval", rootCmd.Flags().Lookup("otlp-export-interval"))
+	viper.BindPFlag("report", rootCmd.Flags().Lookup("report"))
+	viper.BindPFlag("report-interval", rootCmd.Flags().Lookup("report-interval"))
+	viper.BindPFlag("loki-url", rootCmd.Flags().Lookup("loki-url"))
+	viper.BindPFlag("loki-filter", rootCmd.Flags().Lookup("loki-filter"))
+	viper.BindPFlag("loki-label", rootCmd.Flags().Lookup("loki-label"))
+	viper.BindPFlag("loki-header", rootCmd.Flags().Lookup("loki-header"))
+	viper.BindPFlag("loki-tenant", rootCmd.Flags().Lookup("loki-tenant"))
+	viper.BindPFlag("exec", rootCmd.Flags().Lookup("exec"))
+	viper.BindPFlag("watch", rootCmd.Flags().Lookup("watch"))
+	viper.BindPFlag("notify-webhook", rootCmd.Flags().Lookup("notify-webhook"))
+	viper.BindPFlag("notify-interval", rootCmd.Flags().Lookup("notify-interval"))
+	viper.BindPFlag("notify-samples", rootCmd.Flags().Lookup("notify-samples"))
+	viper.BindPFlag("capture-file", rootCmd.Flags().Lookup("capture-file"))
+	viper.BindPFlag("capture-max-size", rootCmd.Flags().Lookup("capture-max-size"))
+	viper.BindPFlag("capture-max-age", rootCmd.Flags().Lookup("capture-max-age"))
+	viper.BindPFlag("redact", rootCmd.Flags().Lookup("redact"))
+	viper.BindPFlag("redact-attr", rootCmd.Flags().Lookup("redact-attr"))
+	viper.BindPFlag("export-redact", rootCmd.Flags().Lookup("export-redact"))
+	viper.BindPFlag("export-deny-attr", rootCmd.Flags().Lookup("export-deny-attr"))
+	viper.BindPFlag("latency-field", rootCmd.Flags().Lookup("latency-field"))
+	viper.BindPFlag("latency-window", rootCmd.Flags().Lookup("latency-window"))
+	viper.BindPFlag("new-pattern-window", rootCmd.Flags().Lookup("new-pattern-window"))
+	viper.BindPFlag("outlier-threshold", rootCmd.Flags().Lookup("outlier-threshold"))
+	viper.BindPFlag("session-key", rootCmd.Flags().Lookup("session-key"))
+	viper.BindPFlag("link", rootCmd.Flags().Lookup("link"))
+	viper.BindPFlag("web-listen", rootCmd.Flags().Lookup("web-listen"))
+	viper.BindPFlag("aggregator-listen", rootCmd.Flags().Lookup("aggregator-listen"))
+	viper.BindPFlag("aggregator-tls-cert", rootCmd.Flags().Lookup("aggregator-tls-cert"))
+	viper.BindPFlag("aggregator-tls-key", rootCmd.Flags().Lookup("aggregator-tls-key"))
+	viper.BindPFlag("aggregator-client-ca", rootCmd.Flags().Lookup("aggregator-client-ca"))
+
+	// serve takes the input flags and attach the display flags of the root command
+	serveCmd.Flags().String("listen", "127.0.0.1:7400", "Address to accept attach connections on")
+	viper.BindPFlag("serve-listen", serveCmd.Flags().Lookup("listen"))
+	serveCmd.Flags().String("api-listen", "", "Address to serve the HTTP query API on, e.g. 127.0.0.1:7401 (default: disabled)")
+	viper.BindPFlag("serve-api-listen", serveCmd.Flags().Lookup("api-listen"))
+	serveCmd.Flags().AddFlagSet(rootCmd.Flags())
+	attachCmd.Flags().AddFlagSet(rootCmd.Flags())
+
+	// agent takes the input flags of the root command
+	agentCmd.Flags().String("name", "", "Name the aggregator shows for this agent (default: the hostname; a verified client certificate's common name takes precedence)")
+	agentCmd.Flags().String("tls-ca", "", "CA certificate to verify the aggregator with (default: system roots when TLS is used)")
+	agentCmd.Flags().String("tls-cert", "", "Client certificate presented to the aggregator")
+	agentCmd.Flags().String("tls-key", "", "Key of --tls-cert")
+	viper.BindPFlag("agent-name", agentCmd.Flags().Lookup("name"))
+	viper.BindPFlag("agent-tls-ca", agentCmd.Flags().Lookup("tls-ca"))
+	viper.BindPFlag("agent-tls-cert", agentCmd.Flags().Lookup("tls-cert"))
+	viper.BindPFlag("agent-tls-key", agentCmd.Flags().Lookup("tls-key"))
+	agentCmd.Flags().AddFlagSet(rootCmd.Flags())
+
+	// tail, analyze, replay, bench and k8s are shortcuts for common uses of
+	// the root command and take all of its flags
+	tailCmd.Flags().AddFlagSet(rootCmd.Flags())
+	analyzeCmd.Flags().Int64Var(&analyzeMaxErrors, "max-errors", -1, "Exit with an error status when the input has more error entries than this (default: -1, no limit)")
+	analyzeCmd.Flags().AddFlagSet(rootCmd.Flags())
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 0, "Replay with the captured pauses between entries, sped up by this factor (default: 0, as fast as possible)")
+	replayCmd.Flags().AddFlagSet(rootCmd.Flags())
+	benchCmd.Flags().IntVar(&benchRate, "rate", 10000, "Lines generated per second (0 for as fast as the pipeline takes them)")
+	benchCmd.Flags().DurationVar(&benchDuration, "duration", 10*time.Second, "How long to generate lines for")
+	benchCmd.Flags().StringSliceVar(&benchFormats, "formats", []string{synth.FormatJSON}, "Formats the lines take in turn: "+strings.Join(synth.Formats, ", "))
+	benchCmd.Flags().IntVar(&benchServices, "services", synth.DefaultServices, "Distinct services logging")
+	benchCmd.Flags().IntVar(&benchPatterns, "patterns", synth.DefaultPatterns, "Distinct message patterns")
+	benchCmd.Flags().IntVar(&benchCardinality, "cardinality", synth.DefaultCardinality, "Distinct values of the user_id attribute")
+	benchCmd.Flags().AddFlagSet(rootCmd.Flags())
+	k8sCmd.Flags().StringSliceP("namespace", "n", []string{}, "Kubernetes namespaces to watch (default: all namespaces)")
+	k8sCmd.Flags().StringP("selector", "l", "", "Label selector to filter pods (e.g., 'app=myapp,env=prod')")
+	k8sCmd.Flags().String("context", "", "Kubernetes context to use (default: current context)")
+	k8sCmd.Flags().AddFlagSet(rootCmd.Flags())
 
 	// Add version command
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(agentCmd)
+	rootCmd.AddCommand(tailCmd)
+	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(k8sCmd)
 }
 
 func initConfig() {
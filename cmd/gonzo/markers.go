@@ -0,0 +1,41 @@
+package main
+
+import (
+	"log"
+
+	"github.com/control-theory/gonzo/internal/k8s"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rolloutBuffer is how many rollouts wait for the dashboard before more are dropped
+const rolloutBuffer = 16
+
+// rolloutMsg carries a Deployment rollout to mark on the counts chart
+type rolloutMsg k8s.Rollout
+
+// startRolloutMarkers marks the rollouts of the Deployments in the watched
+// namespaces on the counts chart (--k8s-rollout-markers)
+func (m *simpleTuiModel) startRolloutMarkers(source *k8s.KubernetesLogSource) {
+	if !cfg.K8sRolloutMarkers || m.dashboard == nil {
+		return
+	}
+	rollouts := make(chan k8s.Rollout, rolloutBuffer)
+	if err := source.WatchRollouts(rollouts); err != nil {
+		log.Printf("Warning: deploys will not be marked on the counts chart: %v", err)
+		return
+	}
+	m.rollouts = rollouts
+}
+
+// waitForRollout delivers the next rollout to the dashboard
+func (m *simpleTuiModel) waitForRollout() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case rollout := <-m.rollouts:
+			return rolloutMsg(rollout)
+		case <-m.ctx.Done():
+			return nil
+		}
+	}
+}
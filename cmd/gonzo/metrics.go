@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/metrics"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// Source labels for the exported metrics
+const (
+	sourceK8s    = "k8s"
+	sourceVmlogs = "vmlogs"
+	sourceOTLP   = "otlp"
+	sourceFile   = "file"
+	sourceStdin  = "stdin"
+	sourceAttach = "attach"
+	sourceReplay = "replay"
+	sourceAgent  = "agent"
+	sourceDemo   = "demo"
+)
+
+// loadMetricSet parses the configured metric rules, skipping invalid ones
+func loadMetricSet() *metrics.Set {
+	if len(cfg.Metrics) == 0 {
+		return nil
+	}
+	var rules []metrics.Rule
+	for _, spec := range cfg.Metrics {
+		rule, err := metrics.ParseRule(spec)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return metrics.NewSet(rules)
+}
+
+// sourceName names the active log input for metric labels
+func (m *simpleTuiModel) sourceName() string {
+	switch {
+	case m.hasAttachInput:
+		return sourceAttach
+	case m.hasReplayInput:
+		return sourceReplay
+	case m.hasAggregatorInput:
+		return sourceAgent
+	case m.hasDemoInput:
+		return sourceDemo
+	case m.hasK8sInput:
+		return sourceK8s
+	case m.hasVmlogsInput:
+		return sourceVmlogs
+	case m.hasOTLPInput:
+		return sourceOTLP
+	case m.hasFileInput:
+		return sourceFile
+	}
+	return sourceStdin
+}
+
+// observeEntry feeds a processed entry to the metric rules and the exporter
+func (m *simpleTuiModel) observeEntry(entry *tui.LogEntry) {
+	if m.metricSet == nil && m.exporter == nil {
+		return
+	}
+	rec := tui.EntryRecord(*entry)
+	if m.metricSet != nil {
+		m.metricSet.Observe(rec, time.Now())
+	}
+	if m.exporter != nil {
+		m.exporter.ObserveEntry(m.sourceName(), tui.ServiceName(*entry), rec.Severity)
+	}
+}
+
+// startMetricsServer serves the Prometheus metrics on --metrics-listen. It
+// runs once the input sources have started, so their counters can be added.
+func (m *simpleTuiModel) startMetricsServer() {
+	if cfg.MetricsListen == "" {
+		return
+	}
+
+	exporter := metrics.NewExporter(m.metricSet)
+	if m.inputQueue != nil {
+		source := m.sourceName()
+		exporter.AddCounterFunc("gonzo_lines_dropped_total", "Input lines dropped because processing fell behind, by source",
+			map[string]string{"source": source}, func() float64 { return float64(m.droppedBySource()[source]) })
+	}
+	if m.k8sReceiver != nil {
+		receiver := m.k8sReceiver
+		exporter.AddGaugeFunc("gonzo_k8s_active_streams", "Active Kubernetes pod log streams",
+			nil, func() float64 { return float64(receiver.GetActiveStreams()) })
+	}
+
+	listener, err := net.Listen("tcp", cfg.MetricsListen)
+	if err != nil {
+		log.Printf("Warning: failed to listen for metrics on %s: %v", cfg.MetricsListen, err)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	go func() {
+		if err := http.Serve(listener, mux); err != nil && !errors.Is(err, net.ErrClosed) {
+			log.Printf("Warning: metrics server stopped: %v", err)
+		}
+	}()
+	log.Printf("Serving metrics on http://%s/metrics", listener.Addr())
+	m.exporter = exporter
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/notify"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// parseWatches parses the --watch expressions, skipping invalid ones
+func parseWatches(specs []string, webhook string) ([]notify.Watch, []error) {
+	var watches []notify.Watch
+	var errs []error
+	for _, spec := range specs {
+		watch, err := notify.ParseWatch(spec, webhook)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		watches = append(watches, watch)
+	}
+	return watches, errs
+}
+
+// startNotifier starts posting the matches of the --watch expressions to
+// their webhooks. Watches without a webhook are only counted on the
+// dashboard.
+func (m *simpleTuiModel) startNotifier() {
+	parsed, errs := parseWatches(cfg.Watches, cfg.NotifyWebhook)
+	for _, err := range errs {
+		log.Printf("Warning: %v", err)
+	}
+	var watches []notify.Watch
+	for _, watch := range parsed {
+		if watch.URL != "" {
+			watches = append(watches, watch)
+		}
+	}
+	if len(watches) == 0 {
+		return
+	}
+	m.notifier = notify.NewNotifier(watches, cfg.NotifyInterval, cfg.NotifySamples)
+	m.notifier.Start()
+}
+
+// stopNotifier posts the matches not sent yet before gonzo exits
+func (m *simpleTuiModel) stopNotifier() {
+	if m.notifier == nil {
+		return
+	}
+	m.notifier.Stop()
+}
+
+// notifyEntry counts a processed entry against the watches
+func (m *simpleTuiModel) notifyEntry(entry *tui.LogEntry) {
+	if m.notifier == nil {
+		return
+	}
+	m.notifier.Observe(tui.EntryRecord(*entry), tui.PlainLogLine(*entry), time.Now())
+}
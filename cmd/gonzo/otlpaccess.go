@@ -0,0 +1,116 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/agent"
+	"github.com/control-theory/gonzo/internal/otlpreceiver"
+)
+
+// tlsVersions are the versions --otlp-tls-min-version takes
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// configureOTLPReceiver restricts who may send logs to the OTLP receiver and
+// how, from the --otlp-* flags. Call it before starting the receiver.
+func configureOTLPReceiver(r *otlpreceiver.Receiver) error {
+	access, err := otlpAccess()
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := otlpTLS()
+	if err != nil {
+		return err
+	}
+	grpcOptions, err := otlpGRPCOptions()
+	if err != nil {
+		return err
+	}
+	r.SetAccess(access)
+	r.SetTLS(tlsConfig)
+	r.SetGRPCOptions(grpcOptions)
+	return nil
+}
+
+// otlpAccess returns who may send logs to the OTLP receiver, from
+// --otlp-token, --otlp-basic-auth and --otlp-allow
+func otlpAccess() (otlpreceiver.Access, error) {
+	access := otlpreceiver.Access{BearerToken: cfg.OTLPToken}
+	if cfg.OTLPBasicAuth != "" {
+		user, password, ok := strings.Cut(cfg.OTLPBasicAuth, ":")
+		if !ok || user == "" {
+			return access, fmt.Errorf("invalid --otlp-basic-auth: use USER:PASSWORD")
+		}
+		access.Username, access.Password = user, password
+	}
+	allowed, err := otlpreceiver.ParseAllowList(cfg.OTLPAllow)
+	if err != nil {
+		return access, fmt.Errorf("invalid --otlp-allow: %w", err)
+	}
+	access.Allowed = allowed
+	return access, nil
+}
+
+// otlpTLS returns the TLS config of the OTLP listeners from --otlp-tls-cert,
+// --otlp-tls-key, --otlp-client-ca and --otlp-tls-min-version, or nil to
+// serve plain text
+func otlpTLS() (*tls.Config, error) {
+	if cfg.OTLPTLSCert == "" && cfg.OTLPTLSKey == "" {
+		if cfg.OTLPClientCA != "" {
+			return nil, fmt.Errorf("--otlp-client-ca needs --otlp-tls-cert and --otlp-tls-key")
+		}
+		return nil, nil
+	}
+	config, err := agent.ServerTLS(cfg.OTLPTLSCert, cfg.OTLPTLSKey, cfg.OTLPClientCA)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.OTLPTLSMinVersion != "" {
+		version, ok := tlsVersions[strings.TrimSpace(cfg.OTLPTLSMinVersion)]
+		if !ok {
+			return nil, fmt.Errorf("invalid --otlp-tls-min-version %q: use 1.2 or 1.3", cfg.OTLPTLSMinVersion)
+		}
+		config.MinVersion = version
+	}
+	return config, nil
+}
+
+// otlpGRPCOptions returns the tuning of the OTLP gRPC listener from the
+// --otlp-grpc-* flags
+func otlpGRPCOptions() (otlpreceiver.GRPCOptions, error) {
+	options := otlpreceiver.GRPCOptions{
+		KeepaliveTime:    cfg.OTLPGRPCKeepalive,
+		KeepaliveTimeout: cfg.OTLPGRPCKeepaliveTTL,
+		MinPingInterval:  cfg.OTLPGRPCMinPing,
+		MaxStreams:       cfg.OTLPGRPCMaxStreams,
+	}
+	var err error
+	if options.WindowSize, err = windowSize(cfg.OTLPGRPCWindow); err != nil {
+		return options, fmt.Errorf("invalid --otlp-grpc-window-size: %w", err)
+	}
+	if options.ConnWindowSize, err = windowSize(cfg.OTLPGRPCConnWindow); err != nil {
+		return options, fmt.Errorf("invalid --otlp-grpc-conn-window-size: %w", err)
+	}
+	return options, nil
+}
+
+// windowSize parses a gRPC flow control window such as 1MB, which gRPC
+// ignores below 64KB
+func windowSize(s string) (int32, error) {
+	if s == "" {
+		return 0, nil
+	}
+	size, err := parseByteSize(s)
+	if err != nil {
+		return 0, err
+	}
+	if size < 64<<10 || size > math.MaxInt32 {
+		return 0, fmt.Errorf("%s is outside 64KB to 2GB", s)
+	}
+	return int32(size), nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/otlpexport"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// parseHeaders parses KEY=VALUE header flags, warning about invalid ones
+func parseHeaders(kind string, specs []string) map[string]string {
+	headers := make(map[string]string)
+	for _, header := range specs {
+		key, value, ok := strings.Cut(header, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			log.Printf("Warning: invalid %s header %q: expected KEY=VALUE", kind, header)
+			continue
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+// startOTLPExport starts forwarding to --otlp-export-endpoint, if set
+func (m *simpleTuiModel) startOTLPExport() {
+	if cfg.OTLPExportEndpoint == "" {
+		return
+	}
+
+	exporter, err := otlpexport.NewExporter(otlpexport.Config{
+		Endpoint: cfg.OTLPExportEndpoint,
+		Signals:  cfg.OTLPExportSignals,
+		Headers:  parseHeaders("OTLP export", cfg.OTLPExportHeaders),
+		Interval: cfg.OTLPExportInterval,
+	}, m.metricSet)
+	if err != nil {
+		log.Printf("Warning: OTLP export disabled: %v", err)
+		return
+	}
+	exporter.Start()
+	m.otlpExporter = exporter
+}
+
+// stopOTLPExport sends what is still queued before gonzo exits
+func (m *simpleTuiModel) stopOTLPExport() {
+	if m.otlpExporter == nil {
+		return
+	}
+	m.otlpExporter.Stop()
+	if dropped := m.otlpExporter.Dropped(); dropped > 0 {
+		log.Printf("Warning: OTLP export dropped %d log records because the endpoint fell behind", dropped)
+	}
+}
+
+// forwardEntry queues a processed entry for the OTLP export endpoint
+func (m *simpleTuiModel) forwardEntry(entry *tui.LogEntry) {
+	if m.otlpExporter == nil || !m.otlpExporter.ExportsLogs() {
+		return
+	}
+	// The record is encoded on another goroutine, so it gets its own attributes
+	entry = redactEntry(m.exportRedactor, entry)
+	body := entry.Message
+	if body == "" {
+		body = entry.RawLine
+	}
+	m.otlpExporter.AddLog(otlpexport.LogRecord{
+		Time:         entry.OrigTimestamp,
+		ObservedTime: entry.Timestamp,
+		Severity:     entry.Severity,
+		Body:         body,
+		Attributes:   entry.Attributes,
+	})
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Plain output formats for --no-tui mode
+const (
+	plainFormatRaw    = "raw"
+	plainFormatJSON   = "json"
+	plainFormatLogfmt = "logfmt"
+)
+
+// plainPrinter writes processed log entries to stdout without the dashboard
+type plainPrinter struct {
+	format string
+	filter *regexp.Regexp
+	out    *bufio.Writer
+}
+
+// plainJSONEntry is the JSON line written for each entry in json output
+type plainJSONEntry struct {
+	Timestamp     time.Time         `json:"timestamp"`
+	OrigTimestamp *time.Time        `json:"original_timestamp,omitempty"`
+	Severity      string            `json:"severity"`
+	Message       string            `json:"message"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// newPlainPrinter validates the output format and filter for plain mode
+func newPlainPrinter(format, filter string, out io.Writer) (*plainPrinter, error) {
+	if format == "" {
+		format = plainFormatRaw
+	}
+	switch format {
+	case plainFormatRaw, plainFormatJSON, plainFormatLogfmt:
+	default:
+		return nil, fmt.Errorf("unknown output format %q (use raw, json or logfmt)", format)
+	}
+
+	printer := &plainPrinter{format: format, out: bufio.NewWriter(out)}
+	if filter != "" {
+		regex, err := regexp.Compile(filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter regex: %w", err)
+		}
+		printer.filter = regex
+	}
+	return printer, nil
+}
+
+// matches applies the filter to the raw line, message and attributes, like
+// the dashboard's regex filter
+func (p *plainPrinter) matches(entry *tui.LogEntry) bool {
+	if p.filter == nil {
+		return true
+	}
+	if p.filter.MatchString(entry.RawLine) || p.filter.MatchString(entry.Message) {
+		return true
+	}
+	for key, value := range entry.Attributes {
+		if p.filter.MatchString(key) || p.filter.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// print writes one entry in the selected format
+func (p *plainPrinter) print(entry *tui.LogEntry) {
+	if entry == nil || !p.matches(entry) {
+		return
+	}
+
+	switch p.format {
+	case plainFormatJSON:
+		p.printJSON(entry)
+	case plainFormatLogfmt:
+		p.printLogfmt(entry)
+	default:
+		p.printRaw(entry)
+	}
+}
+
+// printRaw writes the message, prefixed with its source like stern does
+func (p *plainPrinter) printRaw(entry *tui.LogEntry) {
+	message := entry.RawLine
+	if message == "" {
+		message = entry.Message
+	}
+	line := lipgloss.NewStyle().Foreground(tui.GetSeverityColor(entry.Severity)).Render(message)
+	if source := entrySource(entry); source != "" {
+		line = lipgloss.NewStyle().Foreground(tui.ColorBlue).Render(source) + " " + line
+	}
+	fmt.Fprintln(p.out, line)
+}
+
+// printJSON writes the entry as a JSON object
+func (p *plainPrinter) printJSON(entry *tui.LogEntry) {
+	out := plainJSONEntry{
+		Timestamp:  entry.Timestamp,
+		Severity:   entry.Severity,
+		Message:    entry.Message,
+		Attributes: entry.Attributes,
+	}
+	if !entry.OrigTimestamp.IsZero() {
+		orig := entry.OrigTimestamp
+		out.OrigTimestamp = &orig
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return
+	}
+	p.out.Write(data)
+	p.out.WriteByte('\n')
+}
+
+// printLogfmt writes the entry as logfmt key=value pairs with the attributes
+// sorted by key
+func (p *plainPrinter) printLogfmt(entry *tui.LogEntry) {
+	timestamp := entry.Timestamp
+	if !entry.OrigTimestamp.IsZero() {
+		timestamp = entry.OrigTimestamp
+	}
+	level := lipgloss.NewStyle().Foreground(tui.GetSeverityColor(entry.Severity)).Render(entry.Severity)
+
+	var line strings.Builder
+	fmt.Fprintf(&line, "time=%s level=%s msg=%s", timestamp.Format(time.RFC3339Nano), level, logfmtValue(entry.Message))
+
+	keys := make([]string, 0, len(entry.Attributes))
+	for key := range entry.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&line, " %s=%s", key, logfmtValue(entry.Attributes[key]))
+	}
+	fmt.Fprintln(p.out, line.String())
+}
+
+// entrySource describes where an entry came from, if known
+func entrySource(entry *tui.LogEntry) string {
+	if pod := entry.Attributes["k8s.pod"]; pod != "" {
+		if namespace := entry.Attributes["k8s.namespace"]; namespace != "" {
+			return namespace + "/" + pod
+		}
+		return pod
+	}
+	return entry.Attributes["service.name"]
+}
+
+// logfmtValue quotes a value when it contains spaces, quotes or '='
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if strings.ContainsAny(value, " \t\n\r\"=") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
+
+// runPlain processes the configured log input and prints the entries to
+// stdout instead of running the dashboard. It returns when the input ends
+// or on interrupt.
+func runPlain(m *simpleTuiModel) error {
+	printer, err := newPlainPrinter(cfg.Output, cfg.Filter, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := m.runHeadless(ctx, cancel, printer.print, func() { printer.out.Flush() }); err != nil {
+		return err
+	}
+	return printer.out.Flush()
+}
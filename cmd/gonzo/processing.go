@@ -1,48 +1,241 @@
 package main
 
 import (
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/control-theory/gonzo/internal/analyzer"
+	"github.com/control-theory/gonzo/internal/linelimit"
 	"github.com/control-theory/gonzo/internal/otlplog"
 	"github.com/control-theory/gonzo/internal/tui"
 )
 
+// inputLine is a line of the unified input channel
+type inputLine struct {
+	text   string
+	file   string    // File the line was read from, when several files are merged
+	sent   time.Time // When gonzo bench generated the line, zero otherwise
+	source string    // Source that queued the line, for the drop counts
+}
+
+// Source returns the source that queued the line
+func (l inputLine) Source() string {
+	return l.source
+}
+
+// processInputLine handles one line from the input channel: an entry from
+// a gonzo server when attached or from an agent when aggregating, otherwise
+// a raw log line
+func (m *simpleTuiModel) processInputLine(line inputLine) {
+	m.observeLine(line)
+	if m.hasAttachInput || m.hasReplayInput || m.hasAggregatorInput {
+		m.processRemoteEntry(line.text)
+		return
+	}
+	m.processLogLine(line)
+}
+
+// processBatch handles a batch of lines from the input channel and gives
+// their entries to the dashboard in one update. Log lines are parsed by the
+// --parser-workers and processed in their order.
+func (m *simpleTuiModel) processBatch(lines []inputLine) {
+	if m.hasAttachInput || m.hasReplayInput || m.hasAggregatorInput || m.parserWorkers <= 1 {
+		for _, line := range lines {
+			m.processInputLine(line)
+		}
+	} else {
+		jobs := make([]parseJob, 0, len(lines))
+		for _, line := range lines {
+			m.observeLine(line)
+			if job, ok := m.acceptLogLine(line); ok {
+				jobs = append(jobs, job)
+			}
+		}
+		for _, parsed := range m.parseAll(jobs) {
+			m.processParsed(parsed)
+		}
+	}
+	if len(m.pendingEntries) == 0 {
+		return
+	}
+	m.dashboard.Update(tui.UpdateMsg{NewLogBatch: m.pendingEntries})
+	m.pendingEntries = nil
+}
+
+// observeLine counts a line read from the input
+func (m *simpleTuiModel) observeLine(line inputLine) {
+	if m.exporter != nil {
+		m.exporter.ObserveLine(m.sourceName())
+	}
+	m.sources.line(m.lineSource(line), lineSize(line.text))
+}
+
 // processLogLine processes a single log line and updates frequency memory
-func (m *simpleTuiModel) processLogLine(line string) {
+func (m *simpleTuiModel) processLogLine(line inputLine) {
+	if job, ok := m.acceptLogLine(line); ok {
+		m.processParsed(m.parse(job))
+	}
+}
+
+// parseJob is a complete log line, or multi-line JSON object, to parse
+type parseJob struct {
+	text string
+	json bool   // Read as a JSON object, possibly over several lines
+	file string // File of a merged file input the text was read from
+	old  bool   // Read back from before the tail of a file, not received
+	size int64  // Of the line before --max-line-size cut it, zero when whole
+}
+
+// parsedEntry is an entry parsed from a log line with its analysis
+type parsedEntry struct {
+	result     *analyzer.AnalysisResult
+	attributes map[string]string
+	entry      *tui.LogEntry
+	err        error // Why the line's format failed to parse it; the entry, if any, is the line as plain text
+}
+
+// acceptLogLine takes the steps of processing a line that depend on the
+// lines before it, returning the text to parse once there is one
+func (m *simpleTuiModel) acceptLogLine(line inputLine) (parseJob, bool) {
 	// Early filter: Skip OTLP collector logs about traces/metrics processing
-	if isOTLPSignalLog(line) {
-		return // Skip processing this line entirely
+	if isOTLPSignalLog(line.text) {
+		return parseJob{}, false // Skip processing this line entirely
 	}
 
-	// Handle multi-line JSON accumulation
-	if m.tryAccumulateJSON(line) {
-		return // Line was accumulated, wait for complete JSON
+	// A line cut short is parsed on its own, not as the start of a JSON
+	// object spanning the lines after it
+	text, size := linelimit.Unmark(line.text)
+	if size > 0 {
+		m.logCount++
+		return parseJob{text: text, file: line.file, size: size}, true
+	}
+
+	// Handle multi-line JSON accumulation, unless the source is plain text
+	if !m.formatOf(line.file).joinsJSON() {
+		m.logCount++
+		return parseJob{text: line.text, file: line.file}, true
+	}
+	if complete, accumulated := m.tryAccumulateJSON(line.text); accumulated {
+		if complete == "" {
+			return parseJob{}, false // Line was accumulated, wait for complete JSON
+		}
+		m.logCount++
+		return parseJob{text: complete, json: true, file: line.file}, true
 	}
 
 	// Count only lines that pass the filter
 	m.logCount++
+	return parseJob{text: line.text, file: line.file}, true
+}
+
+// parse parses the entries of an accepted line. It only reads the model, so
+// lines can be parsed concurrently.
+func (m *simpleTuiModel) parse(job parseJob) []parsedEntry {
+	// Patterns are redacted before parsing so no analysis sees the matches
+	job.text = m.redactor.Text(job.text)
+	f := m.formatOf(job.file)
+	var parsed []parsedEntry
+	if job.json {
+		parsed = m.parseCompleteJSON(job.text, f)
+	} else {
+		parsed = m.parseLogLine(job.text, f)
+	}
+	if job.file != "" {
+		for _, p := range parsed {
+			tagFileEntry(p, job.file)
+		}
+	}
+	if job.size > 0 {
+		for _, p := range parsed {
+			tagCutEntry(p, job.size)
+		}
+	}
+	return m.quarantineFailed(job, parsed)
+}
+
+// quarantineFailed counts the entries of a received line its format failed
+// to parse and keeps the line for the unparsed view, returning the entries
+// to process
+func (m *simpleTuiModel) quarantineFailed(job parseJob, parsed []parsedEntry) []parsedEntry {
+	source := m.lineSource(inputLine{file: job.file})
+	kept := parsed[:0]
+	var first error
+	for _, p := range parsed {
+		if p.err != nil && !job.old {
+			m.sources.parseError(source)
+			if first == nil {
+				first = p.err
+			}
+		}
+		if p.entry != nil {
+			kept = append(kept, p)
+		}
+	}
+	if first != nil {
+		m.unparsed.add(source, job.text, first)
+	}
+	return kept
+}
+
+// Attributes naming the file of an entry read from several merged files,
+// as in the OpenTelemetry semantic conventions
+const (
+	fileNameAttribute = "log.file.name"
+	filePathAttribute = "log.file.path"
+)
+
+// tagFileEntry names the file of an entry read from one of several merged
+// files, in its attributes and source
+func tagFileEntry(p parsedEntry, file string) {
+	if p.entry == nil {
+		return
+	}
+	if p.entry.Attributes == nil {
+		p.entry.Attributes = make(map[string]string)
+	}
+	for _, attributes := range []map[string]string{p.attributes, p.entry.Attributes} {
+		if attributes != nil {
+			attributes[fileNameAttribute] = filepath.Base(file)
+			attributes[filePathAttribute] = file
+		}
+	}
+	p.entry.Source = fileSource(file)
+}
+
+// processParsed processes parsed entries in order
+func (m *simpleTuiModel) processParsed(parsed []parsedEntry) {
+	for _, p := range parsed {
+		m.processSingleLogEntry(p.result, p.attributes, p.entry)
+	}
+}
+
+// parseLogLine parses the entries of a single log line
+func (m *simpleTuiModel) parseLogLine(line string, f *lineFormat) []parsedEntry {
+	var parsed []parsedEntry
 
 	// Detect format
-	format := m.formatDetector.DetectFormat(line)
+	format := f.detector.DetectFormat(line)
 
 	var result *analyzer.AnalysisResult
 	var attributes map[string]string
 	var logEntry *tui.LogEntry
+	var parseErr error // Why the line's format failed to parse it
 
 	if format == otlplog.FormatOTLP {
 		// Handle OTLP format
-		if m.formatDetector.IsOTLPBatch(line) {
+		if f.detector.IsOTLPBatch(line) {
 			// Parse OTLP batch and extract ALL log entries
-			logsData, err := m.formatDetector.ParseOTLPBatch(line)
+			logsData, err := f.detector.ParseOTLPBatch(line)
 			if err != nil {
 				// Fallback to text analysis
 				result = m.textAnalyzer.AnalyzeLine(line)
 				attributes = make(map[string]string)
 				logEntry = createFallbackLogEntry(line)
+				parseErr = err
 
 				// Process the single fallback entry
-				m.processSingleLogEntry(result, attributes, logEntry)
+				parsed = append(parsed, parsedEntry{result, attributes, logEntry, parseErr})
 			} else {
 				// Extract ALL log entries from the batch
 				logEntries := extractAllLogEntriesFromOTLPBatch(logsData)
@@ -53,35 +246,39 @@ func (m *simpleTuiModel) processLogLine(line string) {
 					entryResult := m.otlpAnalyzer.AnalyzeOTLPRecord(convertLogEntryToOTLPRecord(entry))
 					entryAttributes := entry.Attributes // Already includes resource + record attributes
 
-					m.processSingleLogEntry(entryResult, entryAttributes, entry)
+					parsed = append(parsed, parsedEntry{entryResult, entryAttributes, entry, nil})
 				}
 			}
-			return // Important: return early for batch processing to avoid duplicate processing below
+			return parsed // Important: return early for batch processing to avoid duplicate processing below
 		} else {
 			// Parse single OTLP record
-			record, err := m.formatDetector.ParseSingleOTLPRecord(line)
+			record, err := f.detector.ParseSingleOTLPRecord(line)
 			if err != nil {
 				result = m.textAnalyzer.AnalyzeLine(line)
 				attributes = make(map[string]string)
 				logEntry = createFallbackLogEntry(line)
+				parseErr = err
 			} else {
 				result = m.otlpAnalyzer.AnalyzeOTLPRecord(record)
 				attributes = m.otlpAnalyzer.ExtractAttributesFromOTLPRecord(record)
 				logEntry = extractLogEntryFromOTLPRecord(record)
+				tagSeverityNumber(logEntry, record.SeverityNumber)
 			}
 		}
 	} else if format == otlplog.FormatCustom {
 
 		// Handle custom format - check if it's a batch format that needs expansion
-		if m.logConverter != nil && m.customParser != nil {
+		if f.converter != nil && f.custom != nil {
 			// Check if this is a batch format using the format configuration
-			if expandedLines, err := m.customParser.ExpandBatch(line); err == nil && len(expandedLines) > 1 {
+			if expandedLines, err := f.custom.ExpandBatch(line); err == nil && len(expandedLines) > 1 {
 				// This is a batch format - process each expanded entry
 				for _, expandedLine := range expandedLines {
 					// Process each expanded entry
-					otlpRecord, err := m.logConverter.ConvertToOTLP(expandedLine, format)
+					otlpRecord, err := f.converter.ConvertToOTLP(expandedLine, format)
 					if err != nil {
-						continue // Skip entries that fail to parse
+						// Quarantined without an entry
+						parsed = append(parsed, parsedEntry{nil, nil, nil, err})
+						continue
 					}
 					result = m.otlpAnalyzer.AnalyzeOTLPRecord(otlpRecord)
 					attributes = m.otlpAnalyzer.ExtractAttributesFromOTLPRecord(otlpRecord)
@@ -89,16 +286,17 @@ func (m *simpleTuiModel) processLogLine(line string) {
 
 					// Note: logEntry already contains all attributes from OTLP record extraction
 					// Process each entry individually
-					m.processSingleLogEntry(result, attributes, logEntry)
+					parsed = append(parsed, parsedEntry{result, attributes, logEntry, nil})
 				}
-				return // All entries processed, exit early
+				return parsed // All entries processed, exit early
 			} else {
 				// Regular custom format processing (single entry or batch expansion failed)
-				otlpRecord, err := m.logConverter.ConvertToOTLP(line, format)
+				otlpRecord, err := f.converter.ConvertToOTLP(line, format)
 				if err != nil {
 					result = m.textAnalyzer.AnalyzeLine(line)
 					attributes = make(map[string]string)
 					logEntry = createFallbackLogEntry(line)
+					parseErr = err
 				} else {
 					result = m.otlpAnalyzer.AnalyzeOTLPRecord(otlpRecord)
 					attributes = m.otlpAnalyzer.ExtractAttributesFromOTLPRecord(otlpRecord)
@@ -108,11 +306,12 @@ func (m *simpleTuiModel) processLogLine(line string) {
 			}
 		} else {
 			// No custom parser available - fallback to regular processing
-			otlpRecord, err := m.logConverter.ConvertToOTLP(line, format)
+			otlpRecord, err := f.converter.ConvertToOTLP(line, format)
 			if err != nil {
 				result = m.textAnalyzer.AnalyzeLine(line)
 				attributes = make(map[string]string)
 				logEntry = createFallbackLogEntry(line)
+				parseErr = err
 			} else {
 				result = m.otlpAnalyzer.AnalyzeOTLPRecord(otlpRecord)
 				attributes = m.otlpAnalyzer.ExtractAttributesFromOTLPRecord(otlpRecord)
@@ -122,11 +321,12 @@ func (m *simpleTuiModel) processLogLine(line string) {
 		}
 	} else {
 		// Convert other non-OTLP formats to OTLP
-		otlpRecord, err := m.logConverter.ConvertToOTLP(line, format)
+		otlpRecord, err := f.converter.ConvertToOTLP(line, format)
 		if err != nil {
 			result = m.textAnalyzer.AnalyzeLine(line)
 			attributes = make(map[string]string)
 			logEntry = createFallbackLogEntry(line)
+			parseErr = err
 		} else {
 			result = m.otlpAnalyzer.AnalyzeOTLPRecord(otlpRecord)
 			attributes = m.otlpAnalyzer.ExtractAttributesFromOTLPRecord(otlpRecord)
@@ -135,12 +335,25 @@ func (m *simpleTuiModel) processLogLine(line string) {
 		}
 	}
 
-	// Process single log entry (for non-batch OTLP and other formats)
-	m.processSingleLogEntry(result, attributes, logEntry)
+	// Single log entry (for non-batch OTLP and other formats)
+	return append(parsed, parsedEntry{result, attributes, logEntry, parseErr})
 }
 
 // processSingleLogEntry processes a single log entry for frequency analysis and dashboard updates
 func (m *simpleTuiModel) processSingleLogEntry(result *analyzer.AnalysisResult, attributes map[string]string, logEntry *tui.LogEntry) {
+	// Entries outside --since and --until or below --min-severity are not
+	// ingested at all
+	if logEntry != nil && (!m.inTimeRange(logEntry) || !tui.SeverityAtLeast(logEntry.Severity, m.minSeverity)) {
+		return
+	}
+
+	// Attributes are redacted by key once parsed; the raw line may still
+	// hold their values
+	if logEntry != nil && m.redactor.Attributes(logEntry.Attributes) {
+		logEntry.RawLine = ""
+	}
+	m.redactor.Attributes(attributes)
+
 	// Add results to frequency memory
 	m.freqMemory.AddWords(result.Words)
 	m.freqMemory.AddPhrases(result.Phrases)
@@ -148,11 +361,28 @@ func (m *simpleTuiModel) processSingleLogEntry(result *analyzer.AnalysisResult,
 
 	// Track severity counts and send log entry to dashboard
 	if logEntry != nil {
+		// Entries of a server or agent keep the source they were read from
+		if logEntry.Source == "" {
+			logEntry.Source = m.sourceName()
+		}
+		m.sources.entry(m.entrySource(logEntry), time.Now())
 		// Count severity for this interval
 		m.severityCounts.AddCount(logEntry.Severity)
-
-		updateMsg := tui.UpdateMsg{NewLogEntry: logEntry}
-		m.dashboard.Update(updateMsg)
+		m.captureEntry(logEntry)
+		m.observeEntry(logEntry)
+		m.forwardEntry(logEntry)
+		m.pushToLoki(logEntry)
+		m.pipeEntry(logEntry)
+		m.notifyEntry(logEntry)
+		m.reportEntry(logEntry)
+		m.summarizeEntry(logEntry)
+		m.webEntry(logEntry)
+
+		if m.entrySink != nil {
+			m.entrySink(logEntry)
+			return
+		}
+		m.pendingEntries = append(m.pendingEntries, logEntry)
 	}
 }
 
@@ -198,8 +428,9 @@ func isOTLPSignalLog(message string) bool {
 	return false
 }
 
-// tryAccumulateJSON attempts to accumulate multi-line JSON and process when complete
-func (m *simpleTuiModel) tryAccumulateJSON(line string) bool {
+// tryAccumulateJSON attempts to accumulate multi-line JSON, reporting whether
+// the line was taken and returning the object once it is complete
+func (m *simpleTuiModel) tryAccumulateJSON(line string) (string, bool) {
 	// Check if this line could be part of a JSON object
 	trimmed := strings.TrimSpace(line)
 
@@ -220,14 +451,13 @@ func (m *simpleTuiModel) tryAccumulateJSON(line string) bool {
 			if m.jsonDepth <= 0 {
 				completeJSON := strings.TrimSpace(m.jsonBuffer.String())
 				m.resetJSONAccumulation()
-				m.processCompleteJSON(completeJSON)
-				return true
+				return completeJSON, true
 			}
 
-			return true // Line was accumulated
+			return "", true // Line was accumulated
 		}
 		// Not starting JSON, process normally
-		return false
+		return "", false
 	}
 
 	// We're already accumulating JSON, add this line
@@ -241,11 +471,10 @@ func (m *simpleTuiModel) tryAccumulateJSON(line string) bool {
 	if m.jsonDepth <= 0 {
 		completeJSON := strings.TrimSpace(m.jsonBuffer.String())
 		m.resetJSONAccumulation()
-		m.processCompleteJSON(completeJSON)
-		return true
+		return completeJSON, true
 	}
 
-	return true // Line was accumulated, waiting for more
+	return "", true // Line was accumulated, waiting for more
 }
 
 // countJSONDepth counts the net change in JSON nesting depth for a line
@@ -288,31 +517,32 @@ func (m *simpleTuiModel) resetJSONAccumulation() {
 	m.jsonBuffer.Reset()
 }
 
-// processCompleteJSON processes a complete JSON object (single or multi-line)
-func (m *simpleTuiModel) processCompleteJSON(jsonStr string) {
-	// Count this as a log entry
-	m.logCount++
+// parseCompleteJSON parses a complete JSON object (single or multi-line)
+func (m *simpleTuiModel) parseCompleteJSON(jsonStr string, f *lineFormat) []parsedEntry {
+	var parsed []parsedEntry
 
 	// Detect format of the complete JSON
-	format := m.formatDetector.DetectFormat(jsonStr)
+	format := f.detector.DetectFormat(jsonStr)
 
 	var result *analyzer.AnalysisResult
 	var attributes map[string]string
 	var logEntry *tui.LogEntry
+	var parseErr error // Why the line's format failed to parse it
 
 	if format == otlplog.FormatOTLP {
 		// Handle OTLP format
-		if m.formatDetector.IsOTLPBatch(jsonStr) {
+		if f.detector.IsOTLPBatch(jsonStr) {
 			// Parse OTLP batch and extract ALL log entries
-			logsData, err := m.formatDetector.ParseOTLPBatch(jsonStr)
+			logsData, err := f.detector.ParseOTLPBatch(jsonStr)
 			if err != nil {
 				// Fallback to text analysis
 				result = m.textAnalyzer.AnalyzeLine(jsonStr)
 				attributes = make(map[string]string)
 				logEntry = createFallbackLogEntry(jsonStr)
+				parseErr = err
 
 				// Process the single fallback entry
-				m.processSingleLogEntry(result, attributes, logEntry)
+				parsed = append(parsed, parsedEntry{result, attributes, logEntry, parseErr})
 			} else {
 				// Extract ALL log entries from the batch
 				logEntries := extractAllLogEntriesFromOTLPBatch(logsData)
@@ -323,31 +553,34 @@ func (m *simpleTuiModel) processCompleteJSON(jsonStr string) {
 					entryResult := m.otlpAnalyzer.AnalyzeOTLPRecord(convertLogEntryToOTLPRecord(entry))
 					entryAttributes := entry.Attributes // Already includes resource + record attributes
 
-					m.processSingleLogEntry(entryResult, entryAttributes, entry)
+					parsed = append(parsed, parsedEntry{entryResult, entryAttributes, entry, nil})
 				}
 			}
-			return // Important: return early for batch processing to avoid duplicate processing below
+			return parsed // Important: return early for batch processing to avoid duplicate processing below
 		} else {
 			// Parse single OTLP record
-			record, err := m.formatDetector.ParseSingleOTLPRecord(jsonStr)
+			record, err := f.detector.ParseSingleOTLPRecord(jsonStr)
 			if err != nil {
 				result = m.textAnalyzer.AnalyzeLine(jsonStr)
 				attributes = make(map[string]string)
 				logEntry = createFallbackLogEntry(jsonStr)
+				parseErr = err
 			} else {
 				result = m.otlpAnalyzer.AnalyzeOTLPRecord(record)
 				attributes = m.otlpAnalyzer.ExtractAttributesFromOTLPRecord(record)
 				logEntry = extractLogEntryFromOTLPRecord(record)
+				tagSeverityNumber(logEntry, record.SeverityNumber)
 				// Note: logEntry already contains all attributes from OTLP record extraction
 			}
 		}
 	} else {
 		// Convert non-OTLP format to OTLP
-		otlpRecord, err := m.logConverter.ConvertToOTLP(jsonStr, format)
+		otlpRecord, err := f.converter.ConvertToOTLP(jsonStr, format)
 		if err != nil {
 			result = m.textAnalyzer.AnalyzeLine(jsonStr)
 			attributes = make(map[string]string)
 			logEntry = createFallbackLogEntry(jsonStr)
+			parseErr = err
 		} else {
 			result = m.otlpAnalyzer.AnalyzeOTLPRecord(otlpRecord)
 			attributes = m.otlpAnalyzer.ExtractAttributesFromOTLPRecord(otlpRecord)
@@ -356,6 +589,6 @@ func (m *simpleTuiModel) processCompleteJSON(jsonStr string) {
 		}
 	}
 
-	// Process single log entry (for non-batch OTLP and other formats)
-	m.processSingleLogEntry(result, attributes, logEntry)
+	// Single log entry (for non-batch OTLP and other formats)
+	return append(parsed, parsedEntry{result, attributes, logEntry, parseErr})
 }
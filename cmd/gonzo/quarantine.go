@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+const (
+	quarantineSize     = 500      // Lines kept for the unparsed view
+	quarantineMaxBytes = 64 << 10 // Longest part of a line kept
+)
+
+// quarantine keeps the latest lines their format failed to parse, for the
+// unparsed view. Lines are parsed concurrently, so it is safe for
+// concurrent use.
+type quarantine struct {
+	mu    sync.Mutex
+	lines []tui.UnparsedLine // A ring once full, next being the oldest
+	next  int
+	total int64
+}
+
+// add keeps a line of a source and why it failed to parse
+func (q *quarantine) add(source, text string, err error) {
+	line := tui.UnparsedLine{Time: time.Now(), Source: source, Error: err.Error(), Raw: text, Size: len(text)}
+	if len(text) > quarantineMaxBytes {
+		line.Raw = text[:quarantineMaxBytes]
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.total++
+	if len(q.lines) < quarantineSize {
+		q.lines = append(q.lines, line)
+		return
+	}
+	q.lines[q.next] = line
+	q.next = (q.next + 1) % quarantineSize
+}
+
+// snapshot returns the lines kept, oldest first, and how many failed in all
+func (q *quarantine) snapshot() ([]tui.UnparsedLine, int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	lines := make([]tui.UnparsedLine, 0, len(q.lines))
+	lines = append(lines, q.lines[q.next:]...)
+	lines = append(lines, q.lines[:q.next]...)
+	return lines, q.total
+}
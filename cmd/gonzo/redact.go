@@ -0,0 +1,40 @@
+package main
+
+import (
+	"log"
+	"maps"
+
+	"github.com/control-theory/gonzo/internal/redact"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// loadRedactors builds the redaction applied at ingest from --redact and
+// --redact-attr, and the one applied to exported and forwarded entries,
+// which adds --export-redact and --export-deny-attr. Invalid rules are
+// skipped with a warning.
+func loadRedactors() (ingest, export *redact.Redactor) {
+	ingest, err := redact.New(redact.Config{
+		Patterns:   cfg.Redact,
+		Attributes: cfg.RedactAttributes,
+	})
+	if err != nil {
+		log.Printf("Warning: redaction: %v", err)
+	}
+	export, err = ingest.Extend(redact.Config{
+		Patterns:       cfg.ExportRedact,
+		DenyAttributes: cfg.ExportDenyAttributes,
+	})
+	if err != nil {
+		log.Printf("Warning: export redaction: %v", err)
+	}
+	return ingest, export
+}
+
+// redactEntry returns a copy of an entry with its own attributes, redacted
+// for export
+func redactEntry(r *redact.Redactor, entry *tui.LogEntry) *tui.LogEntry {
+	redacted := *entry
+	redacted.Attributes = maps.Clone(entry.Attributes)
+	redacted.Message, redacted.RawLine = r.Entry(entry.Message, entry.RawLine, redacted.Attributes)
+	return &redacted
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Redraw rates below --refresh while nobody is looking
+const (
+	refreshIdleAfter  = 30 * time.Second // Without keyboard or mouse input
+	refreshIdleFPS    = 5
+	refreshBlurredFPS = 2 // While the terminal is not focused
+)
+
+// redrawMsg asks for the frame a redraw limit held back
+type redrawMsg struct{}
+
+// redrawLimiter caps how often the dashboard is drawn, so a log storm
+// coalesces into frames instead of a redraw per update. Input is drawn at
+// once; other updates wait for the next frame.
+type redrawLimiter struct {
+	fps       int // Most frames per second while in use, 0 for no limit
+	frame     string
+	lastFrame time.Time
+	lastInput time.Time
+	force     bool // Draw the next frame at once
+	pending   bool // A redrawMsg is scheduled
+	blurred   bool
+}
+
+// newRedrawLimiter returns a limiter drawing at most fps frames per second
+func newRedrawLimiter(fps int) *redrawLimiter {
+	return &redrawLimiter{fps: max(0, fps), lastInput: time.Now()}
+}
+
+// observe notes what a message means for the next frame
+func (r *redrawLimiter) observe(msg tea.Msg) {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg, tea.WindowSizeMsg:
+		r.force = true
+		r.lastInput = time.Now()
+	case tea.FocusMsg:
+		r.blurred = false
+		r.force = true
+	case tea.BlurMsg:
+		r.blurred = true
+	case redrawMsg:
+		r.pending = false
+	}
+}
+
+// interval returns the time between frames, longer while idle or unfocused
+func (r *redrawLimiter) interval(now time.Time) time.Duration {
+	fps := r.fps
+	if r.blurred {
+		fps = min(fps, refreshBlurredFPS)
+	} else if now.Sub(r.lastInput) > refreshIdleAfter {
+		fps = min(fps, refreshIdleFPS)
+	}
+	return time.Second / time.Duration(fps)
+}
+
+// held reports whether the next frame has to wait, returning the last one
+func (r *redrawLimiter) held() (string, bool) {
+	if r.fps == 0 || r.force || r.frame == "" {
+		return "", false
+	}
+	now := time.Now()
+	return r.frame, now.Sub(r.lastFrame) < r.interval(now)
+}
+
+// drawn records a frame as shown
+func (r *redrawLimiter) drawn(frame string) {
+	r.frame = frame
+	r.lastFrame = time.Now()
+	r.force = false
+}
+
+// schedule returns a command drawing the frame an update is held back for,
+// once its time has come
+func (r *redrawLimiter) schedule() tea.Cmd {
+	if r.pending {
+		return nil
+	}
+	if _, held := r.held(); !held {
+		return nil
+	}
+	r.pending = true
+	wait := r.interval(time.Now()) - time.Since(r.lastFrame)
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return redrawMsg{}
+	})
+}
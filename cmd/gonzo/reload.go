@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/alerts"
+	"github.com/control-theory/gonzo/internal/slo"
+	"github.com/control-theory/gonzo/internal/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configReloadDelay coalesces the writes of one save into one reload
+const configReloadDelay = 250 * time.Millisecond
+
+// configReloadMsg carries the config file as read again after it changed
+type configReloadMsg struct {
+	cfg Config
+	err error
+}
+
+// watchConfig sends a configReloadMsg to p whenever the config file in use
+// changes, until ctx is done
+func watchConfig(ctx context.Context, p *tea.Program, cmd *cobra.Command) {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		return
+	}
+	path = filepath.Clean(path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("Warning: failed to watch config file: %v", err)
+		return
+	}
+	// Editors often save by replacing the file, so its directory is watched
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		log.Printf("Warning: failed to watch config file: %v", err)
+		return
+	}
+
+	go func() {
+		defer watcher.Close()
+		var reload <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) == path && event.Has(fsnotify.Write|fsnotify.Create) {
+					reload = time.After(configReloadDelay)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Warning: config file watcher: %v", err)
+			case <-reload:
+				reload = nil
+				next, err := readConfig(path, cmd)
+				p.Send(configReloadMsg{cfg: next, err: err})
+			}
+		}
+	}()
+}
+
+// readConfig reads the config file at path into a new Config, with the
+// command line flags of cmd and environment taking precedence as at startup
+func readConfig(path string, cmd *cobra.Command) (Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix("GONZO")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	if err := v.BindPFlags(cmd.Flags()); err != nil {
+		return Config{}, err
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return Config{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var next Config
+	if err := v.Unmarshal(&next); err != nil {
+		return Config{}, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return next, nil
+}
+
+// applyConfigReload applies the live settings of a reloaded config file
+// that changed: the skin, highlight rules, view filter, alert rules and
+// SLOs. Invalid rules are skipped as at startup, and reported with the
+// other problems instead of stopping gonzo.
+func (m *simpleTuiModel) applyConfigReload(msg configReloadMsg) {
+	if msg.err != nil {
+		m.dashboard.ShowConfigReload(nil, []string{msg.err.Error()})
+		return
+	}
+
+	next := msg.cfg
+	var changed, problems []string
+	report := func(errs []error) {
+		for _, err := range errs {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if next.Skin != m.liveConfig.Skin {
+		if err := tui.InitializeSkin(next.Skin, m.configDir); err != nil {
+			problems = append(problems, fmt.Sprintf("failed to load skin '%s': %v (using default)", next.Skin, err))
+		}
+		m.dashboard.RefreshSkin()
+		changed = append(changed, "skin")
+	}
+	if !slices.Equal(next.Highlights, m.liveConfig.Highlights) {
+		rules, errs := parseHighlightRules(next.Highlights)
+		report(errs)
+		m.dashboard.SetHighlightRules(rules)
+		changed = append(changed, "highlight rules")
+	}
+	if next.ViewFilter != m.liveConfig.ViewFilter {
+		if err := m.dashboard.SetFilterExpression(next.ViewFilter); err != nil {
+			problems = append(problems, fmt.Sprintf("view-filter: %v (keeping the current filter)", err))
+		} else {
+			changed = append(changed, "view filter")
+		}
+	}
+	if !slices.Equal(next.Alerts, m.liveConfig.Alerts) || next.AlertWebhook != m.liveConfig.AlertWebhook {
+		rules, errs := parseAlertRules(next.Alerts, next.AlertWebhook)
+		report(errs)
+		m.dashboard.SetAlertRules(rules)
+		changed = append(changed, "alert rules")
+	}
+	if !slices.Equal(next.SLOs, m.liveConfig.SLOs) {
+		objectives, errs := parseObjectives(next.SLOs)
+		report(errs)
+		m.dashboard.SetObjectives(objectives)
+		changed = append(changed, "SLOs")
+	}
+
+	m.liveConfig = next
+	m.dashboard.ShowConfigReload(changed, problems)
+}
+
+// parseHighlightRules parses highlight rule specs, skipping invalid ones
+func parseHighlightRules(specs []string) ([]tui.HighlightRule, []error) {
+	var rules []tui.HighlightRule
+	var errs []error
+	for _, spec := range specs {
+		rule, err := tui.ParseHighlightRule(spec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errs
+}
+
+// parseAlertRules parses alert rule specs, skipping invalid ones
+func parseAlertRules(specs []string, webhook string) ([]alerts.Rule, []error) {
+	var rules []alerts.Rule
+	var errs []error
+	for _, spec := range specs {
+		rule, err := alerts.ParseRule(spec, webhook)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errs
+}
+
+// parseObjectives parses SLO specs, skipping invalid ones
+func parseObjectives(specs []string) ([]slo.Objective, []error) {
+	var objectives []slo.Objective
+	var errs []error
+	for _, spec := range specs {
+		objective, err := slo.ParseObjective(spec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		objectives = append(objectives, objective)
+	}
+	return objectives, errs
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/capture"
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+// replayMaxGap shortens longer pauses between captured entries when a
+// replay is paced, so an idle night does not stall it
+const replayMaxGap = 5 * time.Second
+
+// Capture file and pace of gonzo replay
+var (
+	replayPath  string
+	replaySpeed float64
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay CAPTURE-FILE",
+	Short: "Open the TUI on the entries of a --capture-file",
+	Long: `Show the entries written by --capture-file as they were parsed in the
+captured session, with their original severities, attributes and receive
+times.
+
+Entries are replayed as fast as they can be read. With --speed, they arrive
+with the pauses they had in the session, 1 in real time and 10 ten times as
+fast; pauses longer than 5 seconds are shortened to 5 seconds.`,
+	Example: `  # Capture a session, then go through it again later
+  gonzo -f app.log --follow --capture-file=session.jsonl.gz
+  gonzo replay session.jsonl.gz
+
+  # Watch an incident unfold again at ten times the speed
+  gonzo replay incident.jsonl.gz --speed 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		replayPath = args[0]
+		return runApp(cmd, nil)
+	},
+}
+
+// readReplayAsync reads the entries of the replayed capture file, pacing
+// them by their receive times under --speed
+func (m *simpleTuiModel) readReplayAsync(reader *capture.Reader) {
+	defer close(m.inputChan)
+	defer reader.Close()
+
+	var previous time.Time
+	for {
+		captured, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			log.Printf("Warning: replay stopped: %v", err)
+			return
+		}
+
+		if replaySpeed > 0 && !previous.IsZero() {
+			gap := min(captured.Timestamp.Sub(previous), replayMaxGap)
+			if gap > 0 {
+				select {
+				case <-m.ctx.Done():
+					return
+				case <-time.After(time.Duration(float64(gap) / replaySpeed)):
+				}
+			}
+		}
+		previous = captured.Timestamp
+
+		// The entry takes the path of entries streamed by a gonzo server
+		data, err := json.Marshal(replayEntry(captured))
+		if err != nil {
+			continue
+		}
+		if !m.sendLine(sourceReplay, inputLine{text: string(data)}) {
+			return
+		}
+	}
+}
+
+// replayEntry converts a captured entry back to a log entry
+func replayEntry(captured capture.Entry) tui.LogEntry {
+	entry := tui.LogEntry{
+		Timestamp:  captured.Timestamp,
+		Severity:   captured.Severity,
+		Message:    captured.Message,
+		Attributes: captured.Attributes,
+		RawLine:    captured.RawLine,
+		Source:     captured.Source,
+	}
+	if captured.OrigTimestamp != nil {
+		entry.OrigTimestamp = *captured.OrigTimestamp
+	}
+	return entry
+}
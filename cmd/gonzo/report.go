@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/report"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// startReport starts collecting the summary written to --report, rewriting
+// it every --report-interval if set
+func (m *simpleTuiModel) startReport() {
+	if cfg.Report == "" {
+		return
+	}
+	m.report = report.New()
+	if cfg.ReportInterval <= 0 {
+		return
+	}
+
+	m.reportDone = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(cfg.ReportInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.reportDone:
+				return
+			case now := <-ticker.C:
+				if err := report.WriteFile(cfg.Report, m.report.Summary(now)); err != nil {
+					log.Printf("Warning: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// stopReport writes the final summary before gonzo exits. The dashboard has
+// closed or was never shown by then, so failures go to stderr.
+func (m *simpleTuiModel) stopReport() {
+	if m.report == nil {
+		return
+	}
+	if m.reportDone != nil {
+		close(m.reportDone)
+		m.reportDone = nil
+	}
+	if err := report.WriteFile(cfg.Report, m.report.Summary(time.Now())); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	m.report = nil
+}
+
+// reportEntry counts a processed entry towards the summary
+func (m *simpleTuiModel) reportEntry(entry *tui.LogEntry) {
+	if m.report == nil {
+		return
+	}
+	m.report.Observe(tui.EntryRecord(*entry), tui.ServiceName(*entry), time.Now())
+}
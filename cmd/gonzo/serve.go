@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/control-theory/gonzo/internal/tui"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+// attachClientBuffer is how many entries may queue for one attached TUI
+// before it is considered too slow and disconnected
+const attachClientBuffer = 1000
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Collect and analyze logs in the background for TUIs to attach to",
+	Long: `Run log ingestion and parsing without a terminal. Attached TUIs receive the
+last --log-buffer entries followed by the live stream.
+
+The server has no authentication and listens on localhost by default; use an
+SSH tunnel to attach from another machine. With --api-listen, scripts can also
+query the buffered entries and the session's analytics over HTTP.`,
+	Example: `  # Collect Kubernetes logs in the background
+  gonzo serve --k8s-enabled --listen 127.0.0.1:7400
+
+  # Attach a TUI from another shell or over an SSH tunnel
+  gonzo attach 127.0.0.1:7400
+
+  # Query the buffer over HTTP
+  gonzo serve -f app.log --follow --api-listen 127.0.0.1:7401
+  curl '127.0.0.1:7401/api/v1/entries?filter=severity=ERROR&since=15m&limit=50'`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+// entryServer keeps the most recent entries and fans new ones out to the
+// attached TUIs as JSON lines
+type entryServer struct {
+	mu       sync.Mutex
+	recent   [][]byte
+	entries  []tui.LogEntry // The recent entries decoded, for the query API
+	capacity int
+	clients  map[*attachClient]struct{}
+	lastSeq  int64 // Numbers entries so every attached TUI shows the same #N
+}
+
+// attachClient is one attached TUI connection
+type attachClient struct {
+	conn net.Conn
+	send chan []byte
+}
+
+// newEntryServer creates a server that replays up to capacity entries
+func newEntryServer(capacity int) *entryServer {
+	return &entryServer{
+		capacity: max(1, capacity),
+		clients:  make(map[*attachClient]struct{}),
+	}
+}
+
+// add records an entry and sends it to every attached TUI
+func (s *entryServer) add(entry *tui.LogEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastSeq++
+	entry.Seq = s.lastSeq
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("Warning: could not encode log entry: %v", err)
+		return
+	}
+
+	s.recent = append(s.recent, data)
+	s.entries = append(s.entries, *entry)
+	if len(s.recent) > s.capacity {
+		s.recent = s.recent[len(s.recent)-s.capacity:]
+		s.entries = s.entries[len(s.entries)-s.capacity:]
+	}
+
+	for client := range s.clients {
+		select {
+		case client.send <- data:
+		default:
+			// Drop clients that cannot keep up rather than stalling ingestion
+			log.Printf("Warning: disconnecting slow client %s", client.conn.RemoteAddr())
+			s.removeLocked(client)
+		}
+	}
+}
+
+// snapshot returns a copy of the recent entries, oldest first
+func (s *entryServer) snapshot() []tui.LogEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]tui.LogEntry, len(s.entries))
+	copy(entries, s.entries)
+	return entries
+}
+
+// removeLocked detaches a client; s.mu must be held
+func (s *entryServer) removeLocked(client *attachClient) {
+	if _, ok := s.clients[client]; !ok {
+		return
+	}
+	delete(s.clients, client)
+	close(client.send)
+}
+
+// serve accepts attach connections until the listener is closed
+func (s *entryServer) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Printf("Warning: accept failed: %v", err)
+			}
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle replays the buffered entries to a new client, then streams live
+// entries until either side disconnects
+func (s *entryServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	client := &attachClient{conn: conn, send: make(chan []byte, attachClientBuffer)}
+
+	// Snapshot and register under the same lock so no entry is missed or duplicated
+	s.mu.Lock()
+	backlog := make([][]byte, len(s.recent))
+	copy(backlog, s.recent)
+	s.clients[client] = struct{}{}
+	s.mu.Unlock()
+
+	log.Printf("Client attached from %s", conn.RemoteAddr())
+	defer func() {
+		s.mu.Lock()
+		s.removeLocked(client)
+		s.mu.Unlock()
+		log.Printf("Client %s detached", conn.RemoteAddr())
+	}()
+
+	// Attached TUIs never send data; a read returning means they hung up
+	go func() {
+		io.Copy(io.Discard, conn)
+		s.mu.Lock()
+		s.removeLocked(client)
+		s.mu.Unlock()
+	}()
+
+	writer := bufio.NewWriter(conn)
+	for _, data := range backlog {
+		if !writeEntryLine(writer, data) {
+			return
+		}
+	}
+	if writer.Flush() != nil {
+		return
+	}
+
+	for data := range client.send {
+		if !writeEntryLine(writer, data) {
+			return
+		}
+		if len(client.send) == 0 && writer.Flush() != nil {
+			return
+		}
+	}
+}
+
+// writeEntryLine writes one newline-terminated JSON entry
+func writeEntryLine(writer *bufio.Writer, data []byte) bool {
+	if _, err := writer.Write(data); err != nil {
+		return false
+	}
+	return writer.WriteByte('\n') == nil
+}
+
+// runServe runs the configured log inputs headless and serves the entries
+// to attached TUIs until interrupted
+func runServe(cmd *cobra.Command, args []string) error {
+	// Server status goes to stderr; there is no TUI to disturb
+	log.SetOutput(os.Stderr)
+	klog.SetOutput(io.Discard)
+	klog.LogToStderr(false)
+
+	listener, err := net.Listen("tcp", cfg.ServeListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.ServeListen, err)
+	}
+	defer listener.Close()
+
+	server := newEntryServer(cfg.LogBuffer)
+	go server.serve(listener)
+	log.Printf("Serving logs on %s (attach with: gonzo attach %s)", listener.Addr(), listener.Addr())
+
+	configDir := os.Getenv("HOME") + "/.config/gonzo"
+	model := newProcessingModel(configDir)
+
+	// Entries leave the host, so they are redacted for export
+	sink := func(entry *tui.LogEntry) {
+		server.add(redactEntry(model.exportRedactor, entry))
+	}
+	if cfg.ServeAPIListen != "" {
+		api, err := startQueryAPI(cfg.ServeAPIListen, server)
+		if err != nil {
+			return err
+		}
+		defer api.close()
+		sink = func(entry *tui.LogEntry) {
+			server.add(redactEntry(model.exportRedactor, entry))
+			api.observe(entry)
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := model.runHeadless(ctx, cancel, sink, nil); err != nil {
+		return err
+	}
+
+	// Keep serving the buffered entries after a finite input ends
+	if ctx.Err() == nil {
+		log.Printf("Input finished; still serving the last %d entries (Ctrl+C to stop)", cfg.LogBuffer)
+		<-ctx.Done()
+	}
+	return nil
+}
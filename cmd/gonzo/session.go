@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// sessionFile holds the view state saved on exit, in the config directory
+const sessionFile = "session.json"
+
+// loadSession reads the view state saved when gonzo last exited
+func loadSession(configDir string) (tui.Session, error) {
+	var session tui.Session
+	data, err := os.ReadFile(filepath.Join(configDir, sessionFile))
+	if err != nil {
+		return session, fmt.Errorf("failed to read saved session: %w", err)
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return session, fmt.Errorf("failed to parse saved session: %w", err)
+	}
+	return session, nil
+}
+
+// saveSession writes the view state for the next --resume, replacing the
+// previous session in one step so an interrupted save cannot corrupt it
+func saveSession(configDir string, session tui.Session) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	path := filepath.Join(configDir, sessionFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"log"
+
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// loadSeverityFloor parses --min-severity, ingesting every severity when it
+// is invalid
+func loadSeverityFloor() string {
+	floor, err := tui.ParseSeverityFloor(cfg.MinSeverity)
+	if err != nil {
+		log.Printf("Warning: min-severity: %v (ingesting all severities)", err)
+		return ""
+	}
+	return floor
+}
@@ -0,0 +1,21 @@
+package main
+
+// stopSources stops the inputs before gonzo exits, so streams and listeners
+// are closed rather than cut off and files save their checkpoints. Each
+// input's reader stops it too once the context is cancelled, so the stops
+// may run twice.
+func (m *simpleTuiModel) stopSources() {
+	if m.cancelFunc != nil {
+		m.cancelFunc()
+	}
+	if m.k8sReceiver != nil {
+		m.k8sReceiver.Stop()
+	}
+	if m.vmlogsReceiver != nil {
+		m.vmlogsReceiver.Stop()
+	}
+	if m.otlpReceiver != nil {
+		m.otlpReceiver.Stop()
+	}
+	m.stopFileReader()
+}
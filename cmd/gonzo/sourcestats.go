@@ -0,0 +1,124 @@
+package main
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// sourceStats counts what each input source delivered, for the sources
+// modal. Lines are parsed concurrently, so it is safe for concurrent use.
+type sourceStats struct {
+	mu      sync.Mutex
+	sources map[string]*tui.SourceStats
+}
+
+// newSourceStats returns empty source statistics
+func newSourceStats() *sourceStats {
+	return &sourceStats{sources: make(map[string]*tui.SourceStats)}
+}
+
+// get returns the counters of a source, adding them if needed. The caller
+// holds the lock.
+func (s *sourceStats) get(source string) *tui.SourceStats {
+	stats, ok := s.sources[source]
+	if !ok {
+		stats = &tui.SourceStats{Source: source}
+		s.sources[source] = stats
+	}
+	return stats
+}
+
+// add lists a source before it delivers anything, so one that never does
+// shows up too
+func (s *sourceStats) add(source string) {
+	s.mu.Lock()
+	s.get(source)
+	s.mu.Unlock()
+}
+
+// line counts a line read from a source
+func (s *sourceStats) line(source string, bytes int) {
+	s.mu.Lock()
+	stats := s.get(source)
+	stats.Lines++
+	stats.Bytes += int64(bytes)
+	s.mu.Unlock()
+}
+
+// entry counts an entry ingested from a source
+func (s *sourceStats) entry(source string, at time.Time) {
+	s.mu.Lock()
+	stats := s.get(source)
+	stats.Entries++
+	stats.LastEntry = at
+	s.mu.Unlock()
+}
+
+// parseError counts a line of a source its format failed to parse
+func (s *sourceStats) parseError(source string) {
+	s.mu.Lock()
+	s.get(source).ParseErrors++
+	s.mu.Unlock()
+}
+
+// snapshot returns the statistics of each source by name, with the lines
+// each dropped
+func (s *sourceStats) snapshot(dropped map[string]int64) []tui.SourceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for source, count := range dropped {
+		if count > 0 {
+			s.get(source).Dropped = count
+		}
+	}
+	snapshot := make([]tui.SourceStats, 0, len(s.sources))
+	for _, stats := range s.sources {
+		snapshot = append(snapshot, *stats)
+	}
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].Source < snapshot[j].Source })
+	return snapshot
+}
+
+// fileSource names the source of a file read among several merged files
+func fileSource(file string) string {
+	return sourceFile + ":" + filepath.Base(file)
+}
+
+// lineSource names the source of an input line
+func (m *simpleTuiModel) lineSource(line inputLine) string {
+	if line.file != "" {
+		return fileSource(line.file)
+	}
+	return m.sourceName()
+}
+
+// entrySource names the source an entry is counted against. Entries of a
+// server or agent keep the source they were read from there, but arrived
+// through the attached input.
+func (m *simpleTuiModel) entrySource(entry *tui.LogEntry) string {
+	if m.hasAttachInput || m.hasReplayInput || m.hasAggregatorInput || entry.Source == "" {
+		return m.sourceName()
+	}
+	return entry.Source
+}
+
+// addSources lists the active input sources in the source statistics:
+// each file when several are merged, or else the input
+func (m *simpleTuiModel) addSources() {
+	if m.fileReader != nil && len(m.fileReader.GetFilePaths()) > 1 {
+		for _, path := range m.fileReader.GetFilePaths() {
+			m.sources.add(fileSource(path))
+		}
+		return
+	}
+	m.sources.add(m.sourceName())
+}
+
+// sourceStatistics reports what each input source delivered
+func (m *simpleTuiModel) sourceStatistics() []tui.SourceStats {
+	return m.sources.snapshot(m.droppedBySource())
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// summaryServices is how many services the exit summary names
+const summaryServices = 3
+
+// sessionSummary counts the entries of a dashboard session, printed once
+// the dashboard closes
+type sessionSummary struct {
+	started  time.Time
+	entries  int64
+	errors   int64
+	services map[string]int64
+}
+
+// newSessionSummary starts counting a session now
+func newSessionSummary() *sessionSummary {
+	return &sessionSummary{started: time.Now(), services: make(map[string]int64)}
+}
+
+// add counts a processed entry
+func (s *sessionSummary) add(entry *tui.LogEntry) {
+	s.entries++
+	switch strings.ToUpper(entry.Severity) {
+	case "ERROR", "FATAL", "CRITICAL":
+		s.errors++
+	}
+	s.services[tui.ServiceName(*entry)]++
+}
+
+// text renders the session's duration, counts and busiest services
+func (s *sessionSummary) text(now time.Time, unparsed int64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "gonzo ran for %s: %s, %s", now.Sub(s.started).Truncate(time.Second), plural(s.entries, "entry", "entries"), plural(s.errors, "error", "errors"))
+	if s.entries > 0 {
+		fmt.Fprintf(&b, " (%.1f%%)", float64(s.errors)*100/float64(s.entries))
+	}
+	if unparsed > 0 {
+		fmt.Fprintf(&b, ", %s", plural(unparsed, "unparsed line", "unparsed lines"))
+	}
+	b.WriteString("\n")
+
+	names := make([]string, 0, len(s.services))
+	for name := range s.services {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if s.services[names[i]] != s.services[names[j]] {
+			return s.services[names[i]] > s.services[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > 0 {
+		var top []string
+		for _, name := range names[:min(len(names), summaryServices)] {
+			top = append(top, fmt.Sprintf("%s (%d)", name, s.services[name]))
+		}
+		fmt.Fprintf(&b, "Top services: %s\n", strings.Join(top, ", "))
+	}
+	return b.String()
+}
+
+// plural returns a count with the word for it
+func plural(n int64, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// summarizeEntry counts a processed entry towards the exit summary
+func (m *simpleTuiModel) summarizeEntry(entry *tui.LogEntry) {
+	if m.summary == nil {
+		return
+	}
+	m.summary.add(entry)
+}
+
+// printSummary prints the exit summary to stdout once the dashboard has
+// closed
+func (m *simpleTuiModel) printSummary() {
+	if m.summary == nil {
+		return
+	}
+	_, unparsed := m.unparsed.snapshot()
+	fmt.Print(m.summary.text(time.Now(), unparsed))
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var tailCmd = &cobra.Command{
+	Use:   "tail FILE...",
+	Short: "Follow log files in the TUI, like tail -f",
+	Long: `Open the TUI on the given files or file globs and keep reading the lines
+appended to them. This is the same as gonzo -f FILE --follow.`,
+	Example: `  # Follow an application log
+  gonzo tail /var/log/app.log
+
+  # Follow every log in a directory, showing only errors
+  gonzo tail "/var/log/*.log" --view-filter 'severity=ERROR'`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg.Files = append(cfg.Files, args...)
+		cfg.Follow = true
+		return runApp(cmd, nil)
+	},
+}
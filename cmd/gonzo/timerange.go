@@ -0,0 +1,33 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/timerange"
+	"github.com/control-theory/gonzo/internal/tui"
+)
+
+// loadTimeRange parses --since and --until, ingesting everything when they
+// are invalid
+func loadTimeRange() timerange.Range {
+	r, err := timerange.Parse(cfg.Since, cfg.Until, time.Now())
+	if err != nil {
+		log.Printf("Warning: %v (ingesting all entries)", err)
+		return timerange.Range{}
+	}
+	return r
+}
+
+// inTimeRange reports whether an entry is within --since and --until, by
+// the log's own timestamp when it has one
+func (m *simpleTuiModel) inTimeRange(entry *tui.LogEntry) bool {
+	if m.timeRange.IsZero() {
+		return true
+	}
+	t := entry.OrigTimestamp
+	if t.IsZero() {
+		t = entry.Timestamp
+	}
+	return m.timeRange.Contains(t)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/control-theory/gonzo/internal/tui"
+	"github.com/control-theory/gonzo/internal/webui"
+)
+
+// startWebUI serves the read-only web UI when --web-listen is set
+func (m *simpleTuiModel) startWebUI() {
+	if cfg.WebListen == "" {
+		return
+	}
+	server := webui.NewServer(cfg.LogBuffer)
+	if err := server.Start(cfg.WebListen); err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+	m.web = server
+}
+
+// stopWebUI disconnects the browsers before gonzo exits
+func (m *simpleTuiModel) stopWebUI() {
+	if m.web == nil {
+		return
+	}
+	m.web.Stop()
+}
+
+// webEntry sends a processed entry to the browsers, numbered like the
+// dashboard numbers its buffer
+func (m *simpleTuiModel) webEntry(entry *tui.LogEntry) {
+	if m.web == nil {
+		return
+	}
+	m.webSeq = max(m.webSeq+1, entry.Seq)
+	data, err := json.Marshal(apiEntry{Seq: m.webSeq, Entry: newCaptureEntry(entry, m.exportRedactor)})
+	if err != nil {
+		log.Printf("Warning: failed to encode entry for the web UI: %v", err)
+		return
+	}
+	m.web.Add(tui.EntryRecord(*entry), data)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/analyzer"
+)
+
+// loadTextAnalyzer builds the word counting rules of the frequency analysis
+// from the stop word, word length and collapse options. Unreadable stop word
+// files are skipped, and invalid token classes collapse nothing.
+func loadTextAnalyzer() *analyzer.TextAnalyzer {
+	stopWords := append([]string{}, cfg.StopWords...)
+	for _, path := range cfg.StopWordsFiles {
+		words, err := readStopWordsFile(path)
+		if err != nil {
+			log.Printf("Warning: failed to read stop words file: %v", err)
+			continue
+		}
+		stopWords = append(stopWords, words...)
+	}
+
+	tokenizer := analyzer.TokenizerConfig{
+		StopWords:          stopWords,
+		NoDefaultStopWords: cfg.NoDefaultStopWords,
+		MinWordLength:      cfg.MinWordLength,
+		Collapse:           cfg.CollapseTokens,
+	}
+	ta, err := analyzer.NewTextAnalyzerWithConfig(tokenizer)
+	if err != nil {
+		log.Printf("Warning: collapse-tokens: %v (collapsing nothing)", err)
+		tokenizer.Collapse = nil
+		ta, _ = analyzer.NewTextAnalyzerWithConfig(tokenizer)
+	}
+	return ta
+}
+
+// readStopWordsFile reads stop words from a file, separated by whitespace or
+// commas. Lines starting with # are comments.
+func readStopWordsFile(path string) ([]string, error) {
+	// The config file gives paths unexpanded by a shell
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})...)
+	}
+	return words, scanner.Err()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"log"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/control-theory/gonzo/internal/cpulimit"
+
+	"github.com/spf13/viper"
+)
+
+// Batches smaller than this per worker are parsed without extra goroutines
+const minLinesPerWorker = 32
+
+// cpus returns how many CPUs gonzo may keep busy: GOMAXPROCS, or the
+// container CPU limit rounded up when lower, as GOMAXPROCS is at least 2
+// unless set
+func cpus() int {
+	procs := runtime.GOMAXPROCS(0)
+	if limit := cpulimit.Limit(); limit > 0 && os.Getenv("GOMAXPROCS") == "" {
+		procs = min(procs, int(math.Ceil(limit)))
+	}
+	return procs
+}
+
+// inputChannelSize returns the capacity of the input channel, from
+// --input-channel-size or scaled with the CPUs
+func inputChannelSize() int {
+	if cfg.InputChannelSize > 0 {
+		return cfg.InputChannelSize
+	}
+	return min(max(128*cpus(), 128), 4096)
+}
+
+// parserWorkers returns how many goroutines parse input lines, from
+// --parser-workers or the CPUs
+func parserWorkers() int {
+	if cfg.ParserWorkers > 0 {
+		return cfg.ParserWorkers
+	}
+	return min(cpus(), 8)
+}
+
+// refreshRate returns the most redraws per second, from --refresh, or
+// fewer by default under a container CPU limit below 2 CPUs, down to 10
+func refreshRate() int {
+	limit := cpulimit.Limit()
+	if viper.IsSet("refresh") || limit <= 0 || limit >= 2 {
+		return cfg.Refresh
+	}
+	return max(10, int(float64(cfg.Refresh)*limit/2))
+}
+
+// logCPULimit logs how a container CPU limit sized the work, if there is one
+func logCPULimit(workers, refresh int) {
+	if limit := cpulimit.Limit(); limit > 0 {
+		log.Printf("CPU limit of %.2f CPUs: GOMAXPROCS %d, %d parser workers, %d redraws per second", limit, runtime.GOMAXPROCS(0), workers, refresh)
+	}
+}
+
+// parseAll parses jobs on up to m.parserWorkers goroutines, returning the
+// entries of each job in the jobs' order
+func (m *simpleTuiModel) parseAll(jobs []parseJob) [][]parsedEntry {
+	parsed := make([][]parsedEntry, len(jobs))
+	workers := min(m.parserWorkers, len(jobs)/minLinesPerWorker)
+	if workers <= 1 {
+		for i, job := range jobs {
+			parsed[i] = m.parse(job)
+		}
+		return parsed
+	}
+
+	chunk := (len(jobs) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(jobs); start += chunk {
+		end := min(start+chunk, len(jobs))
+		wg.Go(func() {
+			for i := start; i < end; i++ {
+				parsed[i] = m.parse(jobs[i])
+			}
+		})
+	}
+	wg.Wait()
+	return parsed
+}
@@ -0,0 +1,118 @@
+// Package agent forwards enriched entries from gonzo agents to a central
+// gonzo, the aggregator, over a gRPC stream. Agents parse and enrich their
+// local logs and send the entries as JSON; the aggregator tags each one with
+// the agent it came from, so one TUI shows the whole fleet.
+//
+// The stream carries JSON rather than protobuf messages, so the service is
+// declared by hand below instead of generated.
+package agent
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// NameHeader is the gRPC metadata key carrying the agent's name
+const NameHeader = "gonzo-agent"
+
+// forwardMethod is the full name of the forwarding stream
+const forwardMethod = "/gonzo.agent.v1.Aggregator/Forward"
+
+// Batch is a group of entries sent by an agent
+type Batch struct {
+	Entries []json.RawMessage `json:"entries"`
+}
+
+// Ack is the aggregator's reply when an agent closes its stream
+type Ack struct {
+	Received int64 `json:"received"`
+}
+
+// jsonCodec encodes the stream's messages as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                       { return "json" }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// aggregatorService is implemented by Server
+type aggregatorService interface {
+	forward(stream grpc.ServerStream) error
+}
+
+// serviceDesc declares the aggregator service: one client stream of batches
+// answered by an Ack
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gonzo.agent.v1.Aggregator",
+	HandlerType: (*aggregatorService)(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Forward",
+		ClientStreams: true,
+		Handler: func(srv any, stream grpc.ServerStream) error {
+			return srv.(aggregatorService).forward(stream)
+		},
+	}},
+}
+
+// ServerTLS loads the certificate of a listener such as the aggregator. With
+// clientCAFile, clients must present a certificate signed by it (mutual TLS).
+func ServerTLS(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate %s: %w", certFile, err)
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if clientCAFile != "" {
+		pool, err := loadCertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// ClientTLS verifies the aggregator against caFile, or the system roots when
+// empty, and presents the agent's certificate when certFile is set
+func ClientTLS(caFile, certFile, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load agent certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// loadCertPool reads PEM certificates from a file
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,189 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Forwarder limits
+const (
+	queueSize    = 10000 // Entries held while the aggregator is slow or unreachable
+	maxBatch     = 500   // Entries per message
+	minBackoff   = time.Second
+	maxBackoff   = 30 * time.Second
+	flushTimeout = 5 * time.Second // How long Stop waits for queued entries to be sent
+)
+
+// Forwarder streams entries to an aggregator, reconnecting with backoff
+// when the connection drops. Entries are dropped, and counted, when the
+// queue is full. It is safe for concurrent use.
+type Forwarder struct {
+	target string
+	name   string
+	creds  credentials.TransportCredentials
+	conn   *grpc.ClientConn
+
+	queue   chan json.RawMessage
+	dropped atomic.Int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewForwarder creates a forwarder to the aggregator at target that
+// identifies itself as name. Without a TLS config, it connects in plain text.
+func NewForwarder(target, name string, tlsConfig *tls.Config) *Forwarder {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Forwarder{
+		target: target,
+		name:   name,
+		creds:  creds,
+		queue:  make(chan json.RawMessage, queueSize),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start begins forwarding in the background
+func (f *Forwarder) Start() error {
+	conn, err := grpc.NewClient(f.target, grpc.WithTransportCredentials(f.creds))
+	if err != nil {
+		return fmt.Errorf("invalid aggregator address %s: %w", f.target, err)
+	}
+	f.conn = conn
+	f.wg.Go(f.run)
+	return nil
+}
+
+// Send queues an entry, given as JSON
+func (f *Forwarder) Send(data []byte) {
+	select {
+	case f.queue <- data:
+	default:
+		f.dropped.Add(1)
+	}
+}
+
+// Dropped returns how many entries were dropped because the queue was full
+func (f *Forwarder) Dropped() int64 {
+	return f.dropped.Load()
+}
+
+// Stop sends the queued entries, waiting up to flushTimeout, then closes the
+// connection
+func (f *Forwarder) Stop() {
+	if f.conn == nil {
+		return
+	}
+	close(f.done)
+	timer := time.AfterFunc(flushTimeout, f.cancel)
+	f.wg.Wait()
+	timer.Stop()
+	f.cancel()
+	f.conn.Close()
+	if dropped := f.Dropped(); dropped > 0 {
+		log.Printf("Warning: %d entries were not forwarded to the aggregator", dropped)
+	}
+}
+
+// run keeps a stream to the aggregator open until stopped
+func (f *Forwarder) run() {
+	backoff := minBackoff
+	var pending []json.RawMessage
+	for {
+		sent, err := f.stream(&pending)
+		if err == nil {
+			return
+		}
+		if f.ctx.Err() != nil {
+			f.dropped.Add(int64(len(pending) + len(f.queue)))
+			return
+		}
+		if sent {
+			backoff = minBackoff
+		}
+		log.Printf("Warning: forwarding to aggregator %s failed, retrying in %s: %v", f.target, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-f.ctx.Done():
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// stream sends batches on one stream until stopped, when it flushes the
+// queue and returns nil. A batch that could not be sent stays in pending
+// for the next stream. sent reports whether any batch went through.
+func (f *Forwarder) stream(pending *[]json.RawMessage) (sent bool, err error) {
+	ctx := metadata.AppendToOutgoingContext(f.ctx, NameHeader, f.name)
+	stream, err := f.conn.NewStream(ctx, &serviceDesc.Streams[0], forwardMethod, grpc.CallContentSubtype(jsonCodec{}.Name()))
+	if err != nil {
+		return false, err
+	}
+
+	stopping := false
+	for {
+		if len(*pending) == 0 && !stopping {
+			select {
+			case data := <-f.queue:
+				*pending = append(*pending, data)
+			case <-f.done:
+				stopping = true
+			}
+		}
+		// Fill the batch with what else is queued, without waiting
+	fill:
+		for len(*pending) < maxBatch {
+			select {
+			case data := <-f.queue:
+				*pending = append(*pending, data)
+			default:
+				break fill
+			}
+		}
+		if len(*pending) == 0 {
+			if stopping {
+				break
+			}
+			continue
+		}
+		if err := stream.SendMsg(&Batch{Entries: *pending}); err != nil {
+			// The aggregator's reason comes with the reply
+			if errors.Is(err, io.EOF) {
+				err = stream.RecvMsg(&Ack{})
+			}
+			return sent, err
+		}
+		sent = true
+		*pending = nil
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return sent, err
+	}
+	var ack Ack
+	if err := stream.RecvMsg(&ack); err != nil {
+		return sent, err
+	}
+	return sent, nil
+}
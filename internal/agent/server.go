@@ -0,0 +1,146 @@
+package agent
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Server limits
+const (
+	entryBuffer    = 1000             // Entries queued for the pipeline before agents are slowed down
+	maxMessageSize = 16 * 1024 * 1024 // Largest batch accepted
+)
+
+// Entry is an entry received from an agent
+type Entry struct {
+	Agent string
+	Data  json.RawMessage
+}
+
+// Server receives the entries of agents
+type Server struct {
+	tls      *tls.Config
+	grpc     *grpc.Server
+	entries  chan Entry
+	done     chan struct{}
+	listener net.Listener
+}
+
+// NewServer creates an aggregator. Without a TLS config, agents connect in
+// plain text.
+func NewServer(tlsConfig *tls.Config) *Server {
+	return &Server{
+		tls:     tlsConfig,
+		entries: make(chan Entry, entryBuffer),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start accepts agents on addr
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for agents on %s: %w", addr, err)
+	}
+	s.listener = listener
+
+	options := []grpc.ServerOption{grpc.MaxRecvMsgSize(maxMessageSize)}
+	if s.tls != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(s.tls)))
+	}
+	s.grpc = grpc.NewServer(options...)
+	s.grpc.RegisterService(&serviceDesc, s)
+
+	go func() {
+		if err := s.grpc.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Printf("Warning: aggregator stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address agents connect to
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Entries returns the received entries
+func (s *Server) Entries() <-chan Entry {
+	return s.entries
+}
+
+// Stop disconnects the agents
+func (s *Server) Stop() {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	close(s.done)
+	if s.grpc != nil {
+		s.grpc.Stop()
+	}
+}
+
+// agentName names the agent of a stream. The common name of a verified
+// client certificate wins, so an agent cannot pass itself off as another;
+// otherwise it is the name the agent sent, or its address.
+func agentName(stream grpc.ServerStream) string {
+	ctx := stream.Context()
+	p, ok := peer.FromContext(ctx)
+	if ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			if cn := info.State.VerifiedChains[0][0].Subject.CommonName; cn != "" {
+				return cn
+			}
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if names := md.Get(NameHeader); len(names) > 0 && names[0] != "" {
+			return names[0]
+		}
+	}
+	if !ok {
+		return "unknown"
+	}
+	return p.Addr.String()
+}
+
+// forward receives the batches of one agent until it closes the stream.
+// A full queue slows the agent down through gRPC flow control.
+func (s *Server) forward(stream grpc.ServerStream) error {
+	name := agentName(stream)
+	log.Printf("Agent %s connected", name)
+	defer log.Printf("Agent %s disconnected", name)
+
+	var received int64
+	for {
+		var batch Batch
+		if err := stream.RecvMsg(&batch); err != nil {
+			if errors.Is(err, io.EOF) {
+				return stream.SendMsg(&Ack{Received: received})
+			}
+			return err
+		}
+		for _, data := range batch.Entries {
+			select {
+			case s.entries <- Entry{Agent: name, Data: data}:
+				received++
+			case <-s.done:
+				return errors.New("aggregator stopped")
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package ai
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DefaultBatchPrompt is the prompt used to analyze several log entries at
+// once. Placeholders in braces are filled from BatchPromptData; a custom
+// template without {logs} gets the entries appended.
+const DefaultBatchPrompt = `You are an expert log analyst. Help me understand what happened in these log entries.
+
+They are {scope}: {count} lines standing for {total} entries.
+Active filters: {filter}
+{sampling}
+
+Log Entries:
+{logs}
+
+Please provide:
+1. A summary of what these entries show together (what happened, in order)
+2. Whether this looks normal/expected or indicates a problem
+3. If there is a problem, the most likely root cause and which entries point to it
+4. Any recommended actions or things to investigate
+
+Keep your response concise but informative.`
+
+// BatchPromptData fills the placeholders of a batch analysis prompt
+type BatchPromptData struct {
+	Scope    string   // What the entries are, e.g. "a visual selection"
+	Total    int      // Number of entries the lines stand for
+	Filter   string   // Description of the active filters
+	Sampling string   // How the entries were sampled, empty when all are sent
+	Lines    []string // The log lines sent to the model
+}
+
+// BuildBatchPrompt fills a batch prompt template. An empty template uses
+// DefaultBatchPrompt.
+func BuildBatchPrompt(tmpl string, data BatchPromptData) string {
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = DefaultBatchPrompt
+	}
+	if !strings.Contains(tmpl, "{logs}") {
+		tmpl += "\n\nLog Entries:\n{logs}"
+	}
+
+	filter := data.Filter
+	if filter == "" {
+		filter = "none"
+	}
+	sampling := data.Sampling
+	if sampling == "" {
+		sampling = "All entries are included."
+	}
+
+	return strings.NewReplacer(
+		"{scope}", data.Scope,
+		"{count}", strconv.Itoa(len(data.Lines)),
+		"{total}", strconv.Itoa(data.Total),
+		"{filter}", filter,
+		"{sampling}", sampling,
+		"{logs}", strings.Join(data.Lines, "\n"),
+	).Replace(tmpl)
+}
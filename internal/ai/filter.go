@@ -0,0 +1,64 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TranslateToFilter turns a request such as "payment errors from the last
+// 10 minutes excluding health checks" into a filter expression. syntax
+// describes the expression language and fields lists the attribute keys
+// seen in the logs, with example values.
+func (c *OpenAIClient) TranslateToFilter(request, syntax string, fields []string) (string, error) {
+	if c == nil {
+		return "", fmt.Errorf("AI client not configured (set OPENAI_API_KEY or --ai-base-url)")
+	}
+
+	prompt := fmt.Sprintf(`Translate a request for log entries into a filter expression for a log viewer.
+
+Expression language:
+%s
+
+Attribute keys in the current logs (with example values):
+%s
+
+The current time is %s.
+
+Request: %s
+
+Reply with the filter expression only, on one line, without quotes around the whole expression, code fences or explanations. Prefer attribute comparisons when a key fits the request, and plain words for text in the message.`,
+		syntax, formatFieldList(fields), time.Now().Format(time.RFC3339), request)
+
+	reply, err := c.sendPrompt(prompt)
+	if err != nil {
+		return "", err
+	}
+	return cleanFilterReply(reply), nil
+}
+
+// formatFieldList renders the attribute keys for the filter prompt
+func formatFieldList(fields []string) string {
+	if len(fields) == 0 {
+		return "(none seen yet)"
+	}
+	return "- " + strings.Join(fields, "\n- ")
+}
+
+// cleanFilterReply extracts the expression from a model reply, dropping
+// code fences, labels and any explanation after the first line
+func cleanFilterReply(reply string) string {
+	for _, line := range strings.Split(reply, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+		for _, label := range []string{"Filter expression:", "Filter:", "Expression:"} {
+			if len(line) >= len(label) && strings.EqualFold(line[:len(label)], label) {
+				line = strings.TrimSpace(line[len(label):])
+			}
+		}
+		return strings.Trim(line, "`")
+	}
+	return ""
+}
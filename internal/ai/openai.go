@@ -6,16 +6,44 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 )
 
+// Supported AI providers
+const (
+	ProviderAuto       = "auto"       // Detect the provider from the base URL
+	ProviderOpenAI     = "openai"     // api.openai.com
+	ProviderOllama     = "ollama"     // Ollama, native API with OpenAI-compatible fallback
+	ProviderAzure      = "azure"      // Azure OpenAI, the model is the deployment name
+	ProviderCompatible = "compatible" // Any OpenAI-compatible server (vLLM, LM Studio, LocalAI)
+)
+
+// defaultOpenAIBaseURL is used when no base URL is configured
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// defaultAzureAPIVersion is the Azure OpenAI API version used when none is configured
+const defaultAzureAPIVersion = "2024-06-01"
+
+// Config selects the AI endpoint. Empty fields fall back to the
+// OPENAI_API_KEY and OPENAI_API_BASE environment variables.
+type Config struct {
+	Provider   string // One of the Provider constants, empty for auto-detection
+	BaseURL    string // API base URL, e.g. http://localhost:11434/v1
+	APIKey     string // API key, optional for local servers
+	Model      string // Model (or Azure deployment), empty to auto-select
+	APIVersion string // Azure OpenAI API version
+}
+
 // OpenAIClient handles OpenAI API compatible requests
 type OpenAIClient struct {
 	APIKey          string
 	BaseURL         string
 	Model           string
+	Provider        string
+	APIVersion      string
 	HTTPClient      *http.Client
 	Validated       bool
 	ValidationErr   string
@@ -28,6 +56,7 @@ type OpenAIClient struct {
 type OpenAIRequest struct {
 	Model    string    `json:"model"`
 	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream,omitempty"`
 }
 
 // Message represents a chat message
@@ -47,6 +76,14 @@ type Choice struct {
 	Message Message `json:"message"`
 }
 
+// StreamChunk is one server-sent event of a streamed chat completion
+type StreamChunk struct {
+	Choices []struct {
+		Delta Message `json:"delta"`
+	} `json:"choices"`
+	Error any `json:"error,omitempty"`
+}
+
 // APIError represents an API error
 type APIError struct {
 	Message string `json:"message"`
@@ -118,43 +155,51 @@ func (r *OpenAIResponse) getErrorMessage() string {
 
 // NewOpenAIClient creates a new OpenAI client with environment variable detection
 func NewOpenAIClient(model string) *OpenAIClient {
-	// Detect API key from standard environment variable
-	apiKey := os.Getenv("OPENAI_API_KEY")
+	return NewClient(Config{Model: model})
+}
+
+// NewClient creates an AI client for the configured endpoint. It returns nil
+// when nothing is configured: no base URL and no API key for OpenAI. Local
+// servers such as Ollama or vLLM need only a base URL, so analysis works
+// without any network access beyond that server.
+func NewClient(cfg Config) *OpenAIClient {
+	apiKey := cfg.APIKey
 	if apiKey == "" {
-		return nil // No API key found
+		apiKey = os.Getenv("OPENAI_API_KEY")
 	}
-
-	// Detect base URL from environment variable, default to OpenAI's endpoint
-	baseURL := os.Getenv("OPENAI_API_BASE")
+	baseURL := cfg.BaseURL
 	if baseURL == "" {
-		baseURL = "https://api.openai.com/v1"
+		baseURL = os.Getenv("OPENAI_API_BASE")
+	}
+	if baseURL == "" {
+		if apiKey == "" {
+			return nil // Nothing configured
+		}
+		baseURL = defaultOpenAIBaseURL
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	provider := strings.ToLower(cfg.Provider)
+	if provider == "" || provider == ProviderAuto {
+		provider = detectProvider(baseURL)
+	}
+
+	apiVersion := cfg.APIVersion
+	if apiVersion == "" {
+		apiVersion = defaultAzureAPIVersion
 	}
 
 	// We'll set the default model after getting available models in ValidateConfiguration
 	// For now, keep track if no model was specified
-	autoSelectModel := model == ""
-
-	// Determine service name from base URL
-	serviceName := "OpenAI"
-	if baseURL != "https://api.openai.com/v1" {
-		if strings.Contains(baseURL, "localhost") || strings.Contains(baseURL, "127.0.0.1") {
-			if strings.Contains(baseURL, "1234") {
-				serviceName = "LM Studio"
-			} else if strings.Contains(baseURL, "11434") {
-				serviceName = "Ollama"
-			} else {
-				serviceName = "Local AI"
-			}
-		} else {
-			serviceName = "Custom API"
-		}
-	}
+	autoSelectModel := cfg.Model == ""
 
 	client := &OpenAIClient{
 		APIKey:          apiKey,
 		BaseURL:         baseURL,
-		Model:           model,
-		ServiceName:     serviceName,
+		Model:           cfg.Model,
+		Provider:        provider,
+		APIVersion:      apiVersion,
+		ServiceName:     serviceName(provider, baseURL),
 		AutoSelectModel: autoSelectModel,
 		HTTPClient: &http.Client{
 			Timeout: 60 * time.Second,
@@ -167,96 +212,80 @@ func NewOpenAIClient(model string) *OpenAIClient {
 	return client
 }
 
-// AnalyzeLog sends a log message to the AI for analysis
-func (c *OpenAIClient) AnalyzeLog(logMessage, severity, timestamp string, attributes map[string]string) (string, error) {
-	if c == nil {
-		return "", fmt.Errorf("OpenAI client not configured (missing OPENAI_API_KEY)")
+// detectProvider guesses the provider from the base URL
+func detectProvider(baseURL string) string {
+	switch {
+	case baseURL == defaultOpenAIBaseURL:
+		return ProviderOpenAI
+	case strings.Contains(baseURL, ".openai.azure.com"):
+		return ProviderAzure
+	case strings.Contains(baseURL, "11434"):
+		return ProviderOllama
+	default:
+		return ProviderCompatible
 	}
+}
 
-	prompt := c.buildAnalysisPrompt(logMessage, severity, timestamp, attributes)
-
-	// Try Ollama native API first if we detect it's Ollama
-	if c.ServiceName == "Ollama" {
-		result, err := c.analyzeWithOllama(prompt)
-		if err == nil {
-			return result, nil
+// serviceName returns the display name of the AI service
+func serviceName(provider, baseURL string) string {
+	switch provider {
+	case ProviderOpenAI:
+		return "OpenAI"
+	case ProviderAzure:
+		return "Azure OpenAI"
+	case ProviderOllama:
+		return "Ollama"
+	}
+	if strings.Contains(baseURL, "localhost") || strings.Contains(baseURL, "127.0.0.1") {
+		if strings.Contains(baseURL, "1234") {
+			return "LM Studio"
 		}
-		// If Ollama native API fails, continue to try OpenAI-compatible API
-	}
-
-	request := OpenAIRequest{
-		Model: c.Model,
-		Messages: []Message{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
-	}
-
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %v", err)
+		return "Local AI"
 	}
+	return "Custom API"
+}
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+// newRequest creates an API request with the provider's authentication
+func (c *OpenAIClient) newRequest(method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
-
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to make request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body for flexible parsing
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %v", err)
-	}
-
-	// Try parsing as standard OpenAI response
-	var response OpenAIResponse
-	if err := json.Unmarshal(bodyBytes, &response); err != nil {
-		// Try parsing as flexible structure for compatibility
-		var flexResponse map[string]any
-		if flexErr := json.Unmarshal(bodyBytes, &flexResponse); flexErr != nil {
-			return "", fmt.Errorf("failed to decode response: %v (body: %s)", err, string(bodyBytes))
-		}
-
-		// Try to extract response manually from flexible structure
-		if choices, ok := flexResponse["choices"].([]any); ok && len(choices) > 0 {
-			if choice, ok := choices[0].(map[string]any); ok {
-				if message, ok := choice["message"].(map[string]any); ok {
-					if content, ok := message["content"].(string); ok {
-						return content, nil
-					}
-				}
-			}
+	if c.APIKey != "" {
+		if c.Provider == ProviderAzure {
+			req.Header.Set("api-key", c.APIKey)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.APIKey)
 		}
-
-		return "", fmt.Errorf("failed to parse response structure: %v (body: %s)", err, string(bodyBytes))
 	}
+	return req, nil
+}
 
-	if errorMsg := response.getErrorMessage(); errorMsg != "" {
-		return "", fmt.Errorf("AI API error (model=%s, url=%s): %s", c.Model, c.BaseURL, errorMsg)
+// chatCompletionsURL returns the chat completions endpoint. Azure routes
+// requests by deployment instead of a model field.
+func (c *OpenAIClient) chatCompletionsURL() string {
+	if c.Provider == ProviderAzure {
+		return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s", c.BaseURL, url.PathEscape(c.Model), url.QueryEscape(c.APIVersion))
 	}
+	return c.BaseURL + "/chat/completions"
+}
 
-	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no response choices returned")
+// AnalyzeLog sends a log message to the AI for analysis
+func (c *OpenAIClient) AnalyzeLog(logMessage, severity, timestamp string, attributes map[string]string) (string, error) {
+	if c == nil {
+		return "", fmt.Errorf("AI client not configured (set OPENAI_API_KEY or --ai-base-url)")
 	}
 
-	return response.Choices[0].Message.Content, nil
+	prompt := c.buildAnalysisPrompt(logMessage, severity, timestamp, attributes)
+
+	return c.sendPrompt(prompt)
 }
 
 // AnalyzeLogWithContext sends a log message to the AI with chat context
 func (c *OpenAIClient) AnalyzeLogWithContext(logMessage, severity, timestamp string, attributes map[string]string, previousAnalysis string, question string) (string, error) {
 	if c == nil {
-		return "", fmt.Errorf("OpenAI client not configured (missing OPENAI_API_KEY)")
+		return "", fmt.Errorf("AI client not configured (set OPENAI_API_KEY or --ai-base-url)")
 	}
 
 	// Build context-aware prompt
@@ -280,8 +309,14 @@ Log Details (for reference):
 
 	prompt += "\n\nPlease answer the user's specific question about this log entry. Be concise and helpful."
 
+	return c.sendPrompt(prompt)
+}
+
+// sendPrompt sends a single user prompt and returns the model's reply. Ollama
+// endpoints are tried through the native API first.
+func (c *OpenAIClient) sendPrompt(prompt string) (string, error) {
 	// Try Ollama native API first if we detect it's Ollama
-	if c.ServiceName == "Ollama" {
+	if c.Provider == ProviderOllama {
 		result, err := c.analyzeWithOllama(prompt)
 		if err == nil {
 			return result, nil
@@ -304,14 +339,11 @@ Log Details (for reference):
 		return "", fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	req, err := c.newRequest("POST", c.chatCompletionsURL(), bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %v", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to make request: %v", err)
@@ -444,8 +476,17 @@ func (c *OpenAIClient) GetAvailableModels() ([]string, error) {
 		return nil, fmt.Errorf("client not initialized")
 	}
 
+	// Azure deployments cannot be listed with an API key; the configured
+	// deployment is the only model
+	if c.Provider == ProviderAzure {
+		if c.Model == "" {
+			return nil, fmt.Errorf("Azure OpenAI needs the deployment name as the model (--ai-model)")
+		}
+		return []string{c.Model}, nil
+	}
+
 	// Try Ollama native API first if we detect it's Ollama
-	if c.ServiceName == "Ollama" {
+	if c.Provider == ProviderOllama {
 		models, err := c.getOllamaModels()
 		if err == nil && len(models) > 0 {
 			return models, nil
@@ -453,14 +494,11 @@ func (c *OpenAIClient) GetAvailableModels() ([]string, error) {
 		// If Ollama native API fails, continue to try OpenAI-compatible API
 	}
 
-	req, err := http.NewRequest("GET", c.BaseURL+"/models", nil)
+	req, err := c.newRequest("GET", c.BaseURL+"/models", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-	req.Header.Set("Content-Type", "application/json")
-
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %v", err)
@@ -652,7 +690,7 @@ func (c *OpenAIClient) findBestModelMatch(requestedModel string, availableModels
 // GetValidationStatus returns the validation status and any error message
 func (c *OpenAIClient) GetValidationStatus() (bool, string, string, string) {
 	if c == nil {
-		return false, "No API key configured", "None", ""
+		return false, "No AI endpoint configured", "None", ""
 	}
 	return c.Validated, c.ValidationErr, c.ServiceName, c.Model
 }
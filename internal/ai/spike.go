@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpikePromptData describes a burst of log lines for BuildSpikePrompt
+type SpikePromptData struct {
+	Window     string   // When the spike happened, e.g. "14:03:05 to 14:03:08"
+	Total      int      // Lines in the spike
+	Intervals  int      // Chart intervals the spike lasted
+	Baseline   float64  // Average lines per interval before the spike
+	Severities string   // Breakdown by severity, e.g. "ERROR 412, INFO 80"
+	Patterns   []string // Drain3 clusters with their counts, most frequent first
+	Attributes []string // Attribute values shared by most of the spike's entries
+	Samples    []string // Representative log lines
+}
+
+// BuildSpikePrompt asks for a short incident summary of a spike in log
+// volume, with the likely causes ranked
+func BuildSpikePrompt(data SpikePromptData) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `You are an on-call engineer writing a short incident summary. Log volume spiked to %d lines over %d interval(s) between %s, against a baseline of %.0f lines per interval.
+
+Severity breakdown: %s
+`, data.Total, max(data.Intervals, 1), data.Window, data.Baseline, data.Severities)
+
+	if len(data.Attributes) > 0 {
+		b.WriteString("\nShared by most spike entries:\n")
+		for _, attr := range data.Attributes {
+			b.WriteString("- " + attr + "\n")
+		}
+	}
+
+	b.WriteString("\nMessage patterns in the spike (count× template, variable parts masked):\n")
+	for _, pattern := range data.Patterns {
+		b.WriteString("- " + pattern + "\n")
+	}
+
+	b.WriteString("\nRepresentative entries:\n")
+	b.WriteString(strings.Join(data.Samples, "\n"))
+
+	b.WriteString(`
+
+Please provide:
+1. A two or three sentence summary of what happened during the spike
+2. Up to three probable causes, most likely first, each with the patterns or entries that support it
+3. What to check first to confirm or rule out the top cause
+
+Keep it short enough to paste into an incident channel.`)
+
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// streamTimeout bounds a whole streamed reply, which can take far longer
+// than the client timeout used for single requests
+const streamTimeout = 5 * time.Minute
+
+// StreamPrompt sends a prompt and calls onChunk with each piece of the reply
+// as it arrives. Servers that ignore the stream option are handled too; their
+// reply arrives as one chunk. Cancelling ctx stops the request.
+func (c *OpenAIClient) StreamPrompt(ctx context.Context, prompt string, onChunk func(string)) error {
+	if c == nil {
+		return fmt.Errorf("AI client not configured (set OPENAI_API_KEY or --ai-base-url)")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+
+	// Try Ollama native API first if we detect it's Ollama
+	if c.Provider == ProviderOllama {
+		sent := false
+		err := c.streamWithOllama(ctx, prompt, func(chunk string) {
+			sent = true
+			onChunk(chunk)
+		})
+		// Fall back to the OpenAI-compatible API unless the reply had started
+		if err == nil || sent || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	request := OpenAIRequest{
+		Model:    c.Model,
+		Messages: []Message{{Role: "user", Content: prompt}},
+		Stream:   true,
+	}
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := c.newRequest("POST", c.chatCompletionsURL(), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.streamHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Not streamed: an error or a complete reply
+		bodyBytes, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		var response OpenAIResponse
+		if err := json.Unmarshal(bodyBytes, &response); err != nil {
+			return fmt.Errorf("failed to decode response: %w (body: %s)", err, string(bodyBytes))
+		}
+		if errorMsg := response.getErrorMessage(); errorMsg != "" {
+			return fmt.Errorf("AI API error (model=%s, url=%s): %s", c.Model, c.BaseURL, errorMsg)
+		}
+		if len(response.Choices) == 0 {
+			return fmt.Errorf("no response choices returned")
+		}
+		onChunk(response.Choices[0].Message.Content)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return nil
+		}
+		var chunk StreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != nil {
+			errResponse := OpenAIResponse{Error: chunk.Error}
+			return fmt.Errorf("AI API error (model=%s, url=%s): %s", c.Model, c.BaseURL, errResponse.getErrorMessage())
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			onChunk(chunk.Choices[0].Delta.Content)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read stream: %w", err)
+	}
+	return nil
+}
+
+// streamWithOllama streams a reply from Ollama's native /api/generate
+// endpoint, which sends one JSON object per line
+func (c *OpenAIClient) streamWithOllama(ctx context.Context, prompt string, onChunk func(string)) error {
+	// Remove /v1 suffix if present for Ollama native API
+	baseURL := strings.TrimSuffix(c.BaseURL, "/v1")
+
+	jsonData, err := json.Marshal(OllamaGenerateRequest{Model: c.Model, Prompt: prompt, Stream: true})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/api/generate", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.streamHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("Ollama API returned status %d", resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var response OllamaGenerateResponse
+		if err := decoder.Decode(&response); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to parse Ollama response: %w", err)
+		}
+		if response.Response != "" {
+			onChunk(response.Response)
+		}
+		if response.Done {
+			return nil
+		}
+	}
+}
+
+// streamHTTPClient returns the HTTP client without its per-request timeout;
+// streamed replies are bounded by streamTimeout instead
+func (c *OpenAIClient) streamHTTPClient() *http.Client {
+	client := *c.HTTPClient
+	client.Timeout = 0
+	return &client
+}
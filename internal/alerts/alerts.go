@@ -0,0 +1,272 @@
+// Package alerts evaluates alert rules over the incoming log stream. A rule
+// pairs a filter expression with a threshold over a sliding window, e.g.
+//
+//	payments-errors: severity=ERROR k8s.namespace=payments > 50/1m => banner,desktop,webhook
+//
+// and fires once when the count of matching entries rises above the
+// threshold, again only after it has dropped back below it.
+package alerts
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// Actions a rule can trigger when it fires
+const (
+	ActionBanner  = "banner"  // Show the alert in the status line while it is active
+	ActionBell    = "bell"    // Ring the terminal bell
+	ActionDesktop = "desktop" // Send a desktop notification
+	ActionWebhook = "webhook" // POST the alert and sample entries as JSON
+)
+
+// defaultActions are used when a rule lists none
+var defaultActions = []string{ActionBanner, ActionBell}
+
+// maxSamples is the number of recent matching lines kept for each rule
+const maxSamples = 5
+
+// Rule is one alert definition
+type Rule struct {
+	Name      string
+	Filter    *filterexpr.Expr
+	Threshold int           // Fires when more than this many entries match...
+	Window    time.Duration // ...within this window
+	Actions   []string
+	Webhook   string // URL for the webhook action
+}
+
+// Has reports whether the rule triggers the given action
+func (r Rule) Has(action string) bool {
+	for _, a := range r.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Condition describes the threshold, e.g. "> 50/min"
+func (r Rule) Condition() string {
+	return fmt.Sprintf("> %d/%s", r.Threshold, formatWindow(r.Window))
+}
+
+// ruleNameRegex matches the optional "name:" prefix of a rule
+var ruleNameRegex = regexp.MustCompile(`^([A-Za-z0-9_.-]+):\s+`)
+
+// thresholdRegex matches the "> COUNT/WINDOW" suffix of a rule's filter
+var thresholdRegex = regexp.MustCompile(`^(.*\S)\s+>\s*(\d+)\s*/\s*([0-9]*[a-z]+)$`)
+
+// ParseRule parses a rule written as
+//
+//	[NAME:] FILTER > COUNT/WINDOW [=> ACTION,...]
+//
+// WINDOW is a duration such as 30s or 5m, or a bare unit (s, min, h) for one
+// of it. Actions are banner, bell, desktop and webhook (or webhook=URL);
+// defaultWebhook is used for webhook actions without a URL.
+func ParseRule(spec, defaultWebhook string) (Rule, error) {
+	rest := strings.TrimSpace(spec)
+	var rule Rule
+
+	if idx := strings.LastIndex(rest, "=>"); idx >= 0 {
+		actions := strings.TrimSpace(rest[idx+2:])
+		rest = strings.TrimSpace(rest[:idx])
+		for _, action := range strings.Split(actions, ",") {
+			action = strings.TrimSpace(action)
+			name, url, _ := strings.Cut(action, "=")
+			name = strings.ToLower(name)
+			switch name {
+			case ActionBanner, ActionBell, ActionDesktop:
+			case ActionWebhook:
+				if url != "" {
+					rule.Webhook = url
+				}
+			case "":
+				continue
+			default:
+				return Rule{}, fmt.Errorf("invalid alert rule %q: unknown action %q (use banner, bell, desktop or webhook)", spec, action)
+			}
+			if !rule.Has(name) {
+				rule.Actions = append(rule.Actions, name)
+			}
+		}
+	}
+	if len(rule.Actions) == 0 {
+		rule.Actions = append(rule.Actions, defaultActions...)
+	}
+	if rule.Has(ActionWebhook) && rule.Webhook == "" {
+		if defaultWebhook == "" {
+			return Rule{}, fmt.Errorf("invalid alert rule %q: webhook action needs webhook=URL or --alert-webhook", spec)
+		}
+		rule.Webhook = defaultWebhook
+	}
+
+	if m := ruleNameRegex.FindStringSubmatch(rest); m != nil {
+		rule.Name = m[1]
+		rest = rest[len(m[0]):]
+	}
+
+	m := thresholdRegex.FindStringSubmatch(rest)
+	if m == nil {
+		return Rule{}, fmt.Errorf("invalid alert rule %q: expected FILTER > COUNT/WINDOW, e.g. severity=ERROR > 50/1m", spec)
+	}
+	threshold, err := strconv.Atoi(m[2])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid alert rule %q: bad count %q", spec, m[2])
+	}
+	window, err := parseWindow(m[3])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid alert rule %q: %w", spec, err)
+	}
+	filter, err := filterexpr.Parse(m[1])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid alert rule %q: %w", spec, err)
+	}
+
+	rule.Filter = filter
+	rule.Threshold = threshold
+	rule.Window = window
+	if rule.Name == "" {
+		rule.Name = filter.String()
+	}
+	return rule, nil
+}
+
+// parseWindow accepts a Go duration or a bare unit meaning one of it
+func parseWindow(s string) (time.Duration, error) {
+	switch s {
+	case "s", "sec":
+		return time.Second, nil
+	case "m", "min":
+		return time.Minute, nil
+	case "h", "hr", "hour":
+		return time.Hour, nil
+	}
+	window, err := time.ParseDuration(s)
+	if err != nil || window < time.Second {
+		return 0, fmt.Errorf("invalid window %q (use e.g. 30s, 1m, 5m or min)", s)
+	}
+	return window.Truncate(time.Second), nil
+}
+
+// formatWindow renders a window the way rules are usually written
+func formatWindow(d time.Duration) string {
+	switch {
+	case d == time.Minute:
+		return "min"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// Alert is a rule whose count is above its threshold
+type Alert struct {
+	Rule    Rule
+	Count   int      // Matching entries in the window
+	Samples []string // Most recent matching lines, oldest first
+	Since   time.Time
+}
+
+// Summary renders the alert on one line, e.g. "payments: 73 > 50/min"
+func (a Alert) Summary() string {
+	return fmt.Sprintf("%s: %d %s", a.Rule.Name, a.Count, a.Rule.Condition())
+}
+
+// bucket counts the matches within one second
+type bucket struct {
+	second int64
+	count  int
+}
+
+// ruleState tracks the sliding window of one rule
+type ruleState struct {
+	rule    Rule
+	buckets []bucket
+	total   int
+	samples []string
+	firing  bool
+	since   time.Time
+}
+
+// prune drops the buckets that have left the window
+func (s *ruleState) prune(now time.Time) {
+	oldest := now.Add(-s.rule.Window).Unix()
+	drop := 0
+	for drop < len(s.buckets) && s.buckets[drop].second <= oldest {
+		s.total -= s.buckets[drop].count
+		drop++
+	}
+	s.buckets = s.buckets[drop:]
+}
+
+// Engine counts matching entries for a set of rules. It is not safe for
+// concurrent use.
+type Engine struct {
+	states []*ruleState
+}
+
+// NewEngine creates an engine for the rules
+func NewEngine(rules []Rule) *Engine {
+	e := &Engine{}
+	for _, rule := range rules {
+		e.states = append(e.states, &ruleState{rule: rule})
+	}
+	return e
+}
+
+// Observe counts an entry that arrived at now against every rule it matches;
+// line is kept as a sample
+func (e *Engine) Observe(rec *filterexpr.Record, line string, now time.Time) {
+	second := now.Unix()
+	for _, s := range e.states {
+		if !s.rule.Filter.Match(rec) {
+			continue
+		}
+		if n := len(s.buckets); n > 0 && s.buckets[n-1].second == second {
+			s.buckets[n-1].count++
+		} else {
+			s.buckets = append(s.buckets, bucket{second: second, count: 1})
+		}
+		s.total++
+		if len(s.samples) == maxSamples {
+			s.samples = s.samples[1:]
+		}
+		s.samples = append(s.samples, line)
+	}
+}
+
+// Evaluate updates the rules' windows at now. It returns the alerts that are
+// active and, among them, the ones that just started.
+func (e *Engine) Evaluate(now time.Time) (active, fired []Alert) {
+	for _, s := range e.states {
+		s.prune(now)
+		if s.total <= s.rule.Threshold {
+			s.firing = false
+			continue
+		}
+		started := !s.firing
+		if started {
+			s.firing = true
+			s.since = now
+		}
+		alert := Alert{
+			Rule:    s.rule,
+			Count:   s.total,
+			Samples: append([]string(nil), s.samples...),
+			Since:   s.since,
+		}
+		active = append(active, alert)
+		if started {
+			fired = append(fired, alert)
+		}
+	}
+	return active, fired
+}
@@ -0,0 +1,99 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// webhookTimeout bounds each webhook delivery
+const webhookTimeout = 10 * time.Second
+
+// WebhookPayload is the JSON body posted to webhooks. Text makes the
+// payload readable by Slack-style incoming webhooks as is.
+type WebhookPayload struct {
+	Text          string    `json:"text"`
+	Rule          string    `json:"rule"`
+	Filter        string    `json:"filter"`
+	Threshold     int       `json:"threshold"`
+	WindowSeconds int       `json:"window_seconds"`
+	Count         int       `json:"count"`
+	FiredAt       time.Time `json:"fired_at"`
+	Samples       []string  `json:"samples"`
+}
+
+// PostWebhook sends the alert and its sample entries to url
+func PostWebhook(url string, alert Alert) error {
+	payload := WebhookPayload{
+		Text:          "Gonzo alert " + alert.Summary(),
+		Rule:          alert.Rule.Name,
+		Filter:        alert.Rule.Filter.String(),
+		Threshold:     alert.Rule.Threshold,
+		WindowSeconds: int(alert.Rule.Window / time.Second),
+		Count:         alert.Count,
+		FiredAt:       alert.Since,
+		Samples:       alert.Samples,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// NotifyDesktop shows a desktop notification using notify-send on Linux and
+// osascript on macOS
+func NotifyDesktop(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("notify-send", "--app-name=gonzo", title, message)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop notification failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// Dispatch runs the desktop and webhook actions of a fired alert. The banner
+// and bell are left to the caller, which owns the terminal.
+func Dispatch(alert Alert) []error {
+	var errs []error
+	if alert.Rule.Has(ActionDesktop) {
+		if err := NotifyDesktop("Gonzo alert: "+alert.Rule.Name, alert.Summary()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if alert.Rule.Has(ActionWebhook) && alert.Rule.Webhook != "" {
+		if err := PostWebhook(alert.Rule.Webhook, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -13,8 +14,58 @@ type TextAnalyzer struct {
 	wordPattern     *regexp.Regexp
 	timestampParser *timestamp.Parser
 	stopWords       map[string]bool
+	collapse        []tokenClass // Tokens replaced by a placeholder before counting
 }
 
+// Token classes that can be collapsed into a placeholder before counting
+const (
+	CollapseNumbers = "numbers"
+	CollapseUUIDs   = "uuids"
+	CollapseHex     = "hex"
+)
+
+// TokenizerConfig sets how messages are split into the words counted for
+// frequency analysis
+type TokenizerConfig struct {
+	StopWords          []string // Added to the built-in stop words
+	NoDefaultStopWords bool     // Drop the built-in stop words
+	MinWordLength      int      // Shorter words are not counted; 0 means 3
+	Collapse           []string // Token classes counted as a placeholder: numbers, uuids, hex
+}
+
+// tokenClass is a kind of token counted as one placeholder, e.g. every UUID
+// as <uuid>
+type tokenClass struct {
+	placeholder string
+	pattern     *regexp.Regexp
+	keep        func(string) bool // Optional check of a match
+}
+
+// tokenClasses are the collapsible token classes, in the order they are
+// replaced: UUIDs hold hex runs, and hex strings hold numbers
+var tokenClasses = map[string]tokenClass{
+	CollapseUUIDs: {
+		placeholder: "<uuid>",
+		pattern:     regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`),
+	},
+	CollapseHex: {
+		placeholder: "<hex>",
+		pattern:     regexp.MustCompile(`(?i)\b(?:0x[0-9a-f]+|[0-9a-f]{8,})\b`),
+		// Bare hex runs need a digit and a letter, so words and numbers stay
+		keep: func(s string) bool {
+			return strings.HasPrefix(strings.ToLower(s), "0x") ||
+				(strings.ContainsAny(s, "0123456789") && strings.ContainsAny(strings.ToLower(s), "abcdef"))
+		},
+	},
+	CollapseNumbers: {
+		placeholder: "<num>",
+		pattern:     regexp.MustCompile(`\b\d+(?:\.\d+)?\b`),
+	},
+}
+
+// tokenClassOrder is the order collapsed token classes are replaced in
+var tokenClassOrder = []string{CollapseUUIDs, CollapseHex, CollapseNumbers}
+
 type AnalysisResult struct {
 	Words   []string
 	Phrases []string
@@ -25,6 +76,26 @@ func NewTextAnalyzer() *TextAnalyzer {
 }
 
 func NewTextAnalyzerWithStopWords(customStopWords []string) *TextAnalyzer {
+	// Without token classes to collapse the config is always valid
+	ta, _ := NewTextAnalyzerWithConfig(TokenizerConfig{StopWords: customStopWords})
+	return ta
+}
+
+// NewTextAnalyzerWithConfig creates a text analyzer counting words as the
+// config sets. It fails on an unknown token class to collapse.
+func NewTextAnalyzerWithConfig(cfg TokenizerConfig) (*TextAnalyzer, error) {
+	collapse := make(map[string]bool)
+	for _, name := range cfg.Collapse {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || name == "none" {
+			continue
+		}
+		if _, ok := tokenClasses[name]; !ok {
+			return nil, fmt.Errorf("invalid token class %q to collapse (use numbers, uuids or hex)", name)
+		}
+		collapse[name] = true
+	}
+
 	// Built-in stop words
 	stopWords := map[string]bool{
 		"the": true, "and": true, "for": true, "are": true, "but": true,
@@ -46,21 +117,37 @@ func NewTextAnalyzerWithStopWords(customStopWords []string) *TextAnalyzer {
 		"around": true, "through": true, "across": true, "against": true,
 		"without": true,
 	}
+	if cfg.NoDefaultStopWords {
+		stopWords = make(map[string]bool)
+	}
 
 	// Add custom stop words
-	for _, word := range customStopWords {
-		if word != "" {
+	for _, word := range cfg.StopWords {
+		if word = strings.TrimSpace(word); word != "" {
 			stopWords[strings.ToLower(word)] = true
 		}
 	}
 
-	return &TextAnalyzer{
+	ta := &TextAnalyzer{
 		minWordLength:   3,
 		maxPhraseLength: 4,
 		wordPattern:     regexp.MustCompile(`[a-zA-Z_][a-zA-Z0-9_]*`),
 		timestampParser: timestamp.NewParser(),
 		stopWords:       stopWords,
 	}
+	if cfg.MinWordLength > 0 {
+		ta.minWordLength = cfg.MinWordLength
+	}
+	for _, name := range tokenClassOrder {
+		if collapse[name] {
+			ta.collapse = append(ta.collapse, tokenClasses[name])
+		}
+	}
+	if len(ta.collapse) > 0 {
+		// Placeholders are words of their own
+		ta.wordPattern = regexp.MustCompile(`<(?:uuid|hex|num)>|[a-zA-Z_][a-zA-Z0-9_]*`)
+	}
+	return ta, nil
 }
 
 func (ta *TextAnalyzer) AnalyzeLine(line string) *AnalysisResult {
@@ -80,7 +167,7 @@ func (ta *TextAnalyzer) AnalyzeLine(line string) *AnalysisResult {
 }
 
 func (ta *TextAnalyzer) extractWords(text string) []string {
-	text = strings.ToLower(text)
+	text = ta.CollapseTokens(strings.ToLower(text))
 	matches := ta.wordPattern.FindAllString(text, -1)
 	return matches
 }
@@ -97,6 +184,35 @@ func (ta *TextAnalyzer) filterWords(words []string) []string {
 	return filtered
 }
 
+// CollapseTokens replaces the tokens of the collapsed classes in text with
+// their placeholders
+func (ta *TextAnalyzer) CollapseTokens(text string) string {
+	for _, class := range ta.collapse {
+		if class.keep == nil {
+			text = class.pattern.ReplaceAllLiteralString(text, class.placeholder)
+			continue
+		}
+		text = class.pattern.ReplaceAllStringFunc(text, func(s string) string {
+			if class.keep(s) {
+				return class.placeholder
+			}
+			return s
+		})
+	}
+	return text
+}
+
+// CountedWord returns the form a lowercase token from a message is counted
+// as, with collapsed numbers, UUIDs and hex strings replaced by their
+// placeholders, or false when it is too short or a stop word
+func (ta *TextAnalyzer) CountedWord(token string) (string, bool) {
+	token = ta.CollapseTokens(token)
+	if len(token) < ta.minWordLength || ta.stopWords[token] {
+		return "", false
+	}
+	return token, true
+}
+
 func (ta *TextAnalyzer) extractPhrases(words []string) []string {
 	phrases := make([]string, 0)
 
@@ -0,0 +1,133 @@
+// Package backpressure decides what happens when log sources produce lines
+// faster than gonzo processes them: the source waits, or lines are dropped
+// and counted per source so the loss is visible instead of a silent stall.
+package backpressure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Policies for a full channel
+const (
+	Block      = "block"       // Wait until there is room, stalling the source
+	DropOldest = "drop-oldest" // Discard the oldest queued line to make room
+	DropNewest = "drop-newest" // Discard the line being sent
+)
+
+// Policies lists the backpressure policies
+var Policies = []string{Block, DropOldest, DropNewest}
+
+// Sourced is a line that knows the source that queued it, so a line the
+// drop-oldest policy evicts is counted against its own source rather than
+// the one sending when the channel was full
+type Sourced interface {
+	Source() string
+}
+
+// Queue sends lines on a channel under a policy and counts the lines it
+// drops. It is safe for concurrent use by several sources.
+type Queue[T any] struct {
+	ch     chan T
+	policy string
+
+	mu      sync.Mutex
+	dropped map[string]int64 // By source
+	total   atomic.Int64
+}
+
+// New returns a queue sending on ch under policy, "" for Block
+func New[T any](ch chan T, policy string) (*Queue[T], error) {
+	switch policy {
+	case "":
+		policy = Block
+	case Block, DropOldest, DropNewest:
+	default:
+		return nil, fmt.Errorf("unknown backpressure policy %q (available: %s)", policy, strings.Join(Policies, ", "))
+	}
+	return &Queue[T]{ch: ch, policy: policy, dropped: make(map[string]int64)}, nil
+}
+
+// Policy returns the queue's policy
+func (q *Queue[T]) Policy() string {
+	return q.policy
+}
+
+// Send puts a line from source on the channel, or drops a line when the
+// channel is full and the policy allows it. It reports false when ctx ends
+// while waiting.
+func (q *Queue[T]) Send(ctx context.Context, source string, line T) bool {
+	switch q.policy {
+	case DropNewest:
+		select {
+		case q.ch <- line:
+		case <-ctx.Done():
+			return false
+		default:
+			q.drop(source)
+		}
+		return true
+
+	case DropOldest:
+		for {
+			select {
+			case q.ch <- line:
+				return true
+			case <-ctx.Done():
+				return false
+			default:
+			}
+			// Make room; the reader may have taken a line meanwhile
+			select {
+			case evicted := <-q.ch:
+				q.drop(sourceOf(evicted, source))
+			default:
+			}
+		}
+	}
+
+	select {
+	case q.ch <- line:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sourceOf returns the source of a queued line, or fallback when the line
+// does not know it
+func sourceOf[T any](line T, fallback string) string {
+	if s, ok := any(line).(Sourced); ok {
+		if source := s.Source(); source != "" {
+			return source
+		}
+	}
+	return fallback
+}
+
+// drop counts a dropped line against source
+func (q *Queue[T]) drop(source string) {
+	q.mu.Lock()
+	q.dropped[source]++
+	q.mu.Unlock()
+	q.total.Add(1)
+}
+
+// Dropped returns how many lines were dropped in total
+func (q *Queue[T]) Dropped() int64 {
+	return q.total.Load()
+}
+
+// DroppedBySource returns how many lines were dropped for each source
+func (q *Queue[T]) DroppedBySource() map[string]int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	counts := make(map[string]int64, len(q.dropped))
+	for source, count := range q.dropped {
+		counts[source] = count
+	}
+	return counts
+}
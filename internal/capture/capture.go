@@ -0,0 +1,273 @@
+// Package capture writes every ingested entry to a gzip-compressed JSON lines
+// file, so a live session can be replayed or searched after its entries have
+// left the ring buffer. The file is rotated when it grows too large or too
+// old; rotated files are kept next to it with the time they were closed in
+// their name, e.g. capture-20260102-150405.jsonl.gz.
+package capture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// flushInterval is how often buffered entries are flushed to disk, bounding
+// what a crash can lose
+const flushInterval = time.Second
+
+// Entry is the JSON representation of a captured entry
+type Entry struct {
+	Timestamp     time.Time         `json:"timestamp"`
+	OrigTimestamp *time.Time        `json:"original_timestamp,omitempty"`
+	Severity      string            `json:"severity"`
+	Message       string            `json:"message"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	RawLine       string            `json:"raw,omitempty"`
+	Source        string            `json:"source,omitempty"` // Input the entry was read from
+}
+
+// Config configures a capture file
+type Config struct {
+	Path    string
+	MaxSize int64         // Compressed bytes before the file is rotated; 0 never rotates by size
+	MaxAge  time.Duration // Age before the file is rotated; 0 never rotates by age
+}
+
+// countingWriter counts the bytes written to the file
+type countingWriter struct {
+	file    *os.File
+	written int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// Writer appends entries to the capture file. It is safe for concurrent use.
+type Writer struct {
+	cfg Config
+
+	mu      sync.Mutex
+	file    *countingWriter
+	gz      *gzip.Writer
+	opened  time.Time
+	err     error // First write error, reported once on Close
+	dropped int64 // Entries lost to write errors
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWriter opens the capture file for appending. An existing file is kept:
+// gzip readers decompress the new entries after the old ones.
+func NewWriter(cfg Config) (*Writer, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("capture file path is empty")
+	}
+	w := &Writer{cfg: cfg, done: make(chan struct{})}
+	if err := w.open(time.Now()); err != nil {
+		return nil, err
+	}
+
+	w.wg.Add(1)
+	go w.flushLoop()
+	return w, nil
+}
+
+// open starts a new gzip stream at the end of the capture file
+func (w *Writer) open(now time.Time) error {
+	file, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open capture file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open capture file: %w", err)
+	}
+	w.file = &countingWriter{file: file, written: info.Size()}
+	w.gz = gzip.NewWriter(w.file)
+	w.opened = now
+	return nil
+}
+
+// closeFile ends the gzip stream and closes the file
+func (w *Writer) closeFile() error {
+	err := w.gz.Close()
+	if closeErr := w.file.file.Close(); err == nil {
+		err = closeErr
+	}
+	w.gz, w.file = nil, nil
+	return err
+}
+
+// rotatedPath names a rotated file after the time it was closed, keeping the
+// extensions so it still reads as compressed JSON lines
+func (w *Writer) rotatedPath(now time.Time) string {
+	dir, base := filepath.Split(w.cfg.Path)
+	name, ext := base, ""
+	if i := strings.Index(base, "."); i > 0 {
+		name, ext = base[:i], base[i:]
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s%s", name, now.Format("20060102-150405"), ext))
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%s-%d%s", name, now.Format("20060102-150405"), n, ext))
+	}
+}
+
+// rotateIfDue moves the file aside and starts a new one when it is too large
+// or too old
+func (w *Writer) rotateIfDue(now time.Time) error {
+	sizeDue := w.cfg.MaxSize > 0 && w.file.written >= w.cfg.MaxSize
+	ageDue := w.cfg.MaxAge > 0 && now.Sub(w.opened) >= w.cfg.MaxAge && w.file.written > 0
+	if !sizeDue && !ageDue {
+		return nil
+	}
+	if err := w.closeFile(); err != nil {
+		return fmt.Errorf("failed to close capture file: %w", err)
+	}
+	if err := os.Rename(w.cfg.Path, w.rotatedPath(now)); err != nil {
+		// Keep appending to the same file rather than losing entries
+		if openErr := w.open(now); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rotate capture file: %w", err)
+	}
+	return w.open(now)
+}
+
+// Write appends an entry to the capture file
+func (w *Writer) Write(entry Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.gz == nil {
+		w.dropped++
+		return
+	}
+	if _, err := w.gz.Write(data); err != nil {
+		w.dropped++
+		w.fail(err)
+	}
+}
+
+// fail records the first error the capture runs into
+func (w *Writer) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// flushLoop flushes the compressed stream and rotates on a timer, so entries
+// reach the disk even when they arrive slowly
+func (w *Writer) flushLoop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case now := <-ticker.C:
+			w.mu.Lock()
+			if w.gz != nil {
+				if err := w.gz.Flush(); err != nil {
+					w.fail(err)
+				} else if err := w.rotateIfDue(now); err != nil {
+					w.fail(err)
+				}
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// Dropped returns the number of entries lost to write errors
+func (w *Writer) Dropped() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dropped
+}
+
+// Close flushes the remaining entries and closes the file. It returns the
+// first error the capture ran into.
+func (w *Writer) Close() error {
+	close(w.done)
+	w.wg.Wait()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.gz != nil {
+		if err := w.closeFile(); err != nil && w.err == nil {
+			w.err = fmt.Errorf("failed to close capture file: %w", err)
+		}
+	}
+	return w.err
+}
+
+// maxLineSize bounds one captured entry when reading a capture file
+const maxLineSize = 4 * 1024 * 1024 // 4MB
+
+// Reader reads the entries of a capture file in the order they were written
+type Reader struct {
+	file    *os.File
+	gz      *gzip.Reader
+	scanner *bufio.Scanner
+}
+
+// Open opens a capture file for reading, including the entries appended by
+// later sessions
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open capture file: %w", err)
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read capture file %s: %w", path, err)
+	}
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &Reader{file: file, gz: gz, scanner: scanner}, nil
+}
+
+// Next returns the next entry, or io.EOF after the last one. A file cut
+// short by a crash ends at its last complete entry.
+func (r *Reader) Next() (Entry, error) {
+	for r.scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(r.scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		return entry, nil
+	}
+	if err := r.scanner.Err(); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return Entry{}, fmt.Errorf("failed to read capture file: %w", err)
+	}
+	return Entry{}, io.EOF
+}
+
+// Close closes the capture file
+func (r *Reader) Close() error {
+	r.gz.Close()
+	return r.file.Close()
+}
@@ -0,0 +1,110 @@
+// Package cpulimit reads the CPU limit of the cgroup gonzo runs in, as a
+// container's CPU limit sets it, so work can be sized to the CPU gonzo may
+// use rather than to the CPUs of the node.
+package cpulimit
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// cgroupRoot is where the cgroup hierarchies are mounted
+const cgroupRoot = "/sys/fs/cgroup"
+
+// limit is the CPU limit, read the first time it is asked for
+var limit = sync.OnceValue(func() float64 {
+	groups := selfCgroups()
+	if limit, ok := limitV2(groups[""]); ok {
+		return limit
+	}
+	return limitV1(groups["cpu"])
+})
+
+// Limit returns how many CPUs the cgroup may use on average, such as 0.5,
+// from its CFS quota, and 0 when it is not limited
+func Limit() float64 {
+	return limit()
+}
+
+// selfCgroups returns the cgroup of this process by controller, "" for
+// the unified hierarchy of cgroup v2, from /proc/self/cgroup
+func selfCgroups() map[string]string {
+	groups := make(map[string]string)
+	file, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		return groups
+	}
+	defer file.Close()
+
+	// Lines are ID:CONTROLLERS:PATH, e.g. 4:cpu,cpuacct:/kubepods/pod1
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			groups[controller] = fields[2]
+		}
+	}
+	return groups
+}
+
+// limitV2 returns the lowest cpu.max limit of a cgroup v2 group and its
+// parents, reporting whether the unified hierarchy has one. Inside a
+// container the group is usually mounted as the root.
+func limitV2(group string) (float64, bool) {
+	found := false
+	limit := 0.0
+	for dir := filepath.Join(cgroupRoot, group); strings.HasPrefix(dir, cgroupRoot); dir = filepath.Dir(dir) {
+		data, err := os.ReadFile(filepath.Join(dir, "cpu.max"))
+		if err == nil {
+			found = true
+			// "max 100000" when unlimited, otherwise "QUOTA PERIOD"
+			quota, period, _ := strings.Cut(strings.TrimSpace(string(data)), " ")
+			if l := quotaLimit(quota, period); l > 0 && (limit == 0 || l < limit) {
+				limit = l
+			}
+		}
+		if dir == cgroupRoot {
+			break
+		}
+	}
+	return limit, found
+}
+
+// limitV1 returns the CFS quota of a cgroup v1 cpu group, looked for under
+// the cpu controller's usual mount points
+func limitV1(group string) float64 {
+	for _, mount := range []string{"cpu,cpuacct", "cpu", "cpuacct,cpu"} {
+		for _, dir := range []string{filepath.Join(cgroupRoot, mount, group), filepath.Join(cgroupRoot, mount)} {
+			quota, err := os.ReadFile(filepath.Join(dir, "cpu.cfs_quota_us"))
+			if err != nil {
+				continue
+			}
+			period, err := os.ReadFile(filepath.Join(dir, "cpu.cfs_period_us"))
+			if err != nil {
+				continue
+			}
+			return quotaLimit(strings.TrimSpace(string(quota)), strings.TrimSpace(string(period)))
+		}
+	}
+	return 0
+}
+
+// quotaLimit returns quota / period, and 0 for no quota, such as "max" or -1
+func quotaLimit(quota, period string) float64 {
+	q, err := strconv.ParseFloat(quota, 64)
+	if err != nil || q <= 0 {
+		return 0
+	}
+	p, err := strconv.ParseFloat(period, 64)
+	if err != nil || p <= 0 {
+		return 0
+	}
+	return q / p
+}
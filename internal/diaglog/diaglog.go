@@ -0,0 +1,169 @@
+// Package diaglog keeps gonzo's own diagnostic messages, from the standard
+// logger and from the Kubernetes client, in a ring buffer the dashboard
+// shows, and optionally appends them to a file, instead of writing them over
+// the terminal.
+package diaglog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is how severe a diagnostic message is
+type Level int
+
+// Levels, least severe first
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+var levelNames = [...]string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// String returns the level's name, e.g. "WARN"
+func (l Level) String() string {
+	if l < Debug || l > Error {
+		return "UNKNOWN"
+	}
+	return levelNames[l]
+}
+
+// ParseLevel parses a level name: debug, info, warn or error. An empty name
+// is Info.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return Debug, nil
+	case "", "info":
+		return Info, nil
+	case "warn", "warning":
+		return Warn, nil
+	case "error":
+		return Error, nil
+	}
+	return Info, fmt.Errorf("invalid log level %q (use debug, info, warn or error)", name)
+}
+
+// Entry is one diagnostic message
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+}
+
+// Log keeps the latest diagnostic messages at or above a level. It is safe
+// for concurrent use.
+type Log struct {
+	mu      sync.Mutex
+	entries []Entry // Ring of the latest entries
+	next    int     // Where the next entry goes once the ring is full
+	min     Level
+	file    io.Writer // Also receives every kept message, nil for none
+	counts  [Error + 1]int64
+}
+
+// New returns a log keeping the latest capacity messages at or above min,
+// also appending them to file unless it is nil
+func New(capacity int, min Level, file io.Writer) *Log {
+	return &Log{entries: make([]Entry, 0, max(1, capacity)), min: min, file: file}
+}
+
+// Write records one message of the standard logger, which should have no
+// prefix or flags. The level is taken from how the message starts:
+// "Warning" is Warn, "Error" and "Failed" are Error, "Debug" is Debug, and
+// everything else is Info.
+func (l *Log) Write(p []byte) (int, error) {
+	message := strings.TrimRight(string(p), "\n")
+	level := Info
+	switch lower := strings.ToLower(message); {
+	case strings.HasPrefix(lower, "warning"):
+		level = Warn
+	case strings.HasPrefix(lower, "error"), strings.HasPrefix(lower, "failed"):
+		level = Error
+	case strings.HasPrefix(lower, "debug"):
+		level = Debug
+	}
+	l.Add(level, message)
+	return len(p), nil
+}
+
+// Klog returns a writer for the Kubernetes client's log lines, which take
+// their level from the I, W, E or F that starts their header
+func (l *Log) Klog() io.Writer {
+	return klogWriter{l}
+}
+
+type klogWriter struct{ log *Log }
+
+// Write records a klog line such as
+// "E1014 13:20:51.587449   12345 reflector.go:123] message"
+func (w klogWriter) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+	level := Info
+	if line != "" {
+		switch line[0] {
+		case 'W':
+			level = Warn
+		case 'E', 'F':
+			level = Error
+		}
+	}
+	if _, message, ok := strings.Cut(line, "] "); ok {
+		line = message
+	}
+	w.log.Add(level, "kubernetes: "+line)
+	return len(p), nil
+}
+
+// Add records a message at level, unless it is below the log's level
+func (l *Log) Add(level Level, message string) {
+	if level < l.min {
+		return
+	}
+	entry := Entry{Time: time.Now(), Level: level, Message: message}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.appendLocked(entry)
+
+	if l.file != nil {
+		line := fmt.Sprintf("%s %-5s %s\n", entry.Time.Format(time.RFC3339Nano), level, message)
+		if _, err := io.WriteString(l.file, line); err != nil {
+			l.file = nil
+			l.appendLocked(Entry{Time: time.Now(), Level: Error, Message: fmt.Sprintf("Error: stopped writing the log file: %v", err)})
+		}
+	}
+}
+
+// appendLocked adds an entry to the ring; l.mu must be held
+func (l *Log) appendLocked(entry Entry) {
+	l.counts[entry.Level]++
+	if len(l.entries) < cap(l.entries) {
+		l.entries = append(l.entries, entry)
+		return
+	}
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+}
+
+// Entries returns the kept messages, oldest first
+func (l *Log) Entries() []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]Entry, 0, len(l.entries))
+	entries = append(entries, l.entries[l.next:]...)
+	return append(entries, l.entries[:l.next]...)
+}
+
+// Count returns how many messages at level were recorded since the start,
+// including those the ring no longer holds
+func (l *Log) Count(level Level) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.counts[level]
+}
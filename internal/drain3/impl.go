@@ -88,6 +88,13 @@ func (d *Drain) AddLogMessage(logMessage string) error {
 	return err
 }
 
+// AddLogMessageCluster processes a single log message and returns its
+// cluster, and whether the message started a new cluster
+func (d *Drain) AddLogMessageCluster(logMessage string) (*goDrain.LogCluster, bool, error) {
+	cluster, updateType, err := d.Drain.AddLogMessage(logMessage)
+	return cluster, updateType == goDrain.ClusterUpdateTypeCreated, err
+}
+
 // GetClusters returns the current clusters of log templates
 func (d *Drain) GetClusters() []*goDrain.LogCluster {
 	return d.Drain.GetClusters()
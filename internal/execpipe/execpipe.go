@@ -0,0 +1,259 @@
+// Package execpipe streams log entries into the standard input of external
+// commands, e.g.
+//
+//	severity=ERROR => jq -r .message | notify-send gonzo
+//
+// pipes the message of every error into a desktop notification. Commands run
+// through the shell, so they can be pipelines. A command that exits is
+// restarted with an increasing delay; entries arriving meanwhile wait in the
+// queue.
+package execpipe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// Pipe limits
+const (
+	queueSize   = 10000            // Entries waiting for the command
+	minBackoff  = time.Second      // Delay before the first restart
+	maxBackoff  = 30 * time.Second // Longest delay between restarts
+	healthyRun  = time.Minute      // A command running this long restarts without delay growth
+	stopTimeout = 5 * time.Second  // Time a command gets to finish after its input closes
+	maxLogLine  = 4096             // Longest output line logged at once
+)
+
+// Pipe streams the entries matching a filter into one command
+type Pipe struct {
+	Filter  *filterexpr.Expr // Entries piped; nil pipes every entry
+	Command string
+
+	queue   chan []byte
+	dropped atomic.Int64
+	mu      sync.Mutex
+	process *os.Process // Running command, to kill one that stops reading
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// Parse parses a pipe written as
+//
+//	[FILTER =>] COMMAND
+//
+// where FILTER is a filter expression selecting the entries piped
+func Parse(spec string) (*Pipe, error) {
+	pipe := &Pipe{Command: strings.TrimSpace(spec)}
+	if filter, command, ok := strings.Cut(spec, "=>"); ok {
+		expr, err := filterexpr.Parse(strings.TrimSpace(filter))
+		if err != nil {
+			return nil, fmt.Errorf("invalid exec %q: %w", spec, err)
+		}
+		pipe.Filter = expr
+		pipe.Command = strings.TrimSpace(command)
+	}
+	if pipe.Command == "" {
+		return nil, fmt.Errorf("invalid exec %q: expected [FILTER =>] COMMAND", spec)
+	}
+	return pipe, nil
+}
+
+// Matches reports whether an entry is piped into the command
+func (p *Pipe) Matches(rec *filterexpr.Record) bool {
+	return p.Filter == nil || p.Filter.Match(rec)
+}
+
+// Start runs the command in the background, restarting it when it exits
+func (p *Pipe) Start() {
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.queue = make(chan []byte, queueSize)
+	p.wg.Go(p.run)
+}
+
+// Stop closes the command's input once the queue is written, and waits for
+// it to exit. A command that stops reading its input is killed.
+func (p *Pipe) Stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(stopTimeout):
+		p.mu.Lock()
+		if p.process != nil {
+			p.process.Kill()
+		}
+		p.mu.Unlock()
+		<-done
+	}
+}
+
+// Send queues one line for the command. It never blocks; lines that do not
+// fit in the queue are dropped and counted.
+func (p *Pipe) Send(line []byte) {
+	if p.queue == nil {
+		return
+	}
+	select {
+	case p.queue <- line:
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+// Dropped returns how many lines were lost to a full queue or a command
+// that exited while they were written
+func (p *Pipe) Dropped() int64 {
+	return p.dropped.Load()
+}
+
+// run keeps the command running until stopped
+func (p *Pipe) run() {
+	backoff := minBackoff
+	for {
+		started := time.Now()
+		err := p.runOnce()
+		if p.ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) >= healthyRun {
+			backoff = minBackoff
+		}
+		if err != nil {
+			log.Printf("Warning: exec %q exited: %v; restarting in %s", p.Command, err, backoff)
+		} else {
+			log.Printf("Warning: exec %q exited; restarting in %s", p.Command, backoff)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-p.ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// shellCommand runs a command line through the platform's shell
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// runOnce starts the command and writes queued lines to it until it exits
+// or the pipe is stopped
+func (p *Pipe) runOnce() error {
+	cmd := shellCommand(p.Command)
+	output := &outputLogger{command: p.Command}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.process = cmd.Process
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		p.process = nil
+		p.mu.Unlock()
+	}()
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	writer := bufio.NewWriter(stdin)
+	write := func(line []byte) {
+		if _, err := writer.Write(line); err != nil {
+			p.dropped.Add(1)
+		}
+	}
+	for {
+		select {
+		case line := <-p.queue:
+			write(line)
+			// Flush once the queue is empty, so the command sees entries as
+			// they arrive without a write per line under load
+			if len(p.queue) == 0 {
+				writer.Flush()
+			}
+		case err := <-exited:
+			output.flush()
+			return err
+		case <-p.ctx.Done():
+			// Write what was queued, then let the command finish
+			for len(p.queue) > 0 {
+				write(<-p.queue)
+			}
+			writer.Flush()
+			stdin.Close()
+			select {
+			case <-exited:
+			case <-time.After(stopTimeout):
+				cmd.Process.Kill()
+				<-exited
+			}
+			output.flush()
+			return nil
+		}
+	}
+}
+
+// outputLogger logs what a command prints, one line at a time
+type outputLogger struct {
+	command string
+	buf     []byte
+}
+
+func (l *outputLogger) Write(data []byte) (int, error) {
+	l.buf = append(l.buf, data...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		l.logLine(l.buf[:i])
+		l.buf = l.buf[i+1:]
+	}
+	if len(l.buf) > maxLogLine {
+		l.flush()
+	}
+	return len(data), nil
+}
+
+// flush logs the output that did not end with a newline
+func (l *outputLogger) flush() {
+	if len(l.buf) > 0 {
+		l.logLine(l.buf)
+		l.buf = nil
+	}
+}
+
+func (l *outputLogger) logLine(line []byte) {
+	if text := strings.TrimRight(string(line), "\r"); text != "" {
+		log.Printf("exec %q: %s", l.command, text)
+	}
+}
@@ -0,0 +1,164 @@
+package filereader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkpointInterval is how often read offsets are saved while reading
+const checkpointInterval = 5 * time.Second
+
+// Checkpoints records how far each file has been read, so that the next run
+// reading the same files resumes after the last line read
+type Checkpoints struct {
+	path string
+
+	mu    sync.Mutex
+	files map[string]checkpoint // By absolute path
+	dirty bool
+}
+
+// checkpoint is the read offset of a file. The device and inode tell a file
+// renamed away from a new one created at its path.
+type checkpoint struct {
+	Device uint64 `json:"device,omitempty"`
+	Inode  uint64 `json:"inode,omitempty"`
+	Offset int64  `json:"offset"` // Of the decompressed content for .gz files
+}
+
+// checkpointFile is the content of a checkpoint file
+type checkpointFile struct {
+	Files map[string]checkpoint `json:"files"`
+}
+
+// LoadCheckpoints reads a checkpoint file, which need not exist yet
+func LoadCheckpoints(path string) (*Checkpoints, error) {
+	c := &Checkpoints{path: path, files: make(map[string]checkpoint)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+	var saved checkpointFile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoints %s: %w", path, err)
+	}
+	for filePath, cp := range saved.Files {
+		c.files[filePath] = cp
+	}
+	return c, nil
+}
+
+// Save writes the checkpoints if they changed since they were last saved.
+// Files read by other runs keep their checkpoints.
+func (c *Checkpoints) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(checkpointFile{Files: c.files}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoints: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write checkpoints: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
+
+// resume returns the offset to resume a file at: its checkpoint when it is
+// still the same file, no shorter than when it was read, or else 0
+func (c *Checkpoints) resume(filePath string, info os.FileInfo) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cp, ok := c.files[filePath]
+	if !ok {
+		return 0
+	}
+	device, inode := fileID(info)
+	if cp.Device != device || cp.Inode != inode {
+		return 0
+	}
+	// A compressed file's size is not that of its content
+	if !isCompressed(filePath) && cp.Offset > info.Size() {
+		return 0
+	}
+	return cp.Offset
+}
+
+// record sets the offset a file has been read to
+func (c *Checkpoints) record(filePath string, info os.FileInfo, offset int64) {
+	device, inode := fileID(info)
+	c.mu.Lock()
+	c.files[filePath] = checkpoint{Device: device, Inode: inode, Offset: offset}
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// SetCheckpoints makes the reader resume each file from its checkpoint and
+// record its progress there, saving it as it goes and when stopped. With
+// fromBeginning the files are read from their start, the checkpoints only
+// recorded.
+func (fr *FileReader) SetCheckpoints(c *Checkpoints, fromBeginning bool) {
+	fr.checkpoints = c
+	fr.fromBeginning = fromBeginning
+}
+
+// checkpoint records the offset a file has been read to, if checkpointing
+func (fr *FileReader) checkpoint(filePath string, info os.FileInfo, offset int64) {
+	if fr.checkpoints != nil && info != nil {
+		fr.checkpoints.record(filePath, info, offset)
+	}
+}
+
+// resumeOffset returns the offset to start reading the existing content of
+// a file at, 0 unless it has a checkpoint
+func (fr *FileReader) resumeOffset(filePath string, info os.FileInfo) int64 {
+	if fr.checkpoints == nil || fr.fromBeginning {
+		return 0
+	}
+	return fr.checkpoints.resume(filePath, info)
+}
+
+// saveCheckpoints saves the checkpoints periodically until the reader is
+// stopped or done
+func (fr *FileReader) saveCheckpoints() {
+	ticker := time.NewTicker(checkpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fr.ctx.Done():
+			return
+		case <-fr.done:
+			return
+		case <-ticker.C:
+			fr.flushCheckpoints()
+		}
+	}
+}
+
+// flushCheckpoints saves the checkpoints, if checkpointing
+func (fr *FileReader) flushCheckpoints() {
+	if fr.checkpoints == nil {
+		return
+	}
+	if err := fr.checkpoints.Save(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package filereader
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileID returns the device and inode of a file, which stay the same when it
+// is renamed and differ for a new file created at its path
+func fileID(info os.FileInfo) (uint64, uint64) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Dev), uint64(st.Ino)
+	}
+	return 0, 0
+}
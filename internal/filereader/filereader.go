@@ -2,6 +2,7 @@ package filereader
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -9,31 +10,62 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
-	"time"
+
+	"github.com/control-theory/gonzo/internal/linelimit"
+	"github.com/control-theory/gonzo/internal/timerange"
+	"github.com/control-theory/gonzo/internal/timestamp"
 
 	"github.com/fsnotify/fsnotify"
 )
 
+// Line is a line read from one of the files
+type Line struct {
+	Text string
+	Path string
+}
+
 // FileReader manages reading from multiple files with optional follow mode
 type FileReader struct {
 	filePaths  []string
 	follow     bool
 	ctx        context.Context
 	cancel     context.CancelFunc
-	lineChan   chan string
+	lineChan   chan Line
 	wg         sync.WaitGroup
 	mu         sync.Mutex
 	watchers   map[string]*fsnotify.Watcher // Track file watchers for follow mode
 	fileStates map[string]*fileState        // Track file states for follow mode
+
+	// --since and --until, see SetTimeRange
+	timeRange  timerange.Range
+	timestamps *timestamp.Parser
+
+	// Read offsets, see SetCheckpoints
+	checkpoints   *Checkpoints
+	fromBeginning bool
+	done          chan struct{} // Closed once the files are read, without follow mode
+
+	// Bounded lookback, see SetTail
+	tailBytes int64
+	tailLines int
+	historyMu sync.Mutex
+	history   map[string]*history
+
+	lineLimit int // See SetMaxLineSize
 }
 
 // fileState tracks the current position and state of a file being followed
 type fileState struct {
-	file     *os.File
-	scanner  *bufio.Scanner
-	size     int64
-	modified time.Time
+	file    *os.File
+	reader  *bufio.Reader
+	info    os.FileInfo // File being read, the target when the path is a symlink
+	size    int64       // Bytes read so far
+	partial string      // Last line read, until its newline is written
+	// Of the last line read, which partial keeps no more of than the line
+	// limit
+	partialSize int64
 }
 
 // New creates a new FileReader with the given file paths and options
@@ -73,9 +105,13 @@ func New(filePaths []string, follow bool) (*FileReader, error) {
 		follow:     follow,
 		ctx:        ctx,
 		cancel:     cancel,
-		lineChan:   make(chan string, 100),
+		lineChan:   make(chan Line, 100),
+		done:       make(chan struct{}),
+		history:    make(map[string]*history),
 		watchers:   make(map[string]*fsnotify.Watcher),
 		fileStates: make(map[string]*fileState),
+
+		lineLimit: linelimit.Default,
 	}
 
 	return fr, nil
@@ -111,7 +147,10 @@ func expandGlobs(patterns []string) ([]string, error) {
 }
 
 // Start begins reading from the files
-func (fr *FileReader) Start() <-chan string {
+func (fr *FileReader) Start() <-chan Line {
+	if fr.checkpoints != nil {
+		fr.wg.Go(fr.saveCheckpoints)
+	}
 	if fr.follow {
 		fr.startFollowMode()
 	} else {
@@ -124,14 +163,24 @@ func (fr *FileReader) Start() <-chan string {
 func (fr *FileReader) startReadMode() {
 	fr.wg.Go(func() {
 		defer close(fr.lineChan)
+		fr.readExisting()
+		close(fr.done)
+		fr.flushCheckpoints()
+	})
+}
 
-		for _, filePath := range fr.filePaths {
-			if err := fr.readFile(filePath); err != nil {
-				log.Printf("Error reading file %s: %v", filePath, err)
-				continue
-			}
+// readExisting reads the existing content of the files, merged in time
+// order when there are several
+func (fr *FileReader) readExisting() {
+	if len(fr.filePaths) > 1 {
+		fr.mergeFiles()
+		return
+	}
+	for _, filePath := range fr.filePaths {
+		if err := fr.readFile(filePath); err != nil {
+			log.Printf("Error reading file %s: %v", filePath, err)
 		}
-	})
+	}
 }
 
 // startFollowMode reads files and then watches for new content
@@ -141,15 +190,14 @@ func (fr *FileReader) startFollowMode() {
 		defer fr.closeAllWatchers()
 
 		// First, read existing content of all files
+		fr.readExisting()
+
+		// Then set up watchers for follow mode; compressed files are
+		// rotated ones that no longer grow
 		for _, filePath := range fr.filePaths {
-			if err := fr.readFile(filePath); err != nil {
-				log.Printf("Error reading file %s: %v", filePath, err)
+			if isCompressed(filePath) {
 				continue
 			}
-		}
-
-		// Then set up watchers for follow mode
-		for _, filePath := range fr.filePaths {
 			if err := fr.setupFileWatcher(filePath); err != nil {
 				log.Printf("Error setting up watcher for %s: %v", filePath, err)
 			}
@@ -162,46 +210,137 @@ func (fr *FileReader) startFollowMode() {
 
 // readFile reads a file from beginning to end
 func (fr *FileReader) readFile(filePath string) error {
-	file, err := os.Open(filePath)
+	existing, err := fr.openExisting(filePath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	defer existing.Close()
 
-	scanner := bufio.NewScanner(file)
-	// Set larger buffer size for long log lines
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	scanner := fr.scanLines(existing)
 
 	for scanner.Scan() {
-		select {
-		case <-fr.ctx.Done():
+		if fr.pastUntil(scanner.Text()) {
 			return nil
-		case fr.lineChan <- scanner.Text():
 		}
+		if !fr.send(Line{Text: scanner.Text(), Path: filePath}) {
+			return nil
+		}
+		fr.checkpoint(filePath, existing.info, existing.offset)
 	}
 
 	return scanner.Err()
 }
 
-// setupFileWatcher sets up a file system watcher for follow mode
+// existingFile is the existing content of a file, from where reading starts
+type existingFile struct {
+	io.ReadCloser
+	info   os.FileInfo
+	offset int64 // Of the content read so far, decompressed for .gz files
+}
+
+// openExisting opens a file to read its existing content from its checkpoint,
+// or --since and its tail on, decompressing .gz files, which are read from
+// their start unless resumed
+func (fr *FileReader) openExisting(filePath string) (*existingFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	offset := fr.resumeOffset(filePath, info)
+	if isCompressed(filePath) {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader := &gzipFile{Reader: gz, file: file}
+		if _, err := io.CopyN(io.Discard, reader, offset); err != nil {
+			reader.Close()
+			return nil, err
+		}
+		return &existingFile{ReadCloser: reader, info: info, offset: offset}, nil
+	}
+	if offset > 0 {
+		_, err = file.Seek(offset, io.SeekStart)
+	} else {
+		err = fr.seekSince(file)
+		if err == nil {
+			offset, err = file.Seek(0, io.SeekCurrent)
+		}
+		if err == nil && (fr.tailBytes > 0 || fr.tailLines > 0) {
+			offset, err = fr.tailStart(file, filePath, offset, info.Size())
+			if err == nil {
+				_, err = file.Seek(offset, io.SeekStart)
+			}
+		}
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &existingFile{ReadCloser: file, info: info, offset: offset}, nil
+}
+
+// countLines splits lines with split, adding the bytes each takes up to
+// offset
+func countLines(offset *int64, split bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := split(data, atEOF)
+		*offset += int64(advance)
+		return advance, token, err
+	}
+}
+
+// isCompressed reports whether a file is gzip-compressed, by its extension
+func isCompressed(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), ".gz")
+}
+
+// gzipFile closes a gzip reader with the file under it
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+// Close closes the gzip reader and the file
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// send passes a line on, reporting false once the reader is stopped
+func (fr *FileReader) send(line Line) bool {
+	select {
+	case <-fr.ctx.Done():
+		return false
+	case fr.lineChan <- line:
+		return true
+	}
+}
+
+// setupFileWatcher sets up a file system watcher for follow mode. The file's
+// directory is watched as well, so that a file renamed away and created
+// again, or a "current" symlink pointed at a new file, is noticed.
 func (fr *FileReader) setupFileWatcher(filePath string) error {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return err
 	}
 
-	// Get initial file info
-	info, err := os.Stat(filePath)
+	// Open file and position at end
+	file, err := os.Open(filePath)
 	if err != nil {
 		watcher.Close()
 		return err
 	}
-
-	// Open file and position at end
-	file, err := os.Open(filePath)
+	info, err := file.Stat()
 	if err != nil {
+		file.Close()
 		watcher.Close()
 		return err
 	}
@@ -214,27 +353,28 @@ func (fr *FileReader) setupFileWatcher(filePath string) error {
 		return err
 	}
 
-	scanner := bufio.NewScanner(file)
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	// Lines from here on are read as they are written
+	fr.checkpoint(filePath, info, currentSize)
 
 	// Store file state
 	fr.mu.Lock()
 	fr.watchers[filePath] = watcher
 	fr.fileStates[filePath] = &fileState{
-		file:     file,
-		scanner:  scanner,
-		size:     currentSize,
-		modified: info.ModTime(),
+		file:   file,
+		reader: bufio.NewReader(file),
+		info:   info,
+		size:   currentSize,
 	}
 	fr.mu.Unlock()
 
-	// Add file to watcher
+	// Add the file, its symlink target when it is one, and its directory
 	if err := watcher.Add(filePath); err != nil {
 		fr.cleanupFile(filePath)
 		return err
 	}
+	if err := watcher.Add(filepath.Dir(filePath)); err != nil {
+		log.Printf("Warning: not watching the directory of %s, a rotation may go unnoticed: %v", filePath, err)
+	}
 
 	// Start watching for changes
 	fr.wg.Go(func() {
@@ -257,8 +397,12 @@ func (fr *FileReader) watchFile(filePath string, watcher *fsnotify.Watcher) {
 				return
 			}
 
-			if event.Op&fsnotify.Write == fsnotify.Write {
-				fr.handleFileWrite(filePath)
+			// The directory reports on its other files too
+			if filepath.Clean(event.Name) != filePath {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+				fr.handleFileChange(filePath, watcher)
 			}
 
 		case err, ok := <-watcher.Errors:
@@ -270,8 +414,10 @@ func (fr *FileReader) watchFile(filePath string, watcher *fsnotify.Watcher) {
 	}
 }
 
-// handleFileWrite handles when a file is written to
-func (fr *FileReader) handleFileWrite(filePath string) {
+// handleFileChange reads what was written to a file. A file replaced at its
+// path, such as the new target of a "current" symlink, is read to its end
+// and the new one from its start; its lines keep the path given.
+func (fr *FileReader) handleFileChange(filePath string, watcher *fsnotify.Watcher) {
 	fr.mu.Lock()
 	state, exists := fr.fileStates[filePath]
 	fr.mu.Unlock()
@@ -280,72 +426,113 @@ func (fr *FileReader) handleFileWrite(filePath string) {
 		return
 	}
 
-	// Check if file was truncated (common with log rotation)
+	// Stat follows a symlink to the file it points at now; it fails while
+	// the path is between an old and a new file
 	info, err := os.Stat(filePath)
 	if err != nil {
+		fr.readNewLines(filePath, state)
 		return
 	}
 
-	if info.Size() < state.size {
+	switch {
+	case !os.SameFile(info, state.info):
+		fr.readNewLines(filePath, state)
+		fr.flushPartial(filePath, state)
+		if fr.reopenFile(filePath) {
+			// Watch the new file rather than the one that was replaced
+			watcher.Remove(filePath)
+			if err := watcher.Add(filePath); err != nil {
+				log.Printf("Error watching %s: %v", filePath, err)
+			}
+		}
+	case info.Size() < state.size:
 		// File was truncated, reopen from beginning
 		fr.reopenFile(filePath)
-		return
 	}
 
-	// Read new lines
-	for state.scanner.Scan() {
-		select {
-		case <-fr.ctx.Done():
+	fr.readNewLines(filePath, state)
+}
+
+// readNewLines sends the lines written to a file since it was last read. A
+// last line without its newline yet is kept until it is completed.
+func (fr *FileReader) readNewLines(filePath string, state *fileState) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	if state.file == nil {
+		return
+	}
+	for {
+		chunk, err := state.reader.ReadSlice('\n')
+		state.size += int64(len(chunk))
+		fr.addPartial(state, chunk)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			if err != io.EOF {
+				log.Printf("Error reading file %s: %v", filePath, err)
+			}
+			return
+		}
+		line := fr.takePartial(state)
+		if !fr.send(Line{Text: line, Path: filePath}) {
 			return
-		case fr.lineChan <- state.scanner.Text():
 		}
+		fr.checkpoint(filePath, state.info, state.size)
 	}
+}
 
-	// Update file state
+// flushPartial sends the unterminated last line of a file that will not be
+// written to any more
+func (fr *FileReader) flushPartial(filePath string, state *fileState) {
 	fr.mu.Lock()
-	if state.file != nil {
-		if pos, err := state.file.Seek(0, io.SeekCurrent); err == nil {
-			state.size = pos
-		}
-		state.modified = info.ModTime()
+	defer fr.mu.Unlock()
+
+	if state.partial != "" && fr.send(Line{Text: fr.takePartial(state), Path: filePath}) {
+		fr.checkpoint(filePath, state.info, state.size)
 	}
-	fr.mu.Unlock()
+	state.partial, state.partialSize = "", 0
 }
 
-// reopenFile reopens a file that may have been rotated
-func (fr *FileReader) reopenFile(filePath string) {
+// reopenFile reopens a file that may have been rotated, reading it from its
+// start, and reports whether it could
+func (fr *FileReader) reopenFile(filePath string) bool {
 	fr.mu.Lock()
 	defer fr.mu.Unlock()
 
 	state, exists := fr.fileStates[filePath]
 	if !exists {
-		return
-	}
-
-	// Close old file
-	if state.file != nil {
-		state.file.Close()
+		return false
 	}
 
 	// Open new file
 	file, err := os.Open(filePath)
 	if err != nil {
 		log.Printf("Error reopening file %s: %v", filePath, err)
-		return
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		log.Printf("Error reopening file %s: %v", filePath, err)
+		return false
 	}
 
-	// Create new scanner
-	scanner := bufio.NewScanner(file)
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	// Close old file
+	if state.file != nil {
+		state.file.Close()
+	}
 
 	// Update state
 	state.file = file
-	state.scanner = scanner
+	state.reader = bufio.NewReader(file)
+	state.info = info
 	state.size = 0
+	state.partial, state.partialSize = "", 0
 
 	log.Printf("Reopened file %s (likely rotated)", filePath)
+	return true
 }
 
 // cleanupFile closes and removes tracking for a file
@@ -353,6 +540,11 @@ func (fr *FileReader) cleanupFile(filePath string) {
 	fr.mu.Lock()
 	defer fr.mu.Unlock()
 
+	fr.cleanupFileLocked(filePath)
+}
+
+// cleanupFileLocked is cleanupFile with fr.mu held
+func (fr *FileReader) cleanupFileLocked(filePath string) {
 	if state, exists := fr.fileStates[filePath]; exists {
 		if state.file != nil {
 			state.file.Close()
@@ -372,13 +564,15 @@ func (fr *FileReader) closeAllWatchers() {
 	defer fr.mu.Unlock()
 
 	for filePath := range fr.watchers {
-		fr.cleanupFile(filePath)
+		fr.cleanupFileLocked(filePath)
 	}
 }
 
-// Stop stops the file reader and closes all resources
+// Stop stops the file reader and closes all resources, saving the
+// checkpoints
 func (fr *FileReader) Stop() {
 	fr.cancel()
+	fr.flushCheckpoints()
 }
 
 // Wait waits for all reading goroutines to finish
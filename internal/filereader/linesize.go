@@ -0,0 +1,49 @@
+package filereader
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/linelimit"
+)
+
+// SetMaxLineSize sets the longest line sent whole; longer lines are cut to
+// their first max bytes and marked with the size they had, see linelimit.
+// Call it before Start.
+func (fr *FileReader) SetMaxLineSize(max int) {
+	if max > 0 {
+		fr.lineLimit = max
+	}
+}
+
+// scanLines returns a scanner splitting the existing content of a file into
+// lines, cutting those longer than the line limit
+func (fr *FileReader) scanLines(existing *existingFile) *bufio.Scanner {
+	scanner := bufio.NewScanner(existing)
+	linelimit.Buffer(scanner, fr.lineLimit)
+	scanner.Split(countLines(&existing.offset, linelimit.Split(fr.lineLimit)))
+	return scanner
+}
+
+// addPartial adds a chunk of the line being followed to the part of it
+// kept, which is at most the line limit and its line ending
+func (fr *FileReader) addPartial(state *fileState, chunk []byte) {
+	state.partialSize += int64(len(chunk))
+	if room := fr.lineLimit + 2 - len(state.partial); room > 0 {
+		state.partial += string(chunk[:min(room, len(chunk))])
+	}
+}
+
+// takePartial returns the line being followed, without its line ending, or
+// cut and marked with the size it had when longer than the line limit
+func (fr *FileReader) takePartial(state *fileState) string {
+	line, size := state.partial, state.partialSize
+	state.partial, state.partialSize = "", 0
+	if size == int64(len(line)) {
+		return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	}
+	if strings.HasSuffix(line, "\n") || size > int64(len(line)) {
+		size-- // The newline ends the line the reader cut
+	}
+	return string(linelimit.Mark([]byte(line), size, fr.lineLimit))
+}
@@ -0,0 +1,123 @@
+package filereader
+
+import (
+	"bufio"
+	"container/heap"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/timestamp"
+)
+
+// mergeCursor is a file being merged, holding the first line of its next
+// record
+type mergeCursor struct {
+	path     string
+	index    int // Position among the files, ordering records logged at the same time
+	existing *existingFile
+	scanner  *bufio.Scanner
+	head     string
+	headEnd  int64     // Offset of the end of the head line
+	at       time.Time // Time of the head record
+}
+
+// mergeHeap orders the files by the time of their next record
+type mergeHeap []*mergeCursor
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	if !h[i].at.Equal(h[j].at) {
+		return h[i].at.Before(h[j].at)
+	}
+	return h[i].index < h[j].index
+}
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)   { *h = append(*h, x.(*mergeCursor)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// mergeFiles reads the existing content of the files as one stream ordered
+// by timestamp, each file taken to be in time order already. A record is a
+// line with a timestamp and the lines after it without one, such as a stack
+// trace, which stay together. JSON lines without a timestamp the parser
+// knows keep the time of the record before them.
+func (fr *FileReader) mergeFiles() {
+	parser := timestamp.NewParser()
+
+	var cursors mergeHeap
+	defer func() {
+		for _, c := range cursors {
+			c.existing.Close()
+		}
+	}()
+	for i, filePath := range fr.filePaths {
+		existing, err := fr.openExisting(filePath)
+		if err != nil {
+			log.Printf("Error reading file %s: %v", filePath, err)
+			continue
+		}
+		scanner := fr.scanLines(existing)
+		c := &mergeCursor{path: filePath, index: i, existing: existing, scanner: scanner}
+		line, _, ok := fr.nextMergeLine(c, parser)
+		if !ok {
+			existing.Close()
+			continue
+		}
+		c.head, c.headEnd = line, existing.offset
+		cursors = append(cursors, c)
+	}
+	heap.Init(&cursors)
+
+	for cursors.Len() > 0 {
+		c := cursors[0]
+		if !fr.send(Line{Text: c.head, Path: c.path}) {
+			return
+		}
+		fr.checkpoint(c.path, c.existing.info, c.headEnd)
+		// The rest of the record follows, up to the file's next record
+		for {
+			line, record, ok := fr.nextMergeLine(c, parser)
+			if !ok {
+				heap.Pop(&cursors)
+				c.existing.Close()
+				break
+			}
+			if record {
+				c.head, c.headEnd = line, c.existing.offset
+				heap.Fix(&cursors, 0)
+				break
+			}
+			if !fr.send(Line{Text: line, Path: c.path}) {
+				return
+			}
+			fr.checkpoint(c.path, c.existing.info, c.existing.offset)
+		}
+	}
+}
+
+// nextMergeLine reads the next line of a merged file, reporting whether it
+// starts a record, and false at the end of the file or once it is past
+// --until. A line starting a record sets the cursor's time when it has a
+// timestamp.
+func (fr *FileReader) nextMergeLine(c *mergeCursor, parser *timestamp.Parser) (string, bool, bool) {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			log.Printf("Error reading file %s: %v", c.path, err)
+		}
+		return "", false, false
+	}
+	line := c.scanner.Text()
+	if fr.pastUntil(line) {
+		return "", false, false
+	}
+	if result := parser.ParseFromText(line); result.Found {
+		c.at = result.Timestamp
+		return line, true, true
+	}
+	return line, strings.HasPrefix(strings.TrimSpace(line), "{"), true
+}
@@ -0,0 +1,112 @@
+package filereader
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/timerange"
+	"github.com/control-theory/gonzo/internal/timestamp"
+)
+
+// Seek limits
+const (
+	seekMinSpan    = 64 * 1024       // The search stops once it narrows the start to this many bytes
+	seekProbeLines = 50              // Lines read at a probe to find one with a dated timestamp
+	untilSlack     = 1 * time.Minute // How far past --until timestamps may be before reading stops
+	maxProbeLine   = 1024 * 1024     // Longest line a probe reads, as the scanner's limit
+)
+
+// SetTimeRange makes the reader skip the part of each file logged before
+// r.Since and stop reading existing content once it is past r.Until. Files
+// are taken to be in time order; the entries are still filtered one by one
+// after parsing, so this only saves reading what would be discarded.
+func (fr *FileReader) SetTimeRange(r timerange.Range) {
+	fr.timeRange = r
+	fr.timestamps = timestamp.NewParser()
+}
+
+// lineTime returns the timestamp of a line if it has one with a date;
+// times of day alone cannot be compared with the bounds
+func (fr *FileReader) lineTime(line string) (time.Time, bool) {
+	result := fr.timestamps.ParseFromText(line)
+	if !result.Found || result.Timestamp.Year() <= 1 {
+		return time.Time{}, false
+	}
+	return result.Timestamp, true
+}
+
+// pastUntil reports whether a line is logged so far after --until that the
+// rest of the file can be skipped
+func (fr *FileReader) pastUntil(line string) bool {
+	if fr.timeRange.Until.IsZero() {
+		return false
+	}
+	t, ok := fr.lineTime(line)
+	return ok && t.After(fr.timeRange.Until.Add(untilSlack))
+}
+
+// seekSince moves file to the start of the first line that may be logged at
+// or after --since, by a binary search over its offsets
+func (fr *FileReader) seekSince(file *os.File) error {
+	if fr.timeRange.Since.IsZero() {
+		return nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	// Invariant: the first dated line after lo is before --since, or lo is
+	// the start of the file
+	lo, hi := int64(0), info.Size()
+	for hi-lo > seekMinSpan {
+		mid := lo + (hi-lo)/2
+		t, ok, err := fr.probe(file, mid)
+		if err != nil {
+			return err
+		}
+		if ok && t.Before(fr.timeRange.Since) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	if _, err := file.Seek(lo, io.SeekStart); err != nil {
+		return err
+	}
+	if lo == 0 {
+		return nil
+	}
+	// lo is within a line; start at the next one
+	return skipPartialLine(file, lo)
+}
+
+// probe returns the timestamp of the first dated line after offset
+func (fr *FileReader) probe(file *os.File, offset int64) (time.Time, bool, error) {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return time.Time{}, false, err
+	}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxProbeLine)
+	scanner.Scan() // The partial line at offset
+	for i := 0; i < seekProbeLines && scanner.Scan(); i++ {
+		if t, ok := fr.lineTime(scanner.Text()); ok {
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+// skipPartialLine moves file from offset past the next newline
+func skipPartialLine(file *os.File, offset int64) error {
+	reader := bufio.NewReader(file)
+	skipped, err := reader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	_, err = file.Seek(offset+int64(len(skipped)), io.SeekStart)
+	return err
+}
@@ -0,0 +1,165 @@
+package filereader
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/linelimit"
+)
+
+// tailChunk is how much of a file is read at a time when reading backwards
+const tailChunk = 64 * 1024
+
+// history is the part of a file before where its tail started, read back on
+// demand from its end
+type history struct {
+	start int64 // Where the history begins, such as the --since offset
+	end   int64 // Where the lines read so far begin
+}
+
+// SetTail makes the reader start the existing content of each uncompressed
+// file at its last bytes or lines, whichever is less, zero meaning no limit.
+// What comes before can be read afterwards with ReadOlder. Files resumed
+// from a checkpoint are read from there instead.
+func (fr *FileReader) SetTail(bytes int64, lines int) {
+	fr.tailBytes = bytes
+	fr.tailLines = lines
+}
+
+// tailStart returns the offset of the line starting a file's tail, no
+// earlier than from, recording what it skips as the file's history
+func (fr *FileReader) tailStart(file *os.File, filePath string, from, size int64) (int64, error) {
+	start := from
+	if fr.tailBytes > 0 && size-fr.tailBytes > start {
+		offset, err := nextLineStart(file, size-fr.tailBytes)
+		if err != nil {
+			return 0, err
+		}
+		start = offset
+	}
+	if fr.tailLines > 0 {
+		_, offset, err := linesBefore(file, start, size, fr.tailLines)
+		if err != nil {
+			return 0, err
+		}
+		start = max(start, offset)
+	}
+	if start > from {
+		fr.historyMu.Lock()
+		fr.history[filePath] = &history{start: from, end: start}
+		fr.historyMu.Unlock()
+	}
+	return start, nil
+}
+
+// ReadOlder returns up to n lines of each file from before those read so
+// far, oldest first, and nil once the files have been read back to where
+// they started
+func (fr *FileReader) ReadOlder(n int) []Line {
+	fr.historyMu.Lock()
+	defer fr.historyMu.Unlock()
+
+	var older []Line
+	for _, filePath := range fr.filePaths {
+		h, ok := fr.history[filePath]
+		if !ok {
+			continue
+		}
+		lines, start, err := readLinesBefore(filePath, h.start, h.end, n)
+		if err != nil {
+			log.Printf("Error reading file %s: %v", filePath, err)
+			delete(fr.history, filePath)
+			continue
+		}
+		for _, line := range lines {
+			older = append(older, Line{Text: linelimit.Cut(line, fr.lineLimit), Path: filePath})
+		}
+		h.end = start
+		if h.end <= h.start {
+			delete(fr.history, filePath)
+		}
+	}
+	return older
+}
+
+// readLinesBefore opens a file to read up to n lines ending at end
+func readLinesBefore(filePath string, start, end int64, n int) ([]string, int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+	return linesBefore(file, start, end, n)
+}
+
+// linesBefore returns up to n lines ending at offset end of a file, reading
+// backwards no further than start, and the offset of the first of them
+func linesBefore(file *os.File, start, end int64, n int) ([]string, int64, error) {
+	var chunks [][]byte
+	pos, newlines := end, 0
+	for pos > start {
+		size := min(tailChunk, pos-start)
+		pos -= size
+		chunk := make([]byte, size)
+		if _, err := file.ReadAt(chunk, pos); err != nil && err != io.EOF {
+			return nil, 0, err
+		}
+		newlines += bytes.Count(chunk, []byte("\n"))
+		if len(chunks) == 0 && chunk[size-1] == '\n' {
+			newlines-- // Ends the last line
+		}
+		chunks = append(chunks, chunk)
+		// Lines are complete once a newline comes before them
+		if newlines >= n {
+			break
+		}
+	}
+	slices.Reverse(chunks)
+	text := bytes.Join(chunks, nil)
+
+	lines := strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+	first := pos
+	if pos > start {
+		// The first line began before what was read
+		first += int64(len(lines[0])) + 1
+		lines = lines[1:]
+	}
+	for len(lines) > n {
+		first += int64(len(lines[0])) + 1
+		lines = lines[1:]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	if len(text) == 0 {
+		lines = nil
+	}
+	return lines, first, nil
+}
+
+// nextLineStart returns the offset of the first line starting at or after
+// offset
+func nextLineStart(file *os.File, offset int64) (int64, error) {
+	if offset <= 0 {
+		return 0, nil
+	}
+	// The line starts at offset if the byte before it ends one
+	reader := bufio.NewReaderSize(io.NewSectionReader(file, offset-1, 1<<62), tailChunk)
+	skipped := int64(0)
+	for {
+		part, err := reader.ReadSlice('\n')
+		skipped += int64(len(part))
+		switch err {
+		case nil, io.EOF:
+			return offset - 1 + skipped, nil
+		case bufio.ErrBufferFull:
+		default:
+			return 0, err
+		}
+	}
+}
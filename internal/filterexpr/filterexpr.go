@@ -0,0 +1,472 @@
+// Package filterexpr implements the filter expression language used to
+// select log entries, e.g.
+//
+//	severity=ERROR service.name=payments since=10m not "health check"
+//
+// Terms next to each other must all match; "or" and parentheses combine them
+// otherwise, and "not" (or a leading "-") negates a term.
+package filterexpr
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Syntax describes the language for help screens and AI prompts
+const Syntax = `Terms separated by spaces must all match. Combine with "or" and parentheses; negate with "not" or a leading "-".
+  word or "quoted text"   message contains the text (case-insensitive)
+  /regex/                 message matches the regular expression
+  key=value               attribute equals the value; key=a,b matches either
+  key!=value              attribute is missing or differs
+  key~regex, key!~regex   attribute matches / does not match the regex
+  key>N, key>=N, key<N    attribute is a number above / at least / below N (also key<=N; spaces allowed: key > N)
+  key in A..B             attribute is a number from A to B, both included, e.g. status in 500..599
+  500ms, 1.5s, 1MB, 1e6   numbers may be durations or sizes (KB, MB, KiB, MiB...); values compare across units
+  duration>1s, size>1MB   the entry's duration (latency_ms, "took 153ms"...) or size (bytes, content_length...), normalized
+  severity=ERROR,WARN     severity is one of the levels (TRACE DEBUG INFO WARN ERROR FATAL CRITICAL)
+  message~regex           message field comparisons use the keys message or msg
+  resource.key=value      only an OTLP resource attribute matches, not a record attribute of that key
+  since=10m               entry is at most this old (s, m, h)`
+
+// Record is the view of a log entry that expressions are evaluated against.
+// Severity should already be normalized, e.g. WARN rather than warning.
+type Record struct {
+	Time       time.Time
+	Severity   string
+	Message    string
+	RawLine    string
+	Attributes map[string]string
+	Resource   []string // Keys of Attributes from the OTLP resource
+
+	// Duration and Size read the entry's duration in milliseconds and its
+	// size in bytes, for the duration and size fields; nil when unknown
+	Duration func() (float64, bool)
+	Size     func() (float64, bool)
+}
+
+// Expr is a parsed filter expression
+type Expr struct {
+	source string
+	root   node
+}
+
+// node is one element of the expression tree
+type node interface {
+	match(r *Record) bool
+}
+
+// Parse compiles a filter expression
+func Parse(source string) (*Expr, error) {
+	p := &parser{lex: lexer{input: source}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, fmt.Errorf("empty filter expression")
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tok.text, p.tok.pos+1)
+	}
+	return &Expr{source: strings.TrimSpace(source), root: root}, nil
+}
+
+// Match reports whether the record satisfies the expression
+func (e *Expr) Match(r *Record) bool {
+	return e.root.match(r)
+}
+
+// String returns the expression as it was written
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Bounds returns the numeric ranges every matching record falls in, from
+// the comparisons the whole expression requires, so a caller can rule out
+// records by their values before matching the rest
+func (e *Expr) Bounds() []Bound {
+	terms := []node{e.root}
+	if and, ok := e.root.(andNode); ok {
+		terms = and
+	}
+	var bounds []Bound
+	for _, term := range terms {
+		if n, ok := term.(numericNode); ok {
+			bounds = append(bounds, n.Bound)
+		}
+	}
+	return bounds
+}
+
+type andNode []node
+
+func (n andNode) match(r *Record) bool {
+	for _, child := range n {
+		if !child.match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode []node
+
+func (n orNode) match(r *Record) bool {
+	for _, child := range n {
+		if child.match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+type notNode struct {
+	child node
+}
+
+func (n notNode) match(r *Record) bool {
+	return !n.child.match(r)
+}
+
+// textNode matches a case-insensitive substring of the message or raw line
+type textNode struct {
+	lower string
+}
+
+func (n textNode) match(r *Record) bool {
+	return strings.Contains(strings.ToLower(r.Message), n.lower) ||
+		(r.RawLine != "" && strings.Contains(strings.ToLower(r.RawLine), n.lower))
+}
+
+// regexNode matches the message or raw line against a regular expression
+type regexNode struct {
+	re *regexp.Regexp
+}
+
+func (n regexNode) match(r *Record) bool {
+	return n.re.MatchString(r.Message) || (r.RawLine != "" && n.re.MatchString(r.RawLine))
+}
+
+// fieldNode compares a field to a list of values or a regex
+type fieldNode struct {
+	field  string
+	values []string
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (n fieldNode) match(r *Record) bool {
+	value, ok := fieldValue(r, n.field)
+	matched := false
+	if ok {
+		if n.re != nil {
+			matched = n.re.MatchString(value)
+		} else {
+			for _, want := range n.values {
+				if value == want {
+					matched = true
+					break
+				}
+			}
+		}
+	}
+	return matched != n.negate
+}
+
+// numericNode checks that an attribute holding a number, or the duration
+// or size of the entry, is within a bound
+type numericNode struct {
+	Bound
+}
+
+func (n numericNode) match(r *Record) bool {
+	value, unit, ok := n.Measure(r)
+	return ok && n.Contains(value, unit)
+}
+
+// Measure reads the quantity of the bound's field from a record
+func (b Bound) Measure(r *Record) (float64, Unit, bool) {
+	switch b.Field {
+	case FieldDuration:
+		ms, ok := measure(r.Duration)
+		return ms, UnitDuration, ok
+	case FieldSize:
+		bytes, ok := measure(r.Size)
+		return bytes, UnitSize, ok
+	}
+	value, ok := fieldValue(r, b.Field)
+	if !ok {
+		return 0, UnitNone, false
+	}
+	return ParseQuantity(value)
+}
+
+// measure calls a Record's Duration or Size, if it has one
+func measure(read func() (float64, bool)) (float64, bool) {
+	if read == nil {
+		return 0, false
+	}
+	return read()
+}
+
+// fieldValue looks up a field, treating severity and message specially. A
+// resource. prefix selects a resource attribute, unless an attribute has
+// the whole field as its key.
+func fieldValue(r *Record, field string) (string, bool) {
+	switch field {
+	case fieldSeverity:
+		return r.Severity, true
+	case fieldMessage:
+		return r.Message, true
+	}
+	value, ok := r.Attributes[field]
+	if key, found := strings.CutPrefix(field, resourcePrefix); found && !ok && slices.Contains(r.Resource, key) {
+		value, ok = r.Attributes[key]
+	}
+	return value, ok
+}
+
+// Field looks up a field of the record as comparisons do: the severity,
+// the message (also as level and msg) or an attribute
+func (r *Record) Field(name string) (string, bool) {
+	if alias, ok := fieldAliases[strings.ToLower(name)]; ok && alias != fieldSince {
+		name = alias
+	}
+	return fieldValue(r, name)
+}
+
+// sinceNode keeps entries newer than a duration ago
+type sinceNode struct {
+	window time.Duration
+}
+
+func (n sinceNode) match(r *Record) bool {
+	return !r.Time.IsZero() && time.Since(r.Time) <= n.window
+}
+
+// Field names with special meaning; the others are attribute keys
+const (
+	fieldSeverity = "severity"
+	fieldMessage  = "message"
+	fieldSince    = "since"
+)
+
+// Normalized fields, which comparisons read from the Record's Duration and
+// Size rather than an attribute of the name
+const (
+	FieldDuration = "duration"
+	FieldSize     = "size"
+)
+
+// resourcePrefix restricts a field to the resource attributes
+const resourcePrefix = "resource."
+
+// fieldAliases maps alternative spellings to the special field names
+var fieldAliases = map[string]string{
+	"severity": fieldSeverity,
+	"level":    fieldSeverity,
+	"message":  fieldMessage,
+	"msg":      fieldMessage,
+	"since":    fieldSince,
+	"last":     fieldSince,
+}
+
+// severityAliases maps common spellings to the normalized severity levels
+var severityAliases = map[string]string{
+	"WARNING":     "WARN",
+	"ERR":         "ERROR",
+	"CRIT":        "CRITICAL",
+	"INFORMATION": "INFO",
+}
+
+// parser is a recursive descent parser over the lexer's tokens
+type parser struct {
+	lex lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := orNode{first}
+	for p.tok.kind == tokOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return children, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	var children andNode
+	for {
+		switch p.tok.kind {
+		case tokAnd:
+			if len(children) == 0 {
+				return nil, fmt.Errorf("\"and\" needs a term before it at position %d", p.tok.pos+1)
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			continue
+		case tokEOF, tokOr, tokRParen:
+			if len(children) == 0 {
+				return nil, p.expected("a term")
+			}
+			if len(children) == 1 {
+				return children[0], nil
+			}
+			return children, nil
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.tok.kind == tokNot {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{child}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.expected(`")"`)
+		}
+		return inner, p.advance()
+	case tokText:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return textNode{lower: strings.ToLower(tok.text)}, nil
+	case tokRegex:
+		re, err := regexp.Compile(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex /%s/: %w", tok.text, err)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return regexNode{re}, nil
+	case tokField:
+		n, err := newFieldNode(tok)
+		if err != nil {
+			return nil, err
+		}
+		return n, p.advance()
+	}
+	return nil, p.expected("a term")
+}
+
+func (p *parser) expected(what string) error {
+	if p.tok.kind == tokEOF {
+		return fmt.Errorf("expected %s at the end of the expression", what)
+	}
+	return fmt.Errorf("expected %s at position %d, found %q", what, p.tok.pos+1, p.tok.text)
+}
+
+// newFieldNode builds the node for a key/operator/value token
+func newFieldNode(tok token) (node, error) {
+	field := tok.field
+	if alias, ok := fieldAliases[strings.ToLower(field)]; ok {
+		field = alias
+	}
+
+	if field == fieldSince {
+		if tok.op != "=" {
+			return nil, fmt.Errorf("%s only supports =, e.g. since=10m", tok.field)
+		}
+		window, err := time.ParseDuration(tok.text)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid duration %q for %s (use e.g. 30s, 10m, 2h)", tok.text, tok.field)
+		}
+		return sinceNode{window}, nil
+	}
+
+	if isOrdering(tok.op) || tok.op == "in" {
+		if field == fieldSeverity || field == fieldMessage {
+			return nil, fmt.Errorf("%s does not support %s, only =, !=, ~ and !~", tok.field, tok.op)
+		}
+		if lower := strings.ToLower(field); lower == FieldDuration || lower == FieldSize {
+			field = lower
+		}
+		bound, err := newBound(field, tok.op, tok.text)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case field == FieldDuration && bound.Unit == UnitSize:
+			return nil, fmt.Errorf("%s compares with durations such as 500ms, not %s", tok.field, tok.text)
+		case field == FieldSize && bound.Unit == UnitDuration:
+			return nil, fmt.Errorf("%s compares with sizes such as 1MB, not %s", tok.field, tok.text)
+		}
+		return numericNode{bound}, nil
+	}
+
+	n := fieldNode{field: field, negate: strings.HasPrefix(tok.op, "!")}
+	switch tok.op {
+	case "~", "!~":
+		re, err := regexp.Compile(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex for %s: %w", tok.field, err)
+		}
+		n.re = re
+	default:
+		for _, value := range strings.Split(tok.text, ",") {
+			if field == fieldSeverity {
+				value = strings.ToUpper(strings.TrimSpace(value))
+				if canonical, ok := severityAliases[value]; ok {
+					value = canonical
+				}
+			}
+			n.values = append(n.values, value)
+		}
+	}
+	return n, nil
+}
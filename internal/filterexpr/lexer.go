@@ -0,0 +1,249 @@
+package filterexpr
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota
+	tokText             // word or quoted text
+	tokRegex            // /regex/
+	tokField            // key, operator and value
+	tokAnd              // and
+	tokOr               // or
+	tokNot              // not, or a leading -
+	tokLParen           // (
+	tokRParen           // )
+)
+
+// token is one lexical element. Field tokens carry the key in field, the
+// operator in op and the value in text.
+type token struct {
+	kind  tokenKind
+	text  string
+	field string
+	op    string
+	pos   int
+}
+
+// lexer splits an expression into tokens
+type lexer struct {
+	input string
+	pos   int
+}
+
+// isDelimiter reports whether c ends a bare word
+func isDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '(' || c == ')'
+}
+
+// isOperatorStart reports whether c can begin a comparison operator
+func isOperatorStart(c byte) bool {
+	return c == '=' || c == '!' || c == '~' || c == '<' || c == '>'
+}
+
+// isOrdering reports whether an operator compares numbers
+func isOrdering(op string) bool {
+	return op == "<" || op == "<=" || op == ">" || op == ">="
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && (l.input[l.pos] == ' ' || l.input[l.pos] == '\t' || l.input[l.pos] == '\n') {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	switch c := l.input[l.pos]; {
+	case c == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start}, nil
+	case c == '"':
+		text, err := l.readQuoted()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokText, text: text, pos: start}, nil
+	case c == '/':
+		text, err := l.readRegex()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokRegex, text: text, pos: start}, nil
+	case (c == '-' || c == '!') && l.pos+1 < len(l.input) && !isDelimiter(l.input[l.pos+1]) && !isOperatorStart(l.input[l.pos+1]):
+		l.pos++
+		return token{kind: tokNot, text: string(c), pos: start}, nil
+	}
+
+	// Bare word, possibly the key of a comparison
+	for l.pos < len(l.input) && !isDelimiter(l.input[l.pos]) && !isOperatorStart(l.input[l.pos]) && l.input[l.pos] != '"' {
+		l.pos++
+	}
+	word := l.input[start:l.pos]
+	if word != "" && l.pos < len(l.input) && isOperatorStart(l.input[l.pos]) {
+		afterWord := l.pos
+		op, ok := l.readOperator()
+		if ok {
+			value, err := l.readValue()
+			// Comparisons need a number, so text such as "a->b" stays text
+			if !isOrdering(op) || (err == nil && isNumber(value)) {
+				if err != nil {
+					return token{}, err
+				}
+				return token{kind: tokField, text: value, field: word, op: op, pos: start}, nil
+			}
+			l.pos = afterWord
+		}
+	}
+	if word != "" && l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+		if tok, ok := l.readSpacedComparison(word, start); ok {
+			return tok, nil
+		}
+	}
+	// A word with a stray operator character, such as "done!", is text
+	for l.pos < len(l.input) && !isDelimiter(l.input[l.pos]) {
+		l.pos++
+	}
+	word = l.input[start:l.pos]
+
+	switch strings.ToLower(word) {
+	case "and", "&&":
+		return token{kind: tokAnd, text: word, pos: start}, nil
+	case "or", "||":
+		return token{kind: tokOr, text: word, pos: start}, nil
+	case "not", "!":
+		return token{kind: tokNot, text: word, pos: start}, nil
+	}
+	return token{kind: tokText, text: word, pos: start}, nil
+}
+
+// readSpacedComparison reads the rest of a numeric comparison written with
+// spaces after its key, such as "latency_ms > 500" or "status in 500..599".
+// Anything else leaves the position after the key, so the words stay text.
+func (l *lexer) readSpacedComparison(key string, start int) (token, bool) {
+	switch strings.ToLower(key) {
+	case "and", "or", "not":
+		return token{}, false
+	}
+	afterKey := l.pos
+	l.skipSpaces()
+	op, ok := l.readOperator()
+	switch {
+	case ok && !isOrdering(op):
+		ok = false
+	case !ok && l.readKeyword("in"):
+		op, ok = "in", true
+	}
+	if ok {
+		l.skipSpaces()
+		valueStart := l.pos
+		for l.pos < len(l.input) && !isDelimiter(l.input[l.pos]) {
+			l.pos++
+		}
+		value := l.input[valueStart:l.pos]
+		if op == "in" && isRange(value) || op != "in" && isNumber(value) {
+			return token{kind: tokField, text: value, field: key, op: op, pos: start}, true
+		}
+	}
+	l.pos = afterKey
+	return token{}, false
+}
+
+// readKeyword reads a keyword followed by a space, ignoring case
+func (l *lexer) readKeyword(keyword string) bool {
+	end := l.pos + len(keyword)
+	if end < len(l.input) && strings.EqualFold(l.input[l.pos:end], keyword) && isSpace(l.input[end]) {
+		l.pos = end
+		return true
+	}
+	return false
+}
+
+// skipSpaces moves past whitespace
+func (l *lexer) skipSpaces() {
+	for l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+		l.pos++
+	}
+}
+
+// isSpace reports whether c separates terms
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// readOperator reads =, !=, ~, !~, <, <=, > or >= at the current position
+func (l *lexer) readOperator() (string, bool) {
+	for _, op := range []string{"!=", "!~", ">=", "<=", "=", "~", ">", "<"} {
+		if strings.HasPrefix(l.input[l.pos:], op) {
+			l.pos += len(op)
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// readValue reads the value of a comparison: quoted, /regex/ or a bare word
+func (l *lexer) readValue() (string, error) {
+	if l.pos < len(l.input) {
+		switch l.input[l.pos] {
+		case '"':
+			return l.readQuoted()
+		case '/':
+			return l.readRegex()
+		}
+	}
+	start := l.pos
+	for l.pos < len(l.input) && !isDelimiter(l.input[l.pos]) {
+		l.pos++
+	}
+	if start == l.pos {
+		return "", fmt.Errorf("missing value at position %d", start+1)
+	}
+	return l.input[start:l.pos], nil
+}
+
+// isNumber reports whether a value is a number to compare with, possibly a
+// duration or a size
+func isNumber(value string) bool {
+	_, _, ok := ParseQuantity(value)
+	return ok
+}
+
+// readQuoted reads a double-quoted string, allowing \" and \\ escapes
+func (l *lexer) readQuoted() (string, error) {
+	return l.readDelimited('"', "quote")
+}
+
+// readRegex reads a /regex/ literal; \/ stands for a slash
+func (l *lexer) readRegex() (string, error) {
+	return l.readDelimited('/', "regex")
+}
+
+func (l *lexer) readDelimited(delim byte, what string) (string, error) {
+	start := l.pos
+	l.pos++ // opening delimiter
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == '\\' && l.pos+1 < len(l.input) && (l.input[l.pos+1] == delim || (delim == '"' && l.input[l.pos+1] == '\\')):
+			b.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		case c == delim:
+			l.pos++
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated %s starting at position %d", what, start+1)
+}
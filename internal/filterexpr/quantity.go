@@ -0,0 +1,140 @@
+package filterexpr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unit is the kind of quantity a number is
+type Unit uint8
+
+// Units of quantities: durations are in milliseconds, sizes in bytes
+const (
+	UnitNone Unit = iota
+	UnitDuration
+	UnitSize
+)
+
+// sizeUnits are the multipliers of the size suffixes, matched
+// case-insensitively
+var sizeUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// ParseQuantity reads a number, a duration such as 500ms or 1.5s, or a size
+// such as 1MB or 512KiB. Durations come back in milliseconds and sizes in
+// bytes.
+func ParseQuantity(s string) (float64, Unit, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, UnitNone, false
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, UnitNone, false
+		}
+		return v, UnitNone, true
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return float64(d) / float64(time.Millisecond), UnitDuration, true
+	}
+	i := strings.LastIndexAny(s, "0123456789.") + 1
+	if multiplier, ok := sizeUnits[strings.ToLower(strings.TrimSpace(s[i:]))]; ok && i > 0 {
+		if v, err := strconv.ParseFloat(s[:i], 64); err == nil && !math.IsInf(v, 0) {
+			return v * multiplier, UnitSize, true
+		}
+	}
+	return 0, UnitNone, false
+}
+
+// Bound is a numeric range a field must fall in
+type Bound struct {
+	Field            string
+	Min, Max         float64 // Infinite for an open end
+	MinOpen, MaxOpen bool    // Whether Min and Max themselves are left out
+	Unit             Unit    // Of Min and Max
+}
+
+// Contains reports whether a quantity read from the field falls in the
+// bound. Bare numbers compared with a duration take their unit from the
+// field's name (latency_s, elapsed_us) and are otherwise milliseconds; a
+// bare bound compares with durations in milliseconds and sizes in bytes.
+func (b Bound) Contains(value float64, unit Unit) bool {
+	switch {
+	case unit == b.Unit, b.Unit == UnitNone:
+	case unit == UnitNone && b.Unit == UnitDuration:
+		value *= durationScale(b.Field)
+	case unit == UnitNone && b.Unit == UnitSize:
+	default:
+		return false
+	}
+	if value < b.Min || value == b.Min && b.MinOpen {
+		return false
+	}
+	return value < b.Max || value == b.Max && !b.MaxOpen
+}
+
+// durationScale converts a bare number of a field named with a unit, such
+// as duration_s, to milliseconds
+func durationScale(field string) float64 {
+	field = strings.ToLower(field)
+	switch {
+	case strings.HasSuffix(field, "_ns") || strings.HasSuffix(field, "nanos"):
+		return 1e-6
+	case strings.HasSuffix(field, "_us") || strings.HasSuffix(field, "micros"):
+		return 1e-3
+	case strings.HasSuffix(field, "_s") || strings.HasSuffix(field, "_sec") || strings.HasSuffix(field, "seconds"):
+		return 1e3
+	}
+	return 1
+}
+
+// isRange reports whether a value is a range of quantities such as
+// 500..599 or 100ms..2s
+func isRange(value string) bool {
+	lo, hi, found := strings.Cut(value, "..")
+	return found && isNumber(lo) && isNumber(hi)
+}
+
+// newBound builds the bound of a comparison or range on a field
+func newBound(field, op, text string) (Bound, error) {
+	b := Bound{Field: field, Min: math.Inf(-1), Max: math.Inf(1)}
+	if op == "in" {
+		lo, hi, _ := strings.Cut(text, "..")
+		start, startUnit, _ := ParseQuantity(lo)
+		end, endUnit, _ := ParseQuantity(hi)
+		if startUnit != endUnit && startUnit != UnitNone && endUnit != UnitNone {
+			return b, fmt.Errorf("range %s of %s mixes a duration and a size", text, field)
+		}
+		if start > end {
+			return b, fmt.Errorf("range %s of %s is empty, its start is above its end", text, field)
+		}
+		b.Min, b.Max, b.Unit = start, end, max(startUnit, endUnit)
+		return b, nil
+	}
+
+	value, unit, _ := ParseQuantity(text)
+	b.Unit = unit
+	switch op {
+	case "<":
+		b.Max, b.MaxOpen = value, true
+	case "<=":
+		b.Max = value
+	case ">":
+		b.Min, b.MinOpen = value, true
+	default:
+		b.Min = value
+	}
+	return b, nil
+}
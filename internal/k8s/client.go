@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// How often a shared client checks that the API server still answers
+const (
+	clientHealthInterval = 30 * time.Second
+	clientHealthTimeout  = 5 * time.Second
+)
+
+// client connects on first use and shares one clientset across the source's
+// watchers and queries. When the API server stops answering its health
+// check, the clientset is built again, picking up a changed kubeconfig or
+// refreshed credentials.
+type client struct {
+	config *Config
+
+	mu        sync.Mutex
+	clientset *kubernetes.Clientset
+	checked   time.Time // When the API server last answered
+}
+
+// newClient returns a client for config that has not connected yet
+func newClient(config *Config) *client {
+	return &client{config: config}
+}
+
+// get returns the shared clientset, connecting or reconnecting as needed
+func (c *client) get(ctx context.Context) (*kubernetes.Clientset, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.clientset != nil && time.Since(c.checked) < clientHealthInterval {
+		return c.clientset, nil
+	}
+	if c.clientset != nil {
+		err := c.ping(ctx)
+		if err == nil {
+			c.checked = time.Now()
+			return c.clientset, nil
+		}
+		log.Printf("Warning: kubernetes API server not answering, reconnecting: %v", err)
+		c.clientset = nil
+	}
+
+	clientset, err := c.config.BuildClientset()
+	if err != nil {
+		return nil, err
+	}
+	c.clientset = clientset
+	c.checked = time.Now()
+	return clientset, nil
+}
+
+// ping asks the API server for its health
+func (c *client) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, clientHealthTimeout)
+	defer cancel()
+	return c.clientset.Discovery().RESTClient().Get().AbsPath("/healthz").Do(ctx).Error()
+}
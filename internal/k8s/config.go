@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -16,8 +17,12 @@ type Config struct {
 	Context    string
 	Namespaces []string
 	Selector   string
-	Since      int64 // Duration in seconds
+	Since      int64     // Duration in seconds
+	SinceTime  time.Time // Only logs from this time on, instead of Since and TailLines; zero for none
 	TailLines  int64
+	// Longest log line kept whole, longer ones are cut; zero for
+	// linelimit.Default
+	MaxLineSize int
 }
 
 // NewDefaultConfig returns a default kubernetes configuration
@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// revisionAnnotation holds the revision the deployment controller gives
+// each rollout of a Deployment
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// Rollout is a Deployment starting a new revision
+type Rollout struct {
+	Time      time.Time
+	Namespace string
+	Name      string
+	Revision  string
+	Images    []string // Container images of the new revision
+}
+
+// String describes the rollout, e.g. "checkout rev 12 (checkout:v1.4.2)"
+func (r Rollout) String() string {
+	s := fmt.Sprintf("%s rev %s", r.Name, r.Revision)
+	if len(r.Images) > 0 {
+		s += " (" + strings.Join(r.Images, ", ") + ")"
+	}
+	return s
+}
+
+// WatchRollouts sends a Rollout to out whenever a Deployment in the
+// source's namespaces starts a new revision, until the source stops. The
+// Deployments found at the start only set the revision to compare with.
+// Rollouts are dropped while out is full.
+func (s *KubernetesLogSource) WatchRollouts(out chan<- Rollout) error {
+	clientset, err := s.client.get(s.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build kubernetes client: %w", err)
+	}
+
+	namespaces := s.config.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	for _, namespace := range namespaces {
+		// Without permission to list Deployments the informer would retry
+		// forever, so access is checked once first
+		if _, err := clientset.AppsV1().Deployments(namespace).List(s.ctx, metav1.ListOptions{Limit: 1}); err != nil {
+			return fmt.Errorf("failed to list deployments in namespace %q: %w", namespace, err)
+		}
+
+		factory := informers.NewSharedInformerFactoryWithOptions(clientset, 10*time.Minute, informers.WithNamespace(namespace))
+		informer := factory.Apps().V1().Deployments().Informer()
+		_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				before, ok := oldObj.(*appsv1.Deployment)
+				if !ok {
+					return
+				}
+				after, ok := newObj.(*appsv1.Deployment)
+				if !ok {
+					return
+				}
+				revision := after.Annotations[revisionAnnotation]
+				if revision == "" || revision == before.Annotations[revisionAnnotation] {
+					return
+				}
+				select {
+				case out <- newRollout(after, revision):
+				default:
+				}
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add event handler: %w", err)
+		}
+		factory.Start(s.ctx.Done())
+	}
+	return nil
+}
+
+// newRollout describes the new revision of a Deployment, naming its images
+// without their registry
+func newRollout(deployment *appsv1.Deployment, revision string) Rollout {
+	rollout := Rollout{
+		Time:      time.Now(),
+		Namespace: deployment.Namespace,
+		Name:      deployment.Name,
+		Revision:  revision,
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		image := container.Image
+		if i := strings.LastIndex(image, "/"); i >= 0 {
+			image = image[i+1:]
+		}
+		rollout.Images = append(rollout.Images, image)
+	}
+	return rollout
+}
@@ -13,11 +13,30 @@ import (
 // KubernetesLogSource is the main entry point for streaming kubernetes logs
 type KubernetesLogSource struct {
 	config   *Config
-	watcher  *PodWatcher
+	client   *client // Shared by the watcher and the listings
 	lineChan chan string
 	ctx      context.Context
 	cancel   context.CancelFunc
 	wg       sync.WaitGroup
+	stopOnce sync.Once
+
+	// Replaced by UpdateFilter while the dashboard lists pods, and stopped
+	// by Stop from another goroutine
+	mu       sync.Mutex
+	watcher  *PodWatcher
+	podNames []string     // Pods the watcher is limited to, nil for all
+	unfollow *watchFilter // What FollowPod narrowed, nil unless following a pod
+}
+
+// FollowTailLines is how many recent lines of each container FollowPod reads
+// again, for the history of the pod followed
+const FollowTailLines = 1000
+
+// watchFilter is what the watcher streams
+type watchFilter struct {
+	namespaces []string
+	selector   string
+	podNames   []string
 }
 
 // NewKubernetesLogSource creates a new kubernetes log source
@@ -30,6 +49,7 @@ func NewKubernetesLogSource(config *Config) (*KubernetesLogSource, error) {
 
 	return &KubernetesLogSource{
 		config:   config,
+		client:   newClient(config),
 		lineChan: make(chan string, 1000),
 		ctx:      ctx,
 		cancel:   cancel,
@@ -38,43 +58,12 @@ func NewKubernetesLogSource(config *Config) (*KubernetesLogSource, error) {
 
 // Start starts streaming logs from kubernetes
 func (s *KubernetesLogSource) Start() error {
-	// Build kubernetes clientset
-	clientset, err := s.config.BuildClientset()
-	if err != nil {
-		return fmt.Errorf("failed to build kubernetes client: %w", err)
-	}
-
-	// Create tail lines pointer if specified
-	var tailLines *int64
-	if s.config.TailLines >= 0 {
-		tailLines = &s.config.TailLines
-	}
-
-	// Create since pointer if specified
-	var since *int64
-	if s.config.Since > 0 {
-		since = &s.config.Since
-	}
-
 	// Create pod watcher (initially no pod name filter)
-	watcher, err := NewPodWatcher(
-		clientset,
-		s.config.Namespaces,
-		s.config.Selector,
-		nil, // No pod name filter initially
-		s.lineChan,
-		tailLines,
-		since,
-	)
+	s.mu.Lock()
+	err := s.startWatcher(nil, s.config.TailLines)
+	s.mu.Unlock()
 	if err != nil {
-		return fmt.Errorf("failed to create pod watcher: %w", err)
-	}
-
-	s.watcher = watcher
-
-	// Start watching pods
-	if err := watcher.Start(); err != nil {
-		return fmt.Errorf("failed to start pod watcher: %w", err)
+		return err
 	}
 
 	log.Printf("Started kubernetes log streaming")
@@ -90,18 +79,32 @@ func (s *KubernetesLogSource) Start() error {
 	return nil
 }
 
-// Stop stops the kubernetes log source
+// Stop stops the kubernetes log source, closing its line channel once its
+// streams have ended. It may be called more than once.
 func (s *KubernetesLogSource) Stop() {
-	if s.cancel != nil {
-		s.cancel()
-	}
+	s.stopOnce.Do(func() {
+		if s.cancel != nil {
+			s.cancel()
+		}
 
-	if s.watcher != nil {
-		s.watcher.Stop()
-	}
+		s.mu.Lock()
+		watcher := s.watcher
+		s.watcher = nil
+		s.mu.Unlock()
+		if watcher != nil {
+			watcher.Stop()
+		}
 
-	s.wg.Wait()
-	close(s.lineChan)
+		s.wg.Wait()
+		close(s.lineChan)
+	})
+}
+
+// currentWatcher returns the pod watcher, nil once stopped
+func (s *KubernetesLogSource) currentWatcher() *PodWatcher {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watcher
 }
 
 // GetLineChan returns the channel for receiving log lines
@@ -111,8 +114,8 @@ func (s *KubernetesLogSource) GetLineChan() <-chan string {
 
 // GetActiveStreams returns the number of active pod log streams
 func (s *KubernetesLogSource) GetActiveStreams() int {
-	if s.watcher != nil {
-		return s.watcher.GetActiveStreams()
+	if watcher := s.currentWatcher(); watcher != nil {
+		return watcher.GetActiveStreams()
 	}
 	return 0
 }
@@ -120,6 +123,12 @@ func (s *KubernetesLogSource) GetActiveStreams() int {
 // UpdateFilter updates the namespace, label selector, and pod name filter
 // This can be used to dynamically change what pods are being watched
 func (s *KubernetesLogSource) UpdateFilter(namespaces []string, selector string, podNames []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return fmt.Errorf("kubernetes log source stopped")
+	}
+
 	// Stop current watcher
 	if s.watcher != nil {
 		s.watcher.Stop()
@@ -128,17 +137,93 @@ func (s *KubernetesLogSource) UpdateFilter(namespaces []string, selector string,
 	// Update config
 	s.config.Namespaces = namespaces
 	s.config.Selector = selector
+	s.unfollow = nil
+
+	// Create new watcher with updated filter
+	if err := s.startWatcher(podNames, s.config.TailLines); err != nil {
+		return err
+	}
 
-	// Build kubernetes clientset
-	clientset, err := s.config.BuildClientset()
+	log.Printf("Updated kubernetes filter - Namespaces: %v, Selector: %s, Pods: %d selected", namespaces, selector, len(podNames))
+
+	return nil
+}
+
+// FollowPod narrows the watcher to one pod, all of its containers, reading
+// their last FollowTailLines lines again. Unfollow widens it back to what it
+// streamed before.
+func (s *KubernetesLogSource) FollowPod(namespace, pod string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return fmt.Errorf("kubernetes log source stopped")
+	}
+
+	// Following another pod keeps the selection from before the first
+	previous := s.unfollow
+	if previous == nil {
+		previous = &watchFilter{namespaces: s.config.Namespaces, selector: s.config.Selector, podNames: s.podNames}
+	}
+	if s.watcher != nil {
+		s.watcher.Stop()
+	}
+
+	// Unless every line is read already
+	tail := int64(FollowTailLines)
+	if s.config.TailLines < 0 || s.config.TailLines > tail {
+		tail = s.config.TailLines
+	}
+	s.config.Namespaces = []string{namespace}
+	s.unfollow = previous
+	if err := s.startWatcher([]string{namespace + "/" + pod}, tail); err != nil {
+		return err
+	}
+
+	log.Printf("Following kubernetes pod %s/%s", namespace, pod)
+	return nil
+}
+
+// Unfollow returns the watcher to what it streamed before FollowPod. It does
+// nothing unless a pod is followed.
+func (s *KubernetesLogSource) Unfollow() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx.Err() != nil {
+		return fmt.Errorf("kubernetes log source stopped")
+	}
+	previous := s.unfollow
+	if previous == nil {
+		return nil
+	}
+	if s.watcher != nil {
+		s.watcher.Stop()
+	}
+
+	s.config.Namespaces = previous.namespaces
+	s.config.Selector = previous.selector
+	s.unfollow = nil
+	if err := s.startWatcher(previous.podNames, s.config.TailLines); err != nil {
+		return err
+	}
+
+	log.Printf("Stopped following kubernetes pod - Namespaces: %v, Selector: %s, Pods: %d selected", previous.namespaces, previous.selector, len(previous.podNames))
+	return nil
+}
+
+// startWatcher starts a pod watcher for the configured namespaces and
+// selector, on the shared client, reading the last tail lines of each
+// container first. The caller holds s.mu.
+func (s *KubernetesLogSource) startWatcher(podNames []string, tail int64) error {
+	clientset, err := s.client.get(s.ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build kubernetes client: %w", err)
 	}
+	s.podNames = podNames
 
 	// Create tail lines pointer if specified
 	var tailLines *int64
-	if s.config.TailLines >= 0 {
-		tailLines = &s.config.TailLines
+	if tail >= 0 {
+		tailLines = &tail
 	}
 
 	// Create since pointer if specified
@@ -147,7 +232,13 @@ func (s *KubernetesLogSource) UpdateFilter(namespaces []string, selector string,
 		since = &s.config.Since
 	}
 
-	// Create new watcher with updated filter
+	// A start time replaces both, so a time window is read in full
+	var sinceTime *metav1.Time
+	if !s.config.SinceTime.IsZero() {
+		sinceTime = &metav1.Time{Time: s.config.SinceTime}
+		tailLines, since = nil, nil
+	}
+
 	watcher, err := NewPodWatcher(
 		clientset,
 		s.config.Namespaces,
@@ -156,6 +247,8 @@ func (s *KubernetesLogSource) UpdateFilter(namespaces []string, selector string,
 		s.lineChan,
 		tailLines,
 		since,
+		sinceTime,
+		s.config.MaxLineSize,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create pod watcher: %w", err)
@@ -167,23 +260,19 @@ func (s *KubernetesLogSource) UpdateFilter(namespaces []string, selector string,
 	if err := watcher.Start(); err != nil {
 		return fmt.Errorf("failed to start pod watcher: %w", err)
 	}
-
-	log.Printf("Updated kubernetes filter - Namespaces: %v, Selector: %s, Pods: %d selected", namespaces, selector, len(podNames))
-
 	return nil
 }
 
 // ListNamespaces returns the list of available namespaces from the cluster
 // If initial config had specific namespaces, those are marked as selected
 func (s *KubernetesLogSource) ListNamespaces() (map[string]bool, error) {
-	// Build kubernetes clientset
-	clientset, err := s.config.BuildClientset()
+	clientset, err := s.client.get(s.ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build kubernetes client: %w", err)
 	}
 
 	// List all namespaces
-	nsList, err := clientset.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	nsList, err := clientset.CoreV1().Namespaces().List(s.ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %w", err)
 	}
@@ -213,12 +302,6 @@ func (s *KubernetesLogSource) ListNamespaces() (map[string]bool, error) {
 // ListPods returns the list of available pods from selected namespaces
 // If initial config had specific namespaces/selector, relevant pods are marked as selected
 func (s *KubernetesLogSource) ListPods(selectedNamespaces map[string]bool) (map[string]bool, error) {
-	// Build kubernetes clientset
-	clientset, err := s.config.BuildClientset()
-	if err != nil {
-		return nil, fmt.Errorf("failed to build kubernetes client: %w", err)
-	}
-
 	result := make(map[string]bool)
 
 	// Build list options with label selector if configured
@@ -246,26 +329,24 @@ func (s *KubernetesLogSource) ListPods(selectedNamespaces map[string]bool) (map[
 		namespacesToQuery = []string{""}
 	}
 
-	// List pods from each namespace
+	// List pods from each namespace, from the watcher's informer cache when
+	// it holds the namespace and from the API server otherwise
 	for _, ns := range namespacesToQuery {
-		var podList *corev1.PodList
-		var err error
-
-		if ns == "" {
-			// List from all namespaces
-			podList, err = clientset.CoreV1().Pods("").List(context.Background(), listOptions)
-		} else {
-			// List from specific namespace
-			podList, err = clientset.CoreV1().Pods(ns).List(context.Background(), listOptions)
+		var pods []*corev1.Pod
+		cached := false
+		if watcher := s.currentWatcher(); watcher != nil {
+			pods, cached = watcher.cachedPods(ns)
 		}
-
-		if err != nil {
-			log.Printf("Warning: failed to list pods in namespace %q: %v", ns, err)
-			continue
+		if !cached {
+			listed, err := s.listPods(ns, listOptions)
+			if err != nil {
+				return nil, err
+			}
+			pods = listed
 		}
 
 		// Add pods to result - select all by default
-		for _, pod := range podList.Items {
+		for _, pod := range pods {
 			// Use namespace/pod format for clarity
 			podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
 			result[podKey] = true
@@ -274,3 +355,24 @@ func (s *KubernetesLogSource) ListPods(selectedNamespaces map[string]bool) (map[
 
 	return result, nil
 }
+
+// listPods lists the pods of a namespace, "" for all, from the API server.
+// A namespace that cannot be listed is skipped with a warning.
+func (s *KubernetesLogSource) listPods(ns string, listOptions metav1.ListOptions) ([]*corev1.Pod, error) {
+	clientset, err := s.client.get(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes client: %w", err)
+	}
+
+	podList, err := clientset.CoreV1().Pods(ns).List(s.ctx, listOptions)
+	if err != nil {
+		log.Printf("Warning: failed to list pods in namespace %q: %v", ns, err)
+		return nil, nil
+	}
+
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+	return pods, nil
+}
@@ -7,8 +7,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sync"
+
+	"github.com/control-theory/gonzo/internal/linelimit"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -22,6 +26,8 @@ type PodLogStreamer struct {
 	cancel    context.CancelFunc
 	tailLines *int64
 	since     *int64
+	sinceTime *metav1.Time
+	lineLimit int // Longest log line kept whole, zero for linelimit.Default
 }
 
 // NewPodLogStreamer creates a new pod log streamer
@@ -33,6 +39,8 @@ func NewPodLogStreamer(
 	parentCtx context.Context,
 	tailLines *int64,
 	since *int64,
+	sinceTime *metav1.Time,
+	lineLimit int,
 ) *PodLogStreamer {
 	ctx, cancel := context.WithCancel(parentCtx)
 	return &PodLogStreamer{
@@ -44,12 +52,15 @@ func NewPodLogStreamer(
 		cancel:    cancel,
 		tailLines: tailLines,
 		since:     since,
+		sinceTime: sinceTime,
+		lineLimit: lineLimit,
 	}
 }
 
-// Start starts streaming logs from the pod
-func (s *PodLogStreamer) Start() {
-	go s.streamLogs()
+// Start starts streaming logs from the pod, adding the stream to wg until
+// it ends
+func (s *PodLogStreamer) Start(wg *sync.WaitGroup) {
+	wg.Go(s.streamLogs)
 }
 
 // Stop stops the log streaming
@@ -77,6 +88,9 @@ func (s *PodLogStreamer) streamLogs() {
 	if s.since != nil && *s.since > 0 {
 		opts.SinceSeconds = s.since
 	}
+	if s.sinceTime != nil {
+		opts.SinceTime = s.sinceTime
+	}
 
 	// Get log stream request
 	req := s.clientset.CoreV1().Pods(s.pod.Namespace).GetLogs(s.pod.Name, opts)
@@ -92,10 +106,13 @@ func (s *PodLogStreamer) streamLogs() {
 
 	// Read logs line by line
 	scanner := bufio.NewScanner(stream)
-	// Set larger buffer for long log lines
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	// Cut long log lines rather than stop at them
+	lineLimit := s.lineLimit
+	if lineLimit <= 0 {
+		lineLimit = linelimit.Default
+	}
+	linelimit.Buffer(scanner, lineLimit)
+	scanner.Split(linelimit.Split(lineLimit))
 
 	for scanner.Scan() {
 		select {
@@ -105,7 +122,12 @@ func (s *PodLogStreamer) streamLogs() {
 			line := scanner.Text()
 			if line != "" {
 				// Format log line with kubernetes metadata
-				enrichedLine := s.enrichLogLine(line)
+				message, size := linelimit.Unmark(line)
+				enrichedLine := s.enrichLogLine(message)
+				if size > 0 {
+					// Marked after the JSON, for the size to reach the parser
+					enrichedLine = string(linelimit.Mark([]byte(enrichedLine), size, 0))
+				}
 				select {
 				case s.output <- enrichedLine:
 				case <-s.ctx.Done():
@@ -23,13 +23,16 @@ type PodWatcher struct {
 	selector   labels.Selector
 	podNames   map[string]bool // Pod names to filter (namespace/podname format), empty = all pods
 	output     chan string
-	streamers  map[string]*PodLogStreamer // key: namespace/podName/containerName
+	streamers  map[string]*PodLogStreamer           // key: namespace/podName/containerName
+	informers  map[string]cache.SharedIndexInformer // Pod informers by namespace, "" for all
 	mu         sync.RWMutex
 	ctx        context.Context
 	cancel     context.CancelFunc
 	wg         sync.WaitGroup
 	tailLines  *int64
 	since      *int64
+	sinceTime  *metav1.Time
+	lineLimit  int // Longest log line kept whole, zero for linelimit.Default
 }
 
 // NewPodWatcher creates a new pod watcher
@@ -41,6 +44,8 @@ func NewPodWatcher(
 	output chan string,
 	tailLines *int64,
 	since *int64,
+	sinceTime *metav1.Time,
+	lineLimit int,
 ) (*PodWatcher, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -75,10 +80,13 @@ func NewPodWatcher(
 		podNames:   podNamesMap,
 		output:     output,
 		streamers:  make(map[string]*PodLogStreamer),
+		informers:  make(map[string]cache.SharedIndexInformer),
 		ctx:        ctx,
 		cancel:     cancel,
 		tailLines:  tailLines,
 		since:      since,
+		sinceTime:  sinceTime,
+		lineLimit:  lineLimit,
 	}, nil
 }
 
@@ -140,6 +148,10 @@ func (w *PodWatcher) watchNamespace(namespace string) error {
 		return fmt.Errorf("failed to add event handler: %w", err)
 	}
 
+	w.mu.Lock()
+	w.informers[namespace] = podInformer
+	w.mu.Unlock()
+
 	// Start informer (use context's Done channel as stop signal)
 	factory.Start(w.ctx.Done())
 
@@ -153,6 +165,33 @@ func (w *PodWatcher) watchNamespace(namespace string) error {
 	return nil
 }
 
+// cachedPods returns the pods of a namespace, "" for all, matching the
+// selector from the informer cache. It reports false when no synced informer
+// covers the namespace, so the API server has to be asked.
+func (w *PodWatcher) cachedPods(namespace string) ([]*corev1.Pod, bool) {
+	w.mu.RLock()
+	informer, ok := w.informers[namespace]
+	if !ok {
+		informer, ok = w.informers[""]
+	}
+	w.mu.RUnlock()
+	if !ok || !informer.HasSynced() {
+		return nil, false
+	}
+
+	var pods []*corev1.Pod
+	for _, obj := range informer.GetStore().List() {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok || (namespace != "" && pod.Namespace != namespace) {
+			continue
+		}
+		if w.selector.Matches(labels.Set(pod.Labels)) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, true
+}
+
 // shouldWatchPod determines if a pod should be watched based on selector, name filter, and phase
 func (w *PodWatcher) shouldWatchPod(pod *corev1.Pod) bool {
 	// Check if pod matches label selector
@@ -202,12 +241,14 @@ func (w *PodWatcher) startPodStreams(pod *corev1.Pod) {
 			w.ctx,
 			w.tailLines,
 			w.since,
+			w.sinceTime,
+			w.lineLimit,
 		)
 		w.streamers[key] = streamer
 		w.mu.Unlock()
 
 		// Start streaming
-		streamer.Start()
+		streamer.Start(&w.wg)
 		log.Printf("Started streaming logs from %s/%s container %s",
 			pod.Namespace, pod.Name, container.Name)
 	}
@@ -243,11 +284,13 @@ func (w *PodWatcher) startPodStreams(pod *corev1.Pod) {
 			w.ctx,
 			w.tailLines,
 			w.since,
+			w.sinceTime,
+			w.lineLimit,
 		)
 		w.streamers[key] = streamer
 		w.mu.Unlock()
 
-		streamer.Start()
+		streamer.Start(&w.wg)
 		log.Printf("Started streaming logs from %s/%s init container %s",
 			pod.Namespace, pod.Name, container.Name)
 	}
@@ -293,7 +336,7 @@ func (w *PodWatcher) Stop() {
 		w.cancel()
 	}
 
-	// Wait for all goroutines to finish
+	// Wait for all goroutines to finish, the streamers' sends included
 	// The context cancellation will cause all streamers and informers to stop naturally
 	w.wg.Wait()
 
@@ -0,0 +1,112 @@
+// Package linelimit caps the length of log lines at ingest. A line longer
+// than the limit is cut to its first bytes and marked with the size it had,
+// so an oversized line neither grows memory nor stops the scanner reading
+// it. The mark travels with the line to where it is parsed, which takes it
+// off again with Unmark.
+package linelimit
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	Default = 256 << 10 // Longest line kept whole unless configured otherwise
+	Max     = 1 << 20   // Longest line kept by readers without a configured limit
+)
+
+// marker separates a cut line from the size it had. Log lines do not
+// contain NUL bytes.
+const marker = "\x00gonzo-truncated:"
+
+// head returns the first bytes of a line up to max, not cutting a
+// character in half
+func head(line []byte, max int) []byte {
+	if max <= 0 || len(line) <= max {
+		return line
+	}
+	cut := max
+	for cut > 0 && cut > max-utf8.UTFMax && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	return line[:cut]
+}
+
+// Mark returns the first max bytes of a line, all of it when max is 0,
+// marked with the size it had
+func Mark(line []byte, size int64, max int) []byte {
+	marked := append([]byte{}, head(line, max)...)
+	marked = append(marked, marker...)
+	return strconv.AppendInt(marked, size, 10)
+}
+
+// Cut returns a line, or its first max bytes marked with the size it had
+// when longer
+func Cut(line string, max int) string {
+	if max <= 0 || len(line) <= max {
+		return line
+	}
+	return string(Mark([]byte(line), int64(len(line)), max))
+}
+
+// Unmark returns a line without its mark and the size it had, or the line
+// and 0 when it was not cut
+func Unmark(line string) (string, int64) {
+	i := strings.LastIndex(line, marker)
+	if i < 0 {
+		return line, 0
+	}
+	size, err := strconv.ParseInt(line[i+len(marker):], 10, 64)
+	if err != nil {
+		return line, 0
+	}
+	return line[:i], size
+}
+
+// Split returns a split function for a bufio.Scanner that splits lines as
+// bufio.ScanLines does, but cuts the lines longer than max and marks them
+// with the size they had. The scanner's buffer must hold max+1 bytes.
+func Split(max int) bufio.SplitFunc {
+	var (
+		cut  []byte // Kept of the line being skipped, nil when there is none
+		size int64  // Of the line being skipped so far
+	)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if cut != nil {
+			// Skip the rest of an oversized line up to its newline
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 && !atEOF {
+				size += int64(len(data))
+				return len(data), nil, nil
+			}
+			advance := len(data)
+			if i >= 0 {
+				advance = i + 1
+				size += int64(i)
+			} else {
+				size += int64(len(data))
+			}
+			token := Mark(cut, size, max)
+			cut, size = nil, 0
+			return advance, token, nil
+		}
+
+		i := bytes.IndexByte(data, '\n')
+		if i > max || (i < 0 && len(data) > max) {
+			if i >= 0 {
+				return i + 1, Mark(data[:i], int64(i), max), nil
+			}
+			cut, size = append([]byte{}, head(data, max)...), int64(len(data))
+			return len(data), nil, nil
+		}
+		return bufio.ScanLines(data, atEOF)
+	}
+}
+
+// Buffer sizes a scanner's buffer for Split(max)
+func Buffer(scanner *bufio.Scanner, max int) {
+	scanner.Buffer(make([]byte, 0, min(64*1024, max+1)), max+1)
+}
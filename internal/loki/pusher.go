@@ -0,0 +1,329 @@
+// Package loki pushes log entries to a Grafana Loki server, so the entries
+// of an ad-hoc tail can be kept as a persisted stream during an incident.
+// Entries are grouped into streams by labels derived from their attributes
+// and sent in batches to the push API.
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults for the pusher
+const (
+	pushPath       = "/loki/api/v1/push"
+	queueSize      = 10000       // Entries waiting to be sent
+	maxBatch       = 1000        // Entries per request
+	flushInterval  = time.Second // Longest an entry waits for a batch
+	requestTimeout = 10 * time.Second
+)
+
+// Label is a stream label read from an entry attribute
+type Label struct {
+	Name      string // Loki label name, e.g. namespace
+	Attribute string // Attribute the value is read from, e.g. k8s.namespace
+}
+
+// ParseLabel parses a label written as NAME=ATTRIBUTE, or as ATTRIBUTE alone
+// to name the label after the attribute
+func ParseLabel(spec string) (Label, error) {
+	name, attribute, ok := strings.Cut(spec, "=")
+	if !ok {
+		attribute = name
+	}
+	name, attribute = strings.TrimSpace(name), strings.TrimSpace(attribute)
+	if attribute == "" {
+		return Label{}, fmt.Errorf("invalid Loki label %q: expected NAME=ATTRIBUTE or ATTRIBUTE", spec)
+	}
+	name = SanitizeLabelName(name)
+	if name == "" {
+		return Label{}, fmt.Errorf("invalid Loki label %q: empty label name", spec)
+	}
+	return Label{Name: name, Attribute: attribute}, nil
+}
+
+// invalidLabelChars matches the characters Loki does not allow in label names
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// SanitizeLabelName turns an attribute key such as k8s.namespace into a
+// valid label name such as k8s_namespace
+func SanitizeLabelName(name string) string {
+	name = invalidLabelChars.ReplaceAllString(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// Config describes where entries are pushed and how they are labeled
+type Config struct {
+	URL     string            // Base URL, e.g. http://localhost:3100
+	Labels  []Label           // Labels read from attributes
+	Static  map[string]string // Labels added to every stream, e.g. job=gonzo
+	Headers map[string]string // Sent with every request, e.g. X-Scope-OrgID or authorization
+}
+
+// Entry is one log entry to push
+type Entry struct {
+	Time       time.Time
+	Severity   string // Normalized level, e.g. ERROR
+	Service    string
+	Message    string
+	Attributes map[string]string
+}
+
+// Pusher sends entries in batches. Add never blocks; entries that do not
+// fit in the queue are dropped and counted.
+type Pusher struct {
+	pushURL string
+	labels  []Label
+	static  map[string]string
+	headers map[string]string
+	client  *http.Client
+
+	queue   chan Entry
+	dropped atomic.Int64
+	failed  atomic.Int64
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewPusher validates the configuration
+func NewPusher(cfg Config) (*Pusher, error) {
+	base, err := url.Parse(cfg.URL)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid Loki URL %q: expected a URL such as http://localhost:3100", cfg.URL)
+	}
+	// Accept the full push URL as well as the server's base URL
+	if !strings.HasSuffix(base.Path, pushPath) {
+		base.Path = strings.TrimSuffix(base.Path, "/") + pushPath
+	}
+	return &Pusher{
+		pushURL: base.String(),
+		labels:  cfg.Labels,
+		static:  cfg.Static,
+		headers: cfg.Headers,
+		client:  &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Start begins sending in the background
+func (p *Pusher) Start() {
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.queue = make(chan Entry, queueSize)
+	p.wg.Go(p.run)
+}
+
+// Stop sends what is queued, then stops
+func (p *Pusher) Stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	p.wg.Wait()
+}
+
+// Add queues an entry for sending
+func (p *Pusher) Add(entry Entry) {
+	if p.queue == nil {
+		return
+	}
+	select {
+	case p.queue <- entry:
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+// Dropped returns how many entries did not fit in the queue
+func (p *Pusher) Dropped() int64 {
+	return p.dropped.Load()
+}
+
+// Failed returns how many requests Loki rejected or did not answer
+func (p *Pusher) Failed() int64 {
+	return p.failed.Load()
+}
+
+// run batches queued entries until stopped
+func (p *Pusher) run() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, maxBatch)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		p.push(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case entry := <-p.queue:
+			batch = append(batch, entry)
+			if len(batch) >= maxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-p.ctx.Done():
+			// Drain what was queued before stopping
+			for {
+				select {
+				case entry := <-p.queue:
+					batch = append(batch, entry)
+					if len(batch) >= maxBatch {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// streamLabels returns the labels of the stream an entry belongs to. Every
+// stream has a level; the service is added when the entry has one.
+func (p *Pusher) streamLabels(entry Entry) map[string]string {
+	labels := make(map[string]string, len(p.static)+len(p.labels)+2)
+	for name, value := range p.static {
+		labels[name] = value
+	}
+	if entry.Service != "" {
+		labels["service_name"] = entry.Service
+	}
+	level := strings.ToLower(entry.Severity)
+	if level == "" {
+		level = "unknown"
+	}
+	labels["level"] = level
+	for _, label := range p.labels {
+		if value := entry.Attributes[label.Attribute]; value != "" {
+			labels[label.Name] = value
+		}
+	}
+	return labels
+}
+
+// labelSetKey renders a label set in a stable order, to group entries
+func labelSetKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// pushStream is one stream of a push request
+type pushStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// buildPushRequest groups a batch into streams, each in time order
+func (p *Pusher) buildPushRequest(batch []Entry) map[string][]*pushStream {
+	sort.SliceStable(batch, func(i, j int) bool { return batch[i].Time.Before(batch[j].Time) })
+
+	byLabels := make(map[string]*pushStream)
+	var streams []*pushStream
+	for _, entry := range batch {
+		labels := p.streamLabels(entry)
+		key := labelSetKey(labels)
+		stream := byLabels[key]
+		if stream == nil {
+			stream = &pushStream{Stream: labels}
+			byLabels[key] = stream
+			streams = append(streams, stream)
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(entry.Time.UnixNano(), 10),
+			formatLine(entry),
+		})
+	}
+	return map[string][]*pushStream{"streams": streams}
+}
+
+// formatLine renders an entry as a logfmt line, so LogQL's logfmt parser
+// recovers the attributes
+func formatLine(entry Entry) string {
+	var b strings.Builder
+	b.WriteString("msg=")
+	b.WriteString(logfmtValue(entry.Message))
+	keys := make([]string, 0, len(entry.Attributes))
+	for key := range entry.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b.WriteByte(' ')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(entry.Attributes[key]))
+	}
+	return b.String()
+}
+
+// logfmtValue quotes a value when it contains spaces, quotes or equal signs
+func logfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// push sends one batch to the push API
+func (p *Pusher) push(batch []Entry) {
+	body, err := json.Marshal(p.buildPushRequest(batch))
+	if err != nil {
+		log.Printf("Warning: failed to encode Loki push request: %v", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, p.pushURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Warning: failed to create Loki push request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range p.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		p.failed.Add(1)
+		log.Printf("Warning: Loki push to %s failed: %v", p.pushURL, err)
+		return
+	}
+	defer resp.Body.Close()
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		p.failed.Add(1)
+		log.Printf("Warning: Loki push to %s returned %s: %s", p.pushURL, resp.Status, strings.TrimSpace(string(message)))
+	}
+}
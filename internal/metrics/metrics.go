@@ -0,0 +1,362 @@
+// Package metrics derives metrics from the log stream. Each rule is written
+// as
+//
+//	NAME = count [where FILTER]
+//	NAME = histogram(FIELD) [where FILTER]
+//	NAME = gauge(FIELD) [where FILTER]
+//
+// where FILTER is a filter expression and FIELD is an attribute key or a
+// /regex/ whose first capture group is taken from the message, e.g.
+//
+//	checkout_latency = histogram(duration) where service.name=checkout
+//	db_time = histogram(/took (\d+)ms/)
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// Kinds of metric
+const (
+	KindCount     = "count"     // Number of matching entries
+	KindHistogram = "histogram" // Distribution of a field's values
+	KindGauge     = "gauge"     // Latest value of a field
+)
+
+// DefaultBuckets are the histogram bucket upper bounds, suited to durations
+// in milliseconds
+var DefaultBuckets = []float64{1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000}
+
+// rateWindow is the window the per-minute rate of count metrics covers
+const rateWindow = time.Minute
+
+// Rule is one metric definition
+type Rule struct {
+	Name   string
+	Kind   string
+	Field  string           // Attribute key the value is read from
+	Regex  *regexp.Regexp   // Or the pattern that extracts it from the message
+	Filter *filterexpr.Expr // Entries counted; nil counts every entry
+}
+
+// Source describes where the rule's value comes from
+func (r Rule) Source() string {
+	if r.Regex != nil {
+		return "/" + r.Regex.String() + "/"
+	}
+	return r.Field
+}
+
+// metricNameRegex matches valid metric names
+var metricNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseRule parses a metric rule, see the package documentation
+func ParseRule(spec string) (Rule, error) {
+	name, rest, ok := strings.Cut(spec, "=")
+	name = strings.TrimSpace(name)
+	if !ok || !metricNameRegex.MatchString(name) {
+		return Rule{}, fmt.Errorf("invalid metric rule %q: expected NAME = count|histogram(FIELD)|gauge(FIELD) [where FILTER]", spec)
+	}
+	rule := Rule{Name: name}
+	rest = strings.TrimSpace(rest)
+
+	kindEnd := strings.IndexAny(rest, "( \t")
+	if kindEnd < 0 {
+		kindEnd = len(rest)
+	}
+	rule.Kind = strings.ToLower(rest[:kindEnd])
+	rest = strings.TrimSpace(rest[kindEnd:])
+	switch rule.Kind {
+	case KindCount, KindHistogram, KindGauge:
+	default:
+		return Rule{}, fmt.Errorf("invalid metric rule %q: unknown kind %q (use count, histogram or gauge)", spec, rule.Kind)
+	}
+
+	if strings.HasPrefix(rest, "(") {
+		field, after, err := readField(rest[1:])
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid metric rule %q: %w", spec, err)
+		}
+		rest = strings.TrimSpace(after)
+		if strings.HasPrefix(field, "/") {
+			re, err := regexp.Compile(strings.ReplaceAll(field[1:len(field)-1], `\/`, "/"))
+			if err != nil {
+				return Rule{}, fmt.Errorf("invalid metric rule %q: bad regex: %w", spec, err)
+			}
+			rule.Regex = re
+		} else {
+			rule.Field = field
+		}
+	}
+	if rule.Kind == KindCount && rule.Source() != "" {
+		return Rule{}, fmt.Errorf("invalid metric rule %q: count takes no field, use \"count where FILTER\"", spec)
+	}
+	if rule.Kind != KindCount && rule.Source() == "" {
+		return Rule{}, fmt.Errorf("invalid metric rule %q: %s needs a field, e.g. %s(duration_ms)", spec, rule.Kind, rule.Kind)
+	}
+
+	if rest != "" {
+		where, filter, _ := strings.Cut(rest, " ")
+		if !strings.EqualFold(where, "where") || strings.TrimSpace(filter) == "" {
+			return Rule{}, fmt.Errorf("invalid metric rule %q: expected \"where FILTER\" after the kind, found %q", spec, rest)
+		}
+		expr, err := filterexpr.Parse(filter)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid metric rule %q: %w", spec, err)
+		}
+		rule.Filter = expr
+	}
+	return rule, nil
+}
+
+// readField reads the field of a histogram or gauge up to the closing
+// parenthesis. A /regex/ may contain parentheses of its own.
+func readField(s string) (field, rest string, err error) {
+	s = strings.TrimLeft(s, " \t")
+	end := 0
+	if strings.HasPrefix(s, "/") {
+		end = 1
+		for end < len(s) && s[end] != '/' {
+			if s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(s) {
+			return "", "", fmt.Errorf("unterminated regex")
+		}
+		end++
+	}
+	closing := strings.IndexByte(s[end:], ')')
+	if closing < 0 {
+		return "", "", fmt.Errorf("missing \")\" after the field")
+	}
+	field = strings.TrimSpace(s[:end+closing])
+	if field == "" {
+		return "", "", fmt.Errorf("empty field")
+	}
+	return field, s[end+closing+1:], nil
+}
+
+// ParseValue reads a number from a field value. Durations such as 153ms or
+// 1.5s are converted to milliseconds; isDuration reports that they were.
+func ParseValue(s string) (value float64, isDuration bool, ok bool) {
+	s = strings.TrimSpace(s)
+	if v, err := strconv.ParseFloat(s, 64); err == nil && !math.IsNaN(v) && !math.IsInf(v, 0) {
+		return v, false, true
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return float64(d) / float64(time.Millisecond), true, true
+	}
+	return 0, false, false
+}
+
+// metric holds the running state of one rule
+type metric struct {
+	rule      Rule
+	count     int64
+	sum       float64
+	min, max  float64
+	last      float64
+	updated   time.Time
+	buckets   []int64 // Histogram counts per bucket, the last one unbounded
+	seconds   []secondCount
+	durations bool
+}
+
+// secondCount counts the matches within one second, for rates
+type secondCount struct {
+	second int64
+	count  int
+}
+
+// Set evaluates metric rules over the stream. It is safe for concurrent use.
+type Set struct {
+	mu      sync.Mutex
+	metrics []*metric
+}
+
+// NewSet creates a set for the rules
+func NewSet(rules []Rule) *Set {
+	s := &Set{}
+	for _, rule := range rules {
+		m := &metric{rule: rule}
+		if rule.Kind == KindHistogram {
+			m.buckets = make([]int64, len(DefaultBuckets)+1)
+		}
+		s.metrics = append(s.metrics, m)
+	}
+	return s
+}
+
+// Observe updates the metrics with an entry that arrived at now
+func (s *Set) Observe(rec *filterexpr.Record, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.metrics {
+		if m.rule.Filter != nil && !m.rule.Filter.Match(rec) {
+			continue
+		}
+		if m.rule.Kind == KindCount {
+			m.add(1, now)
+			continue
+		}
+		raw, found := m.extract(rec)
+		if !found {
+			continue
+		}
+		value, isDuration, ok := ParseValue(raw)
+		if !ok {
+			continue
+		}
+		m.durations = m.durations || isDuration
+		m.add(value, now)
+	}
+}
+
+// extract finds the rule's field in an entry
+func (m *metric) extract(rec *filterexpr.Record) (string, bool) {
+	if m.rule.Regex == nil {
+		value, ok := rec.Attributes[m.rule.Field]
+		return value, ok
+	}
+	match := m.rule.Regex.FindStringSubmatch(rec.Message)
+	if match == nil && rec.RawLine != "" {
+		match = m.rule.Regex.FindStringSubmatch(rec.RawLine)
+	}
+	switch {
+	case match == nil:
+		return "", false
+	case len(match) > 1:
+		return match[1], true
+	}
+	return match[0], true
+}
+
+// add records one observation
+func (m *metric) add(value float64, now time.Time) {
+	if m.count == 0 || value < m.min {
+		m.min = value
+	}
+	if m.count == 0 || value > m.max {
+		m.max = value
+	}
+	m.count++
+	m.sum += value
+	m.last = value
+	m.updated = now
+
+	if m.buckets != nil {
+		m.buckets[sort.SearchFloat64s(DefaultBuckets, value)]++
+	}
+
+	second := now.Unix()
+	if n := len(m.seconds); n > 0 && m.seconds[n-1].second == second {
+		m.seconds[n-1].count++
+	} else {
+		m.seconds = append(m.seconds, secondCount{second: second, count: 1})
+	}
+	m.pruneSeconds(now)
+}
+
+// pruneSeconds drops rate counts older than the rate window
+func (m *metric) pruneSeconds(now time.Time) {
+	oldest := now.Add(-rateWindow).Unix()
+	drop := 0
+	for drop < len(m.seconds) && m.seconds[drop].second <= oldest {
+		drop++
+	}
+	m.seconds = m.seconds[drop:]
+}
+
+// Bucket is one cumulative histogram bucket
+type Bucket struct {
+	UpperBound float64 // +Inf for the last bucket
+	Count      int64   // Observations less than or equal to the bound
+}
+
+// Snapshot is the state of a metric at one point in time
+type Snapshot struct {
+	Rule      Rule
+	Count     int64   // Observations (matching entries for count metrics)
+	PerMinute int     // Observations within the last minute
+	Sum       float64 // Histogram and gauge only
+	Min, Max  float64
+	Last      float64   // Gauge value
+	Updated   time.Time // Time of the latest observation
+	Buckets   []Bucket  // Histogram only
+	Durations bool      // Values came from durations and are in milliseconds
+}
+
+// Snapshot returns the current state of every metric, in rule order
+func (s *Set) Snapshot(now time.Time) []Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshots := make([]Snapshot, 0, len(s.metrics))
+	for _, m := range s.metrics {
+		m.pruneSeconds(now)
+		perMinute := 0
+		for _, sc := range m.seconds {
+			perMinute += sc.count
+		}
+		snap := Snapshot{
+			Rule:      m.rule,
+			Count:     m.count,
+			PerMinute: perMinute,
+			Sum:       m.sum,
+			Min:       m.min,
+			Max:       m.max,
+			Last:      m.last,
+			Updated:   m.updated,
+			Durations: m.durations,
+		}
+		if m.buckets != nil {
+			var cumulative int64
+			for i, count := range m.buckets {
+				cumulative += count
+				bound := math.Inf(1)
+				if i < len(DefaultBuckets) {
+					bound = DefaultBuckets[i]
+				}
+				snap.Buckets = append(snap.Buckets, Bucket{UpperBound: bound, Count: cumulative})
+			}
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots
+}
+
+// Quantile estimates the q-quantile (0..1) of a histogram by interpolating
+// within the bucket that holds it, clamped to the observed range
+func (s Snapshot) Quantile(q float64) float64 {
+	if s.Count == 0 || len(s.Buckets) == 0 {
+		return 0
+	}
+	rank := q * float64(s.Count)
+	lower, below := 0.0, int64(0)
+	for _, b := range s.Buckets {
+		if float64(b.Count) >= rank {
+			upper := b.UpperBound
+			if math.IsInf(upper, 1) {
+				return s.Max
+			}
+			inBucket := b.Count - below
+			value := upper
+			if inBucket > 0 {
+				value = lower + (upper-lower)*(rank-float64(below))/float64(inBucket)
+			}
+			return math.Min(math.Max(value, s.Min), s.Max)
+		}
+		lower, below = b.UpperBound, b.Count
+	}
+	return s.Max
+}
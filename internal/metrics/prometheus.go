@@ -0,0 +1,219 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxServices caps the distinct service label values exported; entries of
+// further services are counted as "other"
+const maxServices = 200
+
+// Exporter serves the ingestion statistics and the metric rules in the
+// Prometheus text format. It is safe for concurrent use.
+type Exporter struct {
+	set *Set
+
+	mu       sync.Mutex
+	lines    map[string]int64   // Lines read by source
+	entries  map[entryKey]int64 // Entries by source, service and severity
+	services map[string]bool
+	funcs    []funcMetric
+}
+
+// entryKey labels the entry counter
+type entryKey struct {
+	source, service, severity string
+}
+
+// funcMetric is a metric whose value is read when scraped
+type funcMetric struct {
+	name, kind, help string
+	labels           string
+	value            func() float64
+}
+
+// NewExporter creates an exporter for the metric rules in set, which may be
+// nil
+func NewExporter(set *Set) *Exporter {
+	return &Exporter{
+		set:      set,
+		lines:    make(map[string]int64),
+		entries:  make(map[entryKey]int64),
+		services: make(map[string]bool),
+	}
+}
+
+// ObserveLine counts a line read from source
+func (e *Exporter) ObserveLine(source string) {
+	e.mu.Lock()
+	e.lines[source]++
+	e.mu.Unlock()
+}
+
+// ObserveEntry counts a parsed entry by source, service and severity
+func (e *Exporter) ObserveEntry(source, service, severity string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.services[service] {
+		if len(e.services) >= maxServices {
+			service = "other"
+		} else {
+			e.services[service] = true
+		}
+	}
+	e.entries[entryKey{source, service, severity}]++
+}
+
+// AddCounterFunc exports a counter read from value when scraped
+func (e *Exporter) AddCounterFunc(name, help string, labels map[string]string, value func() float64) {
+	e.addFunc(name, "counter", help, labels, value)
+}
+
+// AddGaugeFunc exports a gauge read from value when scraped
+func (e *Exporter) AddGaugeFunc(name, help string, labels map[string]string, value func() float64) {
+	e.addFunc(name, "gauge", help, labels, value)
+}
+
+func (e *Exporter) addFunc(name, kind, help string, labels map[string]string, value func() float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.funcs = append(e.funcs, funcMetric{name: name, kind: kind, help: help, labels: formatLabels(labels), value: value})
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.Write(w)
+}
+
+// Write writes the metrics in the Prometheus text exposition format
+func (e *Exporter) Write(out io.Writer) error {
+	w := bufio.NewWriter(out)
+	e.writeIngest(w)
+	if e.set != nil {
+		for _, snap := range e.set.Snapshot(time.Now()) {
+			writeRule(w, snap)
+		}
+	}
+	return w.Flush()
+}
+
+// writeIngest writes the ingestion counters and the function metrics
+func (e *Exporter) writeIngest(w io.Writer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	writeHeader(w, "gonzo_lines_ingested_total", "counter", "Input lines read, by source")
+	sources := make([]string, 0, len(e.lines))
+	for source := range e.lines {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		fmt.Fprintf(w, "gonzo_lines_ingested_total%s %d\n", formatLabels(map[string]string{"source": source}), e.lines[source])
+	}
+
+	writeHeader(w, "gonzo_log_entries_total", "counter", "Parsed log entries, by source, service and severity")
+	keys := make([]entryKey, 0, len(e.entries))
+	for key := range e.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.source != b.source {
+			return a.source < b.source
+		}
+		if a.service != b.service {
+			return a.service < b.service
+		}
+		return a.severity < b.severity
+	})
+	for _, key := range keys {
+		labels := formatLabels(map[string]string{"source": key.source, "service": key.service, "severity": key.severity})
+		fmt.Fprintf(w, "gonzo_log_entries_total%s %d\n", labels, e.entries[key])
+	}
+
+	written := make(map[string]bool)
+	for _, f := range e.funcs {
+		if !written[f.name] {
+			writeHeader(w, f.name, f.kind, f.help)
+			written[f.name] = true
+		}
+		fmt.Fprintf(w, "%s%s %s\n", f.name, f.labels, formatFloat(f.value()))
+	}
+}
+
+// writeRule writes one metric rule as gonzo_rule_NAME
+func writeRule(w io.Writer, snap Snapshot) {
+	name := "gonzo_rule_" + snap.Rule.Name
+	help := fmt.Sprintf("Metric rule %s", snap.Rule.Kind)
+	if source := snap.Rule.Source(); source != "" {
+		help += " of " + source
+	}
+	if snap.Rule.Filter != nil {
+		help += " where " + snap.Rule.Filter.String()
+	}
+
+	switch snap.Rule.Kind {
+	case KindCount:
+		writeHeader(w, name+"_total", "counter", help)
+		fmt.Fprintf(w, "%s_total %d\n", name, snap.Count)
+	case KindHistogram:
+		writeHeader(w, name, "histogram", help)
+		for _, b := range snap.Buckets {
+			fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", name, formatFloat(b.UpperBound), b.Count)
+		}
+		fmt.Fprintf(w, "%s_sum %s\n", name, formatFloat(snap.Sum))
+		fmt.Fprintf(w, "%s_count %d\n", name, snap.Count)
+	case KindGauge:
+		if snap.Count == 0 {
+			return // No value to report yet
+		}
+		writeHeader(w, name, "gauge", help)
+		fmt.Fprintf(w, "%s %s\n", name, formatFloat(snap.Last))
+	}
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric
+func writeHeader(w io.Writer, name, kind, help string) {
+	help = strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// formatLabels renders a label set, sorted by name, e.g. {source="k8s"}
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf(`%s="%s"`, name, escape.Replace(labels[name]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// formatFloat renders a sample value, with +Inf for unbounded buckets
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
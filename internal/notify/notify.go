@@ -0,0 +1,326 @@
+// Package notify posts a message to a webhook when a watch expression
+// matches, e.g.
+//
+//	checkout-panics: panic service.name=checkout => https://hooks.slack.com/services/...
+//
+// Messages carry the number of matches and a few sample lines. Each watch
+// sends at most one message per interval: matches arriving in between are
+// counted into the next one, so a burst of errors is one message, not a
+// flood.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// Defaults for the notifier
+const (
+	DefaultInterval = time.Minute // Shortest time between two messages of a watch
+	DefaultSamples  = 5           // Sample lines per message
+	checkInterval   = time.Second // How often pending matches are looked at
+	requestTimeout  = 10 * time.Second
+	maxSampleLength = 300  // Characters of a sample line kept
+	discordMaxChars = 2000 // Discord rejects longer messages
+)
+
+// Webhook formats
+const (
+	FormatSlack   = "slack"   // {"text": ...} with Slack mrkdwn
+	FormatDiscord = "discord" // {"content": ...} with Discord markdown
+	FormatGeneric = "generic" // JSON with the fields of Payload
+)
+
+// Watch is one watch expression and where its matches are posted
+type Watch struct {
+	Name   string
+	Filter *filterexpr.Expr
+	URL    string
+}
+
+// watchNameRegex matches the optional "name:" prefix of a watch
+var watchNameRegex = regexp.MustCompile(`^([A-Za-z0-9_.-]+):\s+`)
+
+// ParseWatch parses a watch written as
+//
+//	[NAME:] FILTER [=> URL]
+//
+// Without a URL, matches are posted to defaultURL. A watch with neither is
+// only counted on the dashboard, and has an empty URL.
+func ParseWatch(spec, defaultURL string) (Watch, error) {
+	rest := strings.TrimSpace(spec)
+	watch := Watch{URL: defaultURL}
+
+	if idx := strings.LastIndex(rest, "=>"); idx >= 0 {
+		watch.URL = strings.TrimSpace(rest[idx+2:])
+		rest = strings.TrimSpace(rest[:idx])
+	}
+	if watch.URL != "" {
+		if u, err := url.Parse(watch.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return Watch{}, fmt.Errorf("invalid watch %q: invalid webhook URL %q", spec, watch.URL)
+		}
+	}
+
+	if m := watchNameRegex.FindStringSubmatch(rest); m != nil {
+		watch.Name = m[1]
+		rest = rest[len(m[0]):]
+	}
+	filter, err := filterexpr.Parse(rest)
+	if err != nil {
+		return Watch{}, fmt.Errorf("invalid watch %q: %w", spec, err)
+	}
+	watch.Filter = filter
+	if watch.Name == "" {
+		watch.Name = filter.String()
+	}
+	return watch, nil
+}
+
+// FormatForURL picks the message format from the webhook's host
+func FormatForURL(webhook string) string {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return FormatGeneric
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com" || strings.HasSuffix(host, ".slack.com"):
+		return FormatSlack
+	case (host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")) && strings.Contains(u.Path, "/webhooks/"):
+		return FormatDiscord
+	}
+	return FormatGeneric
+}
+
+// Payload is the JSON body posted to generic webhooks. Text makes it
+// readable by Slack-style incoming webhooks as is.
+type Payload struct {
+	Text    string    `json:"text"`
+	Watch   string    `json:"watch"`
+	Filter  string    `json:"filter"`
+	Count   int       `json:"count"`
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+	Samples []string  `json:"samples"`
+}
+
+// watchState collects the matches of a watch since its last message
+type watchState struct {
+	watch    Watch
+	count    int
+	samples  []string
+	since    time.Time // First match since the last message
+	lastSent time.Time
+}
+
+// Notifier matches entries against the watches and posts their messages in
+// the background. It is safe for concurrent use.
+type Notifier struct {
+	interval time.Duration
+	samples  int
+	client   *http.Client
+
+	mu     sync.Mutex
+	states []*watchState
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewNotifier creates a notifier for the watches. Each watch sends at most
+// one message per interval, with up to samples lines.
+func NewNotifier(watches []Watch, interval time.Duration, samples int) *Notifier {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if samples < 0 {
+		samples = 0
+	}
+	n := &Notifier{
+		interval: interval,
+		samples:  samples,
+		client:   &http.Client{Timeout: requestTimeout},
+	}
+	for _, watch := range watches {
+		n.states = append(n.states, &watchState{watch: watch})
+	}
+	return n
+}
+
+// Start begins posting in the background
+func (n *Notifier) Start() {
+	n.done = make(chan struct{})
+	n.wg.Go(n.run)
+}
+
+// Stop posts the matches not sent yet, then stops
+func (n *Notifier) Stop() {
+	if n.done == nil {
+		return
+	}
+	close(n.done)
+	n.wg.Wait()
+}
+
+// Observe counts an entry that arrived at now against every watch it
+// matches; line is kept as a sample
+func (n *Notifier) Observe(rec *filterexpr.Record, line string, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, s := range n.states {
+		if !s.watch.Filter.Match(rec) {
+			continue
+		}
+		if s.count == 0 {
+			s.since = now
+		}
+		s.count++
+		if len(s.samples) < n.samples {
+			s.samples = append(s.samples, truncate(line, maxSampleLength))
+		}
+	}
+}
+
+// run posts the pending matches of each watch once its interval has passed
+func (n *Notifier) run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			n.send(n.due(now, false))
+		case <-n.done:
+			n.send(n.due(time.Now(), true))
+			return
+		}
+	}
+}
+
+// message is a payload and the webhook it is posted to
+type message struct {
+	url     string
+	payload Payload
+}
+
+// due takes the pending matches of the watches whose interval has passed,
+// or of every watch when flushing
+func (n *Notifier) due(now time.Time, flush bool) []message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var messages []message
+	for _, s := range n.states {
+		if s.count == 0 || (!flush && now.Sub(s.lastSent) < n.interval) {
+			continue
+		}
+		messages = append(messages, message{url: s.watch.URL, payload: Payload{
+			Watch:   s.watch.Name,
+			Filter:  s.watch.Filter.String(),
+			Count:   s.count,
+			Since:   s.since,
+			Until:   now,
+			Samples: s.samples,
+		}})
+		s.count = 0
+		s.samples = nil
+		s.lastSent = now
+	}
+	return messages
+}
+
+// send posts the messages one after the other
+func (n *Notifier) send(messages []message) {
+	for _, msg := range messages {
+		if err := n.post(msg.url, msg.payload); err != nil {
+			log.Printf("Warning: watch %s: %v", msg.payload.Watch, err)
+		}
+	}
+}
+
+// summary renders the headline of a message, e.g.
+// "checkout-panics matched 12 entries in 45s"
+func summary(p Payload) string {
+	noun := "entries"
+	if p.Count == 1 {
+		noun = "entry"
+	}
+	return fmt.Sprintf("%s matched %d %s in %s", p.Watch, p.Count, noun, formatSpan(p.Until.Sub(p.Since)))
+}
+
+// formatSpan renders the time the matches spread over
+func formatSpan(d time.Duration) string {
+	if d < time.Second {
+		return "under a second"
+	}
+	return d.Round(time.Second).String()
+}
+
+// body encodes a payload in the webhook's format
+func body(format string, p Payload) ([]byte, error) {
+	var b strings.Builder
+	switch format {
+	case FormatSlack, FormatDiscord:
+		bold := "*"
+		if format == FormatDiscord {
+			bold = "**"
+		}
+		fmt.Fprintf(&b, "%sGonzo watch%s %s\n`%s`", bold, bold, summary(p), p.Filter)
+		if len(p.Samples) > 0 {
+			b.WriteString("\n```\n")
+			b.WriteString(strings.ReplaceAll(strings.Join(p.Samples, "\n"), "```", "'''"))
+			b.WriteString("\n```")
+		}
+	}
+
+	switch format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": b.String()})
+	case FormatDiscord:
+		content := b.String()
+		if len(content) > discordMaxChars {
+			content = truncate(content, discordMaxChars-4) + "\n```"
+		}
+		return json.Marshal(map[string]string{"content": content})
+	}
+	p.Text = "Gonzo watch " + summary(p)
+	return json.Marshal(p)
+}
+
+// post sends one message
+func (n *Notifier) post(webhook string, p Payload) error {
+	data, err := body(FormatForURL(webhook), p)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook message: %w", err)
+	}
+	resp, err := n.client.Post(webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// truncate shortens s to at most limit bytes without splitting a character
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit - len("…")
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…"
+}
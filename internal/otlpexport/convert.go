@@ -0,0 +1,222 @@
+package otlpexport
+
+import (
+	"encoding/hex"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/metrics"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// serviceNameKey is the resource attribute log records are grouped by
+const serviceNameKey = "service.name"
+
+// severityNumberKey is the attribute keeping the severity number of a
+// record received over OTLP, which the normalized level rounds
+const severityNumberKey = "severityNumber"
+
+// Attributes keeping the trace context of a record received over OTLP
+const (
+	traceIDKey    = "trace_id"
+	spanIDKey     = "span_id"
+	traceFlagsKey = "trace_flags"
+)
+
+// scope identifies gonzo as the producer of the exported data
+var scope = &commonpb.InstrumentationScope{Name: "gonzo"}
+
+// severityNumbers maps gonzo's normalized levels to OTLP severity numbers
+var severityNumbers = map[string]logspb.SeverityNumber{
+	"TRACE":    logspb.SeverityNumber_SEVERITY_NUMBER_TRACE,
+	"DEBUG":    logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+	"INFO":     logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+	"WARN":     logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+	"ERROR":    logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+	"FATAL":    logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+	"CRITICAL": logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+}
+
+// buildLogsRequest groups the records by service into one export request
+func buildLogsRequest(batch []LogRecord) *collogspb.ExportLogsServiceRequest {
+	byService := make(map[string]*logspb.ScopeLogs)
+	var services []string
+	for _, record := range batch {
+		service := record.Attributes[serviceNameKey]
+		scopeLogs, ok := byService[service]
+		if !ok {
+			scopeLogs = &logspb.ScopeLogs{Scope: scope}
+			byService[service] = scopeLogs
+			services = append(services, service)
+		}
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, logRecord(record))
+	}
+
+	req := &collogspb.ExportLogsServiceRequest{}
+	for _, service := range services {
+		resource := &resourcepb.Resource{}
+		if service != "" {
+			resource.Attributes = []*commonpb.KeyValue{stringAttribute(serviceNameKey, service)}
+		}
+		req.ResourceLogs = append(req.ResourceLogs, &logspb.ResourceLogs{
+			Resource:  resource,
+			ScopeLogs: []*logspb.ScopeLogs{byService[service]},
+		})
+	}
+	return req
+}
+
+// logRecord converts one record; service.name moves to the resource
+func logRecord(record LogRecord) *logspb.LogRecord {
+	severity := strings.ToUpper(record.Severity)
+	out := &logspb.LogRecord{
+		ObservedTimeUnixNano: unixNano(record.ObservedTime),
+		SeverityNumber:       severityNumbers[severity],
+		SeverityText:         severity,
+		Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: record.Body}},
+	}
+	if !record.Time.IsZero() {
+		out.TimeUnixNano = unixNano(record.Time)
+	}
+	if severity == "" || severity == "UNKNOWN" {
+		out.SeverityText = ""
+	}
+	if n, err := strconv.Atoi(record.Attributes[severityNumberKey]); err == nil &&
+		n >= int(logspb.SeverityNumber_SEVERITY_NUMBER_TRACE) && n <= int(logspb.SeverityNumber_SEVERITY_NUMBER_FATAL4) {
+		out.SeverityNumber = logspb.SeverityNumber(n)
+	}
+	moved := map[string]bool{serviceNameKey: true, severityNumberKey: true}
+	if id, err := hex.DecodeString(record.Attributes[traceIDKey]); err == nil && len(id) == 16 {
+		out.TraceId, moved[traceIDKey] = id, true
+	}
+	if id, err := hex.DecodeString(record.Attributes[spanIDKey]); err == nil && len(id) == 8 {
+		out.SpanId, moved[spanIDKey] = id, true
+	}
+	if flags, err := strconv.ParseUint(record.Attributes[traceFlagsKey], 16, 8); err == nil {
+		out.Flags, moved[traceFlagsKey] = uint32(flags), true
+	}
+
+	keys := make([]string, 0, len(record.Attributes))
+	for key := range record.Attributes {
+		if !moved[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		out.Attributes = append(out.Attributes, stringAttribute(key, record.Attributes[key]))
+	}
+	return out
+}
+
+// buildMetricsRequest converts the metric snapshots into cumulative OTLP
+// metrics. It returns nil when there is nothing to send.
+func buildMetricsRequest(snapshots []metrics.Snapshot, start, now time.Time) *colmetricspb.ExportMetricsServiceRequest {
+	startNano, nowNano := unixNano(start), unixNano(now)
+	var out []*metricspb.Metric
+	for _, snap := range snapshots {
+		metric := &metricspb.Metric{Name: snap.Rule.Name, Description: description(snap.Rule)}
+		if snap.Durations {
+			metric.Unit = "ms"
+		}
+
+		switch snap.Rule.Kind {
+		case metrics.KindCount:
+			metric.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+				DataPoints: []*metricspb.NumberDataPoint{{
+					StartTimeUnixNano: startNano,
+					TimeUnixNano:      nowNano,
+					Value:             &metricspb.NumberDataPoint_AsInt{AsInt: snap.Count},
+				}},
+			}}
+		case metrics.KindHistogram:
+			metric.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				DataPoints:             []*metricspb.HistogramDataPoint{histogramPoint(snap, startNano, nowNano)},
+			}}
+		case metrics.KindGauge:
+			if snap.Count == 0 {
+				continue // No value to report yet
+			}
+			metric.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+				DataPoints: []*metricspb.NumberDataPoint{{
+					TimeUnixNano: unixNano(snap.Updated),
+					Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: snap.Last},
+				}},
+			}}
+		default:
+			continue
+		}
+		out = append(out, metric)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{stringAttribute(serviceNameKey, "gonzo")}},
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope:   scope,
+				Metrics: out,
+			}},
+		}},
+	}
+}
+
+// histogramPoint converts the cumulative buckets of a snapshot into the
+// per-bucket counts OTLP expects
+func histogramPoint(snap metrics.Snapshot, startNano, nowNano uint64) *metricspb.HistogramDataPoint {
+	point := &metricspb.HistogramDataPoint{
+		StartTimeUnixNano: startNano,
+		TimeUnixNano:      nowNano,
+		Count:             uint64(snap.Count),
+		Sum:               &snap.Sum,
+	}
+	if snap.Count > 0 {
+		point.Min, point.Max = &snap.Min, &snap.Max
+	}
+	var below int64
+	for _, b := range snap.Buckets {
+		if !math.IsInf(b.UpperBound, 1) {
+			point.ExplicitBounds = append(point.ExplicitBounds, b.UpperBound)
+		}
+		point.BucketCounts = append(point.BucketCounts, uint64(b.Count-below))
+		below = b.Count
+	}
+	return point
+}
+
+// description describes a metric rule the way the Prometheus help text does
+func description(rule metrics.Rule) string {
+	desc := "Metric rule " + rule.Kind
+	if source := rule.Source(); source != "" {
+		desc += " of " + source
+	}
+	if rule.Filter != nil {
+		desc += " where " + rule.Filter.String()
+	}
+	return desc
+}
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
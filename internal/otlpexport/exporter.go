@@ -0,0 +1,258 @@
+// Package otlpexport forwards ingested log entries and the metric rules to
+// an OTLP/HTTP endpoint such as an OpenTelemetry Collector, so gonzo can tap
+// a stream while feeding the real observability backend.
+package otlpexport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/metrics"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Signals that can be forwarded
+const (
+	SignalLogs    = "logs"
+	SignalMetrics = "metrics"
+)
+
+// Defaults for the exporter
+const (
+	DefaultInterval = 10 * time.Second // How often metrics are sent
+	queueSize       = 10000            // Log records waiting to be sent
+	maxBatch        = 512              // Log records per request
+	flushInterval   = time.Second      // Longest a log record waits for a batch
+	requestTimeout  = 10 * time.Second
+)
+
+// Config describes where and what to forward
+type Config struct {
+	Endpoint string            // Base URL, e.g. http://localhost:4318
+	Signals  []string          // logs and/or metrics
+	Headers  map[string]string // Sent with every request, e.g. authorization
+	Interval time.Duration     // How often metrics are sent
+}
+
+// LogRecord is one log entry to forward
+type LogRecord struct {
+	Time         time.Time // When the event happened, if known
+	ObservedTime time.Time // When gonzo received it
+	Severity     string    // Normalized level, e.g. ERROR
+	Body         string
+	Attributes   map[string]string
+}
+
+// Exporter sends logs in batches and metrics on an interval. AddLog never
+// blocks; records that do not fit in the queue are dropped and counted.
+type Exporter struct {
+	logsURL    string
+	metricsURL string
+	headers    map[string]string
+	interval   time.Duration
+	client     *http.Client
+	set        *metrics.Set
+	start      time.Time
+
+	queue   chan LogRecord
+	dropped atomic.Int64
+	failed  atomic.Int64
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewExporter validates the configuration. The metric rules in set are sent
+// when the metrics signal is enabled; set may be nil.
+func NewExporter(cfg Config, set *metrics.Set) (*Exporter, error) {
+	base, err := url.Parse(cfg.Endpoint)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP export endpoint %q: expected a URL such as http://localhost:4318", cfg.Endpoint)
+	}
+
+	e := &Exporter{
+		headers:  cfg.Headers,
+		interval: cfg.Interval,
+		client:   &http.Client{Timeout: requestTimeout},
+		set:      set,
+		start:    time.Now(),
+	}
+	if e.interval <= 0 {
+		e.interval = DefaultInterval
+	}
+	for _, signal := range cfg.Signals {
+		switch strings.ToLower(strings.TrimSpace(signal)) {
+		case SignalLogs:
+			e.logsURL = signalURL(base, SignalLogs)
+		case SignalMetrics:
+			e.metricsURL = signalURL(base, SignalMetrics)
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown OTLP export signal %q (use logs or metrics)", signal)
+		}
+	}
+	if e.logsURL == "" && e.metricsURL == "" {
+		return nil, fmt.Errorf("no OTLP export signals enabled (use logs and/or metrics)")
+	}
+	return e, nil
+}
+
+// signalURL appends the standard /v1/logs or /v1/metrics path to the base
+// URL, keeping any path prefix of a proxy
+func signalURL(base *url.URL, signal string) string {
+	u := *base
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/" + signal
+	return u.String()
+}
+
+// ExportsLogs reports whether log records are forwarded
+func (e *Exporter) ExportsLogs() bool {
+	return e.logsURL != ""
+}
+
+// Start begins sending in the background
+func (e *Exporter) Start() {
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+	if e.logsURL != "" {
+		e.queue = make(chan LogRecord, queueSize)
+		e.wg.Go(e.runLogs)
+	}
+	if e.metricsURL != "" && e.set != nil {
+		e.wg.Go(e.runMetrics)
+	}
+}
+
+// Stop sends what is queued, and the metrics once more, then stops
+func (e *Exporter) Stop() {
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	e.wg.Wait()
+}
+
+// AddLog queues a log record for sending
+func (e *Exporter) AddLog(record LogRecord) {
+	if e.queue == nil {
+		return
+	}
+	select {
+	case e.queue <- record:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// Dropped returns how many log records did not fit in the queue
+func (e *Exporter) Dropped() int64 {
+	return e.dropped.Load()
+}
+
+// Failed returns how many requests the endpoint rejected or did not answer
+func (e *Exporter) Failed() int64 {
+	return e.failed.Load()
+}
+
+// runLogs batches queued log records until stopped
+func (e *Exporter) runLogs() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]LogRecord, 0, maxBatch)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		e.post(e.logsURL, buildLogsRequest(batch))
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case record := <-e.queue:
+			batch = append(batch, record)
+			if len(batch) >= maxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.ctx.Done():
+			// Drain what was queued before stopping
+			for {
+				select {
+				case record := <-e.queue:
+					batch = append(batch, record)
+					if len(batch) >= maxBatch {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// runMetrics sends the metric rules on every interval until stopped
+func (e *Exporter) runMetrics() {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.sendMetrics()
+		case <-e.ctx.Done():
+			e.sendMetrics()
+			return
+		}
+	}
+}
+
+func (e *Exporter) sendMetrics() {
+	now := time.Now()
+	if req := buildMetricsRequest(e.set.Snapshot(now), e.start, now); req != nil {
+		e.post(e.metricsURL, req)
+	}
+}
+
+// post sends one protobuf-encoded export request
+func (e *Exporter) post(endpoint string, msg proto.Message) {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		log.Printf("Warning: failed to encode OTLP export request: %v", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Warning: failed to create OTLP export request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		e.failed.Add(1)
+		log.Printf("Warning: OTLP export to %s failed: %v", endpoint, err)
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		e.failed.Add(1)
+		log.Printf("Warning: OTLP export to %s returned %s", endpoint, resp.Status)
+	}
+}
@@ -0,0 +1,218 @@
+package otlpreceiver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Access restricts who may send logs to the receiver. The zero value lets
+// anyone send.
+type Access struct {
+	BearerToken string // Sent by clients as "Authorization: Bearer TOKEN"
+	Username    string // Basic auth credentials, required when Username is set
+	Password    string
+	Allowed     []netip.Prefix // Source addresses allowed to connect; empty allows any
+}
+
+// ParseAllowList parses IP addresses and CIDR ranges such as 10.0.0.0/8
+func ParseAllowList(entries []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed address %q: %w", entry, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed address %q: %w", entry, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// SetAccess restricts who may send logs. Call it before Start.
+func (r *Receiver) SetAccess(access Access) {
+	r.access = access
+}
+
+// requiresAuth reports whether clients must send credentials
+func (a Access) requiresAuth() bool {
+	return a.BearerToken != "" || a.Username != ""
+}
+
+// authorized reports whether the value of an Authorization header carries
+// the bearer token or the basic auth credentials
+func (a Access) authorized(header string) bool {
+	if !a.requiresAuth() {
+		return true
+	}
+	scheme, credentials, _ := strings.Cut(header, " ")
+	credentials = strings.TrimSpace(credentials)
+	switch {
+	case strings.EqualFold(scheme, "Bearer") && a.BearerToken != "":
+		return subtle.ConstantTimeCompare([]byte(credentials), []byte(a.BearerToken)) == 1
+	case strings.EqualFold(scheme, "Basic") && a.Username != "":
+		want := base64.StdEncoding.EncodeToString([]byte(a.Username + ":" + a.Password))
+		return subtle.ConstantTimeCompare([]byte(credentials), []byte(want)) == 1
+	}
+	return false
+}
+
+// allows reports whether a client at a remote address may connect
+func (a Access) allows(remote net.Addr) bool {
+	if len(a.Allowed) == 0 {
+		return true
+	}
+	addr, ok := remoteAddr(remote.String())
+	if !ok {
+		return false
+	}
+	for _, prefix := range a.Allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteAddr returns the IP address of a host:port
+func remoteAddr(hostport string) (netip.Addr, bool) {
+	addrPort, err := netip.ParseAddrPort(hostport)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addrPort.Addr().Unmap(), true
+}
+
+// Limits of the refusal warnings: clients are remembered up to a number,
+// beyond which a scan or a flood of spoofed sources is summed up instead
+const (
+	maxRefusalsLogged = 256
+	refusalSummaryGap = time.Minute
+)
+
+// refusals logs the clients refused, once per address so a client retrying
+// does not flood the log
+type refusals struct {
+	mu          sync.Mutex
+	logged      map[string]bool
+	suppressed  int // Refusals not logged one by one, since the last summary
+	lastSummary time.Time
+}
+
+// warn logs that a client was refused, unless it was before for the reason.
+// Once maxRefusalsLogged clients are remembered, the refusals of others are
+// counted and logged together at most once per refusalSummaryGap.
+func (f *refusals) warn(remote, reason string) {
+	host, _, err := net.SplitHostPort(remote)
+	if err != nil {
+		host = remote
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.logged == nil {
+		f.logged = make(map[string]bool)
+	}
+	key := host + " " + reason
+	if f.logged[key] {
+		return
+	}
+	if len(f.logged) < maxRefusalsLogged {
+		f.logged[key] = true
+		log.Printf("Warning: OTLP receiver refused %s: %s", host, reason)
+		return
+	}
+	f.suppressed++
+	if now := time.Now(); now.Sub(f.lastSummary) >= refusalSummaryGap {
+		log.Printf("Warning: OTLP receiver refused %d more connections from clients not logged before, the latest %s: %s", f.suppressed, host, reason)
+		f.suppressed = 0
+		f.lastSummary = now
+	}
+}
+
+// allowListener closes the connections from addresses not allowed
+type allowListener struct {
+	net.Listener
+	access   Access
+	refusals *refusals
+}
+
+// Accept returns the next connection from an allowed address
+func (l *allowListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if l.access.allows(conn.RemoteAddr()) {
+			return conn, nil
+		}
+		l.refusals.warn(conn.RemoteAddr().String(), "address not allowed by --otlp-allow")
+		conn.Close()
+	}
+}
+
+// listen restricts a listener to the allowed addresses, if any
+func (r *Receiver) listen(listener net.Listener) net.Listener {
+	if len(r.access.Allowed) == 0 {
+		return listener
+	}
+	return &allowListener{Listener: listener, access: r.access, refusals: &r.refusals}
+}
+
+// authorizeHTTP rejects HTTP requests without the credentials, reporting
+// whether the request may go on
+func (r *Receiver) authorizeHTTP(w http.ResponseWriter, req *http.Request) bool {
+	if r.access.authorized(req.Header.Get("Authorization")) {
+		return true
+	}
+	r.refusals.warn(req.RemoteAddr, "missing or invalid credentials")
+	if r.access.Username != "" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="gonzo"`)
+	}
+	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	return false
+}
+
+// authorizeGRPC rejects gRPC calls without the credentials
+func (r *Receiver) authorizeGRPC(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+	if !r.access.authorized(header) {
+		remote := "unknown client"
+		if p, ok := peer.FromContext(ctx); ok {
+			remote = p.Addr.String()
+		}
+		r.refusals.warn(remote, "missing or invalid credentials")
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid credentials")
+	}
+	return handler(ctx, req)
+}
@@ -2,18 +2,19 @@ package otlpreceiver
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	otlpgrpc "go.opentelemetry.io/proto/otlp/collector/logs/v1"
-	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -27,13 +28,20 @@ type Receiver struct {
 	grpcListener net.Listener
 	httpListener net.Listener
 	lineChan     chan string
+	dropped      atomic.Int64
+	access       Access
+	refusals     refusals
+	tlsConfig    *tls.Config // nil serves plain text
+	grpcOptions  GRPCOptions
 	wg           sync.WaitGroup
 	ctx          context.Context
 	cancel       context.CancelFunc
+	stopOnce     sync.Once
 
 	// JSON marshaler/unmarshaler for converting protobuf to JSON
 	jsonMarshaler   protojson.MarshalOptions
 	jsonUnmarshaler protojson.UnmarshalOptions
+	lineMarshaler   protojson.MarshalOptions // OTLP's JSON field names, which format detection looks for
 
 	otlpgrpc.UnimplementedLogsServiceServer
 }
@@ -55,9 +63,18 @@ func NewReceiver(grpcPort, httpPort int) *Receiver {
 		jsonUnmarshaler: protojson.UnmarshalOptions{
 			DiscardUnknown: true,
 		},
+		lineMarshaler: protojson.MarshalOptions{
+			UseEnumNumbers: true,
+		},
 	}
 }
 
+// SetTLS makes both listeners serve TLS with the config, or plain text when
+// nil. Call it before Start.
+func (r *Receiver) SetTLS(config *tls.Config) {
+	r.tlsConfig = config
+}
+
 // Start starts the OTLP receiver
 func (r *Receiver) Start() error {
 	// Start gRPC server
@@ -66,13 +83,22 @@ func (r *Receiver) Start() error {
 		if err != nil {
 			return fmt.Errorf("failed to listen on gRPC port %d: %w", r.grpcPort, err)
 		}
+		grpcListener = r.listen(grpcListener)
 		r.grpcListener = grpcListener
 
 		// Create gRPC server with increased message size limits
-		r.grpcServer = grpc.NewServer(
-			grpc.MaxRecvMsgSize(4*1024*1024), // 4MB max receive message size
-			grpc.MaxSendMsgSize(4*1024*1024), // 4MB max send message size
-		)
+		options := []grpc.ServerOption{
+			grpc.MaxRecvMsgSize(4 * 1024 * 1024), // 4MB max receive message size
+			grpc.MaxSendMsgSize(4 * 1024 * 1024), // 4MB max send message size
+		}
+		options = append(options, r.grpcOptions.serverOptions()...)
+		if r.tlsConfig != nil {
+			options = append(options, grpc.Creds(credentials.NewTLS(r.tlsConfig)))
+		}
+		if r.access.requiresAuth() {
+			options = append(options, grpc.UnaryInterceptor(r.authorizeGRPC))
+		}
+		r.grpcServer = grpc.NewServer(options...)
 
 		// Register the OTLP logs service
 		otlpgrpc.RegisterLogsServiceServer(r.grpcServer, r)
@@ -92,6 +118,10 @@ func (r *Receiver) Start() error {
 		if err != nil {
 			return fmt.Errorf("failed to listen on HTTP port %d: %w", r.httpPort, err)
 		}
+		httpListener = r.listen(httpListener)
+		if r.tlsConfig != nil {
+			httpListener = tls.NewListener(httpListener, r.tlsConfig)
+		}
 		r.httpListener = httpListener
 
 		// Create HTTP server with routes
@@ -114,22 +144,25 @@ func (r *Receiver) Start() error {
 	return nil
 }
 
-// Stop stops the OTLP receiver
+// Stop stops the OTLP receiver, closing its line channel once its
+// listeners have. It may be called more than once.
 func (r *Receiver) Stop() {
-	if r.cancel != nil {
-		r.cancel()
-	}
+	r.stopOnce.Do(func() {
+		if r.cancel != nil {
+			r.cancel()
+		}
 
-	if r.grpcServer != nil {
-		r.grpcServer.GracefulStop()
-	}
+		if r.grpcServer != nil {
+			r.grpcServer.GracefulStop()
+		}
 
-	if r.httpServer != nil {
-		r.httpServer.Shutdown(context.Background())
-	}
+		if r.httpServer != nil {
+			r.httpServer.Shutdown(context.Background())
+		}
 
-	r.wg.Wait()
-	close(r.lineChan)
+		r.wg.Wait()
+		close(r.lineChan)
+	})
 }
 
 // GetLineChan returns the channel for receiving log lines
@@ -137,24 +170,22 @@ func (r *Receiver) GetLineChan() <-chan string {
 	return r.lineChan
 }
 
+// Dropped returns how many log records were dropped because the line
+// channel was full
+func (r *Receiver) Dropped() int64 {
+	return r.dropped.Load()
+}
+
 // Export implements the OTLP logs service Export method
 func (r *Receiver) Export(ctx context.Context, req *otlpgrpc.ExportLogsServiceRequest) (*otlpgrpc.ExportLogsServiceResponse, error) {
 	// Process each resource logs in the request
 	for _, resourceLogs := range req.ResourceLogs {
-		// Extract resource attributes
-		resourceAttrs := make(map[string]interface{})
-		if resourceLogs.Resource != nil {
-			for _, attr := range resourceLogs.Resource.Attributes {
-				resourceAttrs[attr.Key] = extractAttributeValue(attr.Value)
-			}
-		}
-
 		// Process each scope logs
 		for _, scopeLogs := range resourceLogs.ScopeLogs {
 			// Process each log record
 			for _, logRecord := range scopeLogs.LogRecords {
 				// Convert log record to JSON for processing
-				jsonLine, err := r.convertLogRecordToJSON(logRecord, resourceAttrs)
+				jsonLine, err := r.recordLine(resourceLogs, scopeLogs, logRecord)
 				if err != nil {
 					log.Printf("Failed to convert log record to JSON: %v", err)
 					continue
@@ -167,6 +198,7 @@ func (r *Receiver) Export(ctx context.Context, req *otlpgrpc.ExportLogsServiceRe
 					return nil, ctx.Err()
 				default:
 					// Channel is full, drop the log
+					r.dropped.Add(1)
 					log.Printf("Warning: OTLP receiver channel is full, dropping log")
 				}
 			}
@@ -183,6 +215,9 @@ func (r *Receiver) handleHTTPLogs(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if !r.authorizeHTTP(w, req) {
+		return
+	}
 
 	// Read the request body
 	body, err := io.ReadAll(req.Body)
@@ -245,150 +280,21 @@ func (r *Receiver) handleHTTPLogs(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-// convertLogRecordToJSON converts an OTLP log record to JSON string
-func (r *Receiver) convertLogRecordToJSON(record *logspb.LogRecord, resourceAttrs map[string]interface{}) (string, error) {
-	// Build a simple JSON object that matches what the stdin processing expects
-	jsonMap := make(map[string]interface{})
-
-	// Add time - prefer TimeUnixNano, fallback to ObservedTimeUnixNano
-	if record.TimeUnixNano > 0 {
-		jsonMap["timeUnixNano"] = fmt.Sprintf("%d", record.TimeUnixNano)
-	} else if record.ObservedTimeUnixNano > 0 {
-		// Use observed time as fallback when actual time is missing
-		jsonMap["timeUnixNano"] = fmt.Sprintf("%d", record.ObservedTimeUnixNano)
-		jsonMap["observedTimeUnixNano"] = fmt.Sprintf("%d", record.ObservedTimeUnixNano)
-	}
-
-	// Add severity
-	if record.SeverityText != "" {
-		jsonMap["severityText"] = record.SeverityText
-	}
-	if record.SeverityNumber != 0 {
-		jsonMap["severityNumber"] = int(record.SeverityNumber)
-	}
-
-	// Add body (message)
-	if record.Body != nil {
-		switch v := record.Body.Value.(type) {
-		case *commonpb.AnyValue_StringValue:
-			jsonMap["body"] = map[string]interface{}{
-				"stringValue": v.StringValue,
-			}
-		case *commonpb.AnyValue_IntValue:
-			jsonMap["body"] = map[string]interface{}{
-				"intValue": fmt.Sprintf("%d", v.IntValue),
-			}
-		case *commonpb.AnyValue_DoubleValue:
-			jsonMap["body"] = map[string]interface{}{
-				"doubleValue": v.DoubleValue,
-			}
-		case *commonpb.AnyValue_BoolValue:
-			jsonMap["body"] = map[string]interface{}{
-				"boolValue": v.BoolValue,
-			}
-		}
-	}
-
-	// Merge resource and record attributes
-	// First add resource attributes
-	mergedAttrs := make([]map[string]interface{}, 0)
-	for key, value := range resourceAttrs {
-		attr := map[string]interface{}{
-			"key": key,
-			"value": map[string]interface{}{
-				"stringValue": fmt.Sprintf("%v", value),
-			},
-		}
-		mergedAttrs = append(mergedAttrs, attr)
-	}
-
-	// Then add record attributes (they can override resource attributes)
-	for _, attr := range record.Attributes {
-		attrMap := map[string]interface{}{
-			"key": attr.Key,
-		}
-
-		// Extract the value properly
-		if attr.Value != nil {
-			switch v := attr.Value.Value.(type) {
-			case *commonpb.AnyValue_StringValue:
-				attrMap["value"] = map[string]interface{}{
-					"stringValue": v.StringValue,
-				}
-			case *commonpb.AnyValue_IntValue:
-				attrMap["value"] = map[string]interface{}{
-					"intValue": fmt.Sprintf("%d", v.IntValue),
-				}
-			case *commonpb.AnyValue_DoubleValue:
-				attrMap["value"] = map[string]interface{}{
-					"doubleValue": v.DoubleValue,
-				}
-			case *commonpb.AnyValue_BoolValue:
-				attrMap["value"] = map[string]interface{}{
-					"boolValue": v.BoolValue,
-				}
-			}
-		}
-
-		mergedAttrs = append(mergedAttrs, attrMap)
-	}
-
-	// Add merged attributes to the JSON
-	if len(mergedAttrs) > 0 {
-		jsonMap["attributes"] = mergedAttrs
-	}
-
-	// Add trace and span IDs if present
-	if len(record.TraceId) > 0 {
-		jsonMap["traceId"] = fmt.Sprintf("%x", record.TraceId)
-	}
-	if len(record.SpanId) > 0 {
-		jsonMap["spanId"] = fmt.Sprintf("%x", record.SpanId)
-	}
-
-	// Convert to JSON string
-	finalJSON, err := json.Marshal(jsonMap)
+// recordLine converts an OTLP log record to a JSON line holding it with
+// its resource and scope, so their attributes stay apart from its own
+func (r *Receiver) recordLine(resourceLogs *logspb.ResourceLogs, scopeLogs *logspb.ScopeLogs, record *logspb.LogRecord) (string, error) {
+	data := &logspb.LogsData{ResourceLogs: []*logspb.ResourceLogs{{
+		Resource:  resourceLogs.Resource,
+		SchemaUrl: resourceLogs.SchemaUrl,
+		ScopeLogs: []*logspb.ScopeLogs{{
+			Scope:      scopeLogs.Scope,
+			SchemaUrl:  scopeLogs.SchemaUrl,
+			LogRecords: []*logspb.LogRecord{record},
+		}},
+	}}}
+	line, err := r.lineMarshaler.Marshal(data)
 	if err != nil {
 		return "", err
 	}
-
-	return string(finalJSON), nil
-}
-
-// extractAttributeValue extracts the value from an AnyValue
-func extractAttributeValue(v *commonpb.AnyValue) interface{} {
-	if v == nil {
-		return nil
-	}
-
-	switch val := v.Value.(type) {
-	case *commonpb.AnyValue_StringValue:
-		return val.StringValue
-	case *commonpb.AnyValue_BoolValue:
-		return val.BoolValue
-	case *commonpb.AnyValue_IntValue:
-		return val.IntValue
-	case *commonpb.AnyValue_DoubleValue:
-		return val.DoubleValue
-	case *commonpb.AnyValue_ArrayValue:
-		if val.ArrayValue != nil {
-			arr := make([]interface{}, len(val.ArrayValue.Values))
-			for i, v := range val.ArrayValue.Values {
-				arr[i] = extractAttributeValue(v)
-			}
-			return arr
-		}
-	case *commonpb.AnyValue_KvlistValue:
-		if val.KvlistValue != nil {
-			m := make(map[string]interface{})
-			for _, kv := range val.KvlistValue.Values {
-				m[kv.Key] = extractAttributeValue(kv.Value)
-			}
-			return m
-		}
-	case *commonpb.AnyValue_BytesValue:
-		return val.BytesValue
-	}
-
-	return nil
+	return string(line), nil
 }
@@ -0,0 +1,53 @@
+package otlpreceiver
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// GRPCOptions tunes the gRPC listener for bursty exporters. Zero values keep
+// gRPC's defaults.
+type GRPCOptions struct {
+	KeepaliveTime    time.Duration // Ping clients idle this long
+	KeepaliveTimeout time.Duration // Close connections not answering a ping within this
+	MinPingInterval  time.Duration // Shortest interval clients may ping at without being disconnected
+	MaxStreams       uint32        // Concurrent streams per connection
+	WindowSize       int32         // Initial flow control window of each stream, in bytes
+	ConnWindowSize   int32         // Initial flow control window of each connection, in bytes
+}
+
+// SetGRPCOptions tunes the gRPC listener. Call it before Start.
+func (r *Receiver) SetGRPCOptions(options GRPCOptions) {
+	r.grpcOptions = options
+}
+
+// serverOptions returns the gRPC server options the tuning asks for
+func (o GRPCOptions) serverOptions() []grpc.ServerOption {
+	var options []grpc.ServerOption
+	if o.KeepaliveTime > 0 || o.KeepaliveTimeout > 0 {
+		options = append(options, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    o.KeepaliveTime,
+			Timeout: o.KeepaliveTimeout,
+		}))
+	}
+	// Exporters pinging faster than the policy allows get GOAWAY
+	// "too_many_pings" and reconnect with backoff
+	if o.MinPingInterval > 0 {
+		options = append(options, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             o.MinPingInterval,
+			PermitWithoutStream: true,
+		}))
+	}
+	if o.MaxStreams > 0 {
+		options = append(options, grpc.MaxConcurrentStreams(o.MaxStreams))
+	}
+	if o.WindowSize > 0 {
+		options = append(options, grpc.InitialWindowSize(o.WindowSize))
+	}
+	if o.ConnWindowSize > 0 {
+		options = append(options, grpc.InitialConnWindowSize(o.ConnWindowSize))
+	}
+	return options
+}
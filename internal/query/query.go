@@ -0,0 +1,389 @@
+// Package query answers aggregate questions about log entries, in the
+// style of LogQL and Splunk. A query is written as
+//
+//	FUNC([FIELD]) [by (KEY, ...)] [over STEP] [where FILTER]
+//
+// e.g.
+//
+//	count() by (service) over 1m
+//	p99(duration) by (service, http.route) where severity!=DEBUG
+//
+// FUNC aggregates the entries of each group, formed by the values of the
+// KEYs; with over, also within each STEP of time. FIELD is an attribute
+// read as a number, duration or size, or the normalized duration and size
+// fields of filter expressions. FILTER is a filter expression.
+package query
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// Aggregate functions
+const (
+	FuncCount = "count"
+	FuncSum   = "sum"
+	FuncAvg   = "avg"
+	FuncMin   = "min"
+	FuncMax   = "max"
+)
+
+// Funcs lists the aggregate functions, the percentiles p50 to p99 aside
+var Funcs = []string{FuncCount, FuncSum, FuncAvg, FuncMin, FuncMax}
+
+// Limits of a result
+const (
+	MaxKeys    = 4   // Keys a query groups by
+	MaxBuckets = 120 // Newest time buckets kept
+	MinStep    = time.Second
+)
+
+// Query is a parsed query
+type Query struct {
+	Func     string
+	Quantile float64 // Of a percentile function, e.g. 0.99 for p99
+	Field    string  // Value aggregated; empty for count()
+	By       []string
+	Step     time.Duration // Width of the time buckets; 0 for none
+	Where    *filterexpr.Expr
+	source   string
+}
+
+// String returns the query as written
+func (q *Query) String() string {
+	return q.source
+}
+
+// funcRegex matches the aggregate function and its field
+var funcRegex = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*)\s*\(\s*([^()]*?)\s*\)`)
+
+// percentileRegex matches the percentile functions, p50 to p99.9
+var percentileRegex = regexp.MustCompile(`^p(\d{1,2}(?:\.\d+)?)$`)
+
+// IsQuery reports whether text starts like a query, so a prompt that also
+// takes other input can tell them apart
+func IsQuery(text string) bool {
+	return funcRegex.MatchString(strings.TrimSpace(text))
+}
+
+// Parse parses a query, see the package documentation
+func Parse(source string) (*Query, error) {
+	rest := strings.TrimSpace(source)
+	q := &Query{source: rest}
+
+	if idx := strings.Index(strings.ToLower(rest), " where "); idx >= 0 {
+		where, err := filterexpr.Parse(strings.TrimSpace(rest[idx+len(" where "):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid query %q: %w", source, err)
+		}
+		q.Where = where
+		rest = strings.TrimSpace(rest[:idx])
+	}
+
+	m := funcRegex.FindStringSubmatch(rest)
+	if m == nil {
+		return nil, fmt.Errorf("invalid query %q: expected FUNC([FIELD]) [by (KEY, ...)] [over STEP] [where FILTER], e.g. count() by (service) over 1m", source)
+	}
+	if err := q.setFunc(strings.ToLower(m[1]), m[2]); err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", source, err)
+	}
+	rest = strings.TrimSpace(rest[len(m[0]):])
+
+	for rest != "" {
+		word, after, _ := strings.Cut(rest, " ")
+		after = strings.TrimSpace(after)
+		switch strings.ToLower(word) {
+		case "by":
+			if q.By != nil {
+				return nil, fmt.Errorf("invalid query %q: by is given twice", source)
+			}
+			keys, remaining, err := readKeys(after)
+			if err != nil {
+				return nil, fmt.Errorf("invalid query %q: %w", source, err)
+			}
+			q.By, rest = keys, remaining
+		case "over":
+			if q.Step != 0 {
+				return nil, fmt.Errorf("invalid query %q: over is given twice", source)
+			}
+			text, remaining, _ := strings.Cut(after, " ")
+			step, err := time.ParseDuration(text)
+			if err != nil || step < MinStep {
+				return nil, fmt.Errorf("invalid query %q: invalid step %q after over (use e.g. 30s, 1m or 1h)", source, text)
+			}
+			q.Step, rest = step, strings.TrimSpace(remaining)
+		default:
+			return nil, fmt.Errorf("invalid query %q: expected by, over or where, found %q", source, word)
+		}
+	}
+	return q, nil
+}
+
+// setFunc checks the aggregate function and its field
+func (q *Query) setFunc(name, field string) error {
+	q.Func, q.Field = name, field
+	switch name {
+	case FuncCount:
+		if field != "" {
+			return fmt.Errorf("count takes no field, add \"where %s=...\" to count some entries", field)
+		}
+		return nil
+	case FuncSum, FuncAvg, FuncMin, FuncMax:
+	default:
+		m := percentileRegex.FindStringSubmatch(name)
+		if m == nil {
+			return fmt.Errorf("unknown function %q (use %s or a percentile such as p99)", name, strings.Join(Funcs, ", "))
+		}
+		q.Quantile, _ = strconv.ParseFloat(m[1], 64)
+		if q.Quantile <= 0 {
+			return fmt.Errorf("unknown function %q (use a percentile from p1 to p99)", name)
+		}
+		q.Quantile /= 100
+	}
+	if field == "" {
+		return fmt.Errorf("%s needs a field, e.g. %s(duration)", name, name)
+	}
+	if lower := strings.ToLower(field); lower == filterexpr.FieldDuration || lower == filterexpr.FieldSize {
+		q.Field = lower
+	}
+	return nil
+}
+
+// readKeys reads the keys after by, either in parentheses or as a single
+// comma-separated word
+func readKeys(s string) (keys []string, rest string, err error) {
+	var list string
+	if strings.HasPrefix(s, "(") {
+		end := strings.IndexByte(s, ')')
+		if end < 0 {
+			return nil, "", fmt.Errorf("missing \")\" after the keys of by")
+		}
+		list, rest = s[1:end], s[end+1:]
+	} else {
+		list, rest, _ = strings.Cut(s, " ")
+	}
+	for _, key := range strings.Split(list, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	switch {
+	case len(keys) == 0:
+		return nil, "", fmt.Errorf("by needs a key, e.g. by (service)")
+	case len(keys) > MaxKeys:
+		return nil, "", fmt.Errorf("by takes at most %d keys", MaxKeys)
+	}
+	return keys, strings.TrimSpace(rest), nil
+}
+
+// keyFallbacks are the OpenTelemetry keys tried when a short key is
+// missing, so by (service) groups OTLP records as well
+var keyFallbacks = map[string]string{
+	"service":   "service.name",
+	"namespace": "k8s.namespace",
+	"pod":       "k8s.pod",
+	"host":      "host.name",
+}
+
+// Group is one row of a result
+type Group struct {
+	Keys    []string  // Values of the query's keys; "" when missing
+	Value   float64   // Aggregated over all of the group's entries
+	Buckets []float64 // Aggregated per time bucket; NaN where it has none
+	Entries int       // Entries aggregated
+}
+
+// Result is the answer to a query
+type Result struct {
+	Query   *Query
+	Groups  []Group     // Largest value first
+	Buckets []time.Time // Starts of the time buckets, oldest first
+	Unit    filterexpr.Unit
+	Entries int // Entries that matched the query
+	Missing int // Matching entries without a value of the field
+	Older   int // Entries in buckets before those kept
+	Others  int // Groups left out beyond the largest
+}
+
+// series holds what a group gathered in one bucket
+type series struct {
+	count  int
+	values []float64
+}
+
+// groupState is a group being aggregated
+type groupState struct {
+	keys    []string
+	total   series
+	buckets map[int64]*series
+}
+
+// Aggregator runs a query over entries added one at a time
+type Aggregator struct {
+	query   *Query
+	groups  map[string]*groupState
+	entries int
+	missing int
+	units   map[filterexpr.Unit]bool
+	first   int64 // Earliest and latest bucket in Unix nanoseconds
+	last    int64
+	timed   bool
+}
+
+// NewAggregator starts running the query
+func (q *Query) NewAggregator() *Aggregator {
+	return &Aggregator{
+		query:  q,
+		groups: make(map[string]*groupState),
+		units:  make(map[filterexpr.Unit]bool),
+	}
+}
+
+// Add aggregates an entry, if it matches the query
+func (a *Aggregator) Add(r *filterexpr.Record) {
+	q := a.query
+	if q.Where != nil && !q.Where.Match(r) {
+		return
+	}
+	a.entries++
+
+	var value float64
+	if q.Func != FuncCount {
+		v, unit, ok := filterexpr.Bound{Field: q.Field}.Measure(r)
+		if !ok {
+			a.missing++
+			return
+		}
+		value = v
+		a.units[unit] = true
+	}
+
+	keys := make([]string, len(q.By))
+	for i, key := range q.By {
+		v, ok := r.Field(key)
+		if fallback, found := keyFallbacks[strings.ToLower(key)]; !ok && found {
+			v, _ = r.Field(fallback)
+		}
+		keys[i] = v
+	}
+	id := strings.Join(keys, "\x00")
+	g, ok := a.groups[id]
+	if !ok {
+		g = &groupState{keys: keys, buckets: make(map[int64]*series)}
+		a.groups[id] = g
+	}
+	g.total.add(value, q.Func)
+
+	if q.Step == 0 || r.Time.IsZero() {
+		return
+	}
+	bucket := r.Time.Truncate(q.Step).UnixNano()
+	if !a.timed || bucket < a.first {
+		a.first = bucket
+	}
+	if !a.timed || bucket > a.last {
+		a.last = bucket
+	}
+	a.timed = true
+	s, ok := g.buckets[bucket]
+	if !ok {
+		s = &series{}
+		g.buckets[bucket] = s
+	}
+	s.add(value, q.Func)
+}
+
+// add gathers an entry's value; count needs none
+func (s *series) add(value float64, fn string) {
+	s.count++
+	if fn != FuncCount {
+		s.values = append(s.values, value)
+	}
+}
+
+// aggregate applies the query's function to the series
+func (q *Query) aggregate(s *series) float64 {
+	if s == nil || s.count == 0 {
+		return math.NaN()
+	}
+	values := s.values
+	switch q.Func {
+	case FuncCount:
+		return float64(s.count)
+	case FuncSum, FuncAvg:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		if q.Func == FuncAvg {
+			return sum / float64(len(values))
+		}
+		return sum
+	case FuncMin:
+		return slices.Min(values)
+	case FuncMax:
+		return slices.Max(values)
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(q.Quantile*float64(len(sorted)))) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+// Result returns the answer so far with the largest maxGroups groups, or
+// all of them when maxGroups is 0
+func (a *Aggregator) Result(maxGroups int) Result {
+	q := a.query
+	res := Result{Query: q, Entries: a.entries, Missing: a.missing}
+	switch {
+	case len(a.units) != 1:
+	case a.units[filterexpr.UnitDuration]:
+		res.Unit = filterexpr.UnitDuration
+	case a.units[filterexpr.UnitSize]:
+		res.Unit = filterexpr.UnitSize
+	}
+
+	var first int64
+	if a.timed {
+		first = max(a.first, a.last-int64(MaxBuckets-1)*int64(q.Step))
+		for at := first; at <= a.last; at += int64(q.Step) {
+			res.Buckets = append(res.Buckets, time.Unix(0, at))
+		}
+	}
+
+	for _, g := range a.groups {
+		group := Group{Keys: g.keys, Value: q.aggregate(&g.total), Entries: g.total.count}
+		if len(res.Buckets) > 0 {
+			group.Buckets = make([]float64, len(res.Buckets))
+			for i, start := range res.Buckets {
+				group.Buckets[i] = q.aggregate(g.buckets[start.UnixNano()])
+			}
+			for at, s := range g.buckets {
+				if at < first {
+					res.Older += s.count
+				}
+			}
+		}
+		res.Groups = append(res.Groups, group)
+	}
+	sort.Slice(res.Groups, func(i, j int) bool {
+		gi, gj := res.Groups[i], res.Groups[j]
+		if gi.Value != gj.Value {
+			return gi.Value > gj.Value
+		}
+		return strings.Join(gi.Keys, "\x00") < strings.Join(gj.Keys, "\x00")
+	})
+	if maxGroups > 0 && len(res.Groups) > maxGroups {
+		res.Others = len(res.Groups) - maxGroups
+		res.Groups = res.Groups[:maxGroups]
+	}
+	return res
+}
@@ -0,0 +1,208 @@
+// Package redact obfuscates sensitive values in log entries, e.g.
+//
+//	email
+//	password=\S+ => password=***
+//
+// replaces e-mail addresses with [REDACTED] and password values with ***.
+// Patterns apply to messages, raw lines and attribute values; attribute
+// rules replace or remove the values of attributes by key.
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Placeholder replaces redacted values without a replacement of their own
+const Placeholder = "[REDACTED]"
+
+// presets are the patterns a rule can name instead of writing a regexp
+var presets = map[string]string{
+	"email":   `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	"ipv4":    `\b(?:\d{1,3}\.){3}\d{1,3}\b`,
+	"bearer":  `(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`,
+	"jwt":     `\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`,
+	"card":    `\b(?:\d[ -]?){12,15}\d\b`,
+	"aws-key": `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+}
+
+// Presets returns the names of the built-in patterns
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Config lists the rules of a Redactor
+type Config struct {
+	Patterns       []string // PATTERN [=> REPLACEMENT], PATTERN a preset name or a regexp
+	Attributes     []string // Key globs of attributes whose values are replaced
+	DenyAttributes []string // Key globs of attributes removed
+}
+
+// rule replaces the matches of a pattern
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor applies redaction rules. A nil Redactor changes nothing.
+type Redactor struct {
+	rules []rule
+	keys  []string // Lowercased globs of attributes replaced
+	deny  []string // Lowercased globs of attributes removed
+}
+
+// New compiles redaction rules, returning nil when there are none. Invalid
+// rules are skipped, their errors joined, so the valid ones still apply.
+func New(cfg Config) (*Redactor, error) {
+	r := &Redactor{}
+	var errs []error
+	for _, spec := range cfg.Patterns {
+		rule, err := parseRule(spec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		r.rules = append(r.rules, rule)
+	}
+	r.keys = parseGlobs(cfg.Attributes, &errs)
+	r.deny = parseGlobs(cfg.DenyAttributes, &errs)
+	if len(r.rules) == 0 && len(r.keys) == 0 && len(r.deny) == 0 {
+		r = nil
+	}
+	return r, errors.Join(errs...)
+}
+
+// Extend returns a Redactor applying the rules of r followed by those of
+// cfg, leaving r unchanged
+func (r *Redactor) Extend(cfg Config) (*Redactor, error) {
+	extra, err := New(cfg)
+	switch {
+	case r == nil:
+		return extra, err
+	case extra == nil:
+		return r, err
+	}
+	return &Redactor{
+		rules: append(append([]rule{}, r.rules...), extra.rules...),
+		keys:  append(append([]string{}, r.keys...), extra.keys...),
+		deny:  append(append([]string{}, r.deny...), extra.deny...),
+	}, err
+}
+
+// parseRule parses a rule written as PATTERN [=> REPLACEMENT]
+func parseRule(spec string) (rule, error) {
+	pattern, replacement, ok := strings.Cut(spec, "=>")
+	pattern = strings.TrimSpace(pattern)
+	replacement = strings.TrimSpace(replacement)
+	if !ok || replacement == "" {
+		replacement = Placeholder
+	}
+	if pattern == "" {
+		return rule{}, fmt.Errorf("invalid redaction rule %q: the pattern is empty", spec)
+	}
+	if preset, ok := presets[strings.ToLower(pattern)]; ok {
+		pattern = preset
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return rule{}, fmt.Errorf("invalid redaction rule %q: %w", spec, err)
+	}
+	return rule{pattern: re, replacement: replacement}, nil
+}
+
+// parseGlobs lowercases attribute key globs, skipping those with a syntax
+// error
+func parseGlobs(globs []string, errs *[]error) []string {
+	var parsed []string
+	for _, glob := range globs {
+		glob = strings.ToLower(strings.TrimSpace(glob))
+		if glob == "" {
+			continue
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			*errs = append(*errs, fmt.Errorf("invalid attribute pattern %q: %w", glob, err))
+			continue
+		}
+		parsed = append(parsed, glob)
+	}
+	return parsed
+}
+
+// matchesAny reports whether a key matches one of the globs
+func matchesAny(globs []string, key string) bool {
+	key = strings.ToLower(key)
+	for _, glob := range globs {
+		if ok, _ := path.Match(glob, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Text replaces the matches of the patterns in a text
+func (r *Redactor) Text(text string) string {
+	if r == nil {
+		return text
+	}
+	for _, rule := range r.rules {
+		text = rule.pattern.ReplaceAllString(text, rule.replacement)
+	}
+	return text
+}
+
+// Attributes redacts attribute values in place, removing the denied keys.
+// It reports whether an attribute was replaced or removed by key, which
+// pattern rules alone cannot find in the raw line.
+func (r *Redactor) Attributes(attrs map[string]string) bool {
+	if r == nil {
+		return false
+	}
+	byKey := false
+	for key, value := range attrs {
+		redacted, kept := r.Value(key, value)
+		if !kept {
+			delete(attrs, key)
+			byKey = true
+			continue
+		}
+		attrs[key] = redacted
+		byKey = byKey || matchesAny(r.keys, key)
+	}
+	return byKey
+}
+
+// Value redacts the value of an attribute, reporting false when the
+// attribute is removed
+func (r *Redactor) Value(key, value string) (string, bool) {
+	switch {
+	case r == nil:
+		return value, true
+	case matchesAny(r.deny, key):
+		return "", false
+	case matchesAny(r.keys, key):
+		return Placeholder, true
+	}
+	return r.Text(value), true
+}
+
+// Entry redacts the fields of a log entry, its attributes in place. The raw
+// line is dropped when attributes were redacted by key, since it may still
+// hold their values.
+func (r *Redactor) Entry(message, raw string, attrs map[string]string) (string, string) {
+	if r == nil {
+		return message, raw
+	}
+	if r.Attributes(attrs) {
+		raw = ""
+	}
+	return r.Text(message), r.Text(raw)
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// severityOrder is the order severities are listed in
+var severityOrder = []string{"FATAL", "CRITICAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE", "UNKNOWN"}
+
+// Markdown renders the summary as a Markdown document
+func (s Summary) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Gonzo Report\n\n")
+	fmt.Fprintf(&b, "%s to %s (%s)\n\n", s.Start.Format(time.RFC3339), s.End.Format(time.RFC3339), s.End.Sub(s.Start).Round(time.Second))
+
+	b.WriteString("## Volume\n\n")
+	fmt.Fprintf(&b, "- **Entries:** %d (%.1f/min)\n", s.Entries, s.PerMinute)
+	fmt.Fprintf(&b, "- **Errors:** %d (%.2f%%)\n", s.Errors, s.ErrorRate*100)
+	var severities []string
+	for _, severity := range s.orderedSeverities() {
+		severities = append(severities, fmt.Sprintf("%s %d", severity, s.Severities[severity]))
+	}
+	if len(severities) > 0 {
+		fmt.Fprintf(&b, "- **Severities:** %s\n", strings.Join(severities, ", "))
+	}
+	b.WriteString("\n")
+
+	if len(s.Timeline) > 0 {
+		b.WriteString("## Timeline\n\n| Start | Entries | Errors | |\n|---|---:|---:|---|\n")
+		peak := s.timelinePeak()
+		for _, bucket := range s.Timeline {
+			fmt.Fprintf(&b, "| %s | %d | %d | %s |\n", bucket.Start.Format(s.bucketLayout()), bucket.Entries, bucket.Errors, bar(bucket.Entries, peak))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Anomalies\n\n")
+	if len(s.Anomalies) == 0 {
+		b.WriteString("No minute's errors or volume spiked.\n\n")
+	} else {
+		b.WriteString("| Minute | Kind | Count | Typical per minute |\n|---|---|---:|---:|\n")
+		for _, a := range s.Anomalies {
+			fmt.Fprintf(&b, "| %s | %s | %d | %.1f |\n", a.Minute.Format("2006-01-02 15:04"), a.Kind, a.Count, a.Typical)
+		}
+		b.WriteString("\n")
+	}
+
+	writeServices(&b, "Error Leaders: Services", s.ErrorServices)
+	writePatterns(&b, "Error Leaders: Patterns", s.ErrorPatterns, false)
+	writePatterns(&b, "New Patterns", s.NewPatterns, true)
+	writePatterns(&b, "Top Patterns", s.TopPatterns, false)
+	writeServices(&b, "Top Services", s.Services)
+	return b.String()
+}
+
+// orderedSeverities lists the severities seen, most severe first
+func (s Summary) orderedSeverities() []string {
+	var ordered []string
+	known := make(map[string]bool)
+	for _, severity := range severityOrder {
+		known[severity] = true
+		if s.Severities[severity] > 0 {
+			ordered = append(ordered, severity)
+		}
+	}
+	var others []string
+	for severity := range s.Severities {
+		if !known[severity] {
+			others = append(others, severity)
+		}
+	}
+	sort.Strings(others)
+	return append(ordered, others...)
+}
+
+// timelinePeak returns the entry count of the timeline's fullest bucket
+func (s Summary) timelinePeak() int64 {
+	var peak int64
+	for _, bucket := range s.Timeline {
+		peak = max(peak, bucket.Entries)
+	}
+	return peak
+}
+
+// bucketLayout formats the start of the timeline's buckets, with the date
+// when they span more than a day
+func (s Summary) bucketLayout() string {
+	if len(s.Timeline) > 0 && s.Timeline[len(s.Timeline)-1].End.Sub(s.Timeline[0].Start) > 24*time.Hour {
+		return "2006-01-02 15:04"
+	}
+	return "15:04"
+}
+
+// barWidth is the length of the timeline's longest bar
+const barWidth = 30
+
+// bar draws count as a bar relative to peak, at least one block long for
+// any count
+func bar(count, peak int64) string {
+	if count == 0 || peak == 0 {
+		return ""
+	}
+	return strings.Repeat("█", max(1, int(count*barWidth/peak)))
+}
+
+// writeServices writes a table of services, or a note when there are none
+func writeServices(b *strings.Builder, title string, services []Service) {
+	fmt.Fprintf(b, "## %s\n\n", title)
+	if len(services) == 0 {
+		b.WriteString("None.\n\n")
+		return
+	}
+	b.WriteString("| Service | Entries | Errors |\n|---|---:|---:|\n")
+	for _, service := range services {
+		fmt.Fprintf(b, "| %s | %d | %d |\n", markdownCell(service.Name), service.Entries, service.Errors)
+	}
+	b.WriteString("\n")
+}
+
+// writePatterns writes a table of patterns, or a note when there are none
+func writePatterns(b *strings.Builder, title string, patterns []Pattern, firstSeen bool) {
+	fmt.Fprintf(b, "## %s\n\n", title)
+	if len(patterns) == 0 {
+		b.WriteString("None.\n\n")
+		return
+	}
+	if firstSeen {
+		b.WriteString("| Pattern | Count | Errors | First seen |\n|---|---:|---:|---|\n")
+	} else {
+		b.WriteString("| Pattern | Count | Errors |\n|---|---:|---:|\n")
+	}
+	for _, pattern := range patterns {
+		fmt.Fprintf(b, "| `%s` | %d | %d |", markdownCell(pattern.Template), pattern.Count, pattern.Errors)
+		if firstSeen {
+			fmt.Fprintf(b, " %s |", pattern.FirstSeen.Format("15:04:05"))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// markdownCell keeps a value from breaking the table it is written in
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "`", "'")
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,422 @@
+// Package report summarizes a session's log stream: volumes over time, the
+// top patterns and the ones first seen during the session, the services and
+// patterns with the most errors, and the minutes whose error or entry counts
+// spiked. Summaries are written as Markdown, or as JSON for paths ending in
+// .json, and can be printed as plain text.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/drain3"
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// Report limits
+const (
+	maxRows          = 10          // Rows in each ranking
+	maxPatterns      = 10000       // Patterns counted; later ones only count towards the totals
+	maxMinutes       = 24 * 60     // Minutes of counts kept for spike detection
+	newPatternWarmup = time.Minute // Patterns seen this soon after the first entry are the baseline
+	spikeFactor      = 3           // A minute spikes at this many times the typical count...
+	minSpikeErrors   = 5           // ...and at least this many errors
+	minSpikeEntries  = 100         // ...or entries
+	maxBuckets       = 24          // Most bars in the timeline
+)
+
+// bucketWidths are the timeline's bucket widths in minutes, the narrowest
+// that keeps the timeline within maxBuckets is used
+var bucketWidths = []int64{1, 2, 5, 10, 15, 30, 60, 120, 180, 360, 720, 1440}
+
+// Kinds of anomaly
+const (
+	AnomalyErrors = "errors" // Error count spiked
+	AnomalyVolume = "volume" // Entry count spiked
+)
+
+// Service is the entry and error counts of one service
+type Service struct {
+	Name    string `json:"name"`
+	Entries int64  `json:"entries"`
+	Errors  int64  `json:"errors"`
+}
+
+// Pattern is a message template and how often it was seen
+type Pattern struct {
+	Template  string    `json:"template"`
+	Count     int64     `json:"count"`
+	Errors    int64     `json:"errors"`
+	FirstSeen time.Time `json:"first_seen"`
+}
+
+// Anomaly is a minute whose counts were far above the typical minute
+type Anomaly struct {
+	Minute  time.Time `json:"minute"`
+	Kind    string    `json:"kind"`
+	Count   int64     `json:"count"`   // Errors or entries in the minute
+	Typical float64   `json:"typical"` // Median count per minute
+}
+
+// Bucket is the entry and error counts of one bar of the timeline
+type Bucket struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Entries int64     `json:"entries"`
+	Errors  int64     `json:"errors"`
+}
+
+// Summary is the report of a session up to a moment
+type Summary struct {
+	Start         time.Time        `json:"start"`
+	End           time.Time        `json:"end"`
+	Entries       int64            `json:"entries"`
+	Errors        int64            `json:"errors"`
+	ErrorRate     float64          `json:"error_rate"`
+	PerMinute     float64          `json:"entries_per_minute"`
+	Severities    map[string]int64 `json:"severities"`
+	Timeline      []Bucket         `json:"timeline"`
+	Services      []Service        `json:"top_services"`
+	TopPatterns   []Pattern        `json:"top_patterns"`
+	NewPatterns   []Pattern        `json:"new_patterns"`
+	ErrorServices []Service        `json:"error_services"`
+	ErrorPatterns []Pattern        `json:"error_patterns"`
+	Anomalies     []Anomaly        `json:"anomalies"`
+}
+
+// minuteCount counts the entries and errors within one minute
+type minuteCount struct {
+	minute          int64
+	entries, errors int64
+}
+
+// Collector gathers the counts a summary is made of. It is safe for
+// concurrent use, so reports can be written while entries arrive.
+type Collector struct {
+	mu         sync.Mutex
+	started    time.Time
+	latest     time.Time
+	entries    int64
+	errors     int64
+	severities map[string]int64
+	services   map[string]*Service
+	drain      *drain3.Drain
+	patterns   map[int64]*Pattern // By cluster ID
+	minutes    []minuteCount      // Ordered by minute
+}
+
+// New creates an empty collector
+func New() *Collector {
+	return &Collector{
+		severities: make(map[string]int64),
+		services:   make(map[string]*Service),
+		drain: drain3.New(&drain3.Config{
+			Depth:        4,
+			SimilarityTh: 0.5,
+			MaxChildren:  100,
+			MaxClusters:  maxPatterns,
+		}),
+		patterns: make(map[int64]*Pattern),
+	}
+}
+
+// isError reports whether a normalized severity counts as an error
+func isError(severity string) bool {
+	return severity == "ERROR" || severity == "FATAL" || severity == "CRITICAL"
+}
+
+// Observe counts an entry that arrived at now. The record's severity should
+// already be normalized. Times may arrive out of order, as the logs' own
+// timestamps of a batch of files do.
+func (c *Collector) Observe(rec *filterexpr.Record, service string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.started.IsZero() || now.Before(c.started) {
+		c.started = now
+	}
+	if now.After(c.latest) {
+		c.latest = now
+	}
+	errors := int64(0)
+	if isError(rec.Severity) {
+		errors = 1
+	}
+	c.entries++
+	c.errors += errors
+	c.severities[rec.Severity]++
+
+	if service != "" {
+		s := c.services[service]
+		if s == nil {
+			s = &Service{Name: service}
+			c.services[service] = s
+		}
+		s.Entries++
+		s.Errors += errors
+	}
+
+	c.countMinute(now.Unix()/60, errors)
+
+	if strings.TrimSpace(rec.Message) == "" {
+		return
+	}
+	cluster, _, err := c.drain.AddLogMessageCluster(rec.Message)
+	if err != nil || cluster == nil {
+		return
+	}
+	pattern := c.patterns[cluster.ClusterId]
+	if pattern == nil {
+		if len(c.patterns) >= maxPatterns {
+			return
+		}
+		pattern = &Pattern{FirstSeen: now}
+		c.patterns[cluster.ClusterId] = pattern
+	} else if now.Before(pattern.FirstSeen) {
+		pattern.FirstSeen = now
+	}
+	pattern.Template = strings.Join(cluster.LogTemplateTokens, " ")
+	pattern.Count++
+	pattern.Errors += errors
+}
+
+// countMinute counts an entry towards its minute, keeping only the latest
+// maxMinutes minutes
+func (c *Collector) countMinute(minute, errors int64) {
+	// Entries nearly always belong to the latest minute
+	n := len(c.minutes)
+	if n > 0 && c.minutes[n-1].minute == minute {
+		c.minutes[n-1].entries++
+		c.minutes[n-1].errors += errors
+		return
+	}
+
+	i := sort.Search(n, func(i int) bool { return c.minutes[i].minute >= minute })
+	if i < n && c.minutes[i].minute == minute {
+		c.minutes[i].entries++
+		c.minutes[i].errors += errors
+		return
+	}
+	if n >= maxMinutes && i == 0 {
+		return
+	}
+	c.minutes = slices.Insert(c.minutes, i, minuteCount{minute: minute, entries: 1, errors: errors})
+	if len(c.minutes) > maxMinutes {
+		c.minutes = c.minutes[1:]
+	}
+}
+
+// Latest returns the latest time an entry was observed at, zero before the
+// first
+func (c *Collector) Latest() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latest
+}
+
+// Summary summarizes the session up to now
+func (c *Collector) Summary(now time.Time) Summary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := Summary{
+		Start:      c.started,
+		End:        now,
+		Entries:    c.entries,
+		Errors:     c.errors,
+		Severities: make(map[string]int64, len(c.severities)),
+	}
+	if c.started.IsZero() {
+		s.Start = now
+	}
+	for severity, count := range c.severities {
+		s.Severities[severity] = count
+	}
+	if c.entries > 0 {
+		s.ErrorRate = float64(c.errors) / float64(c.entries)
+	}
+	if minutes := now.Sub(s.Start).Minutes(); minutes > 0 {
+		s.PerMinute = float64(c.entries) / max(minutes, 1)
+	}
+
+	services := make([]Service, 0, len(c.services))
+	for _, service := range c.services {
+		services = append(services, *service)
+	}
+	s.Services = topServices(services, func(s Service) int64 { return s.Entries })
+	s.ErrorServices = topServices(services, func(s Service) int64 { return s.Errors })
+
+	patterns := make([]Pattern, 0, len(c.patterns))
+	fresh := []Pattern{}
+	for _, pattern := range c.patterns {
+		patterns = append(patterns, *pattern)
+		if pattern.FirstSeen.Sub(c.started) >= newPatternWarmup {
+			fresh = append(fresh, *pattern)
+		}
+	}
+	s.TopPatterns = topPatterns(patterns, func(p Pattern) int64 { return p.Count })
+	s.ErrorPatterns = topPatterns(patterns, func(p Pattern) int64 { return p.Errors })
+	sort.Slice(fresh, func(i, j int) bool { return fresh[i].FirstSeen.After(fresh[j].FirstSeen) })
+	s.NewPatterns = fresh[:min(len(fresh), maxRows)]
+
+	s.Timeline = c.timeline()
+	s.Anomalies = c.anomalies()
+	return s
+}
+
+// timeline sums the counted minutes into at most maxBuckets bars of the
+// narrowest width in bucketWidths, empty bars included
+func (c *Collector) timeline() []Bucket {
+	if len(c.minutes) == 0 {
+		return []Bucket{}
+	}
+	first, last := c.minutes[0].minute, c.minutes[len(c.minutes)-1].minute
+	width := bucketWidths[len(bucketWidths)-1]
+	for _, w := range bucketWidths {
+		if last/w-first/w < maxBuckets {
+			width = w
+			break
+		}
+	}
+
+	start := first / width
+	buckets := make([]Bucket, last/width-start+1)
+	for i := range buckets {
+		buckets[i].Start = time.Unix((start+int64(i))*width*60, 0).In(c.started.Location())
+		buckets[i].End = buckets[i].Start.Add(time.Duration(width) * time.Minute)
+	}
+	for _, m := range c.minutes {
+		bucket := &buckets[m.minute/width-start]
+		bucket.Entries += m.entries
+		bucket.Errors += m.errors
+	}
+	return buckets
+}
+
+// topServices ranks the services by a count, leaving out those without any
+func topServices(services []Service, count func(Service) int64) []Service {
+	ranked := make([]Service, 0, len(services))
+	for _, service := range services {
+		if count(service) > 0 {
+			ranked = append(ranked, service)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ci, cj := count(ranked[i]), count(ranked[j]); ci != cj {
+			return ci > cj
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	return ranked[:min(len(ranked), maxRows)]
+}
+
+// topPatterns ranks the patterns by a count, leaving out those without any
+func topPatterns(patterns []Pattern, count func(Pattern) int64) []Pattern {
+	ranked := make([]Pattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		if count(pattern) > 0 {
+			ranked = append(ranked, pattern)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ci, cj := count(ranked[i]), count(ranked[j]); ci != cj {
+			return ci > cj
+		}
+		return ranked[i].Template < ranked[j].Template
+	})
+	return ranked[:min(len(ranked), maxRows)]
+}
+
+// anomalies finds the minutes whose error or entry counts were more than
+// spikeFactor times the median minute's. Minutes without entries count as
+// zero, so a service that starts failing after a quiet spell stands out.
+func (c *Collector) anomalies() []Anomaly {
+	if len(c.minutes) == 0 {
+		return nil
+	}
+	first, last := c.minutes[0].minute, c.minutes[len(c.minutes)-1].minute
+	span := int(last-first) + 1
+	entries := make([]int64, span)
+	errors := make([]int64, span)
+	for _, m := range c.minutes {
+		entries[m.minute-first] = m.entries
+		errors[m.minute-first] = m.errors
+	}
+	typicalEntries, typicalErrors := median(entries), median(errors)
+
+	anomalies := []Anomaly{}
+	for i := range span {
+		minute := time.Unix((first+int64(i))*60, 0)
+		if errors[i] >= minSpikeErrors && float64(errors[i]) > spikeFactor*typicalErrors {
+			anomalies = append(anomalies, Anomaly{Minute: minute, Kind: AnomalyErrors, Count: errors[i], Typical: typicalErrors})
+		}
+		if entries[i] >= minSpikeEntries && float64(entries[i]) > spikeFactor*typicalEntries {
+			anomalies = append(anomalies, Anomaly{Minute: minute, Kind: AnomalyVolume, Count: entries[i], Typical: typicalEntries})
+		}
+	}
+	return anomalies
+}
+
+// median returns the median of counts
+func median(counts []int64) float64 {
+	sorted := append([]int64(nil), counts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := len(sorted)
+	if n%2 == 1 {
+		return float64(sorted[n/2])
+	}
+	return float64(sorted[n/2-1]+sorted[n/2]) / 2
+}
+
+// JSON returns the summary as indented JSON
+func (s Summary) JSON() ([]byte, error) {
+	encoded, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+	return append(encoded, '\n'), nil
+}
+
+// WriteFile writes the summary to path, as JSON when it ends in .json and
+// as Markdown otherwise. The file is replaced in one step, so a reader never
+// sees a partial report.
+func WriteFile(path string, s Summary) error {
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoded, err := s.JSON()
+		if err != nil {
+			return err
+		}
+		data = encoded
+	} else {
+		data = []byte(s.Markdown())
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Text renders the summary as plain text for a terminal
+func (s Summary) Text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Gonzo Report: %s to %s (%s)\n\n", s.Start.Format(time.RFC3339), s.End.Format(time.RFC3339), s.End.Sub(s.Start).Round(time.Second))
+
+	fmt.Fprintf(&b, "Entries:    %d (%.1f/min)\n", s.Entries, s.PerMinute)
+	fmt.Fprintf(&b, "Errors:     %d (%.2f%%)\n", s.Errors, s.ErrorRate*100)
+	var severities []string
+	for _, severity := range s.orderedSeverities() {
+		severities = append(severities, fmt.Sprintf("%s %d", severity, s.Severities[severity]))
+	}
+	if len(severities) > 0 {
+		fmt.Fprintf(&b, "Severities: %s\n", strings.Join(severities, ", "))
+	}
+
+	if len(s.Timeline) > 0 {
+		fmt.Fprintf(&b, "\nTimeline (%s per bar)\n", formatWidth(s.Timeline[0].End.Sub(s.Timeline[0].Start)))
+		// The counts are aligned first, then the bars are drawn after them
+		var rows strings.Builder
+		w := tabwriter.NewWriter(&rows, 0, 0, 2, ' ', tabwriter.AlignRight)
+		for _, bucket := range s.Timeline {
+			fmt.Fprintf(w, "  %s\t%d\t%d errors\t\n", bucket.Start.Format(s.bucketLayout()), bucket.Entries, bucket.Errors)
+		}
+		w.Flush()
+		peak := s.timelinePeak()
+		for i, row := range strings.Split(strings.TrimSuffix(rows.String(), "\n"), "\n") {
+			fmt.Fprintf(&b, "%s  %s\n", row, bar(s.Timeline[i].Entries, peak))
+		}
+	}
+
+	b.WriteString("\nAnomalies\n")
+	if len(s.Anomalies) == 0 {
+		b.WriteString("  No minute's errors or volume spiked.\n")
+	} else {
+		for _, a := range s.Anomalies {
+			fmt.Fprintf(&b, "  %s  %s %d, typically %.1f per minute\n", a.Minute.Format("2006-01-02 15:04"), a.Kind, a.Count, a.Typical)
+		}
+	}
+
+	writeTextServices(&b, "Error Leaders: Services", s.ErrorServices)
+	writeTextPatterns(&b, "Error Leaders: Patterns", s.ErrorPatterns)
+	writeTextPatterns(&b, "New Patterns", s.NewPatterns)
+	writeTextPatterns(&b, "Top Patterns", s.TopPatterns)
+	writeTextServices(&b, "Top Services", s.Services)
+	return b.String()
+}
+
+// formatWidth formats a bucket width as whole hours or minutes, e.g. 5m
+func formatWidth(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+// writeTextServices writes aligned rows of services, or a note when there
+// are none
+func writeTextServices(b *strings.Builder, title string, services []Service) {
+	fmt.Fprintf(b, "\n%s\n", title)
+	if len(services) == 0 {
+		b.WriteString("  None.\n")
+		return
+	}
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  ENTRIES\tERRORS\tSERVICE\n")
+	for _, service := range services {
+		fmt.Fprintf(w, "  %d\t%d\t%s\n", service.Entries, service.Errors, textCell(service.Name))
+	}
+	w.Flush()
+}
+
+// writeTextPatterns writes aligned rows of patterns, or a note when there
+// are none
+func writeTextPatterns(b *strings.Builder, title string, patterns []Pattern) {
+	fmt.Fprintf(b, "\n%s\n", title)
+	if len(patterns) == 0 {
+		b.WriteString("  None.\n")
+		return
+	}
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  COUNT\tERRORS\tPATTERN\n")
+	for _, pattern := range patterns {
+		fmt.Fprintf(w, "  %d\t%d\t%s\n", pattern.Count, pattern.Errors, textCell(pattern.Template))
+	}
+	w.Flush()
+}
+
+// textCell keeps a value on one line
+func textCell(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
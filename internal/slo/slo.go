@@ -0,0 +1,250 @@
+// Package slo tracks service level objectives over the log stream. An
+// objective bounds the share of bad entries over a rolling window, both
+// selected with filter expressions, e.g.
+//
+//	checkout: severity=ERROR,FATAL < 1% over 30m where service.name=checkout
+//
+// keeps the errors below 1% of the checkout service's entries over the last
+// 30 minutes. The error budget is the share of bad entries the objective
+// allows; the tracker reports how much of it is used and, from the recent
+// burn rate, when the objective will be breached if nothing changes.
+package slo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// minRecentEntries is the number of entries the recent window needs before
+// a breach is projected from it
+const minRecentEntries = 10
+
+// Objective is one SLO definition
+type Objective struct {
+	Name   string
+	Bad    *filterexpr.Expr // Entries that count against the objective
+	Scope  *filterexpr.Expr // Entries the objective covers; nil covers every entry
+	Target float64          // Largest allowed share of bad entries, e.g. 0.01
+	Window time.Duration
+}
+
+// Condition describes the objective, e.g. "< 1% over 30m"
+func (o Objective) Condition() string {
+	return fmt.Sprintf("< %s%% over %s", strconv.FormatFloat(o.Target*100, 'f', -1, 64), formatWindow(o.Window))
+}
+
+// recentWindow is the window the burn rate is measured over: a sixth of the
+// objective's window, but at least a minute
+func (o Objective) recentWindow() time.Duration {
+	return min(o.Window, max(o.Window/6, time.Minute))
+}
+
+// objectiveNameRegex matches the optional "name:" prefix of an objective
+var objectiveNameRegex = regexp.MustCompile(`^([A-Za-z0-9_.-]+):\s+`)
+
+// targetRegex matches the "< TARGET% over WINDOW" suffix of the bad filter
+var targetRegex = regexp.MustCompile(`^(.*\S)\s+<\s*(\d+(?:\.\d+)?)\s*%\s+over\s+(\S+)$`)
+
+// ParseObjective parses an objective written as
+//
+//	[NAME:] FILTER < TARGET% over WINDOW [where SCOPE]
+//
+// FILTER selects the bad entries and SCOPE the entries the objective covers,
+// both filter expressions. WINDOW is a duration such as 30m or 1h.
+func ParseObjective(spec string) (Objective, error) {
+	rest := strings.TrimSpace(spec)
+	var objective Objective
+
+	if m := objectiveNameRegex.FindStringSubmatch(rest); m != nil {
+		objective.Name = m[1]
+		rest = rest[len(m[0]):]
+	}
+
+	if idx := strings.Index(strings.ToLower(rest), " where "); idx >= 0 {
+		scope, err := filterexpr.Parse(strings.TrimSpace(rest[idx+len(" where "):]))
+		if err != nil {
+			return Objective{}, fmt.Errorf("invalid SLO %q: %w", spec, err)
+		}
+		objective.Scope = scope
+		rest = strings.TrimSpace(rest[:idx])
+	}
+
+	m := targetRegex.FindStringSubmatch(rest)
+	if m == nil {
+		return Objective{}, fmt.Errorf("invalid SLO %q: expected FILTER < TARGET%% over WINDOW, e.g. severity=ERROR < 1%% over 30m", spec)
+	}
+	target, err := strconv.ParseFloat(m[2], 64)
+	if err != nil || target <= 0 || target >= 100 {
+		return Objective{}, fmt.Errorf("invalid SLO %q: target %s%% must be between 0 and 100", spec, m[2])
+	}
+	window, err := time.ParseDuration(m[3])
+	if err != nil || window < time.Minute {
+		return Objective{}, fmt.Errorf("invalid SLO %q: invalid window %q (use e.g. 30m or 1h)", spec, m[3])
+	}
+	bad, err := filterexpr.Parse(m[1])
+	if err != nil {
+		return Objective{}, fmt.Errorf("invalid SLO %q: %w", spec, err)
+	}
+
+	objective.Bad = bad
+	objective.Target = target / 100
+	objective.Window = window.Truncate(time.Second)
+	if objective.Name == "" {
+		objective.Name = bad.String()
+	}
+	return objective, nil
+}
+
+// formatWindow renders a window the way objectives are usually written
+func formatWindow(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// Status is the state of an objective at one moment
+type Status struct {
+	Objective  Objective
+	Total      int     // Entries in scope within the window
+	Bad        int     // Bad entries among them
+	Rate       float64 // Share of bad entries
+	BudgetUsed float64 // Rate relative to the target; 1 or more is a breach
+	BurnRate   float64 // Share of bad entries in the recent window relative to the target
+	Breached   bool
+	// BreachIn is when the objective will be breached at the recent burn
+	// rate, if that is within its window; 0 when no breach is projected
+	BreachIn time.Duration
+}
+
+// Projected reports whether a breach is projected but has not happened yet
+func (s Status) Projected() bool {
+	return !s.Breached && s.BreachIn > 0
+}
+
+// bucket counts the entries in scope within one second
+type bucket struct {
+	second     int64
+	total, bad int
+}
+
+// objectiveState tracks the rolling window of one objective
+type objectiveState struct {
+	objective  Objective
+	buckets    []bucket
+	total, bad int
+	first      time.Time // First entry in scope, to not extrapolate from a partial window
+}
+
+// prune drops the buckets that have left the window
+func (s *objectiveState) prune(now time.Time) {
+	oldest := now.Add(-s.objective.Window).Unix()
+	drop := 0
+	for drop < len(s.buckets) && s.buckets[drop].second <= oldest {
+		s.total -= s.buckets[drop].total
+		s.bad -= s.buckets[drop].bad
+		drop++
+	}
+	s.buckets = s.buckets[drop:]
+}
+
+// Tracker counts entries for a set of objectives. It is not safe for
+// concurrent use.
+type Tracker struct {
+	states []*objectiveState
+}
+
+// NewTracker creates a tracker for the objectives
+func NewTracker(objectives []Objective) *Tracker {
+	t := &Tracker{}
+	for _, objective := range objectives {
+		t.states = append(t.states, &objectiveState{objective: objective})
+	}
+	return t
+}
+
+// Observe counts an entry that arrived at now against every objective
+// covering it
+func (t *Tracker) Observe(rec *filterexpr.Record, now time.Time) {
+	second := now.Unix()
+	for _, s := range t.states {
+		if s.objective.Scope != nil && !s.objective.Scope.Match(rec) {
+			continue
+		}
+		bad := 0
+		if s.objective.Bad.Match(rec) {
+			bad = 1
+		}
+		if n := len(s.buckets); n > 0 && s.buckets[n-1].second == second {
+			s.buckets[n-1].total++
+			s.buckets[n-1].bad += bad
+		} else {
+			s.buckets = append(s.buckets, bucket{second: second, total: 1, bad: bad})
+		}
+		s.total++
+		s.bad += bad
+		if s.first.IsZero() {
+			s.first = now
+		}
+	}
+}
+
+// Evaluate updates the objectives' windows at now and returns their status
+func (t *Tracker) Evaluate(now time.Time) []Status {
+	statuses := make([]Status, 0, len(t.states))
+	for _, s := range t.states {
+		s.prune(now)
+		statuses = append(statuses, s.status(now))
+	}
+	return statuses
+}
+
+// status computes the budget used and the projected breach of an objective
+func (s *objectiveState) status(now time.Time) Status {
+	o := s.objective
+	status := Status{Objective: o, Total: s.total, Bad: s.bad}
+	if s.total == 0 {
+		return status
+	}
+	status.Rate = float64(s.bad) / float64(s.total)
+	status.BudgetUsed = status.Rate / o.Target
+	status.Breached = status.Rate > o.Target
+
+	// Rates over the recent window, or since the first entry if that is later
+	recent := o.recentWindow()
+	if elapsed := now.Sub(s.first); elapsed < recent {
+		recent = max(elapsed, time.Second)
+	}
+	since := now.Add(-recent).Unix()
+	recentTotal, recentBad := 0, 0
+	for i := len(s.buckets) - 1; i >= 0 && s.buckets[i].second > since; i-- {
+		recentTotal += s.buckets[i].total
+		recentBad += s.buckets[i].bad
+	}
+	if recentTotal < minRecentEntries {
+		return status
+	}
+	status.BurnRate = float64(recentBad) / float64(recentTotal) / o.Target
+
+	// The budget left shrinks by the bad entries beyond the target's share
+	// of the new ones
+	seconds := recent.Seconds()
+	left := o.Target*float64(s.total) - float64(s.bad)
+	shrinking := (float64(recentBad) - o.Target*float64(recentTotal)) / seconds
+	if !status.Breached && left > 0 && shrinking > 0 {
+		breachIn := time.Duration(math.Ceil(left/shrinking)) * time.Second
+		if breachIn <= o.Window {
+			status.BreachIn = breachIn
+		}
+	}
+	return status
+}
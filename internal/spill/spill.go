@@ -0,0 +1,164 @@
+// Package spill keeps entries evicted from the in-memory buffer on disk, so
+// scrollback can reach far beyond what fits in RAM. Entries are appended to
+// segment files of a temporary directory and read back by index; when the
+// directory outgrows its budget, the oldest segment is deleted.
+package spill
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// segmentEntries is how many entries one segment file holds
+const segmentEntries = 100000
+
+// segment is one file of consecutive entries
+type segment struct {
+	path    string
+	file    *os.File
+	first   int     // Index of its first entry
+	offsets []int64 // Start of each entry, followed by the end of the last
+}
+
+// count returns how many entries the segment holds
+func (s *segment) count() int {
+	return len(s.offsets) - 1
+}
+
+// Store is an append-only sequence of entries on disk, indexed from 0 in
+// the order they were appended. It is not safe for concurrent use.
+type Store struct {
+	dir      string
+	maxBytes int64
+	segments []*segment // Oldest first
+	writer   *bufio.Writer
+	next     int   // Index of the next entry
+	size     int64 // Bytes in the segments
+}
+
+// Open creates a store in a new temporary directory under parent, or the
+// system's temporary directory when empty, that keeps up to maxBytes
+func Open(parent string, maxBytes int64) (*Store, error) {
+	dir, err := os.MkdirTemp(parent, "gonzo-spill-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spill directory: %w", err)
+	}
+	return &Store{dir: dir, maxBytes: maxBytes}, nil
+}
+
+// Dir returns the directory holding the segment files
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// First returns the index of the oldest entry still on disk
+func (s *Store) First() int {
+	if len(s.segments) == 0 {
+		return s.next
+	}
+	return s.segments[0].first
+}
+
+// Len returns the index the next entry gets: every entry ever appended,
+// including those whose segment was deleted
+func (s *Store) Len() int {
+	return s.next
+}
+
+// Size returns the bytes on disk
+func (s *Store) Size() int64 {
+	return s.size
+}
+
+// Append adds an entry, which must not contain a newline
+func (s *Store) Append(data []byte) error {
+	if len(s.segments) == 0 || s.segments[len(s.segments)-1].count() >= segmentEntries {
+		if err := s.startSegment(); err != nil {
+			return err
+		}
+	}
+	current := s.segments[len(s.segments)-1]
+	if _, err := s.writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write spill segment: %w", err)
+	}
+	if err := s.writer.WriteByte('\n'); err != nil {
+		return fmt.Errorf("failed to write spill segment: %w", err)
+	}
+	n := int64(len(data) + 1)
+	current.offsets = append(current.offsets, current.offsets[len(current.offsets)-1]+n)
+	s.size += n
+	s.next++
+
+	// Keep the segment being written, even when it alone is over budget
+	for s.maxBytes > 0 && s.size > s.maxBytes && len(s.segments) > 1 {
+		s.removeOldest()
+	}
+	return nil
+}
+
+// startSegment closes the current segment for writing and opens a new one
+func (s *Store) startSegment() error {
+	if s.writer != nil {
+		if err := s.writer.Flush(); err != nil {
+			return fmt.Errorf("failed to write spill segment: %w", err)
+		}
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("segment-%06d.jsonl", s.next/segmentEntries))
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create spill segment: %w", err)
+	}
+	s.segments = append(s.segments, &segment{path: path, file: file, first: s.next, offsets: []int64{0}})
+	s.writer = bufio.NewWriter(file)
+	return nil
+}
+
+// removeOldest deletes the oldest segment
+func (s *Store) removeOldest() {
+	oldest := s.segments[0]
+	s.segments = s.segments[1:]
+	s.size -= oldest.offsets[len(oldest.offsets)-1]
+	oldest.file.Close()
+	os.Remove(oldest.path)
+}
+
+// Read returns the entries from start up to end, leaving out those no
+// longer on disk
+func (s *Store) Read(start, end int) ([][]byte, error) {
+	start, end = max(start, s.First()), min(end, s.next)
+	if start >= end {
+		return nil, nil
+	}
+	// Entries being written may still sit in the buffer
+	if err := s.writer.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write spill segment: %w", err)
+	}
+
+	records := make([][]byte, 0, end-start)
+	for i := start; i < end; {
+		seg := s.segments[(i-s.First())/segmentEntries]
+		from, to := i-seg.first, min(end-seg.first, seg.count())
+		buf := make([]byte, seg.offsets[to]-seg.offsets[from])
+		if _, err := seg.file.ReadAt(buf, seg.offsets[from]); err != nil {
+			return nil, fmt.Errorf("failed to read spill segment: %w", err)
+		}
+		for k := from; k < to; k++ {
+			// Slice each entry out of the block, without its newline
+			lo, hi := seg.offsets[k]-seg.offsets[from], seg.offsets[k+1]-seg.offsets[from]-1
+			records = append(records, buf[lo:hi:hi])
+		}
+		i = seg.first + to
+	}
+	return records, nil
+}
+
+// Close deletes the segment files and their directory
+func (s *Store) Close() error {
+	for _, seg := range s.segments {
+		seg.file.Close()
+	}
+	s.segments = nil
+	return os.RemoveAll(s.dir)
+}
@@ -0,0 +1,298 @@
+// Package synth generates log lines of made-up services: a mix of formats,
+// severities and message patterns with attribute values of a chosen
+// cardinality, to load gonzo's ingest pipeline with traffic shaped like the
+// real thing.
+package synth
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of the generated lines
+const (
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+	FormatText   = "text"
+	FormatOTLP   = "otlp"
+)
+
+// Formats lists the formats lines can be generated in
+var Formats = []string{FormatJSON, FormatLogfmt, FormatText, FormatOTLP}
+
+// Defaults of a Config left zero
+const (
+	DefaultServices    = 10
+	DefaultPatterns    = 50
+	DefaultCardinality = 1000
+)
+
+// linesPerRequest is how many lines in a row share a request_id
+const linesPerRequest = 4
+
+// Config shapes the generated traffic
+type Config struct {
+	Formats     []string // Formats the lines take in turn (default: JSON)
+	Services    int      // Distinct services logging
+	Patterns    int      // Distinct message patterns
+	Cardinality int      // Distinct values of the user_id attribute
+	Seed        uint64   // Seeds the choices, so a run can be repeated
+	Kubernetes  bool     // Adds the namespace, pod, container and node of the service
+	RequestIDs  bool     // Adds a request_id shared by a few lines in a row
+}
+
+// Words the message patterns are made of and the services named after
+var (
+	verbs      = []string{"processed", "failed to process", "retrying", "received", "completed", "rejected", "cached", "timed out on"}
+	objects    = []string{"order", "payment", "request", "session", "invoice", "upload", "query", "message", "token", "report"}
+	stages     = []string{"validation", "storage", "dispatch", "billing", "auth", "search", "checkout", "shipping"}
+	services   = []string{"checkout", "payments", "inventory", "frontend", "auth", "search", "shipping", "email", "ads", "recommendations"}
+	namespaces = []string{"shop", "payments", "platform"}
+	failures   = []string{"upstream connect error", "context deadline exceeded", "connection refused", "too many open files", "database is locked"}
+)
+
+// podAlphabet is what Kubernetes draws pod name suffixes from
+const podAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+
+// Workload is a service as deployed: its namespace, the revision of its
+// Deployment and the pods of that revision
+type Workload struct {
+	Service   string
+	Namespace string
+	Revision  int
+	Pods      []string
+}
+
+// Generator generates lines. It is not safe for concurrent use.
+type Generator struct {
+	cfg         Config
+	formats     []string
+	workloads   []Workload
+	patterns    []string
+	nodes       []string
+	cardinality int
+	rng         *rand.Rand
+}
+
+// record is what a line says, before it takes a format
+type record struct {
+	severity string
+	workload *Workload
+	pod      string
+	message  string
+	user     string
+	request  string
+	duration int
+	status   int
+}
+
+// New creates a generator, checking the formats
+func New(cfg Config) (*Generator, error) {
+	formats := slices.Clone(cfg.Formats)
+	if len(formats) == 0 {
+		formats = []string{FormatJSON}
+	}
+	for i, format := range formats {
+		formats[i] = strings.ToLower(strings.TrimSpace(format))
+		switch formats[i] {
+		case FormatJSON, FormatLogfmt, FormatText, FormatOTLP:
+		default:
+			return nil, fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(Formats, ", "))
+		}
+	}
+
+	g := &Generator{
+		cfg:         cfg,
+		formats:     formats,
+		cardinality: max(1, orDefault(cfg.Cardinality, DefaultCardinality)),
+		rng:         rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15)),
+	}
+	for i := range 4 {
+		g.nodes = append(g.nodes, fmt.Sprintf("ip-10-0-%d-%d.ec2.internal", i+1, 17+i*29))
+	}
+	for i := range max(1, orDefault(cfg.Services, DefaultServices)) {
+		w := Workload{Service: numbered(services, i), Namespace: namespaces[i%len(namespaces)], Revision: 1}
+		g.deploy(&w, 2+i%2)
+		g.workloads = append(g.workloads, w)
+	}
+	perStage := len(verbs) * len(objects)
+	for i := range max(1, orDefault(cfg.Patterns, DefaultPatterns)) {
+		stage := numbered(stages, i%len(stages)+len(stages)*(i/perStage))
+		g.patterns = append(g.patterns, fmt.Sprintf("%s %s in %s", verbs[i%len(verbs)], objects[i/len(verbs)%len(objects)], stage))
+	}
+	return g, nil
+}
+
+// orDefault returns n, or def when n is zero
+func orDefault(n, def int) int {
+	if n == 0 {
+		return def
+	}
+	return n
+}
+
+// numbered returns the i-th of names, suffixed with letters once they run
+// out so every name stays distinct: checkout, ..., checkout-b
+func numbered(names []string, i int) string {
+	name := names[i%len(names)]
+	for round := i / len(names); round > 0; round /= 26 {
+		name += "-" + string(rune('a'+round%26))
+	}
+	return name
+}
+
+// deploy names replicas pods of the workload's revision, as a Deployment's
+// ReplicaSet does: the service, a hash of the revision and a random suffix
+func (g *Generator) deploy(w *Workload, replicas int) {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%d", w.Service, w.Revision)
+	hash := strconv.FormatUint(uint64(h.Sum32()), 36)
+
+	w.Pods = nil
+	for range replicas {
+		suffix := make([]byte, 5)
+		for i := range suffix {
+			suffix[i] = podAlphabet[g.rng.IntN(len(podAlphabet))]
+		}
+		w.Pods = append(w.Pods, w.Service+"-"+hash+"-"+string(suffix))
+	}
+}
+
+// Workloads returns the services logging, as deployed now
+func (g *Generator) Workloads() []Workload {
+	workloads := slices.Clone(g.workloads)
+	for i := range workloads {
+		workloads[i].Pods = slices.Clone(workloads[i].Pods)
+	}
+	return workloads
+}
+
+// Rollout deploys a new revision of the i-th service, whose lines come from
+// new pods from then on, and returns it
+func (g *Generator) Rollout(i int) Workload {
+	w := &g.workloads[i%len(g.workloads)]
+	w.Revision++
+	g.deploy(w, len(w.Pods))
+	return g.Workloads()[i%len(g.workloads)]
+}
+
+// node returns the node a pod runs on
+func (g *Generator) node(pod string) string {
+	h := fnv.New32a()
+	h.Write([]byte(pod))
+	return g.nodes[h.Sum32()%uint32(len(g.nodes))]
+}
+
+// severity picks a severity: mostly INFO, with some DEBUG, WARN and ERROR
+func (g *Generator) severity() string {
+	switch n := g.rng.IntN(100); {
+	case n < 5:
+		return "ERROR"
+	case n < 15:
+		return "WARN"
+	case n < 30:
+		return "DEBUG"
+	}
+	return "INFO"
+}
+
+// newRecord picks what the seq-th line of a workload says
+func (g *Generator) newRecord(seq uint64, w *Workload, severity string) record {
+	r := record{
+		severity: severity,
+		workload: w,
+		pod:      w.Pods[g.rng.IntN(len(w.Pods))],
+		user:     "user-" + strconv.Itoa(g.rng.IntN(g.cardinality)),
+		duration: 1 + g.rng.IntN(500),
+		status:   200,
+	}
+	if g.cfg.RequestIDs {
+		r.request = fmt.Sprintf("req-%08x", (seq/linesPerRequest+1)*2654435761%(1<<32))
+	}
+	if severity == "ERROR" {
+		r.status = 500 + g.rng.IntN(4)
+	}
+	r.message = fmt.Sprintf("%s for %s after %dms", g.patterns[g.rng.IntN(len(g.patterns))], r.user, r.duration)
+	return r
+}
+
+// Line generates the seq-th line, logged at now
+func (g *Generator) Line(seq uint64, now time.Time) string {
+	w := &g.workloads[g.rng.IntN(len(g.workloads))]
+	return g.format(seq, now, g.newRecord(seq, w, g.severity()))
+}
+
+// ErrorLine generates the seq-th line as an error of the i-th service
+// failing slowly, as in an incident
+func (g *Generator) ErrorLine(seq uint64, now time.Time, i int) string {
+	r := g.newRecord(seq, &g.workloads[i%len(g.workloads)], "ERROR")
+	r.duration = 1000 + g.rng.IntN(4000)
+	r.status = 503
+	r.message = fmt.Sprintf("%s: %s after %dms", g.patterns[g.rng.IntN(len(g.patterns))], failures[g.rng.IntN(len(failures))], r.duration)
+	return g.format(seq, now, r)
+}
+
+// format renders a record in the seq-th line's format
+func (g *Generator) format(seq uint64, now time.Time, r record) string {
+	service := r.workload.Service
+	switch g.formats[seq%uint64(len(g.formats))] {
+	case FormatLogfmt:
+		return fmt.Sprintf("ts=%s level=%s service=%s msg=%q user_id=%s duration_ms=%d status=%d%s",
+			now.Format(time.RFC3339Nano), strings.ToLower(r.severity), service, r.message, r.user, r.duration, r.status, g.logfmtExtra(r))
+	case FormatText:
+		return fmt.Sprintf("%s %s [%s] %s status=%d", now.Format(time.RFC3339Nano), r.severity, service, r.message, r.status)
+	case FormatOTLP:
+		return fmt.Sprintf(`{"timeUnixNano":"%d","severityText":%q,"body":{"stringValue":%q},"attributes":[{"key":"service.name","value":{"stringValue":%q}},{"key":"user_id","value":{"stringValue":%q}},{"key":"duration_ms","value":{"intValue":"%d"}}%s]}`,
+			now.UnixNano(), r.severity, r.message, service, r.user, r.duration, g.otlpExtra(r))
+	}
+	return fmt.Sprintf(`{"timestamp":%q,"level":%q,"service":%q,"msg":%q,"user_id":%q,"duration_ms":%d,"status":%d%s}`,
+		now.Format(time.RFC3339Nano), strings.ToLower(r.severity), service, r.message, r.user, r.duration, r.status, g.jsonExtra(r))
+}
+
+// extra returns the attributes Config adds to a record, in order
+func (g *Generator) extra(r record) [][2]string {
+	var attrs [][2]string
+	if g.cfg.RequestIDs {
+		attrs = append(attrs, [2]string{"request_id", r.request})
+	}
+	if g.cfg.Kubernetes {
+		attrs = append(attrs,
+			[2]string{"k8s.namespace", r.workload.Namespace},
+			[2]string{"k8s.pod", r.pod},
+			[2]string{"k8s.container", r.workload.Service},
+			[2]string{"k8s.node.name", g.node(r.pod)})
+	}
+	return attrs
+}
+
+// jsonExtra renders the added attributes as JSON fields
+func (g *Generator) jsonExtra(r record) string {
+	var b strings.Builder
+	for _, attr := range g.extra(r) {
+		fmt.Fprintf(&b, ",%q:%q", attr[0], attr[1])
+	}
+	return b.String()
+}
+
+// logfmtExtra renders the added attributes as logfmt pairs
+func (g *Generator) logfmtExtra(r record) string {
+	var b strings.Builder
+	for _, attr := range g.extra(r) {
+		fmt.Fprintf(&b, " %s=%s", attr[0], attr[1])
+	}
+	return b.String()
+}
+
+// otlpExtra renders the added attributes as OTLP attributes
+func (g *Generator) otlpExtra(r record) string {
+	var b strings.Builder
+	for _, attr := range g.extra(r) {
+		fmt.Fprintf(&b, `,{"key":%q,"value":{"stringValue":%q}}`, attr[0], attr[1])
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+// Package timerange parses the --since and --until bounds of the entries
+// gonzo ingests: absolute times, or durations back from now such as 90m or
+// 2d.
+package timerange
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// layouts are the absolute times a bound may be given as, without a zone
+// meaning local time
+var layouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// clockLayouts are times of day, meaning today
+var clockLayouts = []string{"15:04:05", "15:04"}
+
+// Range bounds entries by time. A zero bound is open.
+type Range struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Parse parses the since and until bounds, resolving durations against now
+func Parse(since, until string, now time.Time) (Range, error) {
+	var r Range
+	var err error
+	if r.Since, err = ParseBound(since, now); err != nil {
+		return Range{}, fmt.Errorf("invalid --since: %w", err)
+	}
+	if r.Until, err = ParseBound(until, now); err != nil {
+		return Range{}, fmt.Errorf("invalid --until: %w", err)
+	}
+	if !r.Since.IsZero() && !r.Until.IsZero() && r.Until.Before(r.Since) {
+		return Range{}, fmt.Errorf("--until %s is before --since %s", until, since)
+	}
+	return r, nil
+}
+
+// ParseBound parses one bound: a duration back from now such as 15m, 36h or
+// 2d, an RFC 3339 time, a local date and time such as 2026-01-02 15:04, or a
+// time of day today such as 15:04. An empty bound is zero.
+func ParseBound(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if ago, ok := parseAgo(value); ok {
+		return now.Add(-ago), nil
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range clockLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			year, month, day := now.Date()
+			return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), 0, now.Location()), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a duration such as 15m or 2d, nor a time such as 2026-01-02T15:04:05Z, 2026-01-02 15:04 or 15:04", value)
+}
+
+// parseAgo parses a non-negative duration, also in whole days such as 2d
+func parseAgo(value string) (time.Duration, bool) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		return time.Duration(n) * 24 * time.Hour, true
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// IsZero reports whether the range has no bounds
+func (r Range) IsZero() bool {
+	return r.Since.IsZero() && r.Until.IsZero()
+}
+
+// Contains reports whether t is within the range, bounds included
+func (r Range) Contains(t time.Time) bool {
+	return (r.Since.IsZero() || !t.Before(r.Since)) && (r.Until.IsZero() || !t.After(r.Until))
+}
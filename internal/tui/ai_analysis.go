@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/ai"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// samplingMaskRegex matches the variable parts of a message (hex ids, UUIDs,
+// numbers) so repeats of the same message group together when sampling
+var samplingMaskRegex = regexp.MustCompile(`0x[0-9a-fA-F]+|\b[0-9a-fA-F-]*\d[0-9a-fA-F-]*\b|\d+`)
+
+// aiStreamMsg carries one piece of a streamed AI analysis. The id ties it to
+// the analysis it belongs to so chunks of a closed analysis are dropped.
+type aiStreamMsg struct {
+	id    int
+	chunk string
+	err   error
+	done  bool
+	ch    <-chan aiStreamMsg
+}
+
+// SetAIBatchPrompt overrides the prompt template used to analyze a selection
+// or the filtered view. See ai.DefaultBatchPrompt for the placeholders.
+func (m *DashboardModel) SetAIBatchPrompt(tmpl string) {
+	m.aiBatchPrompt = tmpl
+}
+
+// analyzeSelection sends the selected entries to the AI as one batch
+func (m *DashboardModel) analyzeSelection() (tea.Model, tea.Cmd) {
+	entries := m.selectedEntries()
+	m.clearVisualSelection()
+	return m.analyzeEntries(entries, "a selection of consecutive entries")
+}
+
+// analyzeView sends the visual selection, or else the entries of the
+// filtered view, to the AI as one batch
+func (m *DashboardModel) analyzeView() (tea.Model, tea.Cmd) {
+	if m.visualMode {
+		return m.analyzeSelection()
+	}
+	scope := "the most recent entries in the buffer"
+	if len(m.activeFilterChips()) > 0 {
+		scope = "the entries matching the active filters"
+	}
+	return m.analyzeEntries(m.logEntries, scope)
+}
+
+// analyzeEntries samples the entries, builds the batch prompt and streams
+// the model's reply into the info modal
+func (m *DashboardModel) analyzeEntries(entries []LogEntry, scope string) (tea.Model, tea.Cmd) {
+	if len(entries) == 0 {
+		return m, nil
+	}
+	if m.aiClient == nil {
+		m.showBulkResult(fmt.Sprintf("AI Analysis Not Available\n\nError: %s", m.aiErrorMessage))
+		return m, nil
+	}
+	if m.aiAnalyzing {
+		return m, nil
+	}
+
+	lines, sampling := sampleForAnalysis(entries, maxBulkAnalysisEntries)
+	var filters []string
+	for _, chip := range m.activeFilterChips() {
+		filters = append(filters, chip.label)
+	}
+	prompt := ai.BuildBatchPrompt(m.aiBatchPrompt, ai.BatchPromptData{
+		Scope:    scope,
+		Total:    len(entries),
+		Filter:   strings.Join(filters, ", "),
+		Sampling: sampling,
+		Lines:    lines,
+	})
+
+	title := fmt.Sprintf("AI Analysis of %d Entries", len(entries))
+	if sampling != "" {
+		title += fmt.Sprintf(" (%d sampled lines)", len(lines))
+	}
+	return m, m.streamAnalysis(title, prompt)
+}
+
+// streamAnalysis opens the info modal under title and streams the reply to
+// prompt into it
+func (m *DashboardModel) streamAnalysis(title, prompt string) tea.Cmd {
+	m.aiStreamID++
+	ctx, cancel := context.WithCancel(context.Background())
+	m.aiStreamCancel = cancel
+	m.aiStreamTitle = title
+	m.aiStreamText = ""
+	m.aiAnalyzing = true
+	m.showLogViewerModal = false
+	m.showBulkResult(m.aiStreamTitle + "\n\nAnalyzing...")
+	m.infoViewport.GotoTop()
+
+	return startAIStream(ctx, m.aiClient, prompt, m.aiStreamID)
+}
+
+// startAIStream runs the streamed request in the background, handing each
+// chunk to the update loop through a channel
+func startAIStream(ctx context.Context, client *ai.OpenAIClient, prompt string, id int) tea.Cmd {
+	ch := make(chan aiStreamMsg)
+	go func() {
+		defer close(ch)
+		send := func(msg aiStreamMsg) {
+			select {
+			case ch <- msg:
+			case <-ctx.Done():
+			}
+		}
+		err := client.StreamPrompt(ctx, prompt, func(chunk string) {
+			send(aiStreamMsg{id: id, chunk: chunk})
+		})
+		send(aiStreamMsg{id: id, err: err, done: true})
+	}()
+	return waitForAIStream(ch)
+}
+
+// waitForAIStream returns a command that delivers the next chunk of the stream
+func waitForAIStream(ch <-chan aiStreamMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		msg.ch = ch
+		return msg
+	}
+}
+
+// handleAIStream appends a streamed chunk to the analysis modal
+func (m *DashboardModel) handleAIStream(msg aiStreamMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.aiStreamID || m.aiStreamCancel == nil {
+		return m, nil
+	}
+
+	followBottom := m.infoViewport.AtBottom()
+	m.aiStreamText += msg.chunk
+	if msg.done {
+		m.stopAIStream()
+		if msg.err != nil && !errors.Is(msg.err, context.Canceled) {
+			if m.aiStreamText != "" {
+				m.aiStreamText += "\n\n"
+			}
+			m.aiStreamText += fmt.Sprintf("Error: %v", msg.err)
+		}
+	}
+
+	body := m.aiStreamText
+	if body == "" {
+		body = "Analyzing..."
+	}
+	m.modalContent = m.aiStreamTitle + "\n\n" + ansi.Wrap(body, max(20, m.width-14), "")
+	if followBottom {
+		m.infoViewport.SetContent(m.modalContent)
+		m.infoViewport.GotoBottom()
+	}
+
+	if msg.done {
+		return m, nil
+	}
+	return m, waitForAIStream(msg.ch)
+}
+
+// stopAIStream cancels a running streamed analysis, e.g. when its modal is
+// closed before the reply is complete
+func (m *DashboardModel) stopAIStream() {
+	if m.aiStreamCancel == nil {
+		return
+	}
+	m.aiStreamCancel()
+	m.aiStreamCancel = nil
+	m.aiAnalyzing = false
+}
+
+// sampleForAnalysis turns entries into at most limit log lines for the AI.
+// When there are too many, repeats of the same message are collapsed into
+// their first and last occurrence with a count, and errors are kept ahead of
+// frequent messages. The
+// lines stay in chronological order; the returned note describes the
+// sampling and is empty when every entry is included.
+func sampleForAnalysis(entries []LogEntry, limit int) ([]string, string) {
+	if len(entries) <= limit {
+		lines := make([]string, len(entries))
+		for i, entry := range entries {
+			lines[i] = PlainLogLine(entry)
+		}
+		return lines, ""
+	}
+
+	type messageGroup struct {
+		first int
+		last  int
+		count int
+		isErr bool
+	}
+	groups := make(map[string]*messageGroup)
+	var keys []string
+	for i, entry := range entries {
+		key := entry.Severity + " " + samplingMaskRegex.ReplaceAllString(entry.Message, "#")
+		if group, ok := groups[key]; ok {
+			group.last = i
+			group.count++
+			continue
+		}
+		severity := strings.ToUpper(entry.Severity)
+		groups[key] = &messageGroup{
+			first: i,
+			last:  i,
+			count: 1,
+			isErr: severity == "ERROR" || severity == "FATAL" || severity == "CRITICAL",
+		}
+		keys = append(keys, key)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := groups[keys[i]], groups[keys[j]]
+		if a.isErr != b.isErr {
+			return a.isErr
+		}
+		return a.count > b.count
+	})
+	omitted := 0
+	if len(keys) > limit {
+		omitted = len(keys) - limit
+		keys = keys[:limit]
+	}
+
+	// One line per kind of message, plus its last occurrence while there is
+	// room, so the model sees how long each message kept repeating
+	counts := make(map[int]int)
+	for _, key := range keys {
+		counts[groups[key].first] = groups[key].count
+	}
+	for _, key := range keys {
+		if len(counts) >= limit {
+			break
+		}
+		if group := groups[key]; group.count > 1 {
+			counts[group.last] = 0
+		}
+	}
+	indexes := make([]int, 0, len(counts))
+	for index := range counts {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	lines := make([]string, len(indexes))
+	for i, index := range indexes {
+		lines[i] = PlainLogLine(entries[index])
+		if counts[index] > 1 {
+			lines[i] += fmt.Sprintf(" (×%d similar)", counts[index])
+		}
+	}
+
+	note := fmt.Sprintf("The %d entries were sampled: repeated messages are collapsed into their first occurrence with a ×N count plus their last occurrence, and errors are kept first.", len(entries))
+	if omitted > 0 {
+		note += fmt.Sprintf(" %d rarer kinds of message were left out.", omitted)
+	}
+	return lines, note
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/alerts"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetAlertRules sets the alert rules loaded from configuration
+func (m *DashboardModel) SetAlertRules(rules []alerts.Rule) {
+	if len(rules) == 0 {
+		m.alertEngine = nil
+		m.activeAlerts = nil
+		return
+	}
+	m.alertEngine = alerts.NewEngine(rules)
+}
+
+// observeAlerts counts a new entry against the alert rules. Every arrival is
+// counted, whatever the view's filters and even while paused.
+func (m *DashboardModel) observeAlerts(entry LogEntry) {
+	if m.alertEngine == nil {
+		return
+	}
+	m.alertEngine.Observe(m.filterRecord(entry), PlainLogLine(entry), time.Now())
+}
+
+// checkAlerts updates the active alerts and returns a command that runs the
+// actions of the ones that just fired
+func (m *DashboardModel) checkAlerts() tea.Cmd {
+	if m.alertEngine == nil {
+		return nil
+	}
+	var fired []alerts.Alert
+	m.activeAlerts, fired = m.alertEngine.Evaluate(time.Now())
+	if len(fired) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		for _, alert := range fired {
+			if alert.Rule.Has(alerts.ActionBell) {
+				// A single write, so it cannot split a frame being rendered
+				os.Stdout.WriteString("\a")
+			}
+			for _, err := range alerts.Dispatch(alert) {
+				log.Printf("Warning: alert %s: %v", alert.Rule.Name, err)
+			}
+		}
+		return nil
+	}
+}
+
+// alertBanner describes the active alerts shown in the status line, and the
+// SLOs that are breached or projected to be
+func (m *DashboardModel) alertBanner() string {
+	var summaries []string
+	for _, alert := range m.activeAlerts {
+		if alert.Rule.Has(alerts.ActionBanner) {
+			summaries = append(summaries, alert.Summary())
+		}
+	}
+	if banner := m.sloBanner(); banner != "" {
+		summaries = append(summaries, banner)
+	}
+	if len(summaries) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("⚠ ALERT %s", strings.Join(summaries, " • "))
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// ansiBasicColors are the xterm default RGB values of the 16 basic colors
+var ansiBasicColors = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansiStyle is the SGR state while converting styled text
+type ansiStyle struct {
+	fg, bg                     string
+	bold, italic, underline    bool
+	faint, reverse, crossedOut bool
+}
+
+// css renders the style as an inline CSS declaration list
+func (s ansiStyle) css() string {
+	fg, bg := s.fg, s.bg
+	if s.reverse {
+		fg, bg = bg, fg
+		if fg == "" {
+			fg = "#1e1e1e"
+		}
+		if bg == "" {
+			bg = "#d4d4d4"
+		}
+	}
+
+	var parts []string
+	if fg != "" {
+		parts = append(parts, "color:"+fg)
+	}
+	if bg != "" {
+		parts = append(parts, "background-color:"+bg)
+	}
+	if s.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if s.faint {
+		parts = append(parts, "opacity:0.7")
+	}
+	if s.italic {
+		parts = append(parts, "font-style:italic")
+	}
+	if s.underline && s.crossedOut {
+		parts = append(parts, "text-decoration:underline line-through")
+	} else if s.underline {
+		parts = append(parts, "text-decoration:underline")
+	} else if s.crossedOut {
+		parts = append(parts, "text-decoration:line-through")
+	}
+	return strings.Join(parts, ";")
+}
+
+// ansi256Color converts an xterm 256 color index to a CSS color
+func ansi256Color(index int) string {
+	switch {
+	case index < 0 || index > 255:
+		return ""
+	case index < 16:
+		return ansiBasicColors[index]
+	case index < 232:
+		index -= 16
+		levels := []int{0, 95, 135, 175, 215, 255}
+		return fmt.Sprintf("#%02x%02x%02x", levels[index/36], levels[(index/6)%6], levels[index%6])
+	default:
+		gray := 8 + (index-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// applySGR updates the style with the parameters of one SGR sequence
+func (s *ansiStyle) applySGR(params string) {
+	if params == "" {
+		*s = ansiStyle{}
+		return
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 7:
+			s.reverse = true
+		case code == 9:
+			s.crossedOut = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.reverse = false
+		case code == 29:
+			s.crossedOut = false
+		case code >= 30 && code <= 37:
+			s.fg = ansiBasicColors[code-30]
+		case code >= 90 && code <= 97:
+			s.fg = ansiBasicColors[code-90+8]
+		case code == 39:
+			s.fg = ""
+		case code >= 40 && code <= 47:
+			s.bg = ansiBasicColors[code-40]
+		case code >= 100 && code <= 107:
+			s.bg = ansiBasicColors[code-100+8]
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			// Extended colors: 5;n for 256 colors, 2;r;g;b for true color
+			var color string
+			if i+2 < len(codes) && codes[i+1] == "5" {
+				n, _ := strconv.Atoi(codes[i+2])
+				color = ansi256Color(n)
+				i += 2
+			} else if i+4 < len(codes) && codes[i+1] == "2" {
+				r, _ := strconv.Atoi(codes[i+2])
+				g, _ := strconv.Atoi(codes[i+3])
+				b, _ := strconv.Atoi(codes[i+4])
+				color = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+				i += 4
+			}
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// ansiToHTML converts text styled with ANSI SGR escape sequences to HTML
+// spans with inline styles. Other escape sequences are dropped.
+func ansiToHTML(text string) string {
+	var out strings.Builder
+	var style ansiStyle
+	var segment strings.Builder
+
+	flush := func() {
+		if segment.Len() == 0 {
+			return
+		}
+		escaped := html.EscapeString(segment.String())
+		if css := style.css(); css != "" {
+			fmt.Fprintf(&out, `<span style="%s">%s</span>`, css, escaped)
+		} else {
+			out.WriteString(escaped)
+		}
+		segment.Reset()
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != 0x1b {
+			segment.WriteByte(text[i])
+			continue
+		}
+		if i+1 >= len(text) {
+			break
+		}
+
+		switch text[i+1] {
+		case '[':
+			// CSI: parameters up to a final byte in the 0x40-0x7e range
+			j := i + 2
+			for j < len(text) && (text[j] < 0x40 || text[j] > 0x7e) {
+				j++
+			}
+			if j >= len(text) {
+				i = len(text)
+				continue
+			}
+			if text[j] == 'm' {
+				flush()
+				style.applySGR(text[i+2 : j])
+			}
+			i = j
+		case ']':
+			// OSC (e.g. hyperlinks): skip to BEL or ST
+			j := i + 2
+			for j < len(text) && text[j] != 0x07 && !(text[j] == 0x1b && j+1 < len(text) && text[j+1] == '\\') {
+				j++
+			}
+			if j < len(text) && text[j] == 0x1b {
+				j++
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	flush()
+	return out.String()
+}
+
+// renderHTMLDocument wraps ANSI styled lines in a standalone HTML page
+func renderHTMLDocument(title string, lines []string) string {
+	var out strings.Builder
+	out.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&out, "<title>%s</title>\n", html.EscapeString(title))
+	out.WriteString("<style>\nbody { background-color: #1e1e1e; color: #d4d4d4; margin: 0; }\n")
+	out.WriteString("pre { font-family: Menlo, Consolas, 'DejaVu Sans Mono', monospace; font-size: 13px; line-height: 1.25; padding: 12px; margin: 0; }\n")
+	out.WriteString("</style>\n</head>\n<body>\n<pre>")
+	for i, line := range lines {
+		if i > 0 {
+			out.WriteByte('\n')
+		}
+		out.WriteString(ansiToHTML(line))
+	}
+	out.WriteString("</pre>\n</body>\n</html>\n")
+	return out.String()
+}
@@ -0,0 +1,77 @@
+package tui
+
+import "fmt"
+
+// severityFilterKey is the pseudo attribute key used for severity filters
+// created from a selected entry
+const severityFilterKey = "severity"
+
+// attributeFilter includes or excludes entries by an exact attribute value
+type attributeFilter struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Exclude bool   `json:"exclude,omitempty"`
+}
+
+// String renders the filter as key=value or key!=value
+func (f attributeFilter) String() string {
+	op := "="
+	if f.Exclude {
+		op = "!="
+	}
+	return fmt.Sprintf("%s%s%s", f.Key, op, f.Value)
+}
+
+// matches reports whether the entry has the filter's value for its key
+func (f attributeFilter) matches(entry LogEntry) bool {
+	if f.Key == severityFilterKey {
+		return normalizeSeverityLevel(entry.Severity) == f.Value
+	}
+	value, ok := entry.Attributes[f.Key]
+	return ok && value == f.Value
+}
+
+// passesAttributeFilters checks an entry against the attribute filters.
+// Include filters on the same key are OR'ed together and different keys are
+// AND'ed; any matching exclude filter rejects the entry.
+func (m *DashboardModel) passesAttributeFilters(entry LogEntry) bool {
+	if len(m.attributeFilters) == 0 {
+		return true
+	}
+
+	includeKeys := make(map[string]bool) // key -> matched any include
+	for _, filter := range m.attributeFilters {
+		if filter.Exclude {
+			if filter.matches(entry) {
+				return false
+			}
+			continue
+		}
+		if filter.matches(entry) {
+			includeKeys[filter.Key] = true
+		} else if _, seen := includeKeys[filter.Key]; !seen {
+			includeKeys[filter.Key] = false
+		}
+	}
+
+	for _, matched := range includeKeys {
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// addAttributeFilter adds a filter unless an identical one is already active.
+// Adding the opposite of an existing filter replaces it.
+func (m *DashboardModel) addAttributeFilter(filter attributeFilter) {
+	for i, existing := range m.attributeFilters {
+		if existing.Key == filter.Key && existing.Value == filter.Value {
+			m.attributeFilters[i] = filter
+			m.updateFilteredView()
+			return
+		}
+	}
+	m.attributeFilters = append(m.attributeFilters, filter)
+	m.updateFilteredView()
+}
@@ -50,6 +50,9 @@ func (m *DashboardModel) renderAttributesChart(width, height int) string {
 		content = helpStyle.Render("No data available")
 	}
 
+	// Fit the content to the chart height chosen in the layout
+	content = clipLines(content, height-1, false)
+
 	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
 }
 
@@ -105,13 +108,10 @@ func (m *DashboardModel) renderAttributesContent(chartWidth int, attributes []*m
 		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 
 		// Truncate long keys to fit dynamic label width
-		key := entry.Key
-		if len(key) > labelWidth {
-			key = key[:labelWidth-3] + "..."
-		}
+		key := padToWidth(truncateToWidth(entry.Key, labelWidth), labelWidth)
 
-		// Dynamic format string with calculated label width and count field width
-		formatStr := fmt.Sprintf("%%2d. %%-%ds %%%dd |%%s|", labelWidth, countFieldWidth)
+		// Dynamic format string with calculated count field width
+		formatStr := fmt.Sprintf("%%2d. %%s %%%dd |%%s|", countFieldWidth)
 		line := fmt.Sprintf(formatStr, i+1, key, entry.UniqueValueCount, bar)
 
 		if i == selectedIdx && m.activeSection == SectionAttributes {
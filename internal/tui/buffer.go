@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Buffer overflow policies
+const (
+	BufferPolicyDropOldest = "drop-oldest" // Evict the oldest entries to make room
+	BufferPolicyStop       = "stop"        // Keep the buffer as it is and discard new entries
+)
+
+// BufferPolicies lists the overflow policies
+var BufferPolicies = []string{BufferPolicyDropOldest, BufferPolicyStop}
+
+// entryOverhead approximates the memory of an entry beyond its strings: the
+// struct, the attribute map and the copy in the filtered view
+const entryOverhead = 256
+
+// entrySize estimates the memory an entry holds in the buffer
+func entrySize(entry LogEntry) int64 {
+	size := int64(entryOverhead + len(entry.Message) + len(entry.RawLine) + len(entry.Severity) + len(entry.Outlier))
+	for key, value := range entry.Attributes {
+		size += int64(len(key) + len(value) + 16)
+	}
+	return size
+}
+
+// SetBufferBudget caps the buffer's estimated memory at maxBytes on top of
+// the entry limit, 0 for no cap, and sets what happens when it is full
+func (m *DashboardModel) SetBufferBudget(maxBytes int64, policy string) error {
+	switch policy {
+	case "", BufferPolicyDropOldest:
+		policy = BufferPolicyDropOldest
+	case BufferPolicyStop:
+	default:
+		return fmt.Errorf("unknown buffer policy %q (available: %s)", policy, strings.Join(BufferPolicies, ", "))
+	}
+	m.bufferMaxBytes = max(0, maxBytes)
+	m.bufferPolicy = policy
+	return nil
+}
+
+// bufferFull reports whether adding an entry of size would exceed the budget
+func (m *DashboardModel) bufferFull(size int64) bool {
+	if len(m.allLogEntries) >= m.maxLogBuffer {
+		return true
+	}
+	return m.bufferMaxBytes > 0 && m.bufferBytes+size > m.bufferMaxBytes
+}
+
+// rejectEntry reports whether the stop policy discards an entry because the
+// buffer is full
+func (m *DashboardModel) rejectEntry(entry LogEntry) bool {
+	if m.bufferPolicy != BufferPolicyStop || !m.bufferFull(entrySize(entry)) {
+		return false
+	}
+	m.bufferRejected++
+	return true
+}
+
+// trimBuffer evicts the oldest entries until the buffer fits its budget. The
+// newest entry is always kept, even when it alone exceeds the memory cap.
+func (m *DashboardModel) trimBuffer() {
+	for len(m.allLogEntries) > 1 && (len(m.allLogEntries) > m.maxLogBuffer || (m.bufferMaxBytes > 0 && m.bufferBytes > m.bufferMaxBytes)) {
+		m.evictOldestEntry()
+	}
+}
+
+// evictOldestEntry removes the oldest entry from the buffer
+func (m *DashboardModel) evictOldestEntry() {
+	m.bufferBytes -= entrySize(m.allLogEntries[0])
+	m.spillEntry(m.allLogEntries[0])
+	m.allLogEntries = m.allLogEntries[1:]
+	m.searchIndex.evictOldest()
+	m.columns.evictOldest()
+	m.bufferEvicted++
+	// Adjust drain3 tracking if we removed an entry
+	if m.drain3LastProcessed > 0 {
+		m.drain3LastProcessed--
+	}
+}
+
+// bufferFillPercent returns how full the buffer is, by entries or by
+// memory, whichever is closer to its limit
+func (m *DashboardModel) bufferFillPercent() int {
+	if m.maxLogBuffer <= 0 {
+		return 0
+	}
+	percent := len(m.allLogEntries) * 100 / m.maxLogBuffer
+	if m.bufferMaxBytes > 0 {
+		percent = max(percent, int(m.bufferBytes*100/m.bufferMaxBytes))
+	}
+	return min(percent, 100)
+}
+
+// bufferStatus summarizes the buffer for the status bar once it has filled,
+// e.g. "buf 100% • 12,345 evicted"
+func (m *DashboardModel) bufferStatus() string {
+	switch {
+	case m.bufferRejected > 0:
+		return fmt.Sprintf("buf full • %s discarded", formatCount(m.bufferRejected))
+	case m.spilledCount() > 0:
+		return fmt.Sprintf("buf %d%% • %s on disk", m.bufferFillPercent(), formatCount(int64(m.spilledCount())))
+	case m.bufferEvicted > 0:
+		return fmt.Sprintf("buf %d%% • %s evicted", m.bufferFillPercent(), formatCount(m.bufferEvicted))
+	}
+	return ""
+}
+
+// bufferEvictedText reports the entries the buffer let go, for the
+// statistics modal
+func (m *DashboardModel) bufferEvictedText() string {
+	switch {
+	case m.bufferRejected > 0:
+		return fmt.Sprintf("%d (%d discarded when full)", m.bufferEvicted, m.bufferRejected)
+	case m.spill != nil:
+		return fmt.Sprintf("%d (%d on disk, %s)", m.bufferEvicted, m.spilledCount(), m.formatBytes(m.spill.Size()))
+	}
+	return fmt.Sprintf("%d", m.bufferEvicted)
+}
+
+// BufferedEntries returns a copy of the entries held in memory, oldest first
+func (m *DashboardModel) BufferedEntries() []LogEntry {
+	return slices.Clone(m.allLogEntries)
+}
+
+// BufferUsage returns how many entries the buffer holds and their estimated
+// memory
+func (m *DashboardModel) BufferUsage() (int, int64) {
+	return len(m.allLogEntries), m.bufferBytes
+}
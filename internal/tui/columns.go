@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// columnLevels lists the normalized severity levels, indexed by the codes
+// of the severity column
+var columnLevels = [...]string{"UNKNOWN", "TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "CRITICAL"}
+
+// columnStore keeps the fields filters and histograms read most often as
+// typed columns parallel to allLogEntries, so evaluating them is a scan over
+// compact slices instead of a map lookup per entry. Strings are stored as
+// ids into a dictionary; id 0 means the entry has no such attribute.
+type columnStore struct {
+	severity  []uint8  // Code into columnLevels
+	received  []int64  // Timestamp in Unix nanoseconds
+	logged    []int64  // OrigTimestamp in Unix nanoseconds, 0 when unknown
+	service   []uint32 // service.name
+	namespace []uint32 // k8s.namespace
+	pod       []uint32 // k8s.pod
+
+	values []string          // Dictionary by id, values[0] unused
+	ids    map[string]uint32 // Dictionary by value
+
+	// Buffered entries per namespace and per namespace/pod pair, kept as
+	// entries arrive and leave so discovery needs no scan
+	namespaces map[uint32]int
+	pods       map[uint64]int
+
+	// Numeric fields filter expressions compare, read with measure once a
+	// filter first needs them and kept up to date from then on
+	numbers map[string]*numberColumn
+	measure func(entry LogEntry, field string) (float64, filterexpr.Unit, bool)
+	scans   uint64
+}
+
+// newColumnStore creates an empty column store
+func newColumnStore() *columnStore {
+	return &columnStore{
+		values:     []string{""},
+		ids:        make(map[string]uint32),
+		namespaces: make(map[uint32]int),
+		pods:       make(map[uint64]int),
+		numbers:    make(map[string]*numberColumn),
+	}
+}
+
+// add appends the columns of a newly buffered entry
+func (c *columnStore) add(entry LogEntry) {
+	c.severity = append(c.severity, severityCode(entry.Severity))
+	c.received = append(c.received, entry.Timestamp.UnixNano())
+	var logged int64
+	if !entry.OrigTimestamp.IsZero() {
+		logged = entry.OrigTimestamp.UnixNano()
+	}
+	c.logged = append(c.logged, logged)
+	c.service = append(c.service, c.attribute(entry, "service.name"))
+	ns, pod := c.attribute(entry, "k8s.namespace"), c.attribute(entry, "k8s.pod")
+	c.namespace = append(c.namespace, ns)
+	c.pod = append(c.pod, pod)
+	if ns != 0 {
+		c.namespaces[ns]++
+	}
+	if pod != 0 {
+		c.pods[pairKey(ns, pod)]++
+	}
+	for field, col := range c.numbers {
+		c.appendNumber(col, field, entry)
+	}
+}
+
+// evictOldest drops the columns of the oldest buffered entry
+func (c *columnStore) evictOldest() {
+	if len(c.severity) == 0 {
+		return
+	}
+	if ns := c.namespace[0]; ns != 0 {
+		if c.namespaces[ns]--; c.namespaces[ns] <= 0 {
+			delete(c.namespaces, ns)
+		}
+	}
+	if pod := c.pod[0]; pod != 0 {
+		key := pairKey(c.namespace[0], pod)
+		if c.pods[key]--; c.pods[key] <= 0 {
+			delete(c.pods, key)
+		}
+	}
+	c.severity = c.severity[1:]
+	c.received = c.received[1:]
+	c.logged = c.logged[1:]
+	c.service = c.service[1:]
+	c.namespace = c.namespace[1:]
+	c.pod = c.pod[1:]
+	for _, col := range c.numbers {
+		col.values = col.values[1:]
+		col.units = col.units[1:]
+	}
+}
+
+// pairKey combines the namespace and pod ids of a row
+func pairKey(ns, pod uint32) uint64 {
+	return uint64(ns)<<32 | uint64(pod)
+}
+
+// attribute returns the dictionary id of an entry's attribute, interning
+// values not seen before
+func (c *columnStore) attribute(entry LogEntry, key string) uint32 {
+	value, ok := entry.Attributes[key]
+	if !ok {
+		return 0
+	}
+	if id, ok := c.ids[value]; ok {
+		return id
+	}
+	id := uint32(len(c.values))
+	c.values = append(c.values, value)
+	c.ids[value] = id
+	return id
+}
+
+// severityCode returns the severity column code of a severity
+func severityCode(severity string) uint8 {
+	level := normalizeSeverityLevel(severity)
+	for code, name := range columnLevels {
+		if name == level {
+			return uint8(code)
+		}
+	}
+	return 0
+}
+
+// rowMask is a bitset over buffer positions; a nil mask keeps every row
+type rowMask []uint64
+
+// keeps reports whether the row at pos is in the mask
+func (mask rowMask) keeps(pos int) bool {
+	return mask == nil || mask[pos/64]&(1<<(pos%64)) != 0
+}
+
+// columnFilter holds the view filters the columns can evaluate
+type columnFilter struct {
+	levels     *[len(columnLevels)]bool // Shown severity codes, nil for all
+	k8s        bool                     // Whether namespaces and pods filter
+	namespaces map[string]bool          // Selected namespaces
+	pods       map[string]bool          // Selected pods as namespace/pod
+}
+
+// columnFilter returns the severity and Kubernetes filters of the view
+func (m *DashboardModel) columnFilter() columnFilter {
+	var filter columnFilter
+	if m.severityFilterActive {
+		filter.levels = &[len(columnLevels)]bool{}
+		for code, level := range columnLevels {
+			filter.levels[code] = m.severityFilter[level]
+		}
+	}
+	// With a K8s source the filter is applied where the logs are read
+	if m.k8sFilterActive && m.k8sSource == nil {
+		filter.k8s = true
+		filter.namespaces = m.k8sNamespaces
+		filter.pods = m.k8sPods
+	}
+	return filter
+}
+
+// scan returns the rows passing the filter, or nil when it keeps them all.
+// Namespaces and pods are looked up once per distinct value, not per row.
+func (c *columnStore) scan(filter columnFilter) rowMask {
+	if filter.levels == nil && !filter.k8s {
+		return nil
+	}
+
+	keep := make(rowMask, (len(c.severity)+63)/64)
+	namespaces := make(map[uint32]bool)
+	pods := make(map[uint64]bool)
+	for i, code := range c.severity {
+		if filter.levels != nil && !filter.levels[code] {
+			continue
+		}
+		if filter.k8s && !c.passesK8s(i, filter, namespaces, pods) {
+			continue
+		}
+		keep[i/64] |= 1 << (i % 64)
+	}
+	return keep
+}
+
+// passesK8s applies the namespace and pod selection to the row at pos, the
+// same way passesFilters does for an entry; rows without Kubernetes
+// attributes pass
+func (c *columnStore) passesK8s(pos int, filter columnFilter, namespaces map[uint32]bool, pods map[uint64]bool) bool {
+	ns, pod := c.namespace[pos], c.pod[pos]
+	if ns == 0 {
+		return pod == 0
+	}
+	selected, ok := namespaces[ns]
+	if !ok {
+		selected = filter.namespaces[c.values[ns]]
+		namespaces[ns] = selected
+	}
+	if !selected || pod == 0 {
+		return selected
+	}
+	key := pairKey(ns, pod)
+	selected, ok = pods[key]
+	if !ok {
+		selected = filter.pods[c.values[ns]+"/"+c.values[pod]]
+		pods[key] = selected
+	}
+	return selected
+}
+
+// heatmap counts the buffered entries by minute and severity, by log time
+// when useLogTime is set and the entry has one, leaving out minutes before
+// cutoff. Minutes are in order of their first entry.
+func (c *columnStore) heatmap(useLogTime bool, cutoff time.Time) []HeatmapMinute {
+	minutes := make([]HeatmapMinute, 0)
+	index := make(map[int64]int)
+	for i, code := range c.severity {
+		at := c.received[i]
+		if useLogTime && c.logged[i] != 0 {
+			at = c.logged[i]
+		}
+		minute := at - at%int64(time.Minute)
+		if at < 0 && at%int64(time.Minute) != 0 {
+			minute -= int64(time.Minute)
+		}
+
+		pos, ok := index[minute]
+		if !ok {
+			start := time.Unix(0, minute)
+			if !start.After(cutoff) {
+				continue
+			}
+			pos = len(minutes)
+			index[minute] = pos
+			minutes = append(minutes, HeatmapMinute{Timestamp: start})
+		}
+		minutes[pos].Counts.AddCount(columnLevels[code])
+	}
+	return minutes
+}
@@ -3,8 +3,10 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // renderGonzoBranding renders "Gonzo!" with a green to light blue gradient
@@ -109,6 +111,19 @@ func (m *DashboardModel) renderStatusLine() string {
 		}
 	}
 
+	// Active alerts take over the center while no input is being edited
+	alertActive := false
+	if banner := m.alertBanner(); banner != "" && !m.filterActive && !m.searchActive {
+		statusText = banner
+		alertActive = true
+	}
+
+	// A config reload is reported for a few seconds, ahead of the alerts
+	if notice := m.configNoticeText(); notice != "" && !m.filterActive && !m.searchActive {
+		statusText = notice
+		alertActive = false
+	}
+
 	// Build right section (status info and branding)
 	var statusInfo string
 
@@ -121,7 +136,12 @@ func (m *DashboardModel) renderStatusLine() string {
 	}
 
 	if !m.filterActive && !m.searchActive && !m.showModal && !m.showHelp {
-		if m.viewPaused {
+		if m.statusLineTemplate != "" {
+			statusInfo = m.renderStatusLineTemplate()
+			if m.viewPaused {
+				statusInfo = "⏸ " + statusInfo
+			}
+		} else if m.viewPaused {
 			statusInfo = "⏸"
 		} else if !veryNarrow {
 			intervalStr := m.formatDuration(m.updateInterval)
@@ -142,6 +162,22 @@ func (m *DashboardModel) renderStatusLine() string {
 			timestampMode = "⏱ Log Time"
 		}
 	}
+	if m.useUTC {
+		if timestampMode != "" {
+			timestampMode += " "
+		}
+		timestampMode += "UTC"
+	}
+	if m.timestampMode != TimestampModeAbsolute {
+		if timestampMode != "" {
+			timestampMode += " "
+		}
+		if m.timestampMode == TimestampModeDelta {
+			timestampMode += "Δt"
+		} else {
+			timestampMode += "ago"
+		}
+	}
 
 	// Add branding (show unless terminal is very narrow)
 	branding := ""
@@ -149,8 +185,25 @@ func (m *DashboardModel) renderStatusLine() string {
 		branding = m.renderGonzoBranding()
 	}
 
+	// Show the buffer's fill once it starts letting entries go, and the
+	// entries the sources dropped, unless a custom status line decides what
+	// is shown
+	var bufferInfo string
+	if m.statusLineTemplate == "" && !narrow && !m.filterActive && !m.searchActive && !m.showModal && !m.showHelp {
+		var parts []string
+		for _, part := range []string{m.bufferStatus(), m.droppedStatus()} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		bufferInfo = strings.Join(parts, " • ")
+	}
+
 	// Combine status info, timestamp mode, version update, and branding
 	var rightParts []string
+	if bufferInfo != "" {
+		rightParts = append(rightParts, bufferInfo)
+	}
 	if statusInfo != "" {
 		rightParts = append(rightParts, statusInfo)
 	}
@@ -198,10 +251,10 @@ func (m *DashboardModel) renderStatusLine() string {
 
 	// Truncate content if necessary to prevent wrapping
 	if lipgloss.Width(leftText) > leftWidth {
-		leftText = leftText[:max(0, leftWidth-1)]
+		leftText = ansi.Truncate(leftText, max(0, leftWidth-1), "")
 	}
 	if lipgloss.Width(statusText) > centerWidth {
-		statusText = statusText[:max(0, centerWidth-1)]
+		statusText = ansi.Truncate(statusText, max(0, centerWidth-1), "")
 	}
 	if lipgloss.Width(rightText) > rightWidth {
 		// Don't truncate styled text as it would break ANSI codes
@@ -214,18 +267,21 @@ func (m *DashboardModel) renderStatusLine() string {
 	}
 
 	leftPart := leftStyle.Render(leftText)
+	if alertActive {
+		centerStyle = centerStyle.Background(ColorRed).Bold(true)
+	}
 	centerPart := centerStyle.Render(statusText)
 	rightPart := rightStyle.Render(rightText)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, leftPart, centerPart, rightPart)
 }
 
-// renderFilter renders the filter or search input section
+// renderFilter renders the filter or search input section while editing
 func (m *DashboardModel) renderFilter() string {
 	var title, content string
 	var styleColor lipgloss.Color
 
-	// Check what to display based on active state and applied filters/searches
+	// Check what to display based on active input state
 	if m.filterActive {
 		// Actively editing filter
 		title = "🔍 Filter (editing)"
@@ -237,31 +293,17 @@ func (m *DashboardModel) renderFilter() string {
 	} else if m.searchActive {
 		// Actively editing search
 		title = "🔎 Search (editing)"
+		if m.fuzzySearch {
+			title = "🔎 Fuzzy Search (editing)"
+		}
 		content = m.searchInput.View()
 		styleColor = ColorYellow
 		if m.searchTerm != "" {
 			content += fmt.Sprintf(" | Highlighting: %q", m.searchTerm)
 		}
-	} else if m.filterRegex != nil || m.filterInput.Value() != "" {
-		// Filter applied but not editing - show the filter value
-		title = "🔍 Filter"
-		content = fmt.Sprintf("[%s]", m.filterInput.Value())
-		styleColor = ColorGreen
-		content += fmt.Sprintf(" | Showing: %d/%d entries", len(m.logEntries), len(m.allLogEntries))
-		content += " | Press '/' to edit"
-	} else if m.searchTerm != "" || m.searchInput.Value() != "" {
-		// Search applied but not editing - show the search term
-		title = "🔎 Search"
-		searchValue := m.searchTerm
-		if searchValue == "" {
-			searchValue = m.searchInput.Value()
-		}
-		content = fmt.Sprintf("[%s]", searchValue)
-		styleColor = ColorYellow
-		content += fmt.Sprintf(" | Highlighting: %q", searchValue)
-		content += " | Press 's' to edit"
+		content += " | Tab: toggle fuzzy"
 	} else {
-		// Nothing active or applied
+		// Not editing - applied filters are shown as chips
 		return ""
 	}
 
@@ -283,18 +325,28 @@ func (m *DashboardModel) renderLogScrollContent(height int, logWidth int) []stri
 			Foreground(ColorYellow).
 			Bold(true)
 		statusLine := pausedStyle.Render("↑/↓ to navigate • Home: Top • End: Latest • PgUp/PgDn: Page • Enter for details")
+		if indicator := m.selectionIndicator(); indicator != "" {
+			statusLine = indicator
+		}
+		if indicator := m.unreadIndicator(); indicator != "" {
+			statusLine = indicator + "  " + statusLine
+		}
 		logLines = append(logLines, statusLine)
 		height-- // Reduce available height for logs
 	}
 
 	// Add column headers when columns are enabled
 	if m.showColumns {
-		timestampHeader := lipgloss.NewStyle().Foreground(ColorWhite).Render("Time    ")
+		timestampWidth := lipgloss.Width(m.inDisplayZone(time.Now()).Format(m.timeFormat))
+		timestampHeader := lipgloss.NewStyle().Foreground(ColorWhite).Render(fmt.Sprintf("%-*s", timestampWidth, "Time"))
 		severityHeader := lipgloss.NewStyle().Foreground(ColorWhite).Render("Level")
 
 		// Use k8s headers if in k8s mode, otherwise use host/service headers
 		var col1Header, col2Header string
-		if m.isK8sMode() {
+		if m.scopeColumns {
+			col1Header = lipgloss.NewStyle().Foreground(ColorWhite).Render("Scope               ")
+			col2Header = lipgloss.NewStyle().Foreground(ColorWhite).Render("Version   ")
+		} else if m.isK8sMode() {
 			col1Header = lipgloss.NewStyle().Foreground(ColorWhite).Render("Namespace           ")
 			col2Header = lipgloss.NewStyle().Foreground(ColorWhite).Render("Pod                 ")
 		} else {
@@ -336,9 +388,13 @@ func (m *DashboardModel) renderLogScrollContent(height int, logWidth int) []stri
 	for i := startIdx; i < len(m.logEntries) && i < startIdx+maxLines; i++ {
 		entry := m.logEntries[i]
 		isSelected := (m.activeSection == SectionLogs || m.showLogViewerModal) && i == m.selectedLogIndex
+		m.setRelativeBase(m.logEntries, i)
+		m.rowInSelection = m.inVisualSelection(i)
 		formatted := m.formatLogEntry(entry, logWidth, isSelected)
 		logLines = append(logLines, formatted)
 	}
+	m.relativeBase = time.Time{}
+	m.rowInSelection = false
 
 	if len(logLines) <= 1 { // Only status line
 		// Add helpful instructions when no logs are available
@@ -475,6 +531,25 @@ func (m *DashboardModel) buildFilterStatus() []string {
 		filters = append(filters, "  • Search highlight: "+m.searchTerm)
 	}
 
+	// Check include/exclude filters
+	for _, filter := range m.attributeFilters {
+		filters = append(filters, "  • Attribute filter: "+filter.String())
+	}
+
+	// Check outlier toggle
+	if m.outliersOnly {
+		filters = append(filters, "  • Outliers only: no numeric attribute has stood out yet")
+	}
+	if m.sampledOnly {
+		filters = append(filters, "  • Sampled traces only: entries need trace flags, e.g. from OTLP")
+	}
+	if m.serverErrorsOnly {
+		filters = append(filters, "  • 5xx only: no request has failed with a 5xx status")
+	}
+	if m.slowOnlyMs > 0 {
+		filters = append(filters, "  • Slow requests only: at least "+formatMetricValue(m.slowOnlyMs, true))
+	}
+
 	// Add instructions for clearing filters if any are active
 	if len(filters) > 0 {
 		filters = append(filters, "")
@@ -488,6 +563,21 @@ func (m *DashboardModel) buildFilterStatus() []string {
 		if m.searchTerm != "" {
 			filters = append(filters, "    • s → Backspace/Delete → Enter (clear search)")
 		}
+		if m.outliersOnly {
+			filters = append(filters, "    • ! (show all entries again)")
+		}
+		if m.sampledOnly {
+			filters = append(filters, "    • @ (show all entries again)")
+		}
+		if m.serverErrorsOnly {
+			filters = append(filters, "    • % (show all entries again)")
+		}
+		if m.slowOnlyMs > 0 {
+			filters = append(filters, "    • > (show all entries again)")
+		}
+		if len(m.attributeFilters) > 0 {
+			filters = append(filters, "    • ESC (clear all filters)")
+		}
 	}
 
 	return filters
@@ -516,6 +606,22 @@ func joinWithCommas(items []string) string {
 	return result
 }
 
+// unreadIndicator renders the "N new entries ↓" badge shown while tailing is paused
+func (m *DashboardModel) unreadIndicator() string {
+	if m.logAutoScroll || m.unreadLogCount == 0 {
+		return ""
+	}
+	label := "entries"
+	if m.unreadLogCount == 1 {
+		label = "entry"
+	}
+	return lipgloss.NewStyle().
+		Background(ColorOrange).
+		Foreground(ColorBlack).
+		Bold(true).
+		Render(fmt.Sprintf(" %d new %s ↓ End: follow ", m.unreadLogCount, label))
+}
+
 // renderLogScroll renders the scrolling log section
 func (m *DashboardModel) renderLogScroll(height int) string {
 	// Use most of terminal width for logs
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// configNoticeDuration is how long a config reload is reported in the status line
+const configNoticeDuration = 5 * time.Second
+
+// RefreshSkin draws the rows again in the current skin, after
+// InitializeSkin changed it
+func (m *DashboardModel) RefreshSkin() {
+	m.rowCache = rowCache{}
+}
+
+// ShowConfigReload reports a reload of the config file: the settings it
+// changed in the status line, and the problems it had in a modal
+func (m *DashboardModel) ShowConfigReload(changed []string, problems []string) {
+	switch {
+	case len(problems) > 0:
+		m.configNotice = fmt.Sprintf("⚠ Config reloaded with %d problem(s)", len(problems))
+	case len(changed) > 0:
+		m.configNotice = "✓ Config reloaded: " + strings.Join(changed, ", ")
+	default:
+		m.configNotice = "✓ Config reloaded, no live settings changed"
+	}
+	m.configNoticeUntil = time.Now().Add(configNoticeDuration)
+
+	if len(problems) > 0 {
+		m.showBulkResult("Config Reload Problems\n\n" + strings.Join(problems, "\n\n"))
+	}
+}
+
+// configNoticeText returns the config reload notice while it is shown
+func (m *DashboardModel) configNoticeText() string {
+	if m.configNotice == "" || time.Now().After(m.configNoticeUntil) {
+		return ""
+	}
+	return m.configNotice
+}
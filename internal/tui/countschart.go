@@ -47,20 +47,20 @@ func (m *DashboardModel) renderCountsChart(width, height int) string {
 		}
 
 		// Create left and right parts of header
-		leftTitle := "Log Counts"
+		leftTitle := "Log Counts" + m.spikeBadge() + m.markerBadge(max(0, len(m.countsHistory)-countsBarSlots(width)))
 		rightStats := fmt.Sprintf("Min: %d | Max: %d", minTotal, maxTotal)
 
 		// Calculate available space (account for borders and padding)
 		availableWidth := width - 4
 		rightStatsWidth := len(rightStats)
-		leftTitleWidth := len(leftTitle)
+		leftTitleWidth := lipgloss.Width(leftTitle)
 		spacerWidth := availableWidth - leftTitleWidth - rightStatsWidth
 
 		if spacerWidth > 0 {
 			headerText = leftTitle + strings.Repeat(" ", spacerWidth) + rightStats
 		} else {
 			// Fallback if not enough space - just show title
-			headerText = leftTitle
+			headerText = truncateToWidth(leftTitle, availableWidth)
 		}
 	} else {
 		headerText = "Log Counts"
@@ -75,6 +75,9 @@ func (m *DashboardModel) renderCountsChart(width, height int) string {
 		content = helpStyle.Render("No data available")
 	}
 
+	// Fit the content to the chart height, keeping the baseline when shrunk
+	content = clipLines(content, height-1, true)
+
 	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
 }
 
@@ -106,7 +109,7 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 
 	// Prepare data for stacked bar chart
 	dataPoints := len(m.countsHistory)
-	maxBars := actualChartWidth / 3 // Conservative spacing
+	maxBars := countsBarSlots(chartWidth)
 
 	// Always show the most recent data points that fit in the available space
 	var paddingCount int
@@ -271,6 +274,9 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 	// Split chart output into lines to align with legend
 	chartLines := strings.Split(chartOutput, "\n")
 
+	// Deploys and other markers as vertical lines between the bars
+	m.drawMarkers(chartLines, dataStartIdx, paddingCount, bc.BarWidth()+bc.BarGap(), actualChartWidth)
+
 	// Pad chart lines to match desired height
 	for len(chartLines) < chartHeight {
 		chartLines = append(chartLines, "")
@@ -302,3 +308,10 @@ func (m *DashboardModel) renderCountsContent(chartWidth int) string {
 
 	return strings.Join(combinedLines, "\n")
 }
+
+// countsBarSlots returns how many bars the counts chart of a width shows,
+// leaving room for the legend on its right
+func countsBarSlots(chartWidth int) int {
+	const legendWidth = 18
+	return max(chartWidth-legendWidth-2, 20) / 3 // Conservative spacing
+}
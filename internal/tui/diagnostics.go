@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/cpulimit"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// QueueDepth is how full a channel between the sources and the dashboard is
+type QueueDepth struct {
+	Name string
+	Len  int
+	Cap  int
+}
+
+// SetQueueDepths sets the function reporting the channel depths shown in the
+// diagnostics of the statistics modal
+func (m *DashboardModel) SetQueueDepths(depths func() []QueueDepth) {
+	m.queueDepths = depths
+}
+
+// renderDiagnosticsContent renders gonzo's own runtime and ingest statistics,
+// for diagnosing its performance in the field
+func (m *DashboardModel) renderDiagnosticsContent(contentWidth int) string {
+	var sections []string
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Align(lipgloss.Center).
+		Width(contentWidth)
+	sections = append(sections, titleStyle.Render("Gonzo Diagnostics"))
+	sections = append(sections, "")
+
+	halfWidth := (contentWidth - 3) / 2
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	runtimeStats := m.renderStatsSection("Runtime", []StatItem{
+		{"Goroutines", fmt.Sprintf("%d", runtime.NumGoroutine())},
+		{"GOMAXPROCS", fmt.Sprintf("%d", runtime.GOMAXPROCS(0))},
+		{"CPU Limit", cpuLimitText()},
+		{"Heap in Use", m.formatBytes(int64(mem.HeapInuse))},
+		{"Heap Objects", fmt.Sprintf("%d", mem.HeapObjects)},
+		{"Allocated Total", m.formatBytes(int64(mem.TotalAlloc))},
+		{"Memory from OS", m.formatBytes(int64(mem.Sys))},
+	}, halfWidth)
+	sections = append(sections, m.combineSideBySide(runtimeStats, m.renderGCSection(&mem, halfWidth)))
+
+	ingest := []StatItem{
+		{"Current Rate", m.formatCurrentRate()},
+		{"Peak Logs per Second", fmt.Sprintf("%.1f", m.statsPeakLogsPerSec)},
+		{"Logs in Buffer", fmt.Sprintf("%d (%d%%, %s)", len(m.allLogEntries), m.bufferFillPercent(), m.formatBytes(m.bufferBytes))},
+		{"Dropped by Sources", m.droppedText()},
+	}
+	if m.queueDepths != nil {
+		for _, queue := range m.queueDepths() {
+			depth := fmt.Sprintf("%d", queue.Len)
+			if queue.Cap > 0 {
+				depth = fmt.Sprintf("%d / %d (%d%%)", queue.Len, queue.Cap, queue.Len*100/queue.Cap)
+			}
+			ingest = append(ingest, StatItem{queue.Name + " Channel", depth})
+		}
+	}
+	sections = append(sections, m.renderStatsSection("Ingest", ingest, halfWidth))
+
+	return strings.Join(sections, "\n")
+}
+
+// cpuLimitText describes the container CPU limit, if any
+func cpuLimitText() string {
+	if limit := cpulimit.Limit(); limit > 0 {
+		return fmt.Sprintf("%.2f CPUs", limit)
+	}
+	return "none"
+}
+
+// renderGCSection renders the garbage collector's cycles and pauses
+func (m *DashboardModel) renderGCSection(mem *runtime.MemStats, width int) string {
+	items := []StatItem{
+		{"GC Cycles", fmt.Sprintf("%d", mem.NumGC)},
+		{"GC CPU", fmt.Sprintf("%.2f%%", mem.GCCPUFraction*100)},
+		{"Total Pause", time.Duration(mem.PauseTotalNs).String()},
+	}
+	if mem.NumGC > 0 {
+		// PauseNs is a ring of the most recent pauses
+		last := mem.PauseNs[(mem.NumGC+255)%256]
+		var longest uint64
+		for i := uint32(0); i < min(mem.NumGC, 256); i++ {
+			longest = max(longest, mem.PauseNs[i])
+		}
+		items = append(items,
+			StatItem{"Last Pause", time.Duration(last).String()},
+			StatItem{"Longest Recent Pause", time.Duration(longest).String()},
+			StatItem{"Last GC", time.Since(time.Unix(0, int64(mem.LastGC))).Truncate(time.Second).String() + " ago"},
+		)
+	}
+	return m.renderStatsSection("Garbage Collector", items, width)
+}
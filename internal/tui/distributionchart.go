@@ -107,13 +107,10 @@ func (m *DashboardModel) renderDistributionContent(chartWidth int, words []*memo
 		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 
 		// Truncate label if too long for dynamic width
-		label := r.label
-		if len(label) > labelWidth {
-			label = label[:labelWidth-3] + "..."
-		}
+		label := padToWidth(truncateToWidth(r.label, labelWidth), labelWidth)
 
-		// Dynamic format string with calculated label width and count field width
-		formatStr := fmt.Sprintf("%%-%ds %%%dd |%%s|", labelWidth, countFieldWidth)
+		// Dynamic format string with calculated count field width
+		formatStr := fmt.Sprintf("%%s %%%dd |%%s|", countFieldWidth)
 		line := fmt.Sprintf(formatStr, label, count, bar)
 
 		if i == selectedIdx && m.activeSection == SectionDistribution {
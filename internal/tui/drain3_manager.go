@@ -119,9 +119,7 @@ func formatTemplate(cluster *goDrain.LogCluster) string {
 	template = strings.ReplaceAll(template, "<*>", "***")
 	
 	// Truncate very long templates
-	if len(template) > 100 {
-		template = template[:97] + "..."
-	}
+	template = truncateToWidth(template, 100)
 
 	return template
 }
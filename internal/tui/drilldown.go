@@ -0,0 +1,272 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/memory"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// drillDown narrows the log list to the entries behind a word or attribute
+// value picked in a frequency chart, or behind a service heatmap cell, until
+// ESC restores the view from before
+type drillDown struct {
+	word       *regexp.Regexp // Whole-word match of a picked word
+	key, value string         // Picked attribute value, when no word
+	service    string         // Picked heatmap cell's service, when no word or value
+	from, to   time.Time      // Picked heatmap cell's time bucket, open-ended with a zero to
+	label      string
+
+	// View from before the drill-down
+	savedSearch     string
+	savedSection    Section
+	savedFollow     bool
+	savedSelection  int
+	savedSelectedAt *LogEntry
+}
+
+// attributeValueCount is a value of an attribute with its occurrences
+type attributeValueCount struct {
+	value string
+	count int64
+}
+
+// drillIntoWord shows only the entries whose message holds a word from the
+// words chart, the word highlighted
+func (m *DashboardModel) drillIntoWord(word string) {
+	// Words match whole, but placeholders such as <uuid> end in punctuation
+	pattern := regexp.QuoteMeta(word)
+	if isWordByte(word[0]) {
+		pattern = `\b` + pattern
+	}
+	if isWordByte(word[len(word)-1]) {
+		pattern += `\b`
+	}
+	m.startDrillDown(&drillDown{word: regexp.MustCompile(`(?i)` + pattern), label: word}, word)
+}
+
+// isWordByte reports whether a byte is a regexp word character
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= 0x80
+}
+
+// drillIntoAttribute shows only the entries with an attribute value from
+// the attributes chart, the value highlighted where messages mention it
+func (m *DashboardModel) drillIntoAttribute(key, value string) {
+	m.startDrillDown(&drillDown{key: key, value: value, label: key + "=" + value}, value)
+}
+
+// startDrillDown applies a drill-down and selects the newest matching entry
+// in the log list. Drilling again replaces it but keeps the view from
+// before the first one.
+func (m *DashboardModel) startDrillDown(drill *drillDown, highlight string) {
+	if m.drill != nil {
+		drill.savedSearch = m.drill.savedSearch
+		drill.savedSection = m.drill.savedSection
+		drill.savedFollow = m.drill.savedFollow
+		drill.savedSelection = m.drill.savedSelection
+		drill.savedSelectedAt = m.drill.savedSelectedAt
+	} else {
+		drill.savedSearch = m.searchTerm
+		drill.savedSection = m.activeSection
+		drill.savedFollow = m.logAutoScroll
+		drill.savedSelection = m.selectedLogIndex
+		if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			entry := m.logEntries[m.selectedLogIndex]
+			drill.savedSelectedAt = &entry
+		}
+	}
+	m.drill = drill
+	m.searchTerm = highlight
+	m.activeSection = SectionLogs
+	m.logAutoScroll = true
+	m.updateFilteredView()
+}
+
+// endDrillDown removes the drill-down and its highlight, leaving the view
+// to be rebuilt by the caller
+func (m *DashboardModel) endDrillDown() {
+	if m.drill == nil {
+		return
+	}
+	m.searchTerm = m.drill.savedSearch
+	m.activeSection = m.drill.savedSection
+	m.drill = nil
+}
+
+// exitDrillDown restores the view from before the drill-down, selecting the
+// entry that was selected then
+func (m *DashboardModel) exitDrillDown() {
+	drill := m.drill
+	if drill == nil {
+		return
+	}
+	m.endDrillDown()
+	m.logAutoScroll = drill.savedFollow
+	m.updateFilteredView()
+	m.restoreLogSelection(drill.savedSelection, drill.savedSelectedAt)
+}
+
+// passesDrillDown reports whether an entry is behind the drilled-into word,
+// attribute value or heatmap cell
+func (m *DashboardModel) passesDrillDown(entry LogEntry) bool {
+	switch {
+	case m.drill == nil:
+		return true
+	case m.drill.word != nil && m.wordAnalyzer != nil:
+		// Words with placeholders are found in the message as counted
+		return m.drill.word.MatchString(m.wordAnalyzer.CollapseTokens(strings.ToLower(entry.Message)))
+	case m.drill.word != nil:
+		return m.drill.word.MatchString(entry.Message)
+	case m.drill.service != "":
+		ts := m.getDisplayTimestamp(entry)
+		return ServiceName(entry) == m.drill.service && !ts.Before(m.drill.from) && (m.drill.to.IsZero() || ts.Before(m.drill.to))
+	}
+	return entry.Attributes[m.drill.key] == m.drill.value
+}
+
+// openAttributeValuesModal lists the values of an attribute from the
+// attributes chart, most common first, to drill into one
+func (m *DashboardModel) openAttributeValuesModal(entry *memory.AttributeStatsEntry) {
+	values := make([]attributeValueCount, 0, len(entry.Values))
+	for value, count := range entry.Values {
+		values = append(values, attributeValueCount{value, count})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].count == values[j].count {
+			return values[i].value < values[j].value
+		}
+		return values[i].count > values[j].count
+	})
+
+	m.attrValuesKey = entry.Key
+	m.attrValuesTotal = entry.TotalCount
+	m.attrValues = values
+	m.attrValuesSelected = 0
+	m.showAttrValuesModal = true
+}
+
+// handleAttributeValuesModalKeys processes keyboard input for the attribute
+// values modal
+func (m *DashboardModel) handleAttributeValuesModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.attrValues)-1)
+	switch msg.String() {
+	case "escape", "esc":
+		m.showAttrValuesModal = false
+	case "up", "k":
+		m.attrValuesSelected = max(0, m.attrValuesSelected-1)
+	case "down", "j":
+		m.attrValuesSelected = min(last, m.attrValuesSelected+1)
+	case "pgup":
+		m.attrValuesSelected = max(0, m.attrValuesSelected-10)
+	case "pgdown":
+		m.attrValuesSelected = min(last, m.attrValuesSelected+10)
+	case "home":
+		m.attrValuesSelected = 0
+	case "end":
+		m.attrValuesSelected = last
+	case "enter":
+		if m.attrValuesSelected < len(m.attrValues) {
+			m.showAttrValuesModal = false
+			m.drillIntoAttribute(m.attrValuesKey, m.attrValues[m.attrValuesSelected].value)
+		}
+	}
+	return m, nil
+}
+
+// handleAttributeValuesModalMouseEvent processes mouse wheel scrolling in
+// the attribute values modal
+func (m *DashboardModel) handleAttributeValuesModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.attrValuesSelected = max(0, min(len(m.attrValues)-1, m.attrValuesSelected+delta))
+	return m, nil
+}
+
+// renderAttributeValuesModal renders the values of an attribute with their
+// share of its occurrences
+func (m *DashboardModel) renderAttributeValuesModal() string {
+	modalWidth := min(m.width-4, 120)
+	modalHeight := min(m.height-2, len(m.attrValues)+7)
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, summary, column titles and status bar take one line each
+	listHeight := max(1, contentHeight-4)
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(fmt.Sprintf("Attribute Values for %q", m.attrValuesKey))
+	summary := lipgloss.NewStyle().
+		Foreground(ColorWhite).
+		Render(fmt.Sprintf("%s occurrences • %s unique values", formatCount(m.attrValuesTotal), formatCount(int64(len(m.attrValues)))))
+
+	const countWidth = 20
+	valueWidth := max(10, contentWidth-countWidth-2)
+	columns := lipgloss.NewStyle().Foreground(ColorGray).Render(
+		"  " + padToWidth("Value", valueWidth) + fmt.Sprintf("%*s", countWidth, "count"))
+
+	var lines []string
+	if len(m.attrValues) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray).Render("  No values recorded for this attribute"))
+	}
+
+	start := listWindow(m.attrValuesSelected, listHeight, len(m.attrValues))
+
+	selected := lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite)
+	for i := start; i < len(m.attrValues) && i < start+listHeight; i++ {
+		v := m.attrValues[i]
+		share := 0.0
+		if m.attrValuesTotal > 0 {
+			share = float64(v.count) * 100 / float64(m.attrValuesTotal)
+		}
+		line := padToWidth(truncateToWidth(v.value, valueWidth), valueWidth) +
+			fmt.Sprintf("%*s", countWidth, fmt.Sprintf("%s (%.1f%%)", formatCount(v.count), share))
+		if i == m.attrValuesSelected {
+			lines = append(lines, selected.Render("▶ "+line))
+			continue
+		}
+		lines = append(lines, "  "+line)
+	}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(strings.Join(lines, "\n"))
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("↑↓: Navigate • Enter: Show the entries with this value (ESC there restores the view) • ESC: Close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, summary, columns, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
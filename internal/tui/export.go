@@ -0,0 +1,550 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/redact"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Export file formats
+const (
+	ExportFormatJSONL = "jsonl"
+	ExportFormatCSV   = "csv"
+	ExportFormatText  = "txt"
+	ExportFormatHTML  = "html" // Colors preserved as inline styles
+	ExportFormatANSI  = "ansi" // Colors preserved as terminal escape sequences
+)
+
+// exportFormats is the cycle order for the export format toggle
+var exportFormats = []string{ExportFormatJSONL, ExportFormatCSV, ExportFormatText, ExportFormatHTML, ExportFormatANSI}
+
+// styledExportFormats are the formats that keep the on-screen styling and
+// the only ones available when exporting the whole screen
+var styledExportFormats = []string{ExportFormatHTML, ExportFormatANSI}
+
+// exportProgressThreshold is the entry count from which the export runs in
+// the background with a progress bar instead of finishing before the next frame
+const exportProgressThreshold = 10000
+
+// errExportCancelled is returned when the user aborts a running export
+var errExportCancelled = errors.New("export cancelled")
+
+// exportJob tracks a running export. The counters are shared with the
+// writer goroutine.
+type exportJob struct {
+	path      string
+	total     int
+	written   atomic.Int64
+	cancelled atomic.Bool
+}
+
+// ExportDoneMsg reports the end of an export started from the export prompt
+type ExportDoneMsg struct {
+	Path    string
+	Written int
+	Error   error
+}
+
+// exportedEntry is the JSON representation of a log entry written by exports
+type exportedEntry struct {
+	Seq           int64             `json:"seq,omitempty"`
+	Timestamp     time.Time         `json:"timestamp"`
+	OrigTimestamp *time.Time        `json:"original_timestamp,omitempty"`
+	Severity      string            `json:"severity"`
+	Message       string            `json:"message"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	RawLine       string            `json:"raw,omitempty"`
+}
+
+// newExportedEntry converts a log entry for export
+func newExportedEntry(entry LogEntry) exportedEntry {
+	exported := exportedEntry{
+		Seq:        entry.Seq,
+		Timestamp:  entry.Timestamp,
+		Severity:   entry.Severity,
+		Message:    entry.Message,
+		Attributes: entry.Attributes,
+		RawLine:    entry.RawLine,
+	}
+	if !entry.OrigTimestamp.IsZero() {
+		orig := entry.OrigTimestamp
+		exported.OrigTimestamp = &orig
+	}
+	return exported
+}
+
+// exportFormatForPath picks the export format from a file extension,
+// defaulting to JSON lines
+func exportFormatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ExportFormatCSV
+	case ".json":
+		return ExportFormatJSON
+	case ".txt", ".log":
+		return ExportFormatText
+	case ".html", ".htm":
+		return ExportFormatHTML
+	case ".ansi":
+		return ExportFormatANSI
+	default:
+		return ExportFormatJSONL
+	}
+}
+
+// defaultExportPath suggests a timestamped file name in the working directory
+func defaultExportPath(format string) string {
+	return fmt.Sprintf("gonzo-export-%s.%s", time.Now().Format("20060102-150405"), format)
+}
+
+// SetExportRedactor sets the redaction applied to exported entries and
+// analyses, on top of what was redacted at ingest
+func (m *DashboardModel) SetExportRedactor(r *redact.Redactor) {
+	m.exportRedactor = r
+}
+
+// redactForExport returns a copy of an entry with its own attributes,
+// redacted for export
+func (m *DashboardModel) redactForExport(entry LogEntry) LogEntry {
+	if m.exportRedactor == nil {
+		return entry
+	}
+	entry.Attributes = maps.Clone(entry.Attributes)
+	entry.Message, entry.RawLine = m.exportRedactor.Entry(entry.Message, entry.RawLine, entry.Attributes)
+	return entry
+}
+
+// openExportPrompt asks for a destination file for the given entries.
+// The entries are copied, redacted for export, so the export is not
+// affected by new logs.
+func (m *DashboardModel) openExportPrompt(entries []LogEntry, label string) {
+	if len(entries) == 0 {
+		return
+	}
+	m.exportEntries = make([]LogEntry, len(entries))
+	for i, entry := range entries {
+		m.exportEntries[i] = m.redactForExport(entry)
+	}
+	m.exportScreen = nil
+	m.exportAnalysis = nil
+	m.exportLabel = label
+	m.exportFormat = ExportFormatJSONL
+	m.showExportPromptInput()
+}
+
+// openScreenExport asks for a destination file for a snapshot of the screen
+// as it is currently rendered
+func (m *DashboardModel) openScreenExport() {
+	m.exportScreen = strings.Split(m.View(), "\n")
+	// Only patterns apply, as the screen has no attributes left to remove
+	for i, line := range m.exportScreen {
+		m.exportScreen[i] = m.exportRedactor.Text(line)
+	}
+	// The log viewer would hide the export result once the snapshot is taken
+	m.showLogViewerModal = false
+	m.exportEntries = nil
+	m.exportAnalysis = nil
+	m.exportLabel = "screen"
+	m.exportFormat = ExportFormatHTML
+	m.showExportPromptInput()
+}
+
+// showExportPromptInput shows the export prompt with a suggested path
+func (m *DashboardModel) showExportPromptInput() {
+	m.exportInput.SetValue(defaultExportPath(m.exportFormat))
+	m.exportInput.CursorEnd()
+	m.exportInput.Focus()
+	m.showExportPrompt = true
+}
+
+// availableExportFormats returns the formats offered for the current export
+func (m *DashboardModel) availableExportFormats() []string {
+	if m.exportScreen != nil {
+		return styledExportFormats
+	}
+	if m.exportAnalysis != nil {
+		return analysisExportFormats
+	}
+	return exportFormats
+}
+
+// cycleExportFormat switches to the next format and updates the extension
+// of the entered path to match
+func (m *DashboardModel) cycleExportFormat() {
+	formats := m.availableExportFormats()
+	next := formats[0]
+	for i, format := range formats {
+		if format == m.exportFormat {
+			next = formats[(i+1)%len(formats)]
+			break
+		}
+	}
+	m.exportFormat = next
+	path := m.exportInput.Value()
+	if path != "" {
+		m.exportInput.SetValue(strings.TrimSuffix(path, filepath.Ext(path)) + "." + m.exportFormat)
+		m.exportInput.CursorEnd()
+	}
+}
+
+// closeExportPrompt hides the export prompt and releases the copied entries
+func (m *DashboardModel) closeExportPrompt() {
+	m.showExportPrompt = false
+	m.exportInput.Blur()
+	m.exportEntries = nil
+	m.exportScreen = nil
+	m.exportAnalysis = nil
+	m.exportJob = nil
+}
+
+// handleExportPromptKeys processes keyboard input for the export prompt
+func (m *DashboardModel) handleExportPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// While an export is running only cancellation is possible
+	if m.exportJob != nil {
+		if msg.String() == "esc" || msg.String() == "escape" {
+			m.exportJob.cancelled.Store(true)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "escape", "esc":
+		m.closeExportPrompt()
+		return m, nil
+	case "tab":
+		m.cycleExportFormat()
+		return m, nil
+	case "enter":
+		return m.startExport()
+	}
+
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	// Typing an extension selects the matching format, if it is offered
+	if ext := filepath.Ext(m.exportInput.Value()); ext != "" {
+		format := exportFormatForPath(m.exportInput.Value())
+		for _, available := range m.availableExportFormats() {
+			if available == format {
+				m.exportFormat = format
+			}
+		}
+	}
+	return m, cmd
+}
+
+// startExport writes the prompted entries to the entered path. Small exports
+// are written right away, large ones in the background with progress.
+func (m *DashboardModel) startExport() (tea.Model, tea.Cmd) {
+	path := strings.TrimSpace(m.exportInput.Value())
+	if path == "" {
+		return m, nil
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	format := m.exportFormat
+	entries := m.exportEntries
+
+	// The analysis is small and snapshotted already, so it is written right away
+	if m.exportAnalysis != nil {
+		err := writeAnalysis(path, format, m.exportAnalysis)
+		m.closeExportPrompt()
+		return m.handleExportDone(ExportDoneMsg{Path: path, Error: err})
+	}
+
+	// Styled output is rendered up front since rendering uses the model state
+	var styledLines []string
+	if format == ExportFormatHTML || format == ExportFormatANSI {
+		styledLines = m.exportScreen
+		if styledLines == nil {
+			styledLines = m.renderStyledEntries(entries)
+		}
+	}
+
+	write := func(job *exportJob) error {
+		if styledLines != nil {
+			return writeStyledLines(path, format, styledLines, job)
+		}
+		return writeEntries(path, format, entries, job)
+	}
+
+	job := &exportJob{path: path, total: len(entries)}
+	if styledLines != nil {
+		job.total = len(styledLines)
+	}
+
+	if job.total < exportProgressThreshold {
+		err := write(job)
+		m.closeExportPrompt()
+		return m.handleExportDone(ExportDoneMsg{Path: path, Written: int(job.written.Load()), Error: err})
+	}
+
+	m.exportJob = job
+	m.exportInput.Blur()
+	return m, func() tea.Msg {
+		err := write(job)
+		return ExportDoneMsg{Path: path, Written: int(job.written.Load()), Error: err}
+	}
+}
+
+// renderStyledEntries renders entries as they appear in the log list
+func (m *DashboardModel) renderStyledEntries(entries []LogEntry) []string {
+	width := max(40, m.width-2)
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		m.setRelativeBase(entries, i)
+		lines[i] = m.formatLogEntry(entry, width, false)
+	}
+	m.relativeBase = time.Time{}
+	return lines
+}
+
+// handleExportDone reports the result of an export
+func (m *DashboardModel) handleExportDone(msg ExportDoneMsg) (tea.Model, tea.Cmd) {
+	label := m.exportLabel
+	m.closeExportPrompt()
+	switch {
+	case errors.Is(msg.Error, errExportCancelled):
+		m.showBulkResult(fmt.Sprintf("Export Cancelled\n\n%d entries were written to %s before cancelling.", msg.Written, msg.Path))
+	case msg.Error != nil:
+		m.showBulkResult(fmt.Sprintf("Export Failed\n\n%v", msg.Error))
+	case label == "screen":
+		m.showBulkResult(fmt.Sprintf("Exported screen to %s", msg.Path))
+	case label == "analysis":
+		m.showBulkResult(fmt.Sprintf("Exported analysis to %s", msg.Path))
+	default:
+		m.showBulkResult(fmt.Sprintf("Exported %d entries to %s", msg.Written, msg.Path))
+	}
+	return m, nil
+}
+
+// renderExportPrompt renders the export destination prompt and progress
+func (m *DashboardModel) renderExportPrompt() string {
+	modalWidth := min(m.width-8, 80)
+	contentWidth := modalWidth - 4
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Render(fmt.Sprintf("Export %s (%d entries)", m.exportLabel, len(m.exportEntries)))
+	if m.exportScreen != nil {
+		header = lipgloss.NewStyle().
+			Foreground(ColorBlue).
+			Bold(true).
+			Render(fmt.Sprintf("Export screen (%dx%d)", m.width, m.height))
+	}
+	if m.exportAnalysis != nil {
+		header = lipgloss.NewStyle().
+			Foreground(ColorBlue).
+			Bold(true).
+			Render(fmt.Sprintf("Export analysis (%d patterns, %d words, %d attributes, %d minutes of severities)",
+				len(m.exportAnalysis.Patterns), len(m.exportAnalysis.Words), len(m.exportAnalysis.Attributes), len(m.exportAnalysis.SeverityHistogram)))
+	}
+
+	var body, statusBar string
+	if m.exportJob != nil {
+		written := int(m.exportJob.written.Load())
+		body = renderExportProgress(written, m.exportJob.total, contentWidth) + "\n" +
+			lipgloss.NewStyle().Foreground(ColorGray).Render("Writing to "+m.exportJob.path)
+		statusBar = "ESC: Cancel export"
+	} else {
+		var formats []string
+		for _, format := range m.availableExportFormats() {
+			if format == m.exportFormat {
+				formats = append(formats, lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite).Render(" "+format+" "))
+			} else {
+				formats = append(formats, lipgloss.NewStyle().Foreground(ColorGray).Render(" "+format+" "))
+			}
+		}
+		m.exportInput.Width = contentWidth - 8
+		body = "File: " + m.exportInput.View() + "\n\nFormat:" + strings.Join(formats, "")
+		statusBar = "Enter: Export • Tab: Format • ESC: Cancel"
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		"",
+		body,
+		"",
+		lipgloss.NewStyle().Foreground(ColorGray).Render(statusBar),
+	)
+
+	modal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// renderExportProgress renders a progress bar with the entry count
+func renderExportProgress(written, total, width int) string {
+	label := fmt.Sprintf(" %d/%d", written, total)
+	barWidth := max(10, width-lipgloss.Width(label)-2)
+	filled := 0
+	if total > 0 {
+		filled = barWidth * written / total
+	}
+	bar := lipgloss.NewStyle().Foreground(ColorGreen).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(ColorGray).Render(strings.Repeat("░", barWidth-filled))
+	return "[" + bar + "]" + label
+}
+
+// writeEntries writes entries to path in the given format, counting written
+// entries on the job so progress can be shown while it runs
+func writeEntries(path, format string, entries []LogEntry, job *exportJob) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	switch format {
+	case ExportFormatCSV:
+		err = writeEntriesCSV(writer, entries, job)
+	case ExportFormatText:
+		err = writeEntriesText(writer, entries, job)
+	default:
+		err = writeEntriesJSONL(writer, entries, job)
+	}
+	if flushErr := writer.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("failed to write export file: %w", flushErr)
+	}
+	return err
+}
+
+// writeStyledLines writes pre-rendered ANSI lines as an HTML page or as raw
+// ANSI text
+func writeStyledLines(path, format string, lines []string, job *exportJob) error {
+	var content string
+	if format == ExportFormatHTML {
+		content = renderHTMLDocument(filepath.Base(path), lines)
+	} else {
+		content = strings.Join(lines, "\n") + "\n"
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	job.written.Store(int64(len(lines)))
+	return nil
+}
+
+// writeEntriesJSONL writes one JSON object per entry
+func writeEntriesJSONL(writer *bufio.Writer, entries []LogEntry, job *exportJob) error {
+	encoder := json.NewEncoder(writer)
+	for _, entry := range entries {
+		if job.cancelled.Load() {
+			return errExportCancelled
+		}
+		if err := encoder.Encode(newExportedEntry(entry)); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+		job.written.Add(1)
+	}
+	return nil
+}
+
+// writeEntriesCSV writes a header row followed by one row per entry, with a
+// column for every attribute key found in the entries
+func writeEntriesCSV(writer *bufio.Writer, entries []LogEntry, job *exportJob) error {
+	keySet := make(map[string]bool)
+	for _, entry := range entries {
+		for key := range entry.Attributes {
+			keySet[key] = true
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	csvWriter := csv.NewWriter(writer)
+	header := append([]string{"seq", "timestamp", "original_timestamp", "severity", "message"}, keys...)
+	if err := csvWriter.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, entry := range entries {
+		if job.cancelled.Load() {
+			csvWriter.Flush()
+			return errExportCancelled
+		}
+		origTimestamp := ""
+		if !entry.OrigTimestamp.IsZero() {
+			origTimestamp = entry.OrigTimestamp.Format(time.RFC3339Nano)
+		}
+		row := []string{strconv.FormatInt(entry.Seq, 10), entry.Timestamp.Format(time.RFC3339Nano), origTimestamp, entry.Severity, entry.Message}
+		for _, key := range keys {
+			row = append(row, entry.Attributes[key])
+		}
+		if err := csvWriter.Write(row); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+		job.written.Add(1)
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// writeEntriesText writes the plain log line of every entry
+func writeEntriesText(writer *bufio.Writer, entries []LogEntry, job *exportJob) error {
+	for _, entry := range entries {
+		if job.cancelled.Load() {
+			return errExportCancelled
+		}
+		if _, err := writer.WriteString(PlainLogLine(entry) + "\n"); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+		job.written.Add(1)
+	}
+	return nil
+}
+
+// PlainLogLine returns the original line of an entry, or a readable
+// rendering of it when no raw line was kept
+func PlainLogLine(entry LogEntry) string {
+	if entry.RawLine != "" {
+		return entry.RawLine
+	}
+	timestamp := entry.Timestamp
+	if !entry.OrigTimestamp.IsZero() {
+		timestamp = entry.OrigTimestamp
+	}
+	line := fmt.Sprintf("%s %s %s", timestamp.Format(time.RFC3339Nano), entry.Severity, entry.Message)
+	for _, key := range sortedAttributeKeys(entry.Attributes) {
+		line += fmt.Sprintf(" %s=%s", key, entry.Attributes[key])
+	}
+	return line
+}
+
+// sortedAttributeKeys returns the attribute keys in alphabetical order
+func sortedAttributeKeys(attributes map[string]string) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
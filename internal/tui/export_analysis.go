@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/redact"
+)
+
+// ExportFormatJSON writes the analysis as one JSON document
+const ExportFormatJSON = "json"
+
+// analysisExportFormats are the formats offered when exporting the analysis
+var analysisExportFormats = []string{ExportFormatJSON, ExportFormatCSV}
+
+// analysisSeverities lists severities in the order the panels show them
+var analysisSeverities = []string{"FATAL", "CRITICAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE", "UNKNOWN"}
+
+// analysisCount is a counted item of a frequency list
+type analysisCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// analysisPattern is a row of the pattern table
+type analysisPattern struct {
+	Template   string  `json:"template"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+	Severity   string  `json:"severity,omitempty"` // Set for the patterns of one severity
+}
+
+// analysisAttribute is an attribute key with the counts of its values
+type analysisAttribute struct {
+	Key          string          `json:"key"`
+	UniqueValues int             `json:"unique_values"`
+	Total        int64           `json:"total"`
+	Values       []analysisCount `json:"values"`
+}
+
+// analysisMinute is one minute of the severity histogram
+type analysisMinute struct {
+	Minute time.Time        `json:"minute"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+// analysisExport is a snapshot of the analytics panels, for postmortems or
+// further processing
+type analysisExport struct {
+	GeneratedAt        time.Time           `json:"generated_at"`
+	TotalEntries       int64               `json:"total_entries"`
+	Severities         []analysisCount     `json:"severities"`
+	SeverityHistogram  []analysisMinute    `json:"severity_histogram"`
+	Patterns           []analysisPattern   `json:"patterns"`
+	PatternsBySeverity []analysisPattern   `json:"patterns_by_severity"`
+	Words              []analysisCount     `json:"words"`
+	Attributes         []analysisAttribute `json:"attributes"`
+	Services           []analysisCount     `json:"services"`
+}
+
+// sortedCounts turns a count map into a list, highest count first
+func sortedCounts(counts map[string]int64) []analysisCount {
+	list := make([]analysisCount, 0, len(counts))
+	for name, count := range counts {
+		list = append(list, analysisCount{Name: name, Count: count})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// severityCountsMap returns the non-zero counts of a severity breakdown
+func severityCountsMap(counts SeverityCounts) map[string]int64 {
+	byName := map[string]int64{
+		"FATAL":    int64(counts.Fatal),
+		"CRITICAL": int64(counts.Critical),
+		"ERROR":    int64(counts.Error),
+		"WARN":     int64(counts.Warn),
+		"INFO":     int64(counts.Info),
+		"DEBUG":    int64(counts.Debug),
+		"TRACE":    int64(counts.Trace),
+		"UNKNOWN":  int64(counts.Unknown),
+	}
+	for name, count := range byName {
+		if count == 0 {
+			delete(byName, name)
+		}
+	}
+	return byName
+}
+
+// buildAnalysisExport collects what the analytics panels show: the pattern
+// table, the word, attribute and service frequencies, the severity totals
+// and the per-minute severity histogram of the counts modal
+func (m *DashboardModel) buildAnalysisExport() *analysisExport {
+	analysis := &analysisExport{
+		GeneratedAt:  time.Now(),
+		TotalEntries: int64(m.statsTotalLogsEver),
+	}
+
+	for _, severity := range analysisSeverities {
+		if count := m.lifetimeSeverityCounts[severity]; count > 0 {
+			analysis.Severities = append(analysis.Severities, analysisCount{Name: severity, Count: count})
+		}
+	}
+
+	for _, minute := range m.heatmapData {
+		analysis.SeverityHistogram = append(analysis.SeverityHistogram, analysisMinute{
+			Minute: minute.Timestamp,
+			Counts: severityCountsMap(minute.Counts),
+		})
+	}
+
+	if m.drain3Manager != nil {
+		for _, pattern := range m.drain3Manager.GetTopPatterns(0) {
+			analysis.Patterns = append(analysis.Patterns, analysisPattern{
+				Template:   pattern.Template,
+				Count:      pattern.Count,
+				Percentage: pattern.Percentage,
+			})
+		}
+	}
+	for _, severity := range analysisSeverities {
+		drain := m.drain3BySeverity[severity]
+		if drain == nil {
+			continue
+		}
+		for _, pattern := range drain.GetTopPatterns(0) {
+			analysis.PatternsBySeverity = append(analysis.PatternsBySeverity, analysisPattern{
+				Template:   pattern.Template,
+				Count:      pattern.Count,
+				Percentage: pattern.Percentage,
+				Severity:   severity,
+			})
+		}
+	}
+
+	for _, word := range m.getLifetimeWordEntries() {
+		analysis.Words = append(analysis.Words, analysisCount{Name: word.Term, Count: word.Count})
+	}
+	for _, attr := range m.getLifetimeAttributeEntries() {
+		analysis.Attributes = append(analysis.Attributes, analysisAttribute{
+			Key:          attr.Key,
+			UniqueValues: attr.UniqueValueCount,
+			Total:        attr.TotalCount,
+			Values:       sortedCounts(attr.Values),
+		})
+	}
+	analysis.Services = sortedCounts(m.lifetimeServiceCounts)
+	redactAnalysis(analysis, m.exportRedactor)
+	return analysis
+}
+
+// redactAnalysis applies export redaction to the texts of an analysis,
+// leaving out the removed attributes and merging counts redacted alike
+func redactAnalysis(analysis *analysisExport, r *redact.Redactor) {
+	if r == nil {
+		return
+	}
+	for i := range analysis.Patterns {
+		analysis.Patterns[i].Template = r.Text(analysis.Patterns[i].Template)
+	}
+	for i := range analysis.PatternsBySeverity {
+		analysis.PatternsBySeverity[i].Template = r.Text(analysis.PatternsBySeverity[i].Template)
+	}
+	analysis.Words = redactCounts(analysis.Words, r.Text)
+
+	attributes := analysis.Attributes[:0]
+	for _, attr := range analysis.Attributes {
+		if _, kept := r.Value(attr.Key, ""); !kept {
+			continue
+		}
+		attr.Values = redactCounts(attr.Values, func(value string) string {
+			redacted, _ := r.Value(attr.Key, value)
+			return redacted
+		})
+		attr.UniqueValues = min(attr.UniqueValues, len(attr.Values))
+		attributes = append(attributes, attr)
+	}
+	analysis.Attributes = attributes
+}
+
+// redactCounts redacts the names of a frequency list, merging the counts of
+// names redacted alike
+func redactCounts(counts []analysisCount, redactName func(string) string) []analysisCount {
+	merged := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		merged[redactName(count.Name)] += count.Count
+	}
+	return sortedCounts(merged)
+}
+
+// openAnalysisExport asks for a destination file for a snapshot of the
+// analytics panels
+func (m *DashboardModel) openAnalysisExport() {
+	m.exportAnalysis = m.buildAnalysisExport()
+	m.exportEntries = nil
+	m.exportScreen = nil
+	m.exportLabel = "analysis"
+	m.exportFormat = ExportFormatJSON
+	m.showExportPromptInput()
+	m.exportInput.SetValue(fmt.Sprintf("gonzo-analysis-%s.%s", time.Now().Format("20060102-150405"), m.exportFormat))
+	m.exportInput.CursorEnd()
+}
+
+// writeAnalysis writes the analysis to path as a JSON document or as CSV
+// rows of section, name, value and count
+func writeAnalysis(path, format string, analysis *analysisExport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if format == ExportFormatCSV {
+		err = writeAnalysisCSV(writer, analysis)
+	} else {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(analysis); encodeErr != nil {
+			err = fmt.Errorf("failed to write analysis: %w", encodeErr)
+		}
+	}
+	if flushErr := writer.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("failed to write export file: %w", flushErr)
+	}
+	return err
+}
+
+// writeAnalysisCSV flattens the analysis into one table. The value column
+// holds the severity of histogram and per-severity pattern rows, and the
+// value of attribute rows.
+func writeAnalysisCSV(writer *bufio.Writer, analysis *analysisExport) error {
+	csvWriter := csv.NewWriter(writer)
+	write := func(section, name, value string, count int64) {
+		csvWriter.Write([]string{section, name, value, strconv.FormatInt(count, 10)})
+	}
+
+	if err := csvWriter.Write([]string{"section", "name", "value", "count"}); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for _, severity := range analysis.Severities {
+		write("severity", severity.Name, "", severity.Count)
+	}
+	for _, minute := range analysis.SeverityHistogram {
+		for _, severity := range analysisSeverities {
+			if count, ok := minute.Counts[severity]; ok {
+				write("severity_histogram", minute.Minute.Format(time.RFC3339), severity, count)
+			}
+		}
+	}
+	for _, pattern := range analysis.Patterns {
+		write("pattern", pattern.Template, "", int64(pattern.Count))
+	}
+	for _, pattern := range analysis.PatternsBySeverity {
+		write("pattern", pattern.Template, pattern.Severity, int64(pattern.Count))
+	}
+	for _, word := range analysis.Words {
+		write("word", word.Name, "", word.Count)
+	}
+	for _, attr := range analysis.Attributes {
+		for _, value := range attr.Values {
+			write("attribute", attr.Key, value.Name, value.Count)
+		}
+	}
+	for _, service := range analysis.Services {
+		write("service", service.Name, "", service.Count)
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to write analysis: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Limits for the attribute keys described to the AI when translating filters
+const (
+	exprPromptMaxKeys   = 30
+	exprPromptMaxValues = 3
+)
+
+// aiFilterMsg carries the filter expression the AI translated a request into
+type aiFilterMsg struct {
+	id   int
+	expr string
+	err  error
+}
+
+// filterRecord returns the view of an entry that filter expressions use,
+// timed by the timestamp mode in use, its duration read as the latency
+// percentiles read it
+func (m *DashboardModel) filterRecord(entry LogEntry) *filterexpr.Record {
+	rec := EntryRecord(entry)
+	rec.Time = m.getDisplayTimestamp(entry)
+	rec.Duration = func() (float64, bool) { return m.latency.duration(entry) }
+	return rec
+}
+
+// EntryRecord returns the view of an entry that filter expressions use,
+// timed by when the entry was received
+func EntryRecord(entry LogEntry) *filterexpr.Record {
+	return &filterexpr.Record{
+		Time:       entry.Timestamp,
+		Severity:   normalizeSeverityLevel(entry.Severity),
+		Message:    entry.Message,
+		RawLine:    entry.RawLine,
+		Attributes: entry.Attributes,
+		Resource:   entry.ResourceKeys,
+		Duration:   func() (float64, bool) { return entryDuration(entry, "") },
+		Size:       func() (float64, bool) { return entryByteSize(entry) },
+	}
+}
+
+// SetFilterExpression applies a filter expression to the log view. An empty
+// expression removes it.
+func (m *DashboardModel) SetFilterExpression(source string) error {
+	if strings.TrimSpace(source) == "" {
+		m.filterExpr = nil
+		m.updateFilteredView()
+		return nil
+	}
+	expr, err := filterexpr.Parse(source)
+	if err != nil {
+		return fmt.Errorf("invalid filter expression: %w", err)
+	}
+	m.filterExpr = expr
+	m.updateFilteredView()
+	return nil
+}
+
+// openExprPrompt opens the filter expression prompt, asking the AI for the
+// expression when it is configured
+func (m *DashboardModel) openExprPrompt() {
+	m.showExprPrompt = true
+	m.exprAskAI = m.aiClient != nil
+	m.exprPending = ""
+	m.exprError = ""
+	m.exprWorking = false
+	m.exprInput.SetValue("")
+	if !m.exprAskAI && m.filterExpr != nil {
+		m.exprInput.SetValue(m.filterExpr.String())
+	}
+	m.exprInput.CursorEnd()
+	m.exprInput.Focus()
+}
+
+// closeExprPrompt hides the prompt; a translation still running is ignored
+func (m *DashboardModel) closeExprPrompt() {
+	m.showExprPrompt = false
+	m.exprWorking = false
+	m.exprRequestID++
+	m.exprInput.Blur()
+}
+
+// editExprPending moves the generated expression into the input for editing
+func (m *DashboardModel) editExprPending() {
+	m.exprAskAI = false
+	m.exprInput.SetValue(m.exprPending)
+	m.exprInput.CursorEnd()
+	m.exprPending = ""
+}
+
+// handleExprPromptKeys processes keyboard input for the filter expression prompt
+func (m *DashboardModel) handleExprPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc":
+		m.closeExprPrompt()
+		return m, nil
+	case "tab":
+		if m.exprWorking {
+			return m, nil
+		}
+		if m.exprPending != "" {
+			m.editExprPending()
+		} else {
+			m.exprAskAI = !m.exprAskAI
+			if !m.exprAskAI && m.exprInput.Value() == "" && m.filterExpr != nil {
+				m.exprInput.SetValue(m.filterExpr.String())
+				m.exprInput.CursorEnd()
+			}
+		}
+		m.exprError = ""
+		return m, nil
+	case "enter":
+		return m.submitExprPrompt()
+	}
+
+	if m.exprWorking {
+		return m, nil
+	}
+	if m.exprPending != "" {
+		if msg.String() == "e" {
+			m.editExprPending()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.exprInput, cmd = m.exprInput.Update(msg)
+	return m, cmd
+}
+
+// submitExprPrompt applies the expression, or sends the request to the AI
+func (m *DashboardModel) submitExprPrompt() (tea.Model, tea.Cmd) {
+	if m.exprWorking {
+		return m, nil
+	}
+	if m.exprPending != "" {
+		if err := m.SetFilterExpression(m.exprPending); err != nil {
+			m.exprError = err.Error()
+			return m, nil
+		}
+		m.closeExprPrompt()
+		return m, nil
+	}
+
+	value := strings.TrimSpace(m.exprInput.Value())
+	if !m.exprAskAI {
+		if err := m.SetFilterExpression(value); err != nil {
+			m.exprError = err.Error()
+			return m, nil
+		}
+		m.closeExprPrompt()
+		return m, nil
+	}
+
+	if value == "" {
+		return m, nil
+	}
+	if m.aiClient == nil {
+		m.exprError = "AI not available: " + m.aiErrorMessage
+		return m, nil
+	}
+
+	m.exprWorking = true
+	m.exprError = ""
+	m.exprRequestID++
+	id := m.exprRequestID
+	client := m.aiClient
+	fields := m.exprPromptFields()
+	return m, func() tea.Msg {
+		expr, err := client.TranslateToFilter(value, filterexpr.Syntax, fields)
+		return aiFilterMsg{id: id, expr: expr, err: err}
+	}
+}
+
+// handleAIFilter shows the translated expression for confirmation
+func (m *DashboardModel) handleAIFilter(msg aiFilterMsg) (tea.Model, tea.Cmd) {
+	if msg.id != m.exprRequestID || !m.showExprPrompt {
+		return m, nil
+	}
+	m.exprWorking = false
+	if msg.err != nil {
+		m.exprError = fmt.Sprintf("AI request failed: %v", msg.err)
+		return m, nil
+	}
+	if msg.expr == "" {
+		m.exprError = "The AI did not return an expression; try rephrasing"
+		return m, nil
+	}
+	m.exprPending = msg.expr
+	if _, err := filterexpr.Parse(msg.expr); err != nil {
+		m.exprError = fmt.Sprintf("Not a valid expression (%v); press e to fix it", err)
+	}
+	return m, nil
+}
+
+// exprPromptFields describes the most common attribute keys and values so
+// the AI can use the names that actually occur in the logs
+func (m *DashboardModel) exprPromptFields() []string {
+	type keyTotal struct {
+		key   string
+		total int64
+	}
+	var keys []keyTotal
+	for key, values := range m.lifetimeAttrKeyCounts {
+		var total int64
+		for _, count := range values {
+			total += count
+		}
+		keys = append(keys, keyTotal{key, total})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].total != keys[j].total {
+			return keys[i].total > keys[j].total
+		}
+		return keys[i].key < keys[j].key
+	})
+
+	var fields []string
+	for _, kt := range keys[:min(len(keys), exprPromptMaxKeys)] {
+		values := make([]string, 0, len(m.lifetimeAttrKeyCounts[kt.key]))
+		for value := range m.lifetimeAttrKeyCounts[kt.key] {
+			values = append(values, value)
+		}
+		counts := m.lifetimeAttrKeyCounts[kt.key]
+		sort.Slice(values, func(i, j int) bool {
+			if counts[values[i]] != counts[values[j]] {
+				return counts[values[i]] > counts[values[j]]
+			}
+			return values[i] < values[j]
+		})
+		values = values[:min(len(values), exprPromptMaxValues)]
+		for i, value := range values {
+			values[i] = truncateToWidth(value, 40)
+		}
+		fields = append(fields, fmt.Sprintf("%s (%s)", kt.key, strings.Join(values, ", ")))
+	}
+	return fields
+}
+
+// renderExprPrompt renders the filter expression prompt
+func (m *DashboardModel) renderExprPrompt() string {
+	modalWidth := min(m.width-8, 80)
+	contentWidth := modalWidth - 4
+
+	title := "Filter expression"
+	label := "Filter: "
+	hint := "Enter: Apply (empty clears) • Tab: Ask AI • ESC: Cancel"
+	if m.exprAskAI {
+		title = "Ask AI for a filter"
+		label = "Show: "
+		hint = "Enter: Translate • Tab: Write the expression • ESC: Cancel"
+	}
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Render(title)
+
+	m.exprInput.Width = contentWidth - len(label) - 2
+	lines := []string{header, "", label + m.exprInput.View()}
+
+	gray := lipgloss.NewStyle().Foreground(ColorGray)
+	switch {
+	case m.exprWorking:
+		lines = append(lines, "", gray.Render("Translating with "+m.aiModelName+"..."))
+	case m.exprPending != "":
+		lines = append(lines, "", "Generated filter:",
+			lipgloss.NewStyle().Foreground(ColorGreen).Bold(true).Width(contentWidth).Render(m.exprPending))
+		hint = "Enter: Apply • e/Tab: Edit • ESC: Cancel"
+	case m.exprAskAI:
+		lines = append(lines, "", gray.Render(`e.g. "payment errors from the last 10 minutes excluding health checks"`))
+	default:
+		lines = append(lines, "", gray.Width(contentWidth).Render(`e.g. severity=ERROR service.name=payments since=10m not "health check"`))
+	}
+	if m.exprError != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(ColorRed).Width(contentWidth).Render(m.exprError))
+	}
+	lines = append(lines, "", gray.Render(hint))
+
+	modal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
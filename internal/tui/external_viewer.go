@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ExternalViewerDoneMsg is sent when the external editor or pager exits
+type ExternalViewerDoneMsg struct {
+	Err error
+}
+
+// prettyJSON indents text that is a JSON object or array, reporting false
+// for anything else
+func prettyJSON(text string) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(trimmed), "", "  "); err != nil {
+		return "", false
+	}
+	return out.String(), true
+}
+
+// entryDocument renders the full content of an entry for viewing outside
+// the TUI: metadata, message, attributes and the raw line, with JSON
+// pretty-printed
+func (m *DashboardModel) entryDocument(entry LogEntry) string {
+	var doc strings.Builder
+
+	if entry.Seq > 0 {
+		fmt.Fprintf(&doc, "Entry:      #%d\n", entry.Seq)
+	}
+	fmt.Fprintf(&doc, "Received:   %s\n", m.formatFullTimestamp(entry.Timestamp))
+	if !entry.OrigTimestamp.IsZero() {
+		fmt.Fprintf(&doc, "Log Time:   %s\n", m.formatFullTimestamp(entry.OrigTimestamp))
+	}
+	fmt.Fprintf(&doc, "Severity:   %s\n", entry.Severity)
+	if entry.Source != "" {
+		fmt.Fprintf(&doc, "Source:     %s\n", entry.Source)
+	}
+
+	doc.WriteString("\nMessage:\n")
+	if pretty, ok := prettyJSON(entry.Message); ok {
+		doc.WriteString(pretty)
+	} else {
+		doc.WriteString(entry.Message)
+	}
+	doc.WriteString("\n")
+
+	if len(entry.Attributes) > 0 {
+		doc.WriteString("\nAttributes:\n")
+		keys := sortedAttributeKeys(entry.Attributes)
+		keyWidth := 0
+		for _, key := range keys {
+			keyWidth = max(keyWidth, ansi.StringWidth(key))
+		}
+		for _, key := range keys {
+			fmt.Fprintf(&doc, "  %s  %s\n", padToWidth(key, keyWidth), entry.Attributes[key])
+		}
+	}
+
+	if entry.RawLine != "" && entry.RawLine != entry.Message {
+		if entry.StructuredBody {
+			doc.WriteString("\nBody:\n")
+		} else {
+			doc.WriteString("\nRaw:\n")
+		}
+		if pretty, ok := prettyJSON(entry.RawLine); ok {
+			doc.WriteString(pretty)
+		} else {
+			doc.WriteString(entry.RawLine)
+		}
+		doc.WriteString("\n")
+	}
+
+	return doc.String()
+}
+
+// externalViewerCommand returns the command line from an environment
+// variable such as $PAGER, falling back to a default
+func externalViewerCommand(envVar, fallback string) []string {
+	if fields := strings.Fields(os.Getenv(envVar)); len(fields) > 0 {
+		return fields
+	}
+	return []string{fallback}
+}
+
+// openInExternalViewer writes the entry to a temporary file and opens it in
+// $EDITOR (or $PAGER when useEditor is false). The TUI is suspended while
+// the program runs and restored when it exits.
+func (m *DashboardModel) openInExternalViewer(entry LogEntry, useEditor bool) (tea.Model, tea.Cmd) {
+	file, err := os.CreateTemp("", "gonzo-entry-*.txt")
+	if err != nil {
+		m.showBulkResult(fmt.Sprintf("Open Failed\n\nCould not create temporary file: %v", err))
+		return m, nil
+	}
+	_, err = file.WriteString(m.entryDocument(entry))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		m.showBulkResult(fmt.Sprintf("Open Failed\n\nCould not write temporary file: %v", err))
+		return m, nil
+	}
+
+	args := externalViewerCommand("PAGER", "less")
+	if useEditor {
+		args = externalViewerCommand("EDITOR", "vi")
+	}
+	cmd := exec.Command(args[0], append(args[1:], file.Name())...)
+
+	path := file.Name()
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		os.Remove(path)
+		return ExternalViewerDoneMsg{Err: err}
+	})
+}
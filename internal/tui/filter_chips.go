@@ -0,0 +1,265 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filterChip is a removable representation of one applied filter
+type filterChip struct {
+	label  string
+	color  lipgloss.Color
+	remove func()
+}
+
+// activeFilterChips returns a chip for every filter currently applied
+func (m *DashboardModel) activeFilterChips() []filterChip {
+	var chips []filterChip
+
+	if m.filterRegex != nil || m.filterInput.Value() != "" {
+		chips = append(chips, filterChip{
+			label: "/" + m.filterInput.Value() + "/",
+			color: ColorGreen,
+			remove: func() {
+				m.filterInput.SetValue("")
+				m.filterRegex = nil
+			},
+		})
+	}
+
+	if m.drill != nil {
+		chips = append(chips, filterChip{
+			label: "drill: " + m.drill.label,
+			color: ColorBlue,
+			remove: func() {
+				m.endDrillDown()
+			},
+		})
+	} else if m.searchTerm != "" {
+		label := "search: " + m.searchTerm
+		if m.fuzzySearch {
+			label = "fuzzy: " + m.searchTerm
+		}
+		chips = append(chips, filterChip{
+			label: label,
+			color: ColorYellow,
+			remove: func() {
+				m.searchInput.SetValue("")
+				m.searchTerm = ""
+			},
+		})
+	}
+
+	if m.severityFilterActive {
+		var enabled []string
+		for _, severity := range []string{"FATAL", "CRITICAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE", "UNKNOWN"} {
+			if m.severityFilter[severity] {
+				enabled = append(enabled, severity)
+			}
+		}
+		label := "severity: none"
+		if m.hasSeverityFloor() {
+			label = "severity ≥ " + m.severityFloor
+		} else if len(enabled) > 0 {
+			label = "severity: " + strings.Join(enabled, ",")
+		}
+		chips = append(chips, filterChip{
+			label: label,
+			color: ColorOrange,
+			remove: func() {
+				for severity := range m.severityFilter {
+					m.severityFilter[severity] = true
+				}
+				m.updateSeverityFilterActiveStatus()
+			},
+		})
+	}
+
+	if m.k8sFilterActive {
+		if label, ok := m.k8sChipLabel(); ok {
+			chips = append(chips, filterChip{
+				label: label,
+				color: ColorBlue,
+				remove: func() {
+					for ns := range m.k8sNamespaces {
+						m.k8sNamespaces[ns] = true
+					}
+					for pod := range m.k8sPods {
+						m.k8sPods[pod] = true
+					}
+					m.k8sFilterActive = false
+					m.applyK8sFilterToSource()
+				},
+			})
+		}
+	}
+
+	if m.podZoom != nil {
+		chips = append(chips, filterChip{
+			label: "pod: " + m.podZoom.namespace + "/" + m.podZoom.pod,
+			color: ColorBlue,
+			remove: func() {
+				m.endPodZoom()
+			},
+		})
+	}
+
+	if m.filterExpr != nil {
+		chips = append(chips, filterChip{
+			label: "expr: " + m.filterExpr.String(),
+			color: ColorWhite,
+			remove: func() {
+				m.filterExpr = nil
+			},
+		})
+	}
+
+	if m.outliersOnly {
+		chips = append(chips, filterChip{
+			label: "outliers",
+			color: ColorOrange,
+			remove: func() {
+				m.outliersOnly = false
+			},
+		})
+	}
+
+	if m.sampledOnly {
+		chips = append(chips, filterChip{
+			label: "sampled",
+			color: ColorGreen,
+			remove: func() {
+				m.sampledOnly = false
+			},
+		})
+	}
+
+	if m.serverErrorsOnly {
+		chips = append(chips, filterChip{
+			label: "5xx",
+			color: ColorRed,
+			remove: func() {
+				m.serverErrorsOnly = false
+			},
+		})
+	}
+
+	if m.slowOnlyMs > 0 {
+		chips = append(chips, filterChip{
+			label: "slow ≥ " + formatMetricValue(m.slowOnlyMs, true),
+			color: ColorOrange,
+			remove: func() {
+				m.slowOnlyMs = 0
+			},
+		})
+	}
+
+	for i, filter := range m.attributeFilters {
+		chips = append(chips, filterChip{
+			label: filter.String(),
+			color: ColorPink,
+			remove: func() {
+				m.attributeFilters = append(m.attributeFilters[:i:i], m.attributeFilters[i+1:]...)
+			},
+		})
+	}
+
+	return chips
+}
+
+// k8sChipLabel describes the namespace/pod selection, reporting false when
+// everything is selected and the filter has no effect
+func (m *DashboardModel) k8sChipLabel() (string, bool) {
+	var namespaces []string
+	allNamespaces := true
+	for ns, selected := range m.k8sNamespaces {
+		if selected {
+			namespaces = append(namespaces, ns)
+		} else {
+			allNamespaces = false
+		}
+	}
+	selectedPods := 0
+	allPods := true
+	for _, selected := range m.k8sPods {
+		if selected {
+			selectedPods++
+		} else {
+			allPods = false
+		}
+	}
+	if allNamespaces && allPods {
+		return "", false
+	}
+
+	sort.Strings(namespaces)
+	label := "ns: " + strings.Join(namespaces, ",")
+	if len(namespaces) > 3 {
+		label = fmt.Sprintf("ns: %d selected", len(namespaces))
+	}
+	if !allPods {
+		label += fmt.Sprintf(" pods: %d/%d", selectedPods, len(m.k8sPods))
+	}
+	return label, true
+}
+
+// removeFilterChip removes the chip at index and refreshes the view
+func (m *DashboardModel) removeFilterChip(index int) {
+	chips := m.activeFilterChips()
+	if index < 0 || index >= len(chips) {
+		return
+	}
+	chips[index].remove()
+	m.updateFilteredView()
+}
+
+// renderChipText renders the text of a single chip including its number
+func renderChipText(index int, chip filterChip) string {
+	return fmt.Sprintf(" %d ✕ %s ", index+1, chip.label)
+}
+
+// renderFilterChips renders applied filters as numbered chips on one line.
+// Pressing the chip number or clicking it removes the filter.
+func (m *DashboardModel) renderFilterChips() string {
+	chips := m.activeFilterChips()
+	if len(chips) == 0 {
+		return ""
+	}
+
+	parts := []string{lipgloss.NewStyle().Foreground(ColorGray).Render(" Filters:")}
+	for i, chip := range chips {
+		parts = append(parts, lipgloss.NewStyle().
+			Background(chip.color).
+			Foreground(ColorBlack).
+			Render(renderChipText(i, chip)))
+	}
+
+	hints := fmt.Sprintf(" %d/%d entries • 1-9/click: remove", len(m.logEntries), len(m.allLogEntries))
+	if m.searchTerm != "" {
+		hints += " • n/N: next/prev match"
+	}
+	summary := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Render(hints)
+	parts = append(parts, summary)
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(strings.Join(parts, " "))
+}
+
+// filterChipAt returns the index of the chip rendered at column x, or -1
+func (m *DashboardModel) filterChipAt(x int) int {
+	chips := m.activeFilterChips()
+	// Position after the " Filters:" label and the separating space
+	pos := lipgloss.Width(" Filters:") + 1
+	for i, chip := range chips {
+		width := lipgloss.Width(renderChipText(i, chip))
+		if x >= pos && x < pos+width {
+			return i
+		}
+		pos += width + 1
+	}
+	return -1
+}
@@ -6,15 +6,69 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
-// formatLogEntry formats a log entry with colors
+// Attributes holding the OTLP instrumentation scope of an entry
+const (
+	ScopeNameAttribute    = "otel.scope.name"
+	ScopeVersionAttribute = "otel.scope.version"
+)
+
+// cycleColumns switches the log view columns from Host/Service (or
+// Namespace/Pod) to Scope/Version, once entries with a scope arrived, then
+// to none
+func (m *DashboardModel) cycleColumns() {
+	switch {
+	case m.showColumns && !m.scopeColumns && m.scopesSeen:
+		m.scopeColumns = true
+	case m.showColumns:
+		m.showColumns, m.scopeColumns = false, false
+	default:
+		m.showColumns = true
+	}
+}
+
+// formatLogEntry formats a log entry with colors, reusing the row rendered
+// for it before when nothing it depends on has changed
 func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSelected bool) string {
-	// Use getDisplayTimestamp to respect the useLogTime setting
-	timestamp := m.getDisplayTimestamp(entry).Format("15:04:05")
+	prefix := m.lineNumberPrefix(entry)
+	if m.sourceBadgesShown() {
+		// Rows colored as a whole get a plain badge
+		_, highlighted := m.rowHighlightColor(entry.Message)
+		prefix += m.sourceBadgePrefix(entry, isSelected || highlighted || m.rowInSelection)
+	}
+	// Use formatListTimestamp to respect the useLogTime and timezone settings
+	timestamp := prefix + m.formatListTimestamp(entry)
+
+	// Entries outside the buffer have no number to be cached by
+	if entry.Seq == 0 {
+		return m.renderLogEntry(entry, timestamp, availableWidth, isSelected)
+	}
+	key := rowKey{seq: entry.Seq, width: availableWidth, selected: isSelected, inSelection: m.rowInSelection, timestamp: timestamp}
+	if row, ok := m.rowCache.lookup(m.rowRenderState(), key); ok {
+		return row
+	}
+	row := m.renderLogEntry(entry, timestamp, availableWidth, isSelected)
+	m.rowCache.store(key, row)
+	return row
+}
+
+// renderLogEntry renders a log row with the timestamp as shown
+func (m *DashboardModel) renderLogEntry(entry LogEntry, timestamp string, availableWidth int, isSelected bool) string {
+	styles := getRowStyles()
+	// Widths below assume an 8 character "15:04:05" timestamp
+	timestampExtra := lipgloss.Width(timestamp) - 8
+
+	// Row highlight rules color the whole line like a selection does
+	rowColor, rowHighlighted := m.rowHighlightColor(entry.Message)
+	if m.rowInSelection {
+		// Rows in a visual multi-selection take precedence over rules
+		rowColor, rowHighlighted = ColorDarkGray, true
+	}
 
 	// If selected, apply selection style to entire row
-	if isSelected {
+	if isSelected || rowHighlighted {
 		// Format the entire row without individual component styling
 		severity := fmt.Sprintf("%-5s", entry.Severity)
 
@@ -28,18 +82,19 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 			var col1Str, col2Str string
 			var columnsWidth int
 
-			if isK8s {
+			if m.scopeColumns {
+				// Scope mode: show the instrumentation scope name and version
+				col1Str = padToWidth(truncateToWidth(entry.Attributes[ScopeNameAttribute], 20), 20)
+				col2Str = padToWidth(truncateToWidth(entry.Attributes[ScopeVersionAttribute], 10), 10)
+				columnsWidth = 32 // 20 + 10 + 2 spaces
+			} else if isK8s {
 				// K8s mode: show namespace and pod (both truncated to 20 chars)
-				if len(namespace) > 20 {
-					namespace = namespace[:17] + "..."
-				}
-				if len(pod) > 20 {
-					pod = pod[:17] + "..."
-				}
+				namespace = truncateToWidth(namespace, 20)
+				pod = truncateToWidth(pod, 20)
 
 				// Format fixed-width columns
-				col1Str = fmt.Sprintf("%-20s", namespace)
-				col2Str = fmt.Sprintf("%-20s", pod)
+				col1Str = padToWidth(namespace, 20)
+				col2Str = padToWidth(pod, 20)
 				columnsWidth = 42 // 20 + 20 + 2 spaces
 			} else {
 				// Normal mode: show host.name and service.name from OTLP attributes
@@ -47,65 +102,48 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 				service := entry.Attributes["service.name"]
 
 				// Truncate to fit column width
-				if len(host) > 12 {
-					host = host[:9] + "..."
-				}
-				if len(service) > 16 {
-					service = service[:13] + "..."
-				}
+				host = truncateToWidth(host, 12)
+				service = truncateToWidth(service, 16)
 
 				// Format fixed-width columns
-				col1Str = fmt.Sprintf("%-12s", host)
-				col2Str = fmt.Sprintf("%-16s", service)
+				col1Str = padToWidth(host, 12)
+				col2Str = padToWidth(service, 16)
 				columnsWidth = 30 // 12 + 16 + 2 spaces
 			}
 
 			// Calculate remaining space for message
 			// Use same calculation as non-selected: availableWidth - 18 - columnsWidth
-			maxMessageLen := availableWidth - 18 - columnsWidth
+			maxMessageLen := availableWidth - 18 - timestampExtra - columnsWidth
 			if maxMessageLen < 10 {
 				maxMessageLen = 10
 			}
 
-			message := entry.Message
-			if len(message) > maxMessageLen {
-				message = message[:maxMessageLen-3] + "..."
-			}
+			message := outlierMarker(entry) + truncateToWidth(entry.Message, maxMessageLen-outlierMarkerWidth(entry))
 
 			logLine = fmt.Sprintf("%s %-5s %s %s %s", timestamp, severity, col1Str, col2Str, message)
 		} else {
 			// Calculate space for message - use same as non-selected: availableWidth - 18
-			maxMessageLen := availableWidth - 18
+			maxMessageLen := availableWidth - 18 - timestampExtra
 			if maxMessageLen < 10 {
 				maxMessageLen = 10
 			}
 
-			message := entry.Message
-			if len(message) > maxMessageLen {
-				message = message[:maxMessageLen-3] + "..."
-			}
+			message := outlierMarker(entry) + truncateToWidth(entry.Message, maxMessageLen-outlierMarkerWidth(entry))
 
 			logLine = fmt.Sprintf("%s %-5s %s", timestamp, severity, message)
 		}
 
-		// Apply selection style to entire line
-		selectedStyle := lipgloss.NewStyle().
-			Background(ColorBlue).
-			Foreground(ColorWhite)
-		return selectedStyle.Render(logLine)
+		// Apply selection style to entire line (selection wins over row rules)
+		background := ColorBlue
+		if !isSelected {
+			background = rowColor
+		}
+		return styles.rowStyle(background).Render(logLine)
 	}
 
 	// Normal (non-selected) formatting with individual component colors
-	severityColor := GetSeverityColor(entry.Severity)
-
-	styledSeverity := lipgloss.NewStyle().
-		Foreground(severityColor).
-		Bold(true).
-		Render(fmt.Sprintf("%-5s", entry.Severity))
-
-	styledTimestamp := lipgloss.NewStyle().
-		Foreground(ColorGray).
-		Render(timestamp)
+	styledSeverity := styles.severityStyle(entry.Severity).Render(fmt.Sprintf("%-5s", entry.Severity))
+	styledTimestamp := styles.timestamp.Render(timestamp)
 
 	// Extract columns if enabled (K8s or Host/Service)
 	var col1, col2 string
@@ -116,23 +154,20 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 		pod := entry.Attributes["k8s.pod"]
 		isK8s := namespace != "" || pod != ""
 
-		if isK8s {
+		if m.scopeColumns {
+			// Scope mode: show the instrumentation scope name and version
+			col1 = styles.col1.Render(padToWidth(truncateToWidth(entry.Attributes[ScopeNameAttribute], 20), 20))
+			col2 = styles.col2.Render(padToWidth(truncateToWidth(entry.Attributes[ScopeVersionAttribute], 10), 10))
+
+			columnsWidth = 32 // 20 + 10 + 2 spaces
+		} else if isK8s {
 			// K8s mode: show namespace and pod (both truncated to 20 chars)
-			if len(namespace) > 20 {
-				namespace = namespace[:17] + "..."
-			}
-			if len(pod) > 20 {
-				pod = pod[:17] + "..."
-			}
+			namespace = truncateToWidth(namespace, 20)
+			pod = truncateToWidth(pod, 20)
 
 			// Style the k8s columns
-			col1 = lipgloss.NewStyle().
-				Foreground(ColorGreen).
-				Render(fmt.Sprintf("%-20s", namespace))
-
-			col2 = lipgloss.NewStyle().
-				Foreground(ColorBlue).
-				Render(fmt.Sprintf("%-20s", pod))
+			col1 = styles.col1.Render(padToWidth(namespace, 20))
+			col2 = styles.col2.Render(padToWidth(pod, 20))
 
 			columnsWidth = 42 // 20 + 20 + 2 spaces
 		} else {
@@ -141,21 +176,12 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 			service := entry.Attributes["service.name"]
 
 			// Truncate to fit column width (12 chars / 16 chars)
-			if len(host) > 12 {
-				host = host[:9] + "..."
-			}
-			if len(service) > 16 {
-				service = service[:13] + "..."
-			}
+			host = truncateToWidth(host, 12)
+			service = truncateToWidth(service, 16)
 
 			// Style the columns
-			col1 = lipgloss.NewStyle().
-				Foreground(ColorGreen).
-				Render(fmt.Sprintf("%-12s", host))
-
-			col2 = lipgloss.NewStyle().
-				Foreground(ColorBlue).
-				Render(fmt.Sprintf("%-16s", service))
+			col1 = styles.col1.Render(padToWidth(host, 12))
+			col2 = styles.col2.Render(padToWidth(service, 16))
 
 			columnsWidth = 30 // 12 + 16 + 2 spaces
 		}
@@ -164,17 +190,30 @@ func (m *DashboardModel) formatLogEntry(entry LogEntry, availableWidth int, isSe
 	// Truncate message if too long
 	message := entry.Message
 
-	maxMessageLen := availableWidth - 18 - columnsWidth // Account for timestamp, severity, and columns
+	maxMessageLen := availableWidth - 18 - timestampExtra - columnsWidth // Account for timestamp, severity, and columns
 	if maxMessageLen < 10 {
 		maxMessageLen = 10 // Absolute minimum
 	}
-	if len(message) > maxMessageLen {
-		message = message[:maxMessageLen-3] + "..."
-	}
+	message = truncateToWidth(message, maxMessageLen-outlierMarkerWidth(entry))
 
 	// Apply search term highlighting to message (word-level highlighting)
+	plainMessage := message
 	if m.searchTerm != "" {
-		message = m.highlightText(message, m.searchTerm)
+		if m.fuzzySearch {
+			message = m.highlightFuzzy(message, m.searchTerm)
+		} else {
+			message = m.highlightText(message, m.searchTerm)
+		}
+	}
+
+	// Apply user highlight rules unless search highlighting already styled the message
+	if message == plainMessage {
+		message = m.applyHighlightRules(message)
+	}
+
+	// Mark entries with an outlier numeric attribute
+	if entry.Outlier != "" {
+		message = styles.outlier.Render(outlierMarker(entry)) + message
 	}
 
 	// Create the complete log line
@@ -217,12 +256,7 @@ func (m *DashboardModel) highlightText(text, searchTerm string) string {
 		result.WriteString(text[lastIndex:actualIndex])
 
 		// Append highlighted match
-		highlightStyle := lipgloss.NewStyle().
-			Background(ColorYellow). // Yellow for word highlighting
-			Foreground(ColorBlack).
-			Bold(true)
-
-		result.WriteString(highlightStyle.Render(text[actualIndex : actualIndex+len(searchTerm)]))
+		result.WriteString(getRowStyles().match.Render(text[actualIndex : actualIndex+len(searchTerm)]))
 
 		// Move past this match
 		lastIndex = actualIndex + len(searchTerm)
@@ -260,41 +294,25 @@ func (m *DashboardModel) wrapTextToWidth(text string, width int) string {
 		return text
 	}
 
-	lines := strings.Split(text, "\n")
-	var wrappedLines []string
-
-	for _, line := range lines {
-		// Use lipgloss.Width to get visual width (ignoring ANSI sequences)
-		if lipgloss.Width(line) <= width {
-			wrappedLines = append(wrappedLines, line)
-			continue
-		}
-
-		// Character-based wrapping - don't split by words for log content
-		remaining := line
-		for len(remaining) > 0 {
-			// Find the maximum characters that fit within width
-			maxChars := min(len(remaining), width)
-
-			// Adjust maxChars to fit within visual width
-			for maxChars > 0 && lipgloss.Width(remaining[:maxChars]) > width {
-				maxChars--
-			}
-
-			// Try to fit more characters if possible
-			for maxChars < len(remaining) && lipgloss.Width(remaining[:maxChars+1]) <= width {
-				maxChars++
-			}
-
-			if maxChars <= 0 {
-				maxChars = 1 // At least one character
-			}
+	// Character-based wrapping - don't split by words for log content.
+	// Widths are measured per grapheme, so wide characters are never split.
+	return ansi.Hardwrap(text, width, true)
+}
 
-			chunk := remaining[:maxChars]
-			wrappedLines = append(wrappedLines, chunk)
-			remaining = remaining[maxChars:]
-		}
+// truncateToWidth shortens text to fit a display width, adding "...". CJK
+// characters and emoji count as two cells and are never cut in half, and
+// ANSI styling is preserved.
+func truncateToWidth(text string, width int) string {
+	if ansi.StringWidth(text) <= width {
+		return text
 	}
+	return ansi.Truncate(text, width, "...")
+}
 
-	return strings.Join(wrappedLines, "\n")
+// padToWidth pads text with spaces to a display width, for aligned columns
+func padToWidth(text string, width int) string {
+	if gap := width - ansi.StringWidth(text); gap > 0 {
+		return text + strings.Repeat(" ", gap)
+	}
+	return text
 }
@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fuzzy scoring weights, loosely modelled on fzf's v1 algorithm
+const (
+	fuzzyScoreMatch       = 16 // Base score for every matched character
+	fuzzyBonusConsecutive = 8  // Bonus when a match directly follows the previous one
+	fuzzyBonusBoundary    = 8  // Bonus when a match starts a word
+	fuzzyPenaltyGap       = 1  // Penalty for every skipped character inside the match
+
+	// Matches scoring below half the base score per pattern character are too
+	// scattered to be useful and are rejected
+	fuzzyMinScoreRatio = 2
+)
+
+// fuzzyMatch performs case-insensitive subsequence matching of pattern in text.
+// It returns a score (higher is better), the rune positions of the matched
+// characters in text, and whether the pattern matched well enough. Like fzf, a
+// forward scan finds the first complete match and a backward scan from its
+// end tightens it to the shortest window.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return 0, nil, false
+	}
+	textRunes := []rune(text)
+
+	// Forward scan: find where the first full subsequence match ends
+	pi := 0
+	end := -1
+	for ti, r := range textRunes {
+		if unicode.ToLower(r) == patternRunes[pi] {
+			pi++
+			if pi == len(patternRunes) {
+				end = ti
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward scan: tighten the window by matching from the end
+	positions := make([]int, len(patternRunes))
+	pi = len(patternRunes) - 1
+	for ti := end; ti >= 0 && pi >= 0; ti-- {
+		if unicode.ToLower(textRunes[ti]) == patternRunes[pi] {
+			positions[pi] = ti
+			pi--
+		}
+	}
+
+	// Score the match
+	score := 0
+	for i, pos := range positions {
+		score += fuzzyScoreMatch
+		if i > 0 {
+			if pos == positions[i-1]+1 {
+				score += fuzzyBonusConsecutive
+			} else {
+				score -= (pos - positions[i-1] - 1) * fuzzyPenaltyGap
+			}
+		}
+		if pos == 0 || !isWordRune(textRunes[pos-1]) {
+			score += fuzzyBonusBoundary
+		}
+	}
+
+	if score < len(patternRunes)*fuzzyScoreMatch/fuzzyMinScoreRatio {
+		return score, nil, false
+	}
+
+	return score, positions, true
+}
+
+// isWordRune reports whether r is part of a word for boundary scoring
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// matchesSearch reports whether an entry's message matches the current search
+// term, using fuzzy matching when fuzzy search mode is enabled
+func (m *DashboardModel) matchesSearch(entry LogEntry) bool {
+	if m.searchTerm == "" {
+		return false
+	}
+	if m.fuzzySearch {
+		_, _, ok := fuzzyMatch(m.searchTerm, entry.Message)
+		return ok
+	}
+	return strings.Contains(strings.ToLower(entry.Message), strings.ToLower(m.searchTerm))
+}
+
+// jumpToSearchMatch moves the log selection to the next (direction 1) or
+// previous (direction -1) entry matching the search term, wrapping around
+func (m *DashboardModel) jumpToSearchMatch(direction int) {
+	count := len(m.logEntries)
+	if count == 0 || m.searchTerm == "" {
+		return
+	}
+
+	for step := 1; step <= count; step++ {
+		idx := ((m.selectedLogIndex+direction*step)%count + count) % count
+		if m.matchesSearch(m.logEntries[idx]) {
+			m.selectedLogIndex = idx
+			// Stop following new logs so the match stays selected
+			m.syncLogFollow()
+			return
+		}
+	}
+}
+
+// highlightFuzzy highlights the characters of text matched by a fuzzy search
+func (m *DashboardModel) highlightFuzzy(text, searchTerm string) string {
+	_, positions, ok := fuzzyMatch(searchTerm, text)
+	if !ok {
+		return text
+	}
+
+	highlightStyle := getRowStyles().match
+
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	// Group consecutive matched runes so each run is rendered once
+	var result strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && matched[j] == matched[i] {
+			j++
+		}
+		if matched[i] {
+			result.WriteString(highlightStyle.Render(string(runes[i:j])))
+		} else {
+			result.WriteString(string(runes[i:j]))
+		}
+		i = j
+	}
+
+	return result.String()
+}
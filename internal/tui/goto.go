@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/query"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetShowLineNumbers sets whether entry sequence numbers are shown in the log list
+func (m *DashboardModel) SetShowLineNumbers(show bool) {
+	m.showLineNumbers = show
+}
+
+// lineNumberPrefix returns the "#N " column for an entry, padded to the
+// width of the highest number so rows stay aligned
+func (m *DashboardModel) lineNumberPrefix(entry LogEntry) string {
+	if !m.showLineNumbers {
+		return ""
+	}
+	width := len(strconv.FormatInt(m.lastSeq, 10))
+	// Entries read back from before the first one received have no number
+	if entry.Seq == 0 {
+		return strings.Repeat(" ", width+2)
+	}
+	return fmt.Sprintf("#%-*d ", width, entry.Seq)
+}
+
+// openGotoPrompt asks for an entry number to jump to, or a query to run
+func (m *DashboardModel) openGotoPrompt() {
+	if len(m.allLogEntries) == 0 {
+		return
+	}
+	m.gotoError = ""
+	m.gotoInput.SetValue("")
+	m.gotoInput.Focus()
+	m.showGotoPrompt = true
+}
+
+// closeGotoPrompt hides the go to prompt
+func (m *DashboardModel) closeGotoPrompt() {
+	m.showGotoPrompt = false
+	m.gotoInput.Blur()
+}
+
+// handleGotoPromptKeys processes keyboard input for the go to prompt
+func (m *DashboardModel) handleGotoPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc":
+		m.closeGotoPrompt()
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.gotoInput.Value())
+		if query.IsQuery(value) {
+			q, err := query.Parse(value)
+			if err != nil {
+				m.gotoError = err.Error()
+				return m, nil
+			}
+			m.closeGotoPrompt()
+			m.openQueryModal(q)
+			return m, nil
+		}
+		seq, err := strconv.ParseInt(strings.TrimPrefix(value, "#"), 10, 64)
+		if err != nil || seq <= 0 {
+			return m, nil
+		}
+		m.closeGotoPrompt()
+		m.gotoEntry(seq)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.gotoInput, cmd = m.gotoInput.Update(msg)
+	return m, cmd
+}
+
+// findEntryBySeq returns the index of the entry with the sequence number,
+// or -1. Entries are kept in arrival order, so the search is binary.
+func findEntryBySeq(entries []LogEntry, seq int64) int {
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].Seq >= seq })
+	if i < len(entries) && entries[i].Seq == seq {
+		return i
+	}
+	return -1
+}
+
+// gotoEntry selects the entry with the sequence number in the log list,
+// explaining why when it cannot be shown
+func (m *DashboardModel) gotoEntry(seq int64) {
+	if i := findEntryBySeq(m.logEntries, seq); i >= 0 {
+		m.activeSection = SectionLogs
+		m.selectedLogIndex = i
+		// Stop following new logs so the entry stays selected
+		m.syncLogFollow()
+		return
+	}
+
+	switch {
+	case seq > m.lastSeq:
+		m.showBulkResult(fmt.Sprintf("Entry #%d Not Found\n\nOnly %d entries have been received so far.", seq, m.lastSeq))
+	case findEntryBySeq(m.allLogEntries, seq) >= 0:
+		m.showBulkResult(fmt.Sprintf("Entry #%d Is Hidden\n\nThe entry is in the buffer but hidden by the active filters.", seq))
+	default:
+		oldest := int64(0)
+		if len(m.allLogEntries) > 0 {
+			oldest = m.allLogEntries[0].Seq
+		}
+		m.showBulkResult(fmt.Sprintf("Entry #%d Not Found\n\nThe entry is no longer in the log buffer (oldest is #%d).", seq, oldest))
+	}
+}
+
+// renderGotoPrompt renders the go to entry prompt
+func (m *DashboardModel) renderGotoPrompt() string {
+	modalWidth := min(m.width-8, 70)
+	contentWidth := modalWidth - 4
+
+	oldest := int64(0)
+	if len(m.allLogEntries) > 0 {
+		oldest = m.allLogEntries[0].Seq
+	}
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Render(fmt.Sprintf("Go to entry (#%d-#%d) or query the view", oldest, m.lastSeq))
+
+	gray := lipgloss.NewStyle().Foreground(ColorGray)
+	m.gotoInput.Width = contentWidth - 4
+	lines := []string{header, "", ": " + m.gotoInput.View(), "",
+		gray.Width(contentWidth).Render("e.g. 1234, count() by (service) over 1m or p99(duration) by (http.route) where severity!=DEBUG")}
+	if m.gotoError != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(ColorRed).Width(contentWidth).Render(m.gotoError))
+	}
+	lines = append(lines, "", gray.Render("Enter: Go or run • ESC: Cancel"))
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	modal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
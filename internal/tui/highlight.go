@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HighlightRule colorizes log text matching a regex, independent of filtering.
+// Text rules color each match in the message; row rules color the background
+// of the entire log line when the message matches.
+type HighlightRule struct {
+	Pattern *regexp.Regexp
+	Color   lipgloss.Color
+	Row     bool // Apply as background to the whole row instead of the match
+}
+
+// runtimeHighlightColors is the palette cycled through for rules added from the TUI
+var runtimeHighlightColors = []string{"cyan", "magenta", "orange", "green", "pink", "blue"}
+
+// ParseHighlightRule parses a highlight rule spec of the form "PATTERN=COLOR"
+// or "PATTERN=bg:COLOR". PATTERN is a regular expression; the last '=' in the
+// spec separates it from the color so patterns may contain '='. COLOR is a
+// palette name (red, green, blue, cyan, ...), a hex value or an ANSI number.
+// The "bg:" prefix turns the rule into a whole-row background highlight.
+func ParseHighlightRule(spec string) (HighlightRule, error) {
+	idx := strings.LastIndex(spec, "=")
+	if idx <= 0 || idx == len(spec)-1 {
+		return HighlightRule{}, fmt.Errorf("invalid highlight rule %q: expected PATTERN=COLOR", spec)
+	}
+
+	pattern, color := spec[:idx], strings.TrimSpace(spec[idx+1:])
+	row := false
+	if strings.HasPrefix(color, "bg:") {
+		row = true
+		color = strings.TrimPrefix(color, "bg:")
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return HighlightRule{}, fmt.Errorf("invalid highlight pattern %q: %w", pattern, err)
+	}
+
+	return HighlightRule{
+		Pattern: regex,
+		Color:   resolveHighlightColor(color),
+		Row:     row,
+	}, nil
+}
+
+// resolveHighlightColor maps a color name to the current palette, passing
+// hex values and ANSI color numbers through unchanged
+func resolveHighlightColor(name string) lipgloss.Color {
+	switch strings.ToLower(name) {
+	case "red":
+		return ColorRed
+	case "green":
+		return ColorGreen
+	case "blue":
+		return ColorBlue
+	case "yellow":
+		return ColorYellow
+	case "orange":
+		return ColorOrange
+	case "pink":
+		return ColorPink
+	case "gray", "grey":
+		return ColorGray
+	case "white":
+		return ColorWhite
+	case "black":
+		return ColorBlack
+	case "cyan":
+		return lipgloss.Color("#00D7D7")
+	case "magenta", "purple":
+		return lipgloss.Color("#D75FD7")
+	default:
+		return lipgloss.Color(name)
+	}
+}
+
+// SetHighlightRules sets the highlight rules loaded from configuration
+func (m *DashboardModel) SetHighlightRules(rules []HighlightRule) {
+	m.highlightRules = rules
+	m.highlightGeneration++
+}
+
+// toggleSearchHighlightRule adds the current search term as a highlight rule,
+// or removes it if a rule for the term already exists
+func (m *DashboardModel) toggleSearchHighlightRule() {
+	if m.searchTerm == "" {
+		return
+	}
+
+	m.highlightGeneration++
+	pattern := "(?i)" + regexp.QuoteMeta(m.searchTerm)
+	for i, rule := range m.highlightRules {
+		if rule.Pattern.String() == pattern {
+			m.highlightRules = append(m.highlightRules[:i], m.highlightRules[i+1:]...)
+			return
+		}
+	}
+
+	color := runtimeHighlightColors[m.runtimeHighlightCount%len(runtimeHighlightColors)]
+	m.runtimeHighlightCount++
+	m.highlightRules = append(m.highlightRules, HighlightRule{
+		Pattern: regexp.MustCompile(pattern),
+		Color:   resolveHighlightColor(color),
+	})
+}
+
+// rowHighlightColor returns the background color of the first row rule
+// matching the message, if any
+func (m *DashboardModel) rowHighlightColor(message string) (lipgloss.Color, bool) {
+	for _, rule := range m.highlightRules {
+		if rule.Row && rule.Pattern.MatchString(message) {
+			return rule.Color, true
+		}
+	}
+	return "", false
+}
+
+// applyHighlightRules colors all text rule matches in a plain message.
+// Earlier rules win when matches overlap.
+func (m *DashboardModel) applyHighlightRules(message string) string {
+	if len(m.highlightRules) == 0 {
+		return message
+	}
+
+	// Record which rule colors each byte of the message
+	owner := make([]int, len(message))
+	for i := range owner {
+		owner[i] = -1
+	}
+	matched := false
+	for ruleIdx, rule := range m.highlightRules {
+		if rule.Row {
+			continue
+		}
+		for _, loc := range rule.Pattern.FindAllStringIndex(message, -1) {
+			for i := loc[0]; i < loc[1]; i++ {
+				if owner[i] == -1 {
+					owner[i] = ruleIdx
+					matched = true
+				}
+			}
+		}
+	}
+	if !matched {
+		return message
+	}
+
+	var result strings.Builder
+	for i := 0; i < len(message); {
+		j := i
+		for j < len(message) && owner[j] == owner[i] {
+			j++
+		}
+		if owner[i] >= 0 {
+			style := lipgloss.NewStyle().Foreground(m.highlightRules[owner[i]].Color).Bold(true)
+			result.WriteString(style.Render(message[i:j]))
+		} else {
+			result.WriteString(message[i:j])
+		}
+		i = j
+	}
+
+	return result.String()
+}
@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HTTP facet limits
+const (
+	httpFacetWindow    = 5 * time.Minute
+	maxHTTPPaths       = 500  // Distinct endpoints counted per minute
+	maxHTTPDurations   = 2000 // Latest request durations kept per minute
+	maxHTTPPathsShown  = 5
+	httpSlowPercentile = 0.9 // The slow filter keeps requests at or above this
+)
+
+// httpStatusAttributeKeys are the attributes an HTTP status code is read
+// from, as OTLP, JSON and the access-log formats name it
+var httpStatusAttributeKeys = []string{"http.response.status_code", "http.status_code", "http.status", "http_status", "http_status_code", "status_code", "status"}
+
+// httpMinute counts the requests of one minute
+type httpMinute struct {
+	start     time.Time
+	classes   [6]int                    // By status class, 2xx at 2
+	paths     map[string]*httpPathCount // By endpoint, e.g. "GET /users/:id"
+	durations []float64                 // In milliseconds
+}
+
+// httpPathCount is the requests of one endpoint
+type httpPathCount struct {
+	requests     int
+	serverErrors int
+}
+
+// httpPathStat is an endpoint with its requests, for the pane
+type httpPathStat struct {
+	endpoint string
+	httpPathCount
+}
+
+// httpFacet counts the HTTP requests of the last few minutes by status
+// class and endpoint, for the HTTP pane
+type httpFacet struct {
+	minutes []*httpMinute // Oldest first
+}
+
+// httpSummary is what the HTTP pane shows
+type httpSummary struct {
+	requests int
+	classes  [6]int
+	paths    []httpPathStat // Most requested first
+	p50, p90 float64
+	timed    int // Requests with a duration
+}
+
+// httpStatus reads an entry's HTTP status code
+func httpStatus(entry LogEntry) (int, bool) {
+	for _, key := range httpStatusAttributeKeys {
+		value, ok := entry.Attributes[key]
+		if !ok {
+			continue
+		}
+		if status, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && status >= 100 && status <= 599 {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// observe counts an entry if it is an HTTP request, with its duration when
+// the latency tracker finds one
+func (f *httpFacet) observe(entry LogEntry, latency *latencyTracker, now time.Time) {
+	status, ok := httpStatus(entry)
+	if !ok {
+		return
+	}
+	start := now.Truncate(time.Minute)
+	if len(f.minutes) == 0 || f.minutes[len(f.minutes)-1].start.Before(start) {
+		f.prune(now)
+		f.minutes = append(f.minutes, &httpMinute{start: start, paths: make(map[string]*httpPathCount)})
+	}
+	minute := f.minutes[len(f.minutes)-1]
+	minute.classes[status/100]++
+
+	if endpoint := entryEndpoint(entry); endpoint != "" {
+		path, tracked := minute.paths[endpoint]
+		if !tracked && len(minute.paths) < maxHTTPPaths {
+			path = &httpPathCount{}
+			minute.paths[endpoint] = path
+		}
+		if path != nil {
+			path.requests++
+			if status >= 500 {
+				path.serverErrors++
+			}
+		}
+	}
+	if ms, ok := latency.duration(entry); ok {
+		minute.durations = append(minute.durations, ms)
+		if len(minute.durations) > maxHTTPDurations {
+			minute.durations = minute.durations[len(minute.durations)-maxHTTPDurations:]
+		}
+	}
+}
+
+// prune drops the minutes that ended before the window
+func (f *httpFacet) prune(now time.Time) {
+	oldest := now.Add(-httpFacetWindow)
+	drop := 0
+	for drop < len(f.minutes) && f.minutes[drop].start.Add(time.Minute).Before(oldest) {
+		drop++
+	}
+	f.minutes = f.minutes[drop:]
+}
+
+// summary adds up the minutes in the window
+func (f *httpFacet) summary(now time.Time) httpSummary {
+	f.prune(now)
+	var s httpSummary
+	paths := make(map[string]*httpPathCount)
+	var durations []float64
+	for _, minute := range f.minutes {
+		for class, count := range minute.classes {
+			s.classes[class] += count
+			s.requests += count
+		}
+		for endpoint, count := range minute.paths {
+			total, ok := paths[endpoint]
+			if !ok {
+				total = &httpPathCount{}
+				paths[endpoint] = total
+			}
+			total.requests += count.requests
+			total.serverErrors += count.serverErrors
+		}
+		durations = append(durations, minute.durations...)
+	}
+
+	for endpoint, count := range paths {
+		s.paths = append(s.paths, httpPathStat{endpoint, *count})
+	}
+	sort.Slice(s.paths, func(i, j int) bool {
+		if s.paths[i].requests != s.paths[j].requests {
+			return s.paths[i].requests > s.paths[j].requests
+		}
+		return s.paths[i].endpoint < s.paths[j].endpoint
+	})
+
+	sort.Float64s(durations)
+	s.timed = len(durations)
+	s.p50, s.p90 = percentile(durations, 0.5), percentile(durations, httpSlowPercentile)
+	return s
+}
+
+// passesHTTPFilters reports whether an entry passes the 5xx and slow
+// request toggles
+func (m *DashboardModel) passesHTTPFilters(entry LogEntry) bool {
+	if !m.serverErrorsOnly && m.slowOnlyMs == 0 {
+		return true
+	}
+	status, ok := httpStatus(entry)
+	if !ok || m.serverErrorsOnly && status < 500 {
+		return false
+	}
+	if m.slowOnlyMs > 0 {
+		ms, ok := m.latency.duration(entry)
+		return ok && ms >= m.slowOnlyMs
+	}
+	return true
+}
+
+// toggleServerErrorsOnly shows only the requests that failed with a 5xx
+// status, or all entries again
+func (m *DashboardModel) toggleServerErrorsOnly() {
+	m.serverErrorsOnly = !m.serverErrorsOnly
+	m.updateFilteredView()
+}
+
+// toggleSlowOnly shows only the requests at or above the p90 duration of
+// the HTTP pane, or all entries again. The threshold is fixed when the
+// filter is turned on, so the view does not shift as requests arrive.
+func (m *DashboardModel) toggleSlowOnly() {
+	if m.slowOnlyMs > 0 {
+		m.slowOnlyMs = 0
+	} else if s := m.httpFacet.summary(time.Now()); s.timed > 0 {
+		m.slowOnlyMs = s.p90
+	} else {
+		return
+	}
+	m.updateFilteredView()
+}
+
+// httpPaneVisible reports whether the HTTP pane is shown
+func (m *DashboardModel) httpPaneVisible() bool {
+	if m.logsMaximized || m.hiddenPanels[PanelHTTP] {
+		return false
+	}
+	m.httpFacet.prune(time.Now())
+	return len(m.httpFacet.minutes) > 0
+}
+
+// httpPaneHeight returns the number of lines the HTTP pane occupies
+func (m *DashboardModel) httpPaneHeight() int {
+	if !m.httpPaneVisible() {
+		return 0
+	}
+	return 3
+}
+
+// httpClassColors colors the status classes in the pane
+var httpClassColors = [6]lipgloss.Color{1: ColorGray, 2: ColorGreen, 3: ColorBlue, 4: ColorYellow, 5: ColorRed}
+
+// renderHTTPPane renders the requests of the last few minutes by status
+// class and their most requested endpoints above the log list
+func (m *DashboardModel) renderHTTPPane() string {
+	s := m.httpFacet.summary(time.Now())
+	width := max(m.width-2, 40)
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+
+	requests := "requests"
+	if s.requests == 1 {
+		requests = "request"
+	}
+	title := fmt.Sprintf("🌐 HTTP (%s %s in the last %s)", formatCount(int64(s.requests)), requests, formatWindowDuration(httpFacetWindow))
+	titleColor := ColorBlue
+	if s.classes[5] > 0 {
+		titleColor = ColorRed
+	}
+	keys := "• %: 5xx only • >: slow only"
+	if m.serverErrorsOnly || m.slowOnlyMs > 0 {
+		keys = "• %/>: toggle 5xx/slow"
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(titleColor).Bold(true).Padding(0, 1).Render(title) + grayStyle.Render(keys),
+	}
+
+	var classes []string
+	for class := 1; class <= 5; class++ {
+		count := s.classes[class]
+		if count == 0 && class == 1 {
+			continue
+		}
+		text := fmt.Sprintf("%dxx %s", class, formatCount(int64(count)))
+		if class >= 4 && count > 0 {
+			text += fmt.Sprintf(" (%.1f%%)", float64(count)*100/float64(s.requests))
+		}
+		style := lipgloss.NewStyle().Foreground(httpClassColors[class])
+		if count == 0 {
+			style = grayStyle
+		}
+		classes = append(classes, style.Bold(count > 0 && class == 5).Render(text))
+	}
+	line := strings.Join(classes, grayStyle.Render(" • "))
+	if s.timed > 0 {
+		line += grayStyle.Render(fmt.Sprintf(" │ p50 %s p90 %s", formatMetricValue(s.p50, true), formatMetricValue(s.p90, true)))
+	}
+	lines = append(lines, " "+truncateToWidth(line, width))
+
+	var paths []string
+	for _, path := range s.paths[:min(len(s.paths), maxHTTPPathsShown)] {
+		text := path.endpoint + " " + formatCount(int64(path.requests))
+		if path.serverErrors > 0 {
+			text += lipgloss.NewStyle().Foreground(ColorRed).Render(fmt.Sprintf(" (5xx %s)", formatCount(int64(path.serverErrors))))
+		}
+		paths = append(paths, text)
+	}
+	if len(paths) == 0 {
+		lines = append(lines, " "+grayStyle.Render("Top paths: none logged"))
+	} else {
+		lines = append(lines, " "+truncateToWidth(grayStyle.Render("Top paths: ")+strings.Join(paths, grayStyle.Render(" • ")), width))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
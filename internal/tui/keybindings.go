@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// keyBinding documents a keyboard shortcut. Bindings without keys are notes
+// shown between the shortcuts of their group.
+type keyBinding struct {
+	keys string
+	desc string
+}
+
+// keyBindingGroup is a titled group of shortcuts in the help screen
+type keyBindingGroup struct {
+	title    string
+	bindings []keyBinding
+}
+
+// keyBindingGroups lists every dashboard shortcut. The help screen is
+// generated from it, so new keys handled in navigation.go belong here too.
+var keyBindingGroups = []keyBindingGroup{
+	{"NAVIGATION", []keyBinding{
+		{"Tab/Shift+Tab", "Navigate between sections"},
+		{"Mouse Click", "Click on any section to switch to it"},
+		{"↑/↓ or k/j", "Move selection within section"},
+		{"Mouse Wheel", "Scroll up/down to navigate selections"},
+		{"Enter", "Show details for selected item; in Words/Attrs, show only the entries with the word or a value"},
+		{"Escape", "Close modal/exit filter mode; leave a Words/Attrs drill-down, restoring the view"},
+	}},
+	{"ACTIONS", []keyBinding{
+		{"/", "Activate filter (regex supported)"},
+		{"s", "Search and highlight text in logs"},
+		{"F", "Filter expression, or ask the AI to write one (Tab switches)"},
+		{"Ctrl+f", "Open severity filter modal"},
+		{"V", "Cycle the severity floor: INFO+, WARN+, ERROR+, all"},
+		{"W", "Add or remove a watch counted in the watches pane"},
+		{"I", "Mark the counts chart now, e.g. for a deploy"},
+		{"Ctrl+k", "Open Kubernetes namespace/pod filter modal"},
+		{"z / x", "Follow only the selected entry's pod, reading more of its history / zoom back out"},
+		{"f", "Open fullscreen log viewer modal"},
+		{"Space", "Pause/unpause UI updates"},
+		{"c", "Cycle Host/Service, Scope/Version and no columns"},
+		{"T", "Toggle timestamp mode (Log Time / Receive Time)"},
+		{"Z", "Toggle timestamps between local time and UTC"},
+		{"D", `Cycle timestamps: absolute / "2.3s ago" / "+120ms" delta`},
+		{"#", "Toggle entry sequence numbers"},
+		{":", "Go to entry #N, or run a query such as count() by (service) over 1m"},
+		{"M", "Maximize log list (hide charts and pinned pane)"},
+		{"[ / ]", "Shrink/grow the charts"},
+		{"H", "Keep current search term as a highlight rule (toggle)"},
+		{"r", "Reset all data (manual reset)"},
+		{"u/U", "Cycle update intervals (forward/backward)"},
+		{"i", "Show comprehensive statistics modal (Tab/Enter: slowest entries of a latency row, d: diagnostics)"},
+		{"i", "AI analysis (when viewing log details)"},
+		{"A", "AI analysis of the filtered view or visual selection (streamed)"},
+		{"S", "AI summary of the latest spike on the Counts chart (⚡ in its title)"},
+		{"C", "Attribute values most associated with the errors in view (Enter filters to one)"},
+		{"G", "Sessions grouped by request/session ID: slowest or failed (Tab), Enter drills down"},
+		{"J", "Table view: sort by any column (←→, s reverses), numeric field (f), group by an attribute (g)"},
+		{"Y", "Service heatmap: errors of each service over time, Enter shows a cell's entries"},
+		{"!", "Show only entries with an outlier numeric attribute (▲ in the list), toggle"},
+		{"@", "Show only entries of sampled traces (trace_flags 01), toggle"},
+		{"% / >", "Show only HTTP requests that failed with a 5xx / at or above the p90 duration of the HTTP pane, toggle"},
+		{"B", "Sources: entries, bytes, parse errors, drops and last entry age of each input"},
+		{"Q", "Unparsed lines: the raw bytes of each line its format failed to parse, with the error"},
+		{"L", "Gonzo's own log messages: warnings, errors and Kubernetes client messages (Tab: level)"},
+		{"w", "Toggle attribute wrapping (when viewing log details)"},
+		{"m", "Switch AI model (shows available models)"},
+		{"? or h", "Toggle this help"},
+		{"q/Ctrl+C", "Quit"},
+	}},
+	{"LOG VIEWER NAVIGATION", []keyBinding{
+		{"Home", "Jump to top of log buffer (stops auto-scroll)"},
+		{"End", "Jump to latest logs (resumes auto-scroll)"},
+		{"", `Scrolling up pauses auto-scroll; "N new entries ↓" counts new arrivals`},
+		{"PgUp/PgDn", "Navigate by pages (10 entries at a time)"},
+		{"↑/↓ or k/j", "Navigate individual entries with smart auto-scroll"},
+		{"n/N", "Jump to the next/previous search match"},
+		{"1-9", "Remove the numbered filter chip"},
+		{"p", "Pin/unpin selected entry to the pinned pane"},
+		{"P", "Clear all pinned entries"},
+		{"R", "Related entries sharing an attribute (request/trace/pod)"},
+		{"+ / -", "Include/exclude entries by a field of the selected entry"},
+		{"v / Shift+↑↓", "Visual mode: select a range of entries (Esc cancels)"},
+		{"y", "Copy selected entry (or visual selection) to clipboard"},
+		{"o / O", "Open selected entry in $PAGER / $EDITOR (also in details)"},
+		{"b", "Open a --link URL of the selected entry (trace, pod, request) in the browser (also in details)"},
+		{"p / i", "In visual mode: pin all / AI analysis of the selection"},
+		{"e", "Export filtered view (or visual selection) to JSONL/CSV/text, or colored HTML/ANSI"},
+		{"E", "Export the current screen with colors as HTML or ANSI text"},
+		{"X", "Export the analysis (patterns, word/attribute/service counts, severity histogram) as JSON/CSV"},
+	}},
+}
+
+// helpKeyWidth is the width of the key column in the help screen
+const helpKeyWidth = 14
+
+// renderKeyBindings renders the shortcut groups as aligned help text,
+// wrapping long descriptions under their first line
+func renderKeyBindings(width int) string {
+	indent := strings.Repeat(" ", 2+helpKeyWidth+3)
+	descWidth := max(20, width-len(indent))
+
+	var b strings.Builder
+	for _, group := range keyBindingGroups {
+		b.WriteString(group.title + ":\n")
+		for _, binding := range group.bindings {
+			if binding.keys == "" {
+				b.WriteString("  " + binding.desc + "\n")
+				continue
+			}
+			lines := strings.Split(ansi.Wordwrap(binding.desc, descWidth, ""), "\n")
+			b.WriteString("  " + padToWidth(binding.keys, helpKeyWidth) + " - " + lines[0] + "\n")
+			for _, line := range lines[1:] {
+				b.WriteString(indent + line + "\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
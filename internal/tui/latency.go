@@ -0,0 +1,355 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/metrics"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Latency tracking limits
+const (
+	DefaultLatencyWindow  = 5 * time.Minute
+	maxLatencyGroups      = 200  // Service/endpoint pairs tracked
+	maxLatencySamples     = 2000 // Latest durations kept per group
+	maxLatencyRows        = 15   // Groups shown in the statistics modal
+	slowEntriesPercentile = 0.9  // Drill-down lists entries at or above this
+)
+
+// latencyAttributePrefixes start the names of the attributes a duration is
+// read from when no field is configured, e.g. duration_ms or http.duration
+var latencyAttributePrefixes = []string{"duration", "latency", "elapsed", "took", "response_time", "request_time"}
+
+// latencyMessageRegex finds durations such as "took 153ms" or
+// "latency=1.2s" in messages
+var latencyMessageRegex = regexp.MustCompile(`(?i)\b(?:took|duration|latency|elapsed|response[_ ]time)[=:]?\s*(\d+(?:\.\d+)?\s?(?:ns|us|µs|ms|s|m))\b`)
+
+// endpointAttributeKeys are the attributes an endpoint is read from
+var endpointAttributeKeys = []string{"http.route", "url.path", "http.target", "http_path", "path", "route", "endpoint", "http.url", "url"}
+
+// methodAttributeKeys are the attributes an HTTP method is read from
+var methodAttributeKeys = []string{"http.request.method", "http.method", "http_method", "method"}
+
+// endpointMessageRegex finds requests such as "GET /api/users" in messages
+var endpointMessageRegex = regexp.MustCompile(`\b(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)\s+(/[^\s?"']*)`)
+
+// pathIDRegex matches path segments that are IDs: numbers, UUIDs and long
+// hex strings, so /users/42 and /users/43 form one endpoint
+var pathIDRegex = regexp.MustCompile(`/(?:\d+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,})(?:/|$)`)
+
+// latencyKey identifies a group of durations
+type latencyKey struct {
+	service  string
+	endpoint string
+}
+
+// latencySample is one duration and the entry it came from
+type latencySample struct {
+	at    time.Time
+	ms    float64
+	entry LogEntry
+}
+
+// latencyTracker keeps the durations of recent entries per service and
+// endpoint, for percentiles over a rolling window
+type latencyTracker struct {
+	field  string // Attribute the duration is read from; empty auto-detects
+	window time.Duration
+	groups map[latencyKey][]latencySample
+}
+
+// latencyStat is the percentile summary of one group
+type latencyStat struct {
+	key           latencyKey
+	count         int
+	p50, p90, p99 float64
+	max           float64
+}
+
+func newLatencyTracker(field string, window time.Duration) *latencyTracker {
+	if window <= 0 {
+		window = DefaultLatencyWindow
+	}
+	return &latencyTracker{field: field, window: window, groups: make(map[latencyKey][]latencySample)}
+}
+
+// SetLatencyTracking sets the attribute durations are read from (empty
+// detects common attributes and "took 153ms" in messages) and the window the
+// latency percentiles cover
+func (m *DashboardModel) SetLatencyTracking(field string, window time.Duration) {
+	m.latency = newLatencyTracker(field, window)
+}
+
+// observe records the duration of an entry, if it has one
+func (t *latencyTracker) observe(entry LogEntry, now time.Time) {
+	ms, ok := t.duration(entry)
+	if !ok {
+		return
+	}
+	key := latencyKey{service: ServiceName(entry), endpoint: entryEndpoint(entry)}
+	samples, tracked := t.groups[key]
+	if !tracked && len(t.groups) >= maxLatencyGroups {
+		// Make room by dropping groups with nothing left in the window
+		t.prune(now)
+		if len(t.groups) >= maxLatencyGroups {
+			return
+		}
+	}
+	samples = append(samples, latencySample{at: now, ms: ms, entry: entry})
+	if len(samples) > maxLatencySamples {
+		samples = samples[len(samples)-maxLatencySamples:]
+	}
+	t.groups[key] = samples
+}
+
+// duration reads an entry's duration in milliseconds
+func (t *latencyTracker) duration(entry LogEntry) (float64, bool) {
+	return entryDuration(entry, t.field)
+}
+
+// entryDuration reads an entry's duration in milliseconds from field, or
+// when it is empty from the first duration attribute or the message
+func entryDuration(entry LogEntry, field string) (float64, bool) {
+	if field != "" {
+		return attributeDuration(field, entry.Attributes[field])
+	}
+	// Of several duration attributes, the first by name is taken
+	found, best, bestKey := false, 0.0, ""
+	for key, value := range entry.Attributes {
+		if (found && key >= bestKey) || !isLatencyAttribute(key) {
+			continue
+		}
+		if ms, ok := attributeDuration(key, value); ok {
+			found, best, bestKey = true, ms, key
+		}
+	}
+	if found {
+		return best, true
+	}
+	if match := latencyMessageRegex.FindStringSubmatch(entry.Message); match != nil {
+		value, _, ok := metrics.ParseValue(strings.ReplaceAll(strings.ReplaceAll(match[1], " ", ""), "µs", "us"))
+		return value, ok
+	}
+	return 0, false
+}
+
+// isLatencyAttribute reports whether an attribute looks like a duration by
+// the last part of its name
+func isLatencyAttribute(key string) bool {
+	name := strings.ToLower(key[strings.LastIndexByte(key, '.')+1:])
+	for _, prefix := range latencyAttributePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeDuration reads a duration attribute in milliseconds. Values with
+// a unit, e.g. 153ms, are converted; bare numbers take the unit from the key
+// (duration_s, elapsed_us) and are otherwise taken as milliseconds.
+func attributeDuration(key, value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	ms, isDuration, ok := metrics.ParseValue(value)
+	if !ok || ms < 0 {
+		return 0, false
+	}
+	if isDuration {
+		return ms, true
+	}
+	key = strings.ToLower(key)
+	switch {
+	case strings.HasSuffix(key, "_ns") || strings.HasSuffix(key, "nanos"):
+		return ms / 1e6, true
+	case strings.HasSuffix(key, "_us") || strings.HasSuffix(key, "micros"):
+		return ms / 1e3, true
+	case strings.HasSuffix(key, "_s") || strings.HasSuffix(key, "_sec") || strings.HasSuffix(key, "seconds"):
+		return ms * 1e3, true
+	}
+	return ms, true
+}
+
+// entryEndpoint names the endpoint of an entry, e.g. "GET /users/:id", from
+// HTTP attributes or the message
+func entryEndpoint(entry LogEntry) string {
+	method, path := "", ""
+	for _, key := range endpointAttributeKeys {
+		if value := entry.Attributes[key]; value != "" {
+			path = value
+			break
+		}
+	}
+	for _, key := range methodAttributeKeys {
+		if value := entry.Attributes[key]; value != "" {
+			method = strings.ToUpper(value)
+			break
+		}
+	}
+	if path == "" {
+		if match := endpointMessageRegex.FindStringSubmatch(entry.Message); match != nil {
+			method, path = match[1], match[2]
+		}
+	}
+	if path == "" {
+		return ""
+	}
+
+	// Full URLs keep only their path; query strings and IDs are dropped
+	if i := strings.Index(path, "://"); i >= 0 {
+		if slash := strings.IndexByte(path[i+3:], '/'); slash >= 0 {
+			path = path[i+3+slash:]
+		} else {
+			path = "/"
+		}
+	}
+	path, _, _ = strings.Cut(path, "?")
+	for pathIDRegex.MatchString(path) {
+		path = pathIDRegex.ReplaceAllStringFunc(path, func(segment string) string {
+			if strings.HasSuffix(segment, "/") {
+				return "/:id/"
+			}
+			return "/:id"
+		})
+	}
+	if method != "" {
+		return method + " " + path
+	}
+	return path
+}
+
+// prune drops samples older than the window
+func (t *latencyTracker) prune(now time.Time) {
+	oldest := now.Add(-t.window)
+	for key, samples := range t.groups {
+		drop := sort.Search(len(samples), func(i int) bool { return samples[i].at.After(oldest) })
+		if drop == len(samples) {
+			delete(t.groups, key)
+			continue
+		}
+		t.groups[key] = samples[drop:]
+	}
+}
+
+// stats summarizes every group within the window, slowest p99 first
+func (t *latencyTracker) stats(now time.Time) []latencyStat {
+	t.prune(now)
+	stats := make([]latencyStat, 0, len(t.groups))
+	for key, samples := range t.groups {
+		values := make([]float64, len(samples))
+		for i, sample := range samples {
+			values[i] = sample.ms
+		}
+		sort.Float64s(values)
+		stats = append(stats, latencyStat{
+			key:   key,
+			count: len(values),
+			p50:   percentile(values, 0.5),
+			p90:   percentile(values, 0.9),
+			p99:   percentile(values, 0.99),
+			max:   values[len(values)-1],
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].p99 != stats[j].p99 {
+			return stats[i].p99 > stats[j].p99
+		}
+		if stats[i].key.service != stats[j].key.service {
+			return stats[i].key.service < stats[j].key.service
+		}
+		return stats[i].key.endpoint < stats[j].key.endpoint
+	})
+	if len(stats) > maxLatencyRows {
+		stats = stats[:maxLatencyRows]
+	}
+	return stats
+}
+
+// slowSamples returns a group's samples at or above its p90, slowest first
+func (t *latencyTracker) slowSamples(key latencyKey, now time.Time) []latencySample {
+	t.prune(now)
+	samples := t.groups[key]
+	if len(samples) == 0 {
+		return nil
+	}
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		values[i] = sample.ms
+	}
+	sort.Float64s(values)
+	threshold := percentile(values, slowEntriesPercentile)
+
+	var slow []latencySample
+	for _, sample := range samples {
+		if sample.ms >= threshold {
+			slow = append(slow, sample)
+		}
+	}
+	sort.SliceStable(slow, func(i, j int) bool { return slow[i].ms > slow[j].ms })
+	return slow
+}
+
+// percentile returns the nearest-rank q-quantile of sorted values
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
+
+// renderLatencySection renders the latency percentiles for the statistics
+// modal, marking the group selected for drill-down
+func (m *DashboardModel) renderLatencySection(stats []latencyStat, width int) string {
+	title := chartTitleStyle.Render(fmt.Sprintf("Latency by Service/Endpoint (last %s)", formatWindowDuration(m.latency.window)))
+
+	numbers := []string{"n", "p50", "p90", "p99", "max"}
+	header := "  " + padToWidth("Service", 18) + " " + padToWidth("Endpoint", 28)
+	for _, name := range numbers {
+		header += fmt.Sprintf(" %8s", name)
+	}
+	lines := []string{title, lipgloss.NewStyle().Foreground(ColorGray).Render(header)}
+
+	selected := lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite)
+	valueStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	for i, stat := range stats {
+		service, endpoint := stat.key.service, stat.key.endpoint
+		if endpoint == "" {
+			endpoint = "-"
+		}
+		values := fmt.Sprintf(" %8d %8s %8s %8s %8s", stat.count,
+			formatMetricValue(stat.p50, true), formatMetricValue(stat.p90, true),
+			formatMetricValue(stat.p99, true), formatMetricValue(stat.max, true))
+		row := padToWidth(truncateToWidth(service, 18), 18) + " " + padToWidth(truncateToWidth(endpoint, 28), 28)
+		if i == m.latencySelected {
+			lines = append(lines, selected.Render("▶ "+row+values))
+		} else {
+			lines = append(lines, "  "+row+valueStyle.Render(values))
+		}
+	}
+
+	// Cut rows rather than wrap them in narrow terminals
+	for i, line := range lines {
+		lines[i] = truncateToWidth(line, max(20, width-4))
+	}
+	return sectionStyle.Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// formatWindowDuration renders a window such as 5m0s as "5m"
+func formatWindowDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Panel names accepted by SetHiddenPanels
+const (
+	PanelWords      = "words"
+	PanelAttributes = "attributes"
+	PanelPatterns   = "patterns"
+	PanelCounts     = "counts"
+	PanelPinned     = "pinned"
+	PanelMetrics    = "metrics"
+	PanelSLO        = "slo"
+	PanelWatches    = "watches"
+	PanelHTTP       = "http"
+
+	PanelNewPatterns = "new-patterns"
+)
+
+// chartPanels lists the chart panels in layout order, two per row
+var chartPanels = []struct {
+	name    string
+	section Section
+}{
+	{PanelWords, SectionWords},
+	{PanelAttributes, SectionAttributes},
+	{PanelPatterns, SectionDistribution},
+	{PanelCounts, SectionCounts},
+}
+
+// chartsPerRow is how many chart panels share a row
+const chartsPerRow = 2
+
+// minChartLines is the smallest chart content height the resize keys allow
+const minChartLines = 3
+
+// SetHiddenPanels hides dashboard panels by name (words, attributes,
+// patterns, counts, pinned, metrics, slo, watches, http, new-patterns). Unknown names are reported and ignored.
+func (m *DashboardModel) SetHiddenPanels(names []string) error {
+	m.hiddenPanels = make(map[string]bool)
+	var unknown []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+		case PanelWords, PanelAttributes, PanelPatterns, PanelCounts, PanelPinned, PanelMetrics, PanelSLO, PanelWatches, PanelHTTP, PanelNewPatterns:
+			m.hiddenPanels[name] = true
+		default:
+			unknown = append(unknown, name)
+		}
+	}
+	m.ensureVisibleSection()
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown panels %s (use words, attributes, patterns, counts, pinned, metrics, slo, watches, http or new-patterns)", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// SetChartsHeight limits the content lines of each chart row. Zero sizes
+// the charts to their content.
+func (m *DashboardModel) SetChartsHeight(lines int) {
+	if lines > 0 {
+		lines = max(lines, minChartLines)
+	}
+	m.chartsLinesLimit = max(0, lines)
+}
+
+// visibleChartSections returns the sections of the charts currently shown
+func (m *DashboardModel) visibleChartSections() []Section {
+	if m.logsMaximized {
+		return nil
+	}
+	var sections []Section
+	for _, panel := range chartPanels {
+		if !m.hiddenPanels[panel.name] {
+			sections = append(sections, panel.section)
+		}
+	}
+	return sections
+}
+
+// chartRows groups the visible charts into rows. Charts on a row share the
+// width, so a lone chart spans the whole terminal.
+func (m *DashboardModel) chartRows() [][]Section {
+	sections := m.visibleChartSections()
+	var rows [][]Section
+	for start := 0; start < len(sections); start += chartsPerRow {
+		rows = append(rows, sections[start:min(start+chartsPerRow, len(sections))])
+	}
+	return rows
+}
+
+// pinnedPaneVisible reports whether the pinned entries pane is shown
+func (m *DashboardModel) pinnedPaneVisible() bool {
+	return len(m.pinnedEntries) > 0 && !m.logsMaximized && !m.hiddenPanels[PanelPinned]
+}
+
+// navigableSections returns the sections Tab cycles through
+func (m *DashboardModel) navigableSections() []Section {
+	return append(m.visibleChartSections(), SectionLogs)
+}
+
+// ensureVisibleSection moves the focus to the log list when the active
+// chart has been hidden
+func (m *DashboardModel) ensureVisibleSection() {
+	if m.activeSection == SectionLogs || m.activeSection == SectionFilter {
+		return
+	}
+	for _, section := range m.visibleChartSections() {
+		if section == m.activeSection {
+			return
+		}
+	}
+	m.activeSection = SectionLogs
+}
+
+// firstSection returns the first visible section, used when leaving the filter
+func (m *DashboardModel) firstSection() Section {
+	return m.navigableSections()[0]
+}
+
+// toggleLogsMaximized hides or restores every panel except the log list
+func (m *DashboardModel) toggleLogsMaximized() {
+	m.logsMaximized = !m.logsMaximized
+	m.ensureVisibleSection()
+}
+
+// resizeCharts grows or shrinks the chart rows by delta lines. Growing
+// past the content height returns to sizing the charts to their content.
+func (m *DashboardModel) resizeCharts(delta int) {
+	natural := 0
+	for _, row := range m.chartRows() {
+		for _, section := range row {
+			natural = max(natural, m.chartContentLines(section))
+		}
+	}
+	if natural == 0 {
+		return
+	}
+
+	lines := m.chartsLinesLimit
+	if lines == 0 {
+		lines = natural
+	}
+	lines = max(minChartLines, lines+delta)
+	if lines >= natural {
+		lines = 0
+	}
+	m.chartsLinesLimit = lines
+}
+
+// chartContentLines returns the content lines a chart needs, excluding its
+// title and borders
+func (m *DashboardModel) chartContentLines(section Section) int {
+	switch section {
+	case SectionWords:
+		return m.calculateWordsContentLines()
+	case SectionAttributes:
+		return m.calculateAttributesContentLines()
+	case SectionDistribution:
+		return m.calculateDistributionContentLines()
+	case SectionCounts:
+		return m.calculateCountsContentLines()
+	}
+	return 0
+}
+
+// chartRowContentLines returns the content lines of a chart row: the
+// tallest chart on it, capped by the user's chart height
+func (m *DashboardModel) chartRowContentLines(row []Section) int {
+	lines := 0
+	for _, section := range row {
+		lines = max(lines, m.chartContentLines(section))
+	}
+	if m.chartsLinesLimit > 0 {
+		lines = min(lines, m.chartsLinesLimit)
+	}
+	return lines
+}
+
+// renderChart renders one chart panel
+func (m *DashboardModel) renderChart(section Section, width, height int) string {
+	switch section {
+	case SectionWords:
+		return m.renderWordsChart(width, height)
+	case SectionAttributes:
+		return m.renderAttributesChart(width, height)
+	case SectionDistribution:
+		// Use drain3 chart instead of distribution chart, but keep distribution code for future use
+		return m.renderDrain3Chart(width, height)
+	case SectionCounts:
+		return m.renderCountsChart(width, height)
+	}
+	return ""
+}
+
+// chartSectionAt returns the chart under a screen position, if any
+func (m *DashboardModel) chartSectionAt(x, y int) (Section, bool) {
+	top := 0
+	for _, row := range m.chartRows() {
+		// Each chart is its content plus a title and two border lines
+		rowHeight := m.chartRowContentLines(row) + 3
+		if y < top+rowHeight {
+			column := min(x/(m.width/len(row)), len(row)-1)
+			return row[column], true
+		}
+		top += rowHeight
+	}
+	return SectionLogs, false
+}
+
+// clipLines keeps at most n lines of text, dropping lines from the end, or
+// from the start when keepBottom is set
+func clipLines(text string, n int, keepBottom bool) string {
+	lines := strings.Split(text, "\n")
+	if n <= 0 || len(lines) <= n {
+		return text
+	}
+	if keepBottom {
+		return strings.Join(lines[len(lines)-n:], "\n")
+	}
+	return strings.Join(lines[:n], "\n")
+}
@@ -0,0 +1,249 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LinkTemplate renders a URL into another tool from an entry attribute, e.g.
+// the Jaeger page of the entry's trace_id
+type LinkTemplate struct {
+	Name      string // Shown in the link picker, e.g. jaeger
+	Attribute string // Attribute the link is offered for, e.g. trace_id
+	URL       string // URL with {value}, {ATTRIBUTE}, {timestamp} and {timestamp_ms} placeholders
+}
+
+// linkNameRegex matches the optional "name:" prefix of a link template
+var linkNameRegex = regexp.MustCompile(`^([A-Za-z0-9_.-]+):\s+`)
+
+// linkPlaceholderRegex matches the placeholders of a URL template. JSON in
+// URLs, such as Grafana's explore state, does not match: its braces are
+// followed by quotes.
+var linkPlaceholderRegex = regexp.MustCompile(`\{([A-Za-z0-9_.\-/]+)\}`)
+
+// ParseLinkTemplate parses a link template of the form "[NAME:] ATTRIBUTE=URL".
+// In URL, {value} is replaced by the attribute's value, {ATTRIBUTE} by the
+// value of any other attribute and {timestamp} or {timestamp_ms} by the
+// entry's time, as RFC 3339 or Unix milliseconds. Values are URL-escaped.
+func ParseLinkTemplate(spec string) (LinkTemplate, error) {
+	rest := strings.TrimSpace(spec)
+	var link LinkTemplate
+	if m := linkNameRegex.FindStringSubmatch(rest); m != nil {
+		link.Name = m[1]
+		rest = rest[len(m[0]):]
+	}
+
+	attribute, template, ok := strings.Cut(rest, "=")
+	link.Attribute, link.URL = strings.TrimSpace(attribute), strings.TrimSpace(template)
+	if !ok || link.Attribute == "" || link.URL == "" {
+		return LinkTemplate{}, fmt.Errorf("invalid link %q: expected [NAME:] ATTRIBUTE=URL", spec)
+	}
+	if u, err := url.Parse(link.URL); err != nil || u.Scheme == "" {
+		return LinkTemplate{}, fmt.Errorf("invalid link %q: %q is not an absolute URL", spec, link.URL)
+	}
+	if link.Name == "" {
+		link.Name = link.Attribute
+	}
+	return link, nil
+}
+
+// Render fills in the template from an entry. It reports false when the
+// entry lacks the attribute or any other placeholder's value.
+func (l LinkTemplate) Render(entry LogEntry) (string, bool) {
+	if entry.Attributes[l.Attribute] == "" {
+		return "", false
+	}
+	timestamp := entry.OrigTimestamp
+	if timestamp.IsZero() {
+		timestamp = entry.Timestamp
+	}
+
+	ok := true
+	rendered := linkPlaceholderRegex.ReplaceAllStringFunc(l.URL, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		var value string
+		switch name {
+		case "value":
+			value = entry.Attributes[l.Attribute]
+		case "timestamp":
+			value = timestamp.UTC().Format(time.RFC3339)
+		case "timestamp_ms":
+			value = strconv.FormatInt(timestamp.UnixMilli(), 10)
+		default:
+			value = entry.Attributes[name]
+		}
+		if value == "" {
+			ok = false
+			return placeholder
+		}
+		// Escape spaces as %20 so values work in paths as well as queries
+		return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+	})
+	return rendered, ok
+}
+
+// SetLinkTemplates sets the URL templates offered for the selected entry
+func (m *DashboardModel) SetLinkTemplates(links []LinkTemplate) {
+	m.linkTemplates = links
+}
+
+// entryLink is a rendered link offered for an entry
+type entryLink struct {
+	name string
+	url  string
+}
+
+// entryLinks renders the link templates that apply to an entry
+func (m *DashboardModel) entryLinks(entry LogEntry) []entryLink {
+	var links []entryLink
+	for _, template := range m.linkTemplates {
+		if rendered, ok := template.Render(entry); ok {
+			links = append(links, entryLink{name: template.Name, url: rendered})
+		}
+	}
+	return links
+}
+
+// LinkOpenedMsg is sent when the browser was asked to open a link
+type LinkOpenedMsg struct {
+	URL string
+	Err error
+}
+
+// browserCommand returns the command opening a URL in the browser: $BROWSER
+// when set, the platform's opener otherwise
+func browserCommand(link string) *exec.Cmd {
+	if fields := strings.Fields(os.Getenv("BROWSER")); len(fields) > 0 {
+		return exec.Command(fields[0], append(fields[1:], link)...)
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", link)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", link)
+	default:
+		return exec.Command("xdg-open", link)
+	}
+}
+
+// openLink opens a URL in the browser without suspending the TUI
+func openLink(link string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := browserCommand(link)
+		if err := cmd.Start(); err != nil {
+			return LinkOpenedMsg{URL: link, Err: err}
+		}
+		// Reap the opener in the background; browsers keep running on their own
+		go cmd.Wait()
+		return LinkOpenedMsg{URL: link}
+	}
+}
+
+// openEntryLinks opens the link of the selected entry, or lets the user pick
+// one when several templates apply
+func (m *DashboardModel) openEntryLinks(entry LogEntry) (tea.Model, tea.Cmd) {
+	if len(m.linkTemplates) == 0 {
+		m.showBulkResult("No Links Configured\n\nAdd URL templates with --link, e.g.\n\n  --link 'jaeger: trace_id=https://jaeger.example.com/trace/{value}'")
+		return m, nil
+	}
+	links := m.entryLinks(entry)
+	switch len(links) {
+	case 0:
+		var attributes []string
+		for _, template := range m.linkTemplates {
+			attributes = append(attributes, template.Attribute)
+		}
+		m.showBulkResult(fmt.Sprintf("No Links\n\nThe entry has none of the attributes links are configured for: %s", strings.Join(attributes, ", ")))
+		return m, nil
+	case 1:
+		return m, openLink(links[0].url)
+	}
+	m.linkPickerOptions = links
+	m.linkPickerSelected = 0
+	m.showLinkPicker = true
+	return m, nil
+}
+
+// handleLinkPickerKeys processes keyboard input for the link picker
+func (m *DashboardModel) handleLinkPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc", "b":
+		m.showLinkPicker = false
+	case "up", "k":
+		m.linkPickerSelected = max(0, m.linkPickerSelected-1)
+	case "down", "j":
+		m.linkPickerSelected = min(len(m.linkPickerOptions)-1, m.linkPickerSelected+1)
+	case "enter":
+		m.showLinkPicker = false
+		return m, openLink(m.linkPickerOptions[m.linkPickerSelected].url)
+	}
+	return m, nil
+}
+
+// renderLinkPickerModal renders the links offered for the selected entry
+func (m *DashboardModel) renderLinkPickerModal() string {
+	modalWidth := min(m.width-16, 100)
+	modalHeight := min(m.height-8, 20)
+	contentWidth := modalWidth - 4
+	contentHeight := modalHeight - 4
+
+	nameWidth := 0
+	for _, link := range m.linkPickerOptions {
+		nameWidth = max(nameWidth, lipgloss.Width(link.name))
+	}
+
+	// Keep the selection visible when there are more links than rows
+	start := max(0, m.linkPickerSelected-contentHeight+1)
+
+	var lines []string
+	for i := start; i < len(m.linkPickerOptions) && i < start+contentHeight; i++ {
+		link := m.linkPickerOptions[i]
+		prefix := "  "
+		if i == m.linkPickerSelected {
+			prefix = "► "
+		}
+		line := truncateToWidth(prefix+padToWidth(link.name, nameWidth)+"  "+link.url, contentWidth)
+		if i == m.linkPickerSelected {
+			line = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	contentPane := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(contentHeight).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(ColorBlue).
+		Render(strings.Join(lines, "\n"))
+
+	header := lipgloss.NewStyle().
+		Width(contentWidth).
+		Foreground(ColorBlue).
+		Bold(true).
+		Render("Open in browser...")
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Render("↑↓: Navigate • Enter: Open • ESC: Cancel")
+
+	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
+
+	finalModal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Height(modalHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(modal)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, finalModal)
+}
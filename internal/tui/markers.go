@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxMarkers is the number of markers kept, oldest dropped first
+const maxMarkers = 100
+
+// maxMarkerBadgeWidth is the widest marker label shown in the counts chart title
+const maxMarkerBadgeWidth = 40
+
+// marker flags a moment on the counts chart, such as a deploy
+type marker struct {
+	label    string
+	at       time.Time
+	interval int64 // Counts interval the marker falls in, see countsIntervals
+}
+
+// AddMarker flags the counts interval in progress with a label, drawn as a
+// vertical line on the counts chart, e.g. for a deploy
+func (m *DashboardModel) AddMarker(label string, at time.Time) {
+	m.markers = append(m.markers, marker{label: label, at: at, interval: m.countsIntervals})
+	if len(m.markers) > maxMarkers {
+		m.markers = m.markers[len(m.markers)-maxMarkers:]
+	}
+}
+
+// openMarkerPrompt asks for the label of a new marker
+func (m *DashboardModel) openMarkerPrompt() {
+	m.markerInput.SetValue("")
+	m.markerInput.Focus()
+	m.showMarkerPrompt = true
+}
+
+// closeMarkerPrompt hides the marker prompt
+func (m *DashboardModel) closeMarkerPrompt() {
+	m.showMarkerPrompt = false
+	m.markerInput.Blur()
+}
+
+// handleMarkerPromptKeys processes keyboard input for the marker prompt
+func (m *DashboardModel) handleMarkerPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc":
+		m.closeMarkerPrompt()
+		return m, nil
+	case "enter":
+		now := time.Now()
+		label := strings.TrimSpace(m.markerInput.Value())
+		if label == "" {
+			label = "mark " + m.inDisplayZone(now).Format("15:04:05")
+		}
+		m.AddMarker(label, now)
+		m.closeMarkerPrompt()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.markerInput, cmd = m.markerInput.Update(msg)
+	return m, cmd
+}
+
+// renderMarkerPrompt renders the marker prompt
+func (m *DashboardModel) renderMarkerPrompt() string {
+	modalWidth := min(m.width-8, 60)
+	contentWidth := modalWidth - 4
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Render("Mark the counts chart")
+
+	const label = "Label: "
+	m.markerInput.Width = contentWidth - len(label) - 2
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		"",
+		label+m.markerInput.View(),
+		"",
+		lipgloss.NewStyle().Foreground(ColorGray).Render("Enter: Mark now • ESC: Cancel"),
+	)
+
+	modal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// markerHistoryIndex returns the countsHistory index of a marker's interval:
+// len(countsHistory) for the interval in progress, negative once scrolled
+// out of the history
+func (m *DashboardModel) markerHistoryIndex(mk marker) int {
+	return int(mk.interval - (m.countsIntervals - int64(len(m.countsHistory))))
+}
+
+// markerBadge names the latest marker still on the counts chart, for its
+// title
+func (m *DashboardModel) markerBadge(firstShown int) string {
+	for i := len(m.markers) - 1; i >= 0; i-- {
+		mk := m.markers[i]
+		if m.markerHistoryIndex(mk) < firstShown {
+			break
+		}
+		return fmt.Sprintf(" │ %s %s", truncateToWidth(mk.label, maxMarkerBadgeWidth), formatAgo(time.Since(mk.at)))
+	}
+	return ""
+}
+
+// drawMarkers draws the markers on the bars of the counts chart as vertical
+// lines in the gap before their interval's bar, leaving the bars intact.
+// Bar i of the chart is history entry firstShown+i-padding, and each bar
+// takes barStep columns with its gap.
+func (m *DashboardModel) drawMarkers(lines []string, firstShown, padding, barStep, width int) {
+	style := lipgloss.NewStyle().Foreground(ColorPink).Bold(true)
+	drawn := make(map[int]bool)
+	for _, mk := range m.markers {
+		index := m.markerHistoryIndex(mk)
+		if index < firstShown {
+			continue
+		}
+		column := max(0, (padding+index-firstShown)*barStep-1)
+		if column >= width || drawn[column] {
+			continue
+		}
+		drawn[column] = true
+		for i, line := range lines {
+			plain := []rune(ansi.Strip(line))
+			if column < len(plain) && plain[column] != ' ' {
+				continue
+			}
+			for len(plain) <= column {
+				line += " "
+				plain = append(plain, ' ')
+			}
+			lines[i] = ansi.Truncate(line, column, "") + style.Render("│") + ansi.TruncateLeft(line, column+1, "")
+		}
+	}
+}
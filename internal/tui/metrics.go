@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/metrics"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxMetricRows is the number of lines of metrics shown in the pane
+const maxMetricRows = 3
+
+// metricCellSeparator separates the metrics sharing a line
+const metricCellSeparator = "   │   "
+
+// SetMetricSet sets the metrics shown in the metrics pane. The processing
+// pipeline feeds them, so they cover every arrival whatever the view's filters.
+func (m *DashboardModel) SetMetricSet(set *metrics.Set) {
+	m.metricSet = set
+}
+
+// metricsPaneVisible reports whether the metrics pane is shown
+func (m *DashboardModel) metricsPaneVisible() bool {
+	return m.metricSet != nil && !m.logsMaximized && !m.hiddenPanels[PanelMetrics]
+}
+
+// metricsPaneHeight returns the number of lines the metrics pane occupies
+func (m *DashboardModel) metricsPaneHeight() int {
+	if !m.metricsPaneVisible() {
+		return 0
+	}
+	return 1 + len(m.metricLines())
+}
+
+// metricLines packs the formatted metrics into lines of the pane's width
+func (m *DashboardModel) metricLines() []string {
+	width := max(m.width-2, 40)
+	var lines []string
+	current := ""
+	for _, snap := range m.metricSet.Snapshot(time.Now()) {
+		cell := formatMetric(snap)
+		switch {
+		case current == "":
+			current = cell
+		case lipgloss.Width(current+metricCellSeparator+cell) <= width:
+			current += metricCellSeparator + cell
+		default:
+			lines = append(lines, current)
+			current = cell
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	if len(lines) > maxMetricRows {
+		lines = lines[:maxMetricRows]
+	}
+	for i, line := range lines {
+		lines[i] = truncateToWidth(line, width)
+	}
+	return lines
+}
+
+// formatMetric renders one metric, e.g. "latency n=812 p50 45ms p95 230ms"
+func formatMetric(snap metrics.Snapshot) string {
+	name := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(snap.Rule.Name)
+	value := func(v float64) string {
+		return formatMetricValue(v, snap.Durations)
+	}
+
+	switch snap.Rule.Kind {
+	case metrics.KindCount:
+		return fmt.Sprintf("%s %s (%s/min)", name, formatCount(snap.Count), formatCount(int64(snap.PerMinute)))
+	case metrics.KindHistogram:
+		if snap.Count == 0 {
+			return name + " no values yet"
+		}
+		return fmt.Sprintf("%s n=%s p50 %s p95 %s p99 %s max %s", name, formatCount(snap.Count),
+			value(snap.Quantile(0.5)), value(snap.Quantile(0.95)), value(snap.Quantile(0.99)), value(snap.Max))
+	case metrics.KindGauge:
+		if snap.Count == 0 {
+			return name + " no values yet"
+		}
+		return fmt.Sprintf("%s %s (min %s max %s, %s)", name, value(snap.Last), value(snap.Min), value(snap.Max),
+			formatAgo(time.Since(snap.Updated)))
+	}
+	return name
+}
+
+// formatMetricValue renders a metric value with precision suited to its
+// size; durations in milliseconds are shown with a unit
+func formatMetricValue(v float64, durations bool) string {
+	if durations {
+		d := time.Duration(v * float64(time.Millisecond))
+		switch {
+		case d >= time.Second:
+			return fmt.Sprintf("%.2fs", d.Seconds())
+		case d >= time.Millisecond:
+			return fmt.Sprintf("%.0fms", v)
+		}
+		return fmt.Sprintf("%.2fms", v)
+	}
+	switch abs := math.Abs(v); {
+	case abs >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case abs >= 1e4:
+		return fmt.Sprintf("%.1fk", v/1e3)
+	case abs >= 100 || v == math.Trunc(v):
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+// formatCount renders a count with thousands separators
+func formatCount(n int64) string {
+	s := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 && s[i-1] != '-' {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// renderMetricsPane renders the extracted metrics above the log list
+func (m *DashboardModel) renderMetricsPane() string {
+	title := lipgloss.NewStyle().Foreground(ColorGreen).Bold(true).Padding(0, 1).Render("📏 Metrics")
+	lines := []string{title}
+	for _, line := range m.metricLines() {
+		lines = append(lines, " "+line)
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -105,6 +105,7 @@ func (m *DashboardModel) renderModalStatusBar() string {
 			} else {
 				statusItems = append(statusItems, "w: Enable wrapping")
 			}
+			statusItems = append(statusItems, "o/O: Pager/Editor")
 			statusItems = append(statusItems, "↑↓/Wheel: Scroll", "PgUp/PgDn: Page")
 		}
 	} else {
@@ -138,4 +139,14 @@ func getSeverityColor(severity string) lipgloss.Color {
 	default:
 		return ColorWhite
 	}
-}
\ No newline at end of file
+}
+
+// listWindow returns the first row of a list of total rows shown height at a
+// time, keeping the selected row centered when possible
+func listWindow(selected, height, total int) int {
+	start := selected - height/2
+	if start+height > total {
+		start = total - height
+	}
+	return max(0, start)
+}
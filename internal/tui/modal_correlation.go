@@ -0,0 +1,253 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Error correlation limits
+const (
+	correlationMinErrors   = 2   // Errors a value needs before it is ranked
+	correlationMaxRows     = 50  // Values listed
+	correlationMaxDistinct = 0.5 // Keys with more distinct values per entry are identifiers and skipped,
+	correlationMinDistinct = 20  // unless they have no more values than this
+)
+
+// errorCorrelation is one attribute value and how often it appears among
+// the errors compared to the other entries
+type errorCorrelation struct {
+	key, value string
+	errors     int     // Errors with the value
+	others     int     // Other entries with the value
+	errorShare float64 // Share of all errors with the value
+	otherShare float64 // Share of all other entries with the value
+}
+
+// errorRate is the share of the entries with the value that are errors
+func (c errorCorrelation) errorRate() float64 {
+	return float64(c.errors) / float64(c.errors+c.others)
+}
+
+// isErrorEntry reports whether an entry counts as a failure
+func isErrorEntry(entry LogEntry) bool {
+	switch normalizeSeverityLevel(entry.Severity) {
+	case "ERROR", "FATAL", "CRITICAL":
+		return true
+	}
+	return false
+}
+
+// correlateErrors ranks the attribute values most over-represented among the
+// error entries, by how much larger their share of the errors is than their
+// share of the other entries
+func correlateErrors(entries []LogEntry) (rows []errorCorrelation, errors, others int) {
+	type valueCounts struct{ errors, others int }
+	counts := make(map[string]map[string]*valueCounts)
+	for _, entry := range entries {
+		isError := isErrorEntry(entry)
+		if isError {
+			errors++
+		} else {
+			others++
+		}
+		for key, value := range entry.Attributes {
+			if value == "" {
+				continue
+			}
+			values := counts[key]
+			if values == nil {
+				values = make(map[string]*valueCounts)
+				counts[key] = values
+			}
+			c := values[value]
+			if c == nil {
+				c = &valueCounts{}
+				values[value] = c
+			}
+			if isError {
+				c.errors++
+			} else {
+				c.others++
+			}
+		}
+	}
+	if errors == 0 {
+		return nil, errors, others
+	}
+
+	for key, values := range counts {
+		// Request IDs, timestamps and the like say nothing about the errors
+		if len(values) > correlationMinDistinct && float64(len(values)) > correlationMaxDistinct*float64(len(entries)) {
+			continue
+		}
+		for value, c := range values {
+			if c.errors < correlationMinErrors {
+				continue
+			}
+			row := errorCorrelation{
+				key:        key,
+				value:      value,
+				errors:     c.errors,
+				others:     c.others,
+				errorShare: float64(c.errors) / float64(errors),
+			}
+			if others > 0 {
+				row.otherShare = float64(c.others) / float64(others)
+			}
+			if row.errorShare > row.otherShare {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if da, db := a.errorShare-a.otherShare, b.errorShare-b.otherShare; da != db {
+			return da > db
+		}
+		if a.errors != b.errors {
+			return a.errors > b.errors
+		}
+		if a.key != b.key {
+			return a.key < b.key
+		}
+		return a.value < b.value
+	})
+	if len(rows) > correlationMaxRows {
+		rows = rows[:correlationMaxRows]
+	}
+	return rows, errors, others
+}
+
+// openCorrelationModal analyzes the entries in the current view
+func (m *DashboardModel) openCorrelationModal() {
+	m.correlationRows, m.correlationErrors, m.correlationOthers = correlateErrors(m.logEntries)
+	m.correlationSelected = 0
+	m.showCorrelationModal = true
+}
+
+// handleCorrelationModalKeys processes keyboard input for the error correlation modal
+func (m *DashboardModel) handleCorrelationModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.correlationRows)-1)
+	switch msg.String() {
+	case "escape", "esc", "C":
+		m.showCorrelationModal = false
+	case "up", "k":
+		m.correlationSelected = max(0, m.correlationSelected-1)
+	case "down", "j":
+		m.correlationSelected = min(last, m.correlationSelected+1)
+	case "pgup":
+		m.correlationSelected = max(0, m.correlationSelected-10)
+	case "pgdown":
+		m.correlationSelected = min(last, m.correlationSelected+10)
+	case "home":
+		m.correlationSelected = 0
+	case "end":
+		m.correlationSelected = last
+	case "enter", "+", "=", "-":
+		// Filter the view by the selected value: include it, or exclude it
+		// to see what the remaining errors have in common
+		if m.correlationSelected < len(m.correlationRows) {
+			row := m.correlationRows[m.correlationSelected]
+			m.addAttributeFilter(attributeFilter{Key: row.key, Value: row.value, Exclude: msg.String() == "-"})
+			m.showCorrelationModal = false
+		}
+	case "r":
+		// Analyze the current view again
+		m.openCorrelationModal()
+	}
+	return m, nil
+}
+
+// handleCorrelationModalMouseEvent processes mouse wheel scrolling in the error correlation modal
+func (m *DashboardModel) handleCorrelationModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.correlationSelected = max(0, min(len(m.correlationRows)-1, m.correlationSelected+delta))
+	return m, nil
+}
+
+// renderCorrelationModal renders the attribute values most associated with
+// errors in the current view
+func (m *DashboardModel) renderCorrelationModal() string {
+	modalWidth := min(m.width-4, 120)
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, column titles and status bar take one line each
+	listHeight := max(1, contentHeight-3)
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(fmt.Sprintf("What's Different About the Errors: %d errors vs %d other entries in view", m.correlationErrors, m.correlationOthers))
+
+	const numbersWidth = 45
+	valueWidth := max(20, contentWidth-numbersWidth-2)
+	columns := lipgloss.NewStyle().Foreground(ColorGray).Render(
+		"  " + padToWidth("Attribute value", valueWidth) + fmt.Sprintf("%15s%15s%15s", "of errors", "of others", "error rate"))
+
+	var lines []string
+	switch {
+	case m.correlationErrors == 0:
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray).Render("  No ERROR, FATAL or CRITICAL entries in the current view"))
+	case len(m.correlationRows) == 0:
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray).Render("  No attribute value stands out among the errors"))
+	}
+
+	start := listWindow(m.correlationSelected, listHeight, len(m.correlationRows))
+
+	selected := lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite)
+	errorStyle := lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+	for i := start; i < len(m.correlationRows) && i < start+listHeight; i++ {
+		row := m.correlationRows[i]
+		name := padToWidth(truncateToWidth(row.key+"="+row.value, valueWidth), valueWidth)
+		errorsCol := fmt.Sprintf("%4.0f%% (%5d)", row.errorShare*100, row.errors)
+		othersCol := fmt.Sprintf("%4.0f%% (%5d)", row.otherShare*100, row.others)
+		rate := fmt.Sprintf("%13.0f%%", row.errorRate()*100)
+		if i == m.correlationSelected {
+			lines = append(lines, selected.Render("▶ "+name+"  "+errorsCol+"  "+othersCol+" "+rate))
+			continue
+		}
+		lines = append(lines, "  "+name+"  "+errorStyle.Render(errorsCol)+"  "+othersCol+" "+rate)
+	}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(strings.Join(lines, "\n"))
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("↑↓: Navigate • Enter/+: Filter to value • -: Exclude value • r: Refresh • ESC: Close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, columns, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorRed).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -202,7 +202,7 @@ func (m *DashboardModel) renderHeatmapSection(width int) string {
 	for _, severity := range severities {
 		// Create severity label with total count
 		severityWithCount := fmt.Sprintf("%s (%d)", severity, severityTotals[severity])
-		coloredLabel := lipgloss.NewStyle().Foreground(getSeverityColor(severity)).Bold(true).Render(fmt.Sprintf("%-12s", severityWithCount))
+		coloredLabel := lipgloss.NewStyle().Foreground(getSeverityColor(severity)).Bold(true).Render(padToWidth(severityWithCount, 12))
 
 		// Align data with time header - "Time (mins ago):" is 16 chars, so we need 16 chars total
 		line := coloredLabel + "    " // 12 + 4 = 16 to match header
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filterPickerPreferredKeys are offered first when filtering by selection
+var filterPickerPreferredKeys = []string{"service.name", "k8s.namespace", "k8s.pod", "k8s.container", "host.name"}
+
+// openFilterPicker opens the picker to build an include or exclude filter
+// from the fields of the selected entry
+func (m *DashboardModel) openFilterPicker(entry LogEntry, exclude bool) {
+	options := []attributeFilter{{
+		Key:     severityFilterKey,
+		Value:   normalizeSeverityLevel(entry.Severity),
+		Exclude: exclude,
+	}}
+
+	seen := make(map[string]bool)
+	for _, key := range filterPickerPreferredKeys {
+		if value := entry.Attributes[key]; value != "" {
+			options = append(options, attributeFilter{Key: key, Value: value, Exclude: exclude})
+			seen[key] = true
+		}
+	}
+
+	var rest []string
+	for key, value := range entry.Attributes {
+		if !seen[key] && value != "" {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	for _, key := range rest {
+		options = append(options, attributeFilter{Key: key, Value: entry.Attributes[key], Exclude: exclude})
+	}
+
+	m.filterPickerOptions = options
+	m.filterPickerSelected = 0
+	m.showFilterPicker = true
+}
+
+// handleFilterPickerKeys processes keyboard input for the filter picker
+func (m *DashboardModel) handleFilterPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc":
+		m.showFilterPicker = false
+	case "up", "k":
+		m.filterPickerSelected = max(0, m.filterPickerSelected-1)
+	case "down", "j":
+		m.filterPickerSelected = min(len(m.filterPickerOptions)-1, m.filterPickerSelected+1)
+	case "enter":
+		m.addAttributeFilter(m.filterPickerOptions[m.filterPickerSelected])
+		m.showFilterPicker = false
+	}
+	return m, nil
+}
+
+// renderFilterPickerModal renders the include/exclude filter picker
+func (m *DashboardModel) renderFilterPickerModal() string {
+	modalWidth := min(m.width-16, 70)
+	modalHeight := min(m.height-8, 20)
+	contentWidth := modalWidth - 4
+	contentHeight := modalHeight - 4
+
+	// Keep the selection visible when there are more options than rows
+	start := max(0, m.filterPickerSelected-contentHeight+1)
+
+	var lines []string
+	for i := start; i < len(m.filterPickerOptions) && i < start+contentHeight; i++ {
+		option := m.filterPickerOptions[i]
+		prefix := "  "
+		if i == m.filterPickerSelected {
+			prefix = "► "
+		}
+		line := prefix + option.Key + ": " + option.Value
+		if lipgloss.Width(line) > contentWidth {
+			line = truncateToWidth(line, contentWidth)
+		}
+
+		if i == m.filterPickerSelected {
+			line = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(line)
+		} else if option.Key == severityFilterKey {
+			line = lipgloss.NewStyle().Foreground(getSeverityColor(option.Value)).Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	contentPane := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(contentHeight).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(ColorBlue).
+		Render(strings.Join(lines, "\n"))
+
+	headerText := "Include entries with..."
+	if len(m.filterPickerOptions) > 0 && m.filterPickerOptions[0].Exclude {
+		headerText = "Exclude entries with..."
+	}
+	header := lipgloss.NewStyle().
+		Width(contentWidth).
+		Foreground(ColorBlue).
+		Bold(true).
+		Render(headerText)
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Render("↑↓: Navigate • Enter: Add filter • ESC: Cancel")
+
+	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
+
+	finalModal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Height(modalHeight).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(modal)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, finalModal)
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/diaglog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// SetDiagLog sets gonzo's own log messages, shown in the gonzo logs modal
+func (m *DashboardModel) SetDiagLog(logs *diaglog.Log) {
+	m.diagLog = logs
+}
+
+// openDiagLogModal shows gonzo's own log messages, newest at the bottom
+func (m *DashboardModel) openDiagLogModal() {
+	m.showDiagLogModal = true
+	m.diagLogOffset = 0
+}
+
+// diagLogLines renders the messages at or above the selected level, wrapped
+// to width, oldest first
+func (m *DashboardModel) diagLogLines(width int) []string {
+	timeStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	levelStyles := map[diaglog.Level]lipgloss.Style{
+		diaglog.Debug: lipgloss.NewStyle().Foreground(ColorGray),
+		diaglog.Info:  lipgloss.NewStyle().Foreground(ColorGreen),
+		diaglog.Warn:  lipgloss.NewStyle().Foreground(ColorYellow).Bold(true),
+		diaglog.Error: lipgloss.NewStyle().Foreground(ColorRed).Bold(true),
+	}
+
+	// Continuation lines are indented past the time and level
+	const prefixWidth = 19
+	indent := strings.Repeat(" ", prefixWidth)
+	var lines []string
+	for _, entry := range m.diagLog.Entries() {
+		if entry.Level < m.diagLogLevel {
+			continue
+		}
+		prefix := timeStyle.Render(m.inDisplayZone(entry.Time).Format("15:04:05.000")) + " " +
+			levelStyles[entry.Level].Render(fmt.Sprintf("%-5s", entry.Level)) + " "
+		wrapped := strings.Split(ansi.Wrap(entry.Message, max(10, width-prefixWidth), ""), "\n")
+		lines = append(lines, prefix+wrapped[0])
+		for _, rest := range wrapped[1:] {
+			lines = append(lines, indent+rest)
+		}
+	}
+	return lines
+}
+
+// handleDiagLogModalKeys processes keyboard input for the gonzo logs modal
+func (m *DashboardModel) handleDiagLogModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc", "L":
+		m.showDiagLogModal = false
+	case "tab":
+		// Cycle the least severe level shown
+		m.diagLogLevel = (m.diagLogLevel + 1) % (diaglog.Error + 1)
+		m.diagLogOffset = 0
+	case "shift+tab":
+		m.diagLogLevel = (m.diagLogLevel + diaglog.Error) % (diaglog.Error + 1)
+		m.diagLogOffset = 0
+	case "up", "k":
+		m.diagLogOffset++
+	case "down", "j":
+		m.diagLogOffset = max(0, m.diagLogOffset-1)
+	case "pgup":
+		m.diagLogOffset += 10
+	case "pgdown":
+		m.diagLogOffset = max(0, m.diagLogOffset-10)
+	case "home":
+		m.diagLogOffset = int(^uint(0) >> 1)
+	case "end":
+		m.diagLogOffset = 0
+	}
+	return m, nil
+}
+
+// handleDiagLogModalMouseEvent processes mouse wheel scrolling in the gonzo logs modal
+func (m *DashboardModel) handleDiagLogModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = 1
+	case tea.MouseButtonWheelDown:
+		delta = -1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.diagLogOffset = max(0, m.diagLogOffset+delta)
+	return m, nil
+}
+
+// renderDiagLogModal renders gonzo's own log messages, following the newest
+// unless scrolled back
+func (m *DashboardModel) renderDiagLogModal() string {
+	modalWidth := min(m.width-4, 160)
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, level tabs and status bar take one line each
+	listHeight := max(1, contentHeight-3)
+
+	summary := fmt.Sprintf("Gonzo Logs: %s errors • %s warnings since start",
+		formatCount(m.diagLog.Count(diaglog.Error)), formatCount(m.diagLog.Count(diaglog.Warn)))
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(summary)
+
+	activeTab := lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite)
+	inactiveTab := lipgloss.NewStyle().Foreground(ColorGray)
+	var tabs string
+	for level := diaglog.Debug; level <= diaglog.Error; level++ {
+		style := inactiveTab
+		if level == m.diagLogLevel {
+			style = activeTab
+		}
+		tabs += style.Render(fmt.Sprintf(" %s+ ", level))
+	}
+
+	lines := m.diagLogLines(contentWidth)
+	if len(lines) == 0 {
+		lines = []string{lipgloss.NewStyle().Foreground(ColorGray).Render("  No messages at this level")}
+	}
+
+	// The offset counts lines back from the newest
+	m.diagLogOffset = min(m.diagLogOffset, max(0, len(lines)-listHeight))
+	end := len(lines) - m.diagLogOffset
+	start := max(0, end-listHeight)
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(strings.Join(lines[start:end], "\n"))
+
+	status := "Tab: Level • ↑↓/PgUp/PgDn: Scroll • Home/End: Oldest/Newest • ESC: Close"
+	if m.diagLogOffset > 0 {
+		status = fmt.Sprintf("%d newer lines below • ", m.diagLogOffset) + status
+	}
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(status)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, tabs, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
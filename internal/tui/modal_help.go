@@ -4,6 +4,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// helpContentWidth is the width the help text is laid out for
+const helpContentWidth = 80
+
 // renderHelpModal renders the help modal using full screen
 func (m *DashboardModel) renderHelpModal() string {
 	// Calculate dimensions
@@ -41,7 +44,7 @@ func (m *DashboardModel) renderHelpModal() string {
 	// Status bar
 	statusBar := lipgloss.NewStyle().
 		Foreground(ColorGray).
-		Render("↑↓/Wheel: Scroll • PgUp/PgDn: Page • ?/h: Toggle Help • ESC: Close")
+		Render("↑↓/Wheel: Scroll • PgUp/PgDn: Page • t: Guided Tour • ?/h: Toggle Help • ESC: Close")
 
 	// Combine all parts
 	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
@@ -59,39 +62,7 @@ func (m *DashboardModel) renderHelpModal() string {
 
 // renderHelpModalContent returns the help modal content without positioning
 func (m *DashboardModel) renderHelpModalContent() string {
-	helpContent := `🎯 Log Analyzer Dashboard Help
-
-NAVIGATION:
-  Tab/Shift+Tab  - Navigate between sections
-  Mouse Click    - Click on any section to switch to it
-  ↑/↓ or k/j     - Move selection within section
-  Mouse Wheel    - Scroll up/down to navigate selections
-  Enter          - Show details for selected item
-  Escape         - Close modal/exit filter mode
-
-ACTIONS:
-  /              - Activate filter (regex supported)
-  s              - Search and highlight text in logs
-  Ctrl+f         - Open severity filter modal
-  f              - Open fullscreen log viewer modal
-  Space          - Pause/unpause UI updates
-  c              - Toggle Host/Service columns in log view
-  T              - Toggle timestamp mode (Log Time / Receive Time)
-  r              - Reset all data (manual reset)
-  u/U            - Cycle update intervals (forward/backward)
-  i              - Show comprehensive statistics modal
-  i              - AI analysis (when viewing log details)
-  m              - Switch AI model (shows available models)
-  ? or h         - Toggle this help
-  q/Ctrl+C       - Quit
-
-LOG VIEWER NAVIGATION:
-  Home           - Jump to top of log buffer (stops auto-scroll)
-  End            - Jump to latest logs (resumes auto-scroll)
-  PgUp/PgDn      - Navigate by pages (10 entries at a time)
-  ↑/↓ or k/j     - Navigate individual entries with smart auto-scroll
-
-SECTIONS:
+	helpContent := "🎯 Log Analyzer Dashboard Help\n\n" + renderKeyBindings(helpContentWidth) + `SECTIONS:
   Words          - Most frequent words in logs
   Attributes     - OTLP attributes by unique value count
   Log Patterns   - Common log message patterns (Drain3)
@@ -101,24 +72,36 @@ SECTIONS:
 FILTER & SEARCH:
   Filter (/): Type regex patterns to filter logs (searches message & attributes)
   Search (s): Type text to highlight in displayed logs
+    Tab while typing toggles fuzzy mode (fzf-style subsequence matching)
+    n/N in the log section jumps to the next/previous matching entry
   Severity (Ctrl+f): Filter by log severity levels
+  By selection (+/-): Pick severity, service, pod or any attribute of the
+    selected entry to include or exclude; ESC clears all filters
+  Filter chips: Applied filters are shown as numbered chips below the
+    charts; press 1-9 or click a chip to remove that filter
+  Highlights: --highlight "PATTERN=COLOR" colors matches, "PATTERN=bg:COLOR"
+    colors the whole row (e.g. "deadline exceeded=bg:red", "req-[0-9a-f]+=cyan")
   Examples: "error", "k8s.*pod", "service.name", "host.name.*prod"
 
 AI ANALYSIS:
-  Set environment variables for AI-powered log analysis:
-  • OPENAI_API_KEY   - Your API key (required)
-  • OPENAI_API_BASE  - Custom endpoint (optional)
+  Configure the AI endpoint with flags, config or environment variables:
+  • --ai-base-url / OPENAI_API_BASE  - Endpoint (default: OpenAI)
+  • --ai-api-key / OPENAI_API_KEY    - API key (not needed for local servers)
+  • --ai-provider                    - auto, openai, ollama, azure or compatible
+  • --ai-model                       - Model, or the Azure deployment name
 
   Examples:
   • OpenAI: export OPENAI_API_KEY=sk-your-key
-  • LM Studio: export OPENAI_API_BASE=http://localhost:1234/v1
-  • Ollama: export OPENAI_API_BASE=http://localhost:11434/v1
+  • Ollama (offline): --ai-base-url=http://localhost:11434/v1
+  • vLLM / LM Studio: --ai-base-url=http://localhost:8000/v1
+  • Azure: --ai-provider=azure --ai-base-url=https://NAME.openai.azure.com
+           --ai-model=DEPLOYMENT
 
   Press 'i' in log detail modal for AI insights.
   Press 'm' anywhere to switch between available models.
 `
 
 	return lipgloss.NewStyle().
-		Width(65).
+		Width(helpContentWidth).
 		Render(helpContent)
 }
\ No newline at end of file
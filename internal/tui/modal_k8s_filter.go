@@ -185,10 +185,7 @@ func (m *DashboardModel) renderNamespaceList(maxItemWidth int) []string {
 		}
 
 		// Truncate namespace name if too long
-		displayName := ns
-		if len(displayName) > maxItemWidth {
-			displayName = displayName[:maxItemWidth-3] + "..."
-		}
+		displayName := truncateToWidth(ns, maxItemWidth)
 
 		line := prefix + displayName + status
 
@@ -258,10 +255,7 @@ func (m *DashboardModel) renderPodList(maxItemWidth int) []string {
 		}
 
 		// Truncate pod name if too long
-		displayName := pod
-		if len(displayName) > maxItemWidth {
-			displayName = displayName[:maxItemWidth-3] + "..."
-		}
+		displayName := truncateToWidth(pod, maxItemWidth)
 
 		line := prefix + displayName + status
 
@@ -292,9 +286,9 @@ func (m *DashboardModel) updateK8sNamespacesFromLogs() {
 		m.k8sNamespaces = make(map[string]bool)
 	}
 
-	// Scan all logs for k8s.namespace attribute
-	for _, entry := range m.allLogEntries {
-		if ns, ok := entry.Attributes["k8s.namespace"]; ok && ns != "" {
+	// The column store counts the namespaces of the buffer
+	for nsID := range m.columns.namespaces {
+		if ns := m.columns.values[nsID]; ns != "" {
 			if _, exists := m.k8sNamespaces[ns]; !exists {
 				// New namespace found, enable it by default
 				m.k8sNamespaces[ns] = true
@@ -309,10 +303,13 @@ func (m *DashboardModel) updateK8sPodsFromLogs() {
 		m.k8sPods = make(map[string]bool)
 	}
 
-	// Scan all logs for k8s.pod attribute (filtered by selected namespaces)
-	for _, entry := range m.allLogEntries {
-		ns, hasNs := entry.Attributes["k8s.namespace"]
-		pod, hasPod := entry.Attributes["k8s.pod"]
+	// The column store counts the namespace/pod pairs of the buffer
+	// (filtered by selected namespaces)
+	for key := range m.columns.pods {
+		nsID, podID := uint32(key>>32), uint32(key)
+
+		ns, hasNs := m.columns.values[nsID], nsID != 0
+		pod, hasPod := m.columns.values[podID], podID != 0
 
 		// Only include pods from selected namespaces
 		if hasPod && pod != "" {
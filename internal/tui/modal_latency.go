@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// selectLatencyRow moves the latency row selected in the statistics modal
+func (m *DashboardModel) selectLatencyRow(delta int) {
+	rows := len(m.latency.stats(time.Now()))
+	if rows == 0 {
+		return
+	}
+	m.latencySelected = ((m.latencySelected+delta)%rows + rows) % rows
+}
+
+// openSlowEntries opens the slowest entries of the selected latency row
+func (m *DashboardModel) openSlowEntries() {
+	now := time.Now()
+	stats := m.latency.stats(now)
+	if m.latencySelected >= len(stats) {
+		return
+	}
+	m.slowKey = stats[m.latencySelected].key
+	m.slowSamples = m.latency.slowSamples(m.slowKey, now)
+	m.slowSelected = 0
+	m.showSlowModal = len(m.slowSamples) > 0
+}
+
+// handleSlowModalKeys processes keyboard input for the slow entries modal
+func (m *DashboardModel) handleSlowModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.slowSamples)-1)
+	switch msg.String() {
+	case "escape", "esc":
+		// Back to the statistics modal
+		m.showSlowModal = false
+	case "up", "k":
+		m.slowSelected = max(0, m.slowSelected-1)
+	case "down", "j":
+		m.slowSelected = min(last, m.slowSelected+1)
+	case "pgup":
+		m.slowSelected = max(0, m.slowSelected-10)
+	case "pgdown":
+		m.slowSelected = min(last, m.slowSelected+10)
+	case "home":
+		m.slowSelected = 0
+	case "end":
+		m.slowSelected = last
+	case "p":
+		// Pin the selected slow entry
+		if m.slowSelected < len(m.slowSamples) {
+			m.togglePinnedEntry(m.slowSamples[m.slowSelected].entry)
+		}
+	case "enter":
+		// Show details of the selected slow entry
+		if m.slowSelected < len(m.slowSamples) {
+			entry := m.slowSamples[m.slowSelected].entry
+			m.currentLogEntry = &entry
+			m.modalContent = m.formatLogDetails(entry, 60)
+			m.showModal = true
+			m.modalReady = false
+			m.modalActiveSection = "info"
+			m.aiAnalysisResult = ""
+			m.showSlowModal = false
+			m.showStatsModal = false
+		}
+	}
+	return m, nil
+}
+
+// handleSlowModalMouseEvent processes mouse wheel scrolling in the slow entries modal
+func (m *DashboardModel) handleSlowModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.slowSelected = max(0, min(len(m.slowSamples)-1, m.slowSelected+delta))
+	return m, nil
+}
+
+// renderSlowModal renders the slowest entries of a latency row with their
+// durations, slowest first
+func (m *DashboardModel) renderSlowModal() string {
+	modalWidth := m.width - 4
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header and status bar take one line each
+	listHeight := max(1, contentHeight-2)
+
+	name := m.slowKey.service
+	if m.slowKey.endpoint != "" {
+		name += " " + m.slowKey.endpoint
+	}
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(fmt.Sprintf("Slow Entries: %s (p%.0f and above, %d)", name, slowEntriesPercentile*100, len(m.slowSamples)))
+
+	start := listWindow(m.slowSelected, listHeight, len(m.slowSamples))
+
+	const durationWidth = 9
+	durationStyle := lipgloss.NewStyle().Foreground(ColorOrange).Bold(true)
+	var lines []string
+	for i := start; i < len(m.slowSamples) && i < start+listHeight; i++ {
+		sample := m.slowSamples[i]
+		duration := durationStyle.Render(padToWidth(formatMetricValue(sample.ms, true), durationWidth))
+		lines = append(lines, duration+" "+m.formatLogEntry(sample.entry, contentWidth-durationWidth-1, i == m.slowSelected))
+	}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("↑↓: Navigate • Enter: Details • p: Pin • ESC: Back to statistics")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -52,6 +52,14 @@ func (m *DashboardModel) renderLogViewerModal() string {
 		statusParts = append(statusParts, "⏸ PAUSED")
 	}
 
+	if indicator := m.unreadIndicator(); indicator != "" {
+		statusParts = append(statusParts, indicator)
+	}
+
+	if indicator := m.selectionIndicator(); indicator != "" {
+		statusParts = append(statusParts, indicator)
+	}
+
 	if hasActiveFilter {
 		if m.filterActive {
 			// Currently editing filter
@@ -68,9 +68,7 @@ func (m *DashboardModel) renderModelSelectionModal() string {
 
 			// Truncate long model names
 			maxModelLen := contentWidth - 6 // Account for prefix and padding
-			if len(displayModel) > maxModelLen {
-				displayModel = displayModel[:maxModelLen-3] + "..."
-			}
+			displayModel = truncateToWidth(displayModel, maxModelLen)
 
 			line := prefix + displayModel
 
@@ -118,10 +118,7 @@ func (m *DashboardModel) renderAllPatternsContent(contentWidth int) string {
 		percentage := fmt.Sprintf("%5.1f%%", pattern.Percentage)
 
 		// Truncate template if needed
-		template := pattern.Template
-		if len(template) > templateWidth {
-			template = template[:templateWidth-3] + "..."
-		}
+		template := truncateToWidth(pattern.Template, templateWidth)
 
 		// Color code based on frequency (high frequency = more important)
 		var barColor lipgloss.Style
@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+	"github.com/control-theory/gonzo/internal/query"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Limits of the query modal
+const (
+	maxQueryGroups     = 200 // Groups listed, largest first
+	maxQueryKeyWidth   = 28
+	queryValueWidth    = 10
+	queryMinChartWidth = 8
+)
+
+// sparkBlocks draw the bucket values of a query on a row, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// openQueryModal answers a query over the entries in the view
+func (m *DashboardModel) openQueryModal(q *query.Query) {
+	m.query = q
+	m.refreshQueryResult()
+	m.querySelected = 0
+	m.showQueryModal = true
+}
+
+// refreshQueryResult runs the query again over the entries in the view,
+// which are timed by the timestamp mode in use
+func (m *DashboardModel) refreshQueryResult() {
+	agg := m.query.NewAggregator()
+	for _, entry := range m.logEntries {
+		agg.Add(m.filterRecord(entry))
+	}
+	m.queryResult = agg.Result(maxQueryGroups)
+	m.querySelected = min(m.querySelected, max(0, len(m.queryResult.Groups)-1))
+}
+
+// handleQueryModalKeys processes keyboard input for the query modal
+func (m *DashboardModel) handleQueryModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.queryResult.Groups)-1)
+	switch msg.String() {
+	case "escape", "esc":
+		m.showQueryModal = false
+	case "up", "k":
+		m.querySelected = max(0, m.querySelected-1)
+	case "down", "j":
+		m.querySelected = min(last, m.querySelected+1)
+	case "pgup":
+		m.querySelected = max(0, m.querySelected-10)
+	case "pgdown":
+		m.querySelected = min(last, m.querySelected+10)
+	case "home":
+		m.querySelected = 0
+	case "end":
+		m.querySelected = last
+	case "r":
+		m.refreshQueryResult()
+	case "e", ":":
+		// Edit the query in the prompt it was typed in
+		m.showQueryModal = false
+		m.openGotoPrompt()
+		m.gotoInput.SetValue(m.query.String())
+		m.gotoInput.CursorEnd()
+	}
+	return m, nil
+}
+
+// handleQueryModalMouseEvent processes mouse wheel scrolling in the query
+// modal
+func (m *DashboardModel) handleQueryModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.querySelected = max(0, min(len(m.queryResult.Groups)-1, m.querySelected+delta))
+	return m, nil
+}
+
+// formatQueryValue renders an aggregated value in the unit of its field
+func (m *DashboardModel) formatQueryValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "-"
+	case m.query.Func == query.FuncCount:
+		return formatCount(int64(v))
+	case m.queryResult.Unit == filterexpr.UnitDuration:
+		return formatMetricValue(v, true)
+	case m.queryResult.Unit == filterexpr.UnitSize:
+		return m.formatBytes(int64(v))
+	}
+	return formatMetricValue(v, false)
+}
+
+// sparkline draws values as blocks between lo and hi; buckets without
+// entries are dots
+func sparkline(values []float64, lo, hi float64) string {
+	var b strings.Builder
+	for _, v := range values {
+		if math.IsNaN(v) {
+			b.WriteRune('·')
+			continue
+		}
+		level := len(sparkBlocks) - 1
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[max(0, min(level, len(sparkBlocks)-1))])
+	}
+	return b.String()
+}
+
+// queryBucketLayout returns the time layout of a step's bucket starts
+func queryBucketLayout(step time.Duration) string {
+	switch {
+	case step < time.Minute:
+		return "15:04:05"
+	case step >= 24*time.Hour:
+		return "Jan 2"
+	}
+	return "15:04"
+}
+
+// renderQueryModal renders a query's groups as a table, with a chart of
+// each group's values over time when the query has a step
+func (m *DashboardModel) renderQueryModal() string {
+	res := m.queryResult
+	modalWidth := min(m.width-4, 160)
+	modalHeight := min(m.height-2, len(res.Groups)+8)
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, summary, column titles, selected group and status bar take
+	// one line each
+	listHeight := max(1, contentHeight-5)
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("Query: " + m.query.String())
+
+	gray := lipgloss.NewStyle().Foreground(ColorGray)
+	parts := []string{fmt.Sprintf("%s of %s entries in the view matched", formatCount(int64(res.Entries)), formatCount(int64(len(m.logEntries))))}
+	if len(m.query.By) > 0 {
+		groups := fmt.Sprintf("%d groups", len(res.Groups)+res.Others)
+		if res.Others > 0 {
+			groups += fmt.Sprintf(", the largest %d shown", len(res.Groups))
+		}
+		parts = append(parts, groups)
+	}
+	if res.Missing > 0 {
+		parts = append(parts, fmt.Sprintf("%s without %s", formatCount(int64(res.Missing)), m.query.Field))
+	}
+	if len(res.Buckets) > 0 {
+		layout := queryBucketLayout(m.query.Step)
+		parts = append(parts, fmt.Sprintf("%d buckets of %s from %s", len(res.Buckets), formatWindowDuration(m.query.Step), m.inDisplayZone(res.Buckets[0]).Format(layout)))
+	}
+	if res.Older > 0 {
+		parts = append(parts, fmt.Sprintf("%s older entries not charted", formatCount(int64(res.Older))))
+	}
+	summary := lipgloss.NewStyle().Foreground(ColorWhite).Width(contentWidth).MaxWidth(contentWidth).Render(strings.Join(parts, " • "))
+
+	// Key columns are as wide as their longest value, within limits
+	keyWidths := make([]int, len(m.query.By))
+	for i, key := range m.query.By {
+		keyWidths[i] = min(maxQueryKeyWidth, lipgloss.Width(key))
+		for _, group := range res.Groups {
+			keyWidths[i] = max(keyWidths[i], min(maxQueryKeyWidth, max(lipgloss.Width(group.Keys[i]), len("(none)"))))
+		}
+	}
+	valueTitle := m.query.Func + "(" + m.query.Field + ")"
+	valueWidth := max(queryValueWidth, lipgloss.Width(valueTitle))
+	showEntries := m.query.Func != query.FuncCount
+	fixedWidth := 2 + valueWidth
+	for _, width := range keyWidths {
+		fixedWidth += width + 2
+	}
+	if showEntries {
+		fixedWidth += queryValueWidth + 2
+	}
+
+	// The chart shows the newest buckets that fit, scaled to the largest
+	// value on it so groups compare
+	chartWidth := min(len(res.Buckets), contentWidth-fixedWidth-2)
+	if chartWidth < queryMinChartWidth {
+		chartWidth = 0
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, group := range res.Groups {
+		for _, v := range group.Buckets[len(group.Buckets)-chartWidth:] {
+			if !math.IsNaN(v) {
+				lo, hi = min(lo, v), max(hi, v)
+			}
+		}
+	}
+	lo = min(lo, 0)
+
+	titles := []string{""}
+	for i, key := range m.query.By {
+		titles = append(titles, padToWidth(truncateToWidth(key, keyWidths[i]), keyWidths[i]))
+	}
+	titles = append(titles, fmt.Sprintf("%*s", valueWidth, truncateToWidth(valueTitle, valueWidth)))
+	if showEntries {
+		titles = append(titles, fmt.Sprintf("%*s", queryValueWidth, "Entries"))
+	}
+	if chartWidth > 0 {
+		titles = append(titles, fmt.Sprintf("Last %s", formatWindowDuration(time.Duration(chartWidth)*m.query.Step)))
+	}
+	columnTitles := lipgloss.NewStyle().Foreground(ColorWhite).Bold(true).MaxWidth(contentWidth).Render(strings.Join(titles, "  "))
+
+	start := listWindow(m.querySelected, listHeight, len(res.Groups))
+
+	var lines []string
+	for i := start; i < len(res.Groups) && i < start+listHeight; i++ {
+		group := res.Groups[i]
+		keyStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+		if i == m.querySelected {
+			keyStyle = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+		}
+		var cells []string
+		for k, value := range group.Keys {
+			if value == "" {
+				cells = append(cells, gray.Render(padToWidth("(none)", keyWidths[k])))
+				continue
+			}
+			cells = append(cells, keyStyle.Render(padToWidth(truncateToWidth(value, keyWidths[k]), keyWidths[k])))
+		}
+		cells = append(cells, lipgloss.NewStyle().Foreground(ColorOrange).Bold(true).Render(fmt.Sprintf("%*s", valueWidth, m.formatQueryValue(group.Value))))
+		if showEntries {
+			cells = append(cells, gray.Render(fmt.Sprintf("%*s", queryValueWidth, formatCount(int64(group.Entries)))))
+		}
+		if chartWidth > 0 {
+			cells = append(cells, lipgloss.NewStyle().Foreground(ColorGreen).Render(sparkline(group.Buckets[len(group.Buckets)-chartWidth:], lo, hi)))
+		}
+		marker := "  "
+		if i == m.querySelected {
+			marker = keyStyle.Render("▶ ")
+		}
+		lines = append(lines, marker+strings.Join(cells, "  "))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, gray.Render("No entries in the view matched the query"))
+	}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		MaxWidth(contentWidth).
+		Render(strings.Join(lines, "\n"))
+
+	// The selected group's latest bucket and peak, which the chart only hints at
+	selected := ""
+	if m.querySelected < len(res.Groups) && len(res.Buckets) > 0 {
+		group := res.Groups[m.querySelected]
+		layout := queryBucketLayout(m.query.Step)
+		latest := len(group.Buckets) - 1
+		peak := -1
+		for b, v := range group.Buckets {
+			if !math.IsNaN(v) && (peak < 0 || v > group.Buckets[peak]) {
+				peak = b
+			}
+		}
+		selected = fmt.Sprintf("Latest %s (%s): %s", formatWindowDuration(m.query.Step), m.inDisplayZone(res.Buckets[latest]).Format(layout), m.formatQueryValue(group.Buckets[latest]))
+		if peak >= 0 {
+			selected += fmt.Sprintf(" • peak %s at %s", m.formatQueryValue(group.Buckets[peak]), m.inDisplayZone(res.Buckets[peak]).Format(layout))
+		}
+	}
+	selectedGroup := lipgloss.NewStyle().
+		Foreground(ColorWhite).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(selected)
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("↑↓: Navigate • r: Run again on the view • e: Edit the query • ESC: Close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, summary, columnTitles, list, selectedGroup, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
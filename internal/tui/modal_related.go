@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// relatedPreferredKeys lists correlation attributes offered first in the
+// related entries picker, most specific first
+var relatedPreferredKeys = []string{
+	"trace_id", "traceId", "trace.id",
+	"request_id", "requestId", "request.id", "req_id",
+	"correlation_id", "session_id", "user_id",
+	"span_id", "spanId",
+	"k8s.pod", "k8s.namespace", "service.name", "host.name",
+}
+
+// openRelatedModal opens the related entries view for an anchor entry
+func (m *DashboardModel) openRelatedModal(anchor LogEntry) {
+	if len(anchor.Attributes) == 0 {
+		return
+	}
+
+	// Order keys: preferred correlation keys first, then the rest alphabetically
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range relatedPreferredKeys {
+		if value, ok := anchor.Attributes[key]; ok && value != "" {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	var rest []string
+	for key, value := range anchor.Attributes {
+		if !seen[key] && value != "" {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+	if len(keys) == 0 {
+		return
+	}
+
+	m.relatedAnchor = anchor
+	m.relatedKeys = keys
+	m.relatedKeyIndex = 0
+	m.showRelatedModal = true
+	m.refreshRelatedEntries()
+}
+
+// refreshRelatedEntries collects all buffered entries sharing the selected
+// attribute value with the anchor, ordered by time
+func (m *DashboardModel) refreshRelatedEntries() {
+	key := m.relatedKeys[m.relatedKeyIndex]
+	value := m.relatedAnchor.Attributes[key]
+
+	m.relatedEntries = m.relatedEntries[:0]
+	for _, entry := range m.allLogEntries {
+		if entry.Attributes[key] == value {
+			m.relatedEntries = append(m.relatedEntries, entry)
+		}
+	}
+	sort.SliceStable(m.relatedEntries, func(i, j int) bool {
+		return m.getDisplayTimestamp(m.relatedEntries[i]).Before(m.getDisplayTimestamp(m.relatedEntries[j]))
+	})
+
+	// Start on the anchor entry itself
+	m.relatedSelected = 0
+	for i, entry := range m.relatedEntries {
+		if samePinnedEntry(entry, m.relatedAnchor) {
+			m.relatedSelected = i
+			break
+		}
+	}
+}
+
+// handleRelatedModalKeys processes keyboard input for the related entries modal
+func (m *DashboardModel) handleRelatedModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc", "R":
+		m.showRelatedModal = false
+	case "tab", "right", "l":
+		// Next attribute
+		m.relatedKeyIndex = (m.relatedKeyIndex + 1) % len(m.relatedKeys)
+		m.refreshRelatedEntries()
+	case "shift+tab", "left":
+		// Previous attribute
+		m.relatedKeyIndex = (m.relatedKeyIndex - 1 + len(m.relatedKeys)) % len(m.relatedKeys)
+		m.refreshRelatedEntries()
+	case "up", "k":
+		m.relatedSelected = max(0, m.relatedSelected-1)
+	case "down", "j":
+		m.relatedSelected = max(0, min(len(m.relatedEntries)-1, m.relatedSelected+1))
+	case "pgup":
+		m.relatedSelected = max(0, m.relatedSelected-10)
+	case "pgdown":
+		m.relatedSelected = max(0, min(len(m.relatedEntries)-1, m.relatedSelected+10))
+	case "home":
+		m.relatedSelected = 0
+	case "end":
+		m.relatedSelected = max(0, len(m.relatedEntries)-1)
+	case "p":
+		// Pin the selected related entry
+		if m.relatedSelected < len(m.relatedEntries) {
+			m.togglePinnedEntry(m.relatedEntries[m.relatedSelected])
+		}
+	case "enter":
+		// Show details of the selected related entry
+		if m.relatedSelected < len(m.relatedEntries) {
+			entry := m.relatedEntries[m.relatedSelected]
+			m.currentLogEntry = &entry
+			m.modalContent = m.formatLogDetails(entry, 60)
+			m.showModal = true
+			m.modalReady = false
+			m.modalActiveSection = "info"
+			m.aiAnalysisResult = ""
+			m.showRelatedModal = false
+			m.showSessionsModal = false
+		}
+	}
+	return m, nil
+}
+
+// handleRelatedModalMouseEvent processes mouse wheel scrolling in the related entries modal
+func (m *DashboardModel) handleRelatedModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.relatedSelected = max(0, min(len(m.relatedEntries)-1, m.relatedSelected+delta))
+	return m, nil
+}
+
+// renderRelatedModal renders all entries sharing an attribute value with the anchor entry
+func (m *DashboardModel) renderRelatedModal() string {
+	modalWidth := m.width - 4
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, attribute picker and status bar take one line each
+	listHeight := max(1, contentHeight-3)
+
+	key := m.relatedKeys[m.relatedKeyIndex]
+	value := m.relatedAnchor.Attributes[key]
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(fmt.Sprintf("Related Entries: %s=%s (%d)", key, value, len(m.relatedEntries)))
+
+	// Attribute picker with the current key highlighted
+	var keyParts []string
+	for i, k := range m.relatedKeys {
+		if i == m.relatedKeyIndex {
+			keyParts = append(keyParts, lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite).Render(" "+k+" "))
+		} else {
+			keyParts = append(keyParts, lipgloss.NewStyle().Foreground(ColorGray).Render(" "+k+" "))
+		}
+	}
+	picker := lipgloss.NewStyle().
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(strings.Join(keyParts, ""))
+
+	start := listWindow(m.relatedSelected, listHeight, len(m.relatedEntries))
+
+	var lines []string
+	for i := start; i < len(m.relatedEntries) && i < start+listHeight; i++ {
+		m.setRelativeBase(m.relatedEntries, i)
+		lines = append(lines, m.formatLogEntry(m.relatedEntries[i], contentWidth, i == m.relatedSelected))
+	}
+	m.relativeBase = time.Time{}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("Tab/←→: Attribute • ↑↓: Navigate • Enter: Details • p: Pin • ESC: Close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, picker, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
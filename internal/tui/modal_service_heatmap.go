@@ -0,0 +1,368 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// serviceHeatmapCellWidth is the columns each time bucket takes
+const serviceHeatmapCellWidth = 2
+
+// serviceHeatmapLabelWidth is the width of the service names on the rows
+const serviceHeatmapLabelWidth = 22
+
+// serviceHeatmapSteps are the bucket sizes a heatmap picks from, the
+// smallest fitting the view's time span in the columns available
+var serviceHeatmapSteps = []time.Duration{
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 15 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// serviceHeatmap counts the entries and errors of each service per time
+// bucket
+type serviceHeatmap struct {
+	start     time.Time // Start of the first bucket
+	step      time.Duration
+	buckets   int
+	rows      []serviceHeatmapRow // Most errors first
+	maxErrors int                 // Most errors in a cell
+}
+
+// serviceHeatmapRow is one service's counts per bucket
+type serviceHeatmapRow struct {
+	service string
+	entries []int
+	errors  []int
+	total   int
+	failed  int
+}
+
+// bucketStart returns the start of a bucket
+func (h *serviceHeatmap) bucketStart(bucket int) time.Time {
+	return h.start.Add(time.Duration(bucket) * h.step)
+}
+
+// openServiceHeatmapModal shows the errors of each service in the current
+// view over time
+func (m *DashboardModel) openServiceHeatmapModal() {
+	m.refreshServiceHeatmap()
+	m.svcHeatmapRow = 0
+	m.svcHeatmapCol = max(0, m.svcHeatmap.buckets-1)
+	m.showSvcHeatmapModal = true
+}
+
+// refreshServiceHeatmap rebuilds the heatmap from the current view, keeping
+// the selected cell in range
+func (m *DashboardModel) refreshServiceHeatmap() {
+	m.svcHeatmap = buildServiceHeatmap(m.logEntries, m.getDisplayTimestamp, m.serviceHeatmapColumns())
+	m.svcHeatmapRow = max(0, min(m.svcHeatmapRow, len(m.svcHeatmap.rows)-1))
+	m.svcHeatmapCol = max(0, min(m.svcHeatmapCol, m.svcHeatmap.buckets-1))
+}
+
+// serviceHeatmapColumns returns the number of buckets that fit the modal
+func (m *DashboardModel) serviceHeatmapColumns() int {
+	modalWidth := min(m.width-4, 160)
+	return max(1, (modalWidth-2-serviceHeatmapLabelWidth-1)/serviceHeatmapCellWidth)
+}
+
+// buildServiceHeatmap buckets entries by service and time, sizing the
+// buckets so the entries' time span takes at most columns buckets
+func buildServiceHeatmap(entries []LogEntry, timestamp func(LogEntry) time.Time, columns int) *serviceHeatmap {
+	heatmap := &serviceHeatmap{step: serviceHeatmapSteps[0]}
+	if len(entries) == 0 {
+		return heatmap
+	}
+
+	first, last := timestamp(entries[0]), timestamp(entries[0])
+	for _, entry := range entries {
+		ts := timestamp(entry)
+		if ts.Before(first) {
+			first = ts
+		}
+		if ts.After(last) {
+			last = ts
+		}
+	}
+	for _, step := range serviceHeatmapSteps {
+		heatmap.step = step
+		if int(last.Sub(first.Truncate(step))/step) < columns {
+			break
+		}
+	}
+	heatmap.start = first.Truncate(heatmap.step)
+	heatmap.buckets = min(columns, int(last.Sub(heatmap.start)/heatmap.step)+1)
+
+	// Spans too long for the largest step keep their latest buckets
+	if over := int(last.Sub(heatmap.start)/heatmap.step) + 1 - heatmap.buckets; over > 0 {
+		heatmap.start = heatmap.bucketStart(over)
+	}
+
+	rows := make(map[string]*serviceHeatmapRow)
+	for _, entry := range entries {
+		ts := timestamp(entry)
+		if ts.Before(heatmap.start) {
+			continue
+		}
+		bucket := min(heatmap.buckets-1, int(ts.Sub(heatmap.start)/heatmap.step))
+		service := ServiceName(entry)
+		row := rows[service]
+		if row == nil {
+			row = &serviceHeatmapRow{
+				service: service,
+				entries: make([]int, heatmap.buckets),
+				errors:  make([]int, heatmap.buckets),
+			}
+			rows[service] = row
+		}
+		row.entries[bucket]++
+		row.total++
+		if isErrorEntry(entry) {
+			row.errors[bucket]++
+			row.failed++
+			heatmap.maxErrors = max(heatmap.maxErrors, row.errors[bucket])
+		}
+	}
+
+	for _, row := range rows {
+		heatmap.rows = append(heatmap.rows, *row)
+	}
+	sort.Slice(heatmap.rows, func(i, j int) bool {
+		a, b := heatmap.rows[i], heatmap.rows[j]
+		if a.failed != b.failed {
+			return a.failed > b.failed
+		}
+		if a.total != b.total {
+			return a.total > b.total
+		}
+		return a.service < b.service
+	})
+	return heatmap
+}
+
+// drillIntoServiceHeatmapCell shows only the entries of the selected cell's
+// service and time bucket
+func (m *DashboardModel) drillIntoServiceHeatmapCell() {
+	heatmap := m.svcHeatmap
+	if heatmap == nil || m.svcHeatmapRow >= len(heatmap.rows) || heatmap.buckets == 0 {
+		return
+	}
+	row := heatmap.rows[m.svcHeatmapRow]
+	if row.entries[m.svcHeatmapCol] == 0 {
+		return
+	}
+	from := heatmap.bucketStart(m.svcHeatmapCol)
+	to := from.Add(heatmap.step)
+	if m.svcHeatmapCol == heatmap.buckets-1 {
+		// The last bucket also holds the entries past the span it was built for
+		to = time.Time{}
+	}
+
+	highlight := m.searchTerm
+	if m.drill != nil {
+		highlight = m.drill.savedSearch
+	}
+	m.showSvcHeatmapModal = false
+	m.startDrillDown(&drillDown{
+		service: row.service,
+		from:    from,
+		to:      to,
+		label:   fmt.Sprintf("%s %s", row.service, m.serviceHeatmapBucketLabel(from, heatmap.step)),
+	}, highlight)
+}
+
+// serviceHeatmapBucketLabel names a bucket, e.g. "14:05–14:06"
+func (m *DashboardModel) serviceHeatmapBucketLabel(from time.Time, step time.Duration) string {
+	layout := "15:04"
+	if step < time.Minute {
+		layout = "15:04:05"
+	}
+	if step >= 24*time.Hour {
+		layout = "Jan 2"
+	}
+	return m.inDisplayZone(from).Format(layout) + "–" + m.inDisplayZone(from.Add(step)).Format(layout)
+}
+
+// handleServiceHeatmapModalKeys processes keyboard input for the service
+// heatmap
+func (m *DashboardModel) handleServiceHeatmapModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	lastRow := max(0, len(m.svcHeatmap.rows)-1)
+	lastCol := max(0, m.svcHeatmap.buckets-1)
+	switch msg.String() {
+	case "escape", "esc":
+		m.showSvcHeatmapModal = false
+	case "up", "k":
+		m.svcHeatmapRow = max(0, m.svcHeatmapRow-1)
+	case "down", "j":
+		m.svcHeatmapRow = min(lastRow, m.svcHeatmapRow+1)
+	case "left", "h":
+		m.svcHeatmapCol = max(0, m.svcHeatmapCol-1)
+	case "right", "l":
+		m.svcHeatmapCol = min(lastCol, m.svcHeatmapCol+1)
+	case "pgup":
+		m.svcHeatmapRow = max(0, m.svcHeatmapRow-10)
+	case "pgdown":
+		m.svcHeatmapRow = min(lastRow, m.svcHeatmapRow+10)
+	case "home":
+		m.svcHeatmapCol = 0
+	case "end":
+		m.svcHeatmapCol = lastCol
+	case "r":
+		m.refreshServiceHeatmap()
+	case "enter":
+		m.drillIntoServiceHeatmapCell()
+	}
+	return m, nil
+}
+
+// handleServiceHeatmapModalMouseEvent processes mouse wheel scrolling in the
+// service heatmap
+func (m *DashboardModel) handleServiceHeatmapModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.svcHeatmapRow = max(0, min(len(m.svcHeatmap.rows)-1, m.svcHeatmapRow+delta))
+	return m, nil
+}
+
+// serviceHeatmapCell renders a cell: a dot without entries, green with
+// entries but no errors, and red shades scaled to the most errors in a cell
+func serviceHeatmapCell(entries, errors, maxErrors int, selected bool) string {
+	style := lipgloss.NewStyle()
+	symbol := "·"
+	switch {
+	case errors > 0:
+		intensity := float64(errors) / float64(max(1, maxErrors))
+		switch {
+		case intensity > 0.75:
+			symbol = "█"
+		case intensity > 0.5:
+			symbol = "▓"
+		case intensity > 0.25:
+			symbol = "▒"
+		default:
+			symbol = "░"
+		}
+		style = style.Foreground(ColorRed)
+		if intensity <= 0.25 {
+			style = style.Foreground(ColorOrange)
+		}
+	case entries > 0:
+		symbol = "░"
+		style = style.Foreground(ColorGreen)
+	default:
+		style = style.Foreground(ColorGray)
+	}
+	if selected {
+		style = style.Background(ColorBlue)
+	}
+	return style.Render(strings.Repeat(symbol, serviceHeatmapCellWidth))
+}
+
+// renderServiceHeatmapModal renders the services on rows, the time buckets
+// on columns and each cell colored by its errors
+func (m *DashboardModel) renderServiceHeatmapModal() string {
+	heatmap := m.svcHeatmap
+	modalWidth := min(m.width-4, 160)
+	modalHeight := min(m.height-2, len(heatmap.rows)+7)
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, summary, time axis, selected cell and status bar take one line each
+	listHeight := max(1, contentHeight-5)
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("Errors by Service over Time")
+
+	gray := lipgloss.NewStyle().Foreground(ColorGray)
+	summary := gray.Render("No entries in the view")
+	if heatmap.buckets > 0 {
+		summary = lipgloss.NewStyle().Foreground(ColorWhite).MaxWidth(contentWidth).Render(fmt.Sprintf("%d services • %d buckets of %s from %s • at most %d errors in a cell",
+			len(heatmap.rows), heatmap.buckets, heatmap.step, m.inDisplayZone(heatmap.start).Format(m.dateTimeFormat), heatmap.maxErrors))
+	}
+
+	// Time labels go above every few buckets, where they fit
+	layout := "15:04"
+	if heatmap.step < time.Minute {
+		layout = "15:04:05"
+	}
+	labelEvery := (len(layout) + serviceHeatmapCellWidth) / serviceHeatmapCellWidth
+	axis := []rune(strings.Repeat(" ", heatmap.buckets*serviceHeatmapCellWidth+len(layout)))
+	for bucket := 0; bucket < heatmap.buckets; bucket += labelEvery {
+		copy(axis[bucket*serviceHeatmapCellWidth:], []rune(m.inDisplayZone(heatmap.bucketStart(bucket)).Format(layout)))
+	}
+	timeAxis := gray.Render(truncateToWidth(strings.Repeat(" ", serviceHeatmapLabelWidth+1)+strings.TrimRight(string(axis), " "), contentWidth))
+
+	start := listWindow(m.svcHeatmapRow, listHeight, len(heatmap.rows))
+
+	var lines []string
+	for i := start; i < len(heatmap.rows) && i < start+listHeight; i++ {
+		row := heatmap.rows[i]
+		label := padToWidth(truncateToWidth(row.service, serviceHeatmapLabelWidth), serviceHeatmapLabelWidth)
+		if i == m.svcHeatmapRow {
+			label = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(label)
+		} else if row.failed > 0 {
+			label = lipgloss.NewStyle().Foreground(ColorWhite).Render(label)
+		} else {
+			label = gray.Render(label)
+		}
+		var cells strings.Builder
+		for bucket := 0; bucket < heatmap.buckets; bucket++ {
+			cells.WriteString(serviceHeatmapCell(row.entries[bucket], row.errors[bucket], heatmap.maxErrors, i == m.svcHeatmapRow && bucket == m.svcHeatmapCol))
+		}
+		lines = append(lines, label+" "+cells.String())
+	}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(strings.Join(lines, "\n"))
+
+	cell := ""
+	if m.svcHeatmapRow < len(heatmap.rows) && heatmap.buckets > 0 {
+		row := heatmap.rows[m.svcHeatmapRow]
+		cell = fmt.Sprintf("%s • %s • %d entries • %d errors",
+			row.service, m.serviceHeatmapBucketLabel(heatmap.bucketStart(m.svcHeatmapCol), heatmap.step),
+			row.entries[m.svcHeatmapCol], row.errors[m.svcHeatmapCol])
+	}
+	selectedCell := lipgloss.NewStyle().
+		Foreground(ColorWhite).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(cell)
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("←→↑↓: Move • Enter: Show the cell's entries (ESC there restores the view) • r: Refresh • ESC: Close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, summary, timeAxis, list, selectedCell, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -0,0 +1,297 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultSessionKeys are the correlation attributes entries are grouped into
+// sessions by when none are configured
+var DefaultSessionKeys = []string{"request_id", "session_id", "order_id"}
+
+// sessionsMaxRows is the number of sessions listed
+const sessionsMaxRows = 200
+
+// logSession is the entries sharing one correlation key value
+type logSession struct {
+	key, value  string
+	first, last time.Time
+	entries     int
+	errors      int
+	anchor      LogEntry // Earliest entry, the drill-down starts from it
+}
+
+// duration is the time between the session's first and last entries
+func (s logSession) duration() time.Duration {
+	return s.last.Sub(s.first)
+}
+
+// SetSessionKeys sets the correlation attributes entries are grouped into
+// sessions by, in order of preference
+func (m *DashboardModel) SetSessionKeys(keys []string) {
+	m.sessionKeys = nil
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			m.sessionKeys = append(m.sessionKeys, key)
+		}
+	}
+	if len(m.sessionKeys) == 0 {
+		m.sessionKeys = DefaultSessionKeys
+	}
+}
+
+// groupSessions groups entries into sessions by the first correlation key
+// each one has, and returns them slowest first
+func (m *DashboardModel) groupSessions(entries []LogEntry) []logSession {
+	type sessionID struct{ key, value string }
+	byID := make(map[sessionID]*logSession)
+	for _, entry := range entries {
+		for _, key := range m.sessionKeys {
+			value := entry.Attributes[key]
+			if value == "" {
+				continue
+			}
+			ts := m.getDisplayTimestamp(entry)
+			s := byID[sessionID{key, value}]
+			if s == nil {
+				s = &logSession{key: key, value: value, first: ts, last: ts, anchor: entry}
+				byID[sessionID{key, value}] = s
+			}
+			if ts.Before(s.first) {
+				s.first = ts
+				s.anchor = entry
+			}
+			if ts.After(s.last) {
+				s.last = ts
+			}
+			s.entries++
+			if isErrorEntry(entry) {
+				s.errors++
+			}
+			break
+		}
+	}
+
+	sessions := make([]logSession, 0, len(byID))
+	for _, s := range byID {
+		sessions = append(sessions, *s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if di, dj := sessions[i].duration(), sessions[j].duration(); di != dj {
+			return di > dj
+		}
+		return sessions[i].first.After(sessions[j].first)
+	})
+	return sessions
+}
+
+// openSessionsModal groups the buffered entries into sessions
+func (m *DashboardModel) openSessionsModal() {
+	m.sessions = m.groupSessions(m.allLogEntries)
+	m.sessionsSelected = 0
+	m.showSessionsModal = true
+}
+
+// visibleSessions returns the sessions listed in the current mode: all of
+// them slowest first, or only the failed ones by their number of errors
+func (m *DashboardModel) visibleSessions() []logSession {
+	sessions := m.sessions
+	if m.sessionsFailedOnly {
+		sessions = nil
+		for _, s := range m.sessions {
+			if s.errors > 0 {
+				sessions = append(sessions, s)
+			}
+		}
+		sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].errors > sessions[j].errors })
+	}
+	return sessions[:min(len(sessions), sessionsMaxRows)]
+}
+
+// handleSessionsModalKeys processes keyboard input for the sessions modal
+func (m *DashboardModel) handleSessionsModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	sessions := m.visibleSessions()
+	last := max(0, len(sessions)-1)
+	switch msg.String() {
+	case "escape", "esc", "G":
+		m.showSessionsModal = false
+	case "tab", "shift+tab":
+		// Switch between the slowest and the failed sessions
+		m.sessionsFailedOnly = !m.sessionsFailedOnly
+		m.sessionsSelected = 0
+	case "up", "k":
+		m.sessionsSelected = max(0, m.sessionsSelected-1)
+	case "down", "j":
+		m.sessionsSelected = min(last, m.sessionsSelected+1)
+	case "pgup":
+		m.sessionsSelected = max(0, m.sessionsSelected-10)
+	case "pgdown":
+		m.sessionsSelected = min(last, m.sessionsSelected+10)
+	case "home":
+		m.sessionsSelected = 0
+	case "end":
+		m.sessionsSelected = last
+	case "enter":
+		// Drill down into the session's entries; ESC there comes back here
+		if m.sessionsSelected < len(sessions) {
+			session := sessions[m.sessionsSelected]
+			m.openRelatedModal(session.anchor)
+			for i, key := range m.relatedKeys {
+				if key == session.key {
+					m.relatedKeyIndex = i
+					m.refreshRelatedEntries()
+					break
+				}
+			}
+		}
+	case "r":
+		// Group the buffer again
+		m.sessions = m.groupSessions(m.allLogEntries)
+		m.sessionsSelected = min(m.sessionsSelected, max(0, len(m.visibleSessions())-1))
+	}
+	return m, nil
+}
+
+// handleSessionsModalMouseEvent processes mouse wheel scrolling in the sessions modal
+func (m *DashboardModel) handleSessionsModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.sessionsSelected = max(0, min(len(m.visibleSessions())-1, m.sessionsSelected+delta))
+	return m, nil
+}
+
+// sessionDurationPercentile returns the duration below which the given share
+// of the sessions fall
+func sessionDurationPercentile(sessions []logSession, p float64) time.Duration {
+	if len(sessions) == 0 {
+		return 0
+	}
+	durations := make([]time.Duration, len(sessions))
+	for i, s := range sessions {
+		durations[i] = s.duration()
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations[min(len(durations)-1, int(p*float64(len(durations))))]
+}
+
+// formatSessionDuration renders a session duration, e.g. "1.25s" or "340ms"
+func formatSessionDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	return formatMetricValue(float64(d)/float64(time.Millisecond), true)
+}
+
+// renderSessionsModal renders the sessions reconstructed from the buffer,
+// the slowest or the failed ones first
+func (m *DashboardModel) renderSessionsModal() string {
+	modalWidth := min(m.width-4, 140)
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, mode tabs, column titles and status bar take one line each
+	listHeight := max(1, contentHeight-4)
+
+	failed := 0
+	for _, s := range m.sessions {
+		if s.errors > 0 {
+			failed++
+		}
+	}
+	summary := fmt.Sprintf("Sessions by %s: %s", strings.Join(m.sessionKeys, ", "), formatCount(int64(len(m.sessions))))
+	if len(m.sessions) > 0 {
+		summary += fmt.Sprintf(" • %s failed (%.1f%%) • p50 %s • p95 %s",
+			formatCount(int64(failed)),
+			float64(failed)*100/float64(len(m.sessions)),
+			formatSessionDuration(sessionDurationPercentile(m.sessions, 0.5)),
+			formatSessionDuration(sessionDurationPercentile(m.sessions, 0.95)))
+	}
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(summary)
+
+	activeTab := lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite)
+	inactiveTab := lipgloss.NewStyle().Foreground(ColorGray)
+	slowestTab, failedTab := activeTab, inactiveTab
+	if m.sessionsFailedOnly {
+		slowestTab, failedTab = inactiveTab, activeTab
+	}
+	tabs := slowestTab.Render(" Slowest ") + failedTab.Render(fmt.Sprintf(" Failed (%d) ", failed))
+
+	const numbersWidth = 46
+	idWidth := max(20, contentWidth-numbersWidth-2)
+	columns := lipgloss.NewStyle().Foreground(ColorGray).Render(
+		"  " + padToWidth("Session", idWidth) + fmt.Sprintf("%10s%9s%8s%19s", "duration", "entries", "errors", "started"))
+
+	sessions := m.visibleSessions()
+	var lines []string
+	switch {
+	case len(m.sessions) == 0:
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray).Render(
+			fmt.Sprintf("  No buffered entry has a %s attribute (set the keys with --session-key)", strings.Join(m.sessionKeys, ", "))))
+	case len(sessions) == 0:
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray).Render("  No session has ERROR, FATAL or CRITICAL entries"))
+	}
+
+	start := listWindow(m.sessionsSelected, listHeight, len(sessions))
+
+	selected := lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite)
+	errorStyle := lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+	for i := start; i < len(sessions) && i < start+listHeight; i++ {
+		s := sessions[i]
+		id := padToWidth(truncateToWidth(s.key+"="+s.value, idWidth), idWidth)
+		duration := fmt.Sprintf("%10s", formatSessionDuration(s.duration()))
+		entries := fmt.Sprintf("%9d", s.entries)
+		errors := fmt.Sprintf("%8d", s.errors)
+		started := fmt.Sprintf("%19s", m.inDisplayZone(s.first).Format(m.dateTimeFormat))
+		if i == m.sessionsSelected {
+			lines = append(lines, selected.Render("▶ "+id+duration+entries+errors+started))
+			continue
+		}
+		if s.errors > 0 {
+			errors = errorStyle.Render(errors)
+		}
+		lines = append(lines, "  "+id+duration+entries+errors+started)
+	}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(strings.Join(lines, "\n"))
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("Tab: Slowest/Failed • ↑↓: Navigate • Enter: Session entries • r: Refresh • ESC: Close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, tabs, columns, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sourceIdleAfter is how long a source may go without an entry before the
+// sources modal marks it idle
+const sourceIdleAfter = time.Minute
+
+// SourceStats is what one input source delivered since gonzo started
+type SourceStats struct {
+	Source      string
+	Lines       int64
+	Bytes       int64
+	Entries     int64
+	ParseErrors int64     // Entries their format failed to parse, shown as plain text or dropped
+	Dropped     int64     // Lines dropped because processing fell behind
+	LastEntry   time.Time // Zero until the first entry
+}
+
+// SetSourceStats sets the function reporting what each input source
+// delivered, shown in the sources modal
+func (m *DashboardModel) SetSourceStats(stats func() []SourceStats) {
+	m.sourceStats = stats
+}
+
+// openSourcesModal shows the statistics of each input source
+func (m *DashboardModel) openSourcesModal() {
+	m.showSourcesModal = true
+	m.sourcesOffset = 0
+}
+
+// handleSourcesModalKeys processes keyboard input for the sources modal
+func (m *DashboardModel) handleSourcesModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc", "B":
+		m.showSourcesModal = false
+	case "up", "k":
+		m.sourcesOffset = max(0, m.sourcesOffset-1)
+	case "down", "j":
+		m.sourcesOffset++
+	case "pgup":
+		m.sourcesOffset = max(0, m.sourcesOffset-10)
+	case "pgdown":
+		m.sourcesOffset += 10
+	}
+	return m, nil
+}
+
+// sourceState describes whether a source is delivering, e.g. "ok", "idle"
+// or "no entries", with the color to show it in
+func sourceState(stats SourceStats, now time.Time) (string, lipgloss.Color) {
+	switch {
+	case stats.Entries == 0:
+		return "no entries", ColorRed
+	case now.Sub(stats.LastEntry) > sourceIdleAfter:
+		return "idle", ColorYellow
+	case stats.ParseErrors > 0:
+		return "misparsing", ColorYellow
+	}
+	return "ok", ColorGreen
+}
+
+// sourceRows renders a line per source: its counters, the age of its last
+// entry and its state
+func (m *DashboardModel) sourceRows(sources []SourceStats, nameWidth int) []string {
+	now := time.Now()
+	warnStyle := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
+
+	var rows []string
+	for _, stats := range sources {
+		errors := fmt.Sprintf("%10s", formatCount(stats.ParseErrors))
+		if stats.ParseErrors > 0 && stats.Lines > 0 {
+			errors = warnStyle.Render(fmt.Sprintf("%10s", fmt.Sprintf("%s %d%%", formatCount(stats.ParseErrors), stats.ParseErrors*100/stats.Lines)))
+		}
+		dropped := fmt.Sprintf("%9s", formatCount(stats.Dropped))
+		if stats.Dropped > 0 {
+			dropped = warnStyle.Render(dropped)
+		}
+		last := "never"
+		if !stats.LastEntry.IsZero() {
+			last = formatAgo(now.Sub(stats.LastEntry))
+		}
+		state, color := sourceState(stats, now)
+		rows = append(rows, padToWidth(truncateToWidth(stats.Source, nameWidth), nameWidth)+
+			fmt.Sprintf(" %10s %10s %10s ", formatCount(stats.Entries), m.formatBytes(stats.Bytes), formatCount(stats.Lines))+
+			errors+" "+dropped+fmt.Sprintf(" %10s ", last)+
+			lipgloss.NewStyle().Foreground(color).Render(state))
+	}
+	return rows
+}
+
+// renderSourcesModal renders the statistics of each input source, so a dead
+// or misparsing input stands out
+func (m *DashboardModel) renderSourcesModal() string {
+	modalWidth := min(m.width-4, 120)
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, column titles and status bar take one line each
+	listHeight := max(1, contentHeight-3)
+
+	var sources []SourceStats
+	if m.sourceStats != nil {
+		sources = m.sourceStats()
+	}
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(fmt.Sprintf("Sources: %d", len(sources)))
+
+	// The counters take a fixed width; the name gets the rest
+	nameWidth := max(8, contentWidth-76)
+	titles := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Bold(true).
+		MaxWidth(contentWidth).
+		Render(padToWidth("Source", nameWidth) +
+			fmt.Sprintf(" %10s %10s %10s %10s %9s %10s %s", "Entries", "Bytes", "Lines", "Errors", "Dropped", "Last", "State"))
+
+	rows := m.sourceRows(sources, nameWidth)
+	if len(rows) == 0 {
+		rows = []string{lipgloss.NewStyle().Foreground(ColorGray).Render("  No input sources")}
+	}
+	m.sourcesOffset = min(m.sourcesOffset, max(0, len(rows)-listHeight))
+	end := min(len(rows), m.sourcesOffset+listHeight)
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		MaxWidth(contentWidth).
+		Render(strings.Join(rows[m.sourcesOffset:end], "\n"))
+
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render("Errors: lines their format failed to parse (Q shows them) • ↑↓: Scroll • ESC: Close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, titles, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
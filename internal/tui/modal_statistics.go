@@ -19,7 +19,12 @@ func (m *DashboardModel) renderStatsModal() string {
 	m.infoViewport.Height = contentHeight
 
 	// Get statistics content and set it to viewport
+	title := "Log Statistics"
 	statsContent := m.renderStatsContent(contentWidth)
+	if m.statsDiagnostics {
+		title = "Diagnostics"
+		statsContent = m.renderDiagnosticsContent(contentWidth)
+	}
 	m.infoViewport.SetContent(statsContent)
 
 	// Create content pane
@@ -35,12 +40,18 @@ func (m *DashboardModel) renderStatsModal() string {
 		Width(contentWidth).
 		Foreground(ColorBlue).
 		Bold(true).
-		Render("Log Statistics")
+		Render(title)
 
 	// Status bar
+	help := "↑↓/Wheel: Scroll • PgUp/PgDn: Page • d: Diagnostics • i: Toggle Stats • ESC: Close"
+	if m.statsDiagnostics {
+		help = "↑↓/Wheel: Scroll • PgUp/PgDn: Page • d: Log statistics • i: Toggle Stats • ESC: Close"
+	} else if len(m.latency.groups) > 0 {
+		help = "↑↓/Wheel: Scroll • Tab: Select latency row • Enter: Slow entries • d: Diagnostics • i: Toggle Stats • ESC: Close"
+	}
 	statusBar := lipgloss.NewStyle().
 		Foreground(ColorGray).
-		Render("↑↓/Wheel: Scroll • PgUp/PgDn: Page • i: Toggle Stats • ESC: Close")
+		Render(help)
 
 	// Combine all parts
 	modal := lipgloss.JoinVertical(lipgloss.Left, header, contentPane, statusBar)
@@ -0,0 +1,573 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/metrics"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Columns of the table view, in the order the sort column cycles through
+const (
+	tableColTime = iota
+	tableColSeverity
+	tableColService
+	tableColHost
+	tableColField // The numeric field, only while one is chosen
+	tableColMessage
+	tableColumnCount
+)
+
+// tableColumnNames title the table view's columns
+var tableColumnNames = [tableColumnCount]string{"Time", "Severity", "Service", "Host", "", "Message"}
+
+// tableGroupSeverity groups the table view by severity rather than an attribute
+const tableGroupSeverity = "severity"
+
+// tableMaxChoices is the number of numeric fields and group attributes
+// offered, most common first
+const tableMaxChoices = 20
+
+// tableRow is a line of the table view: a group header or an entry
+type tableRow struct {
+	group  string // Group value, "" when not grouped
+	header bool
+	count  int // Entries in the group, for headers
+	errors int // Error entries in the group, for headers
+	entry  LogEntry
+}
+
+// openTableModal shows the entries in the view as a table, keeping the
+// sort column, numeric field and grouping of the last time
+func (m *DashboardModel) openTableModal() {
+	if m.tableCollapsed == nil {
+		m.tableCollapsed = make(map[string]bool)
+	}
+	m.refreshTableEntries()
+	m.tableSelected = 0
+	m.showTableModal = true
+}
+
+// refreshTableEntries copies the entries in the view and sorts them
+func (m *DashboardModel) refreshTableEntries() {
+	m.tableEntries = append(m.tableEntries[:0], m.logEntries...)
+	m.tableFields = numericFieldChoices(m.tableEntries)
+	m.tableGroups = groupChoices(m.tableEntries)
+	m.sortTableEntries()
+}
+
+// numericFieldChoices returns the attributes holding numbers or durations,
+// most common first
+func numericFieldChoices(entries []LogEntry) []string {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		for key, value := range entry.Attributes {
+			if isOutlierSkipped(key) {
+				continue
+			}
+			if _, _, ok := metrics.ParseValue(value); ok {
+				counts[key]++
+			}
+		}
+	}
+	return mostCommonKeys(counts)
+}
+
+// groupChoices returns the attributes the table view can group by: the
+// usual grouping attributes first, then the rest most common first
+func groupChoices(entries []LogEntry) []string {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		for key, value := range entry.Attributes {
+			if value != "" {
+				counts[key]++
+			}
+		}
+	}
+	choices := []string{tableGroupSeverity}
+	for _, key := range filterPickerPreferredKeys {
+		if counts[key] > 0 {
+			choices = append(choices, key)
+			delete(counts, key)
+		}
+	}
+	choices = append(choices, mostCommonKeys(counts)...)
+	return choices[:min(len(choices), tableMaxChoices)]
+}
+
+// mostCommonKeys returns up to tableMaxChoices keys, the highest counts first
+func mostCommonKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys[:min(len(keys), tableMaxChoices)]
+}
+
+// tableFieldValue returns the numeric field of an entry
+func (m *DashboardModel) tableFieldValue(entry LogEntry) (float64, bool) {
+	v, _, ok := metrics.ParseValue(entry.Attributes[m.tableField])
+	return v, ok
+}
+
+// compareTableEntries orders two entries by the sort column, ascending.
+// Entries without the numeric field come last either way, see
+// sortTableEntries.
+func (m *DashboardModel) compareTableEntries(a, b LogEntry) int {
+	switch m.tableSortColumn {
+	case tableColSeverity:
+		ra, oka := severityRanks[normalizeSeverityLevel(a.Severity)]
+		rb, okb := severityRanks[normalizeSeverityLevel(b.Severity)]
+		if !oka {
+			ra = -1
+		}
+		if !okb {
+			rb = -1
+		}
+		return ra - rb
+	case tableColService:
+		return strings.Compare(a.Attributes["service.name"], b.Attributes["service.name"])
+	case tableColHost:
+		return strings.Compare(a.Attributes["host.name"], b.Attributes["host.name"])
+	case tableColField:
+		va, _ := m.tableFieldValue(a)
+		vb, _ := m.tableFieldValue(b)
+		switch {
+		case va < vb:
+			return -1
+		case va > vb:
+			return 1
+		}
+		return 0
+	case tableColMessage:
+		return strings.Compare(a.Message, b.Message)
+	}
+	return m.getDisplayTimestamp(a).Compare(m.getDisplayTimestamp(b))
+}
+
+// sortTableEntries sorts the table's entries by the sort column, ties in
+// arrival order, and lays out the rows
+func (m *DashboardModel) sortTableEntries() {
+	sort.SliceStable(m.tableEntries, func(i, j int) bool {
+		a, b := m.tableEntries[i], m.tableEntries[j]
+		if m.tableSortColumn == tableColField {
+			_, oka := m.tableFieldValue(a)
+			_, okb := m.tableFieldValue(b)
+			if oka != okb {
+				return oka
+			}
+		}
+		c := m.compareTableEntries(a, b)
+		if m.tableSortDesc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return a.Seq < b.Seq
+	})
+	m.layoutTableRows()
+}
+
+// tableGroupValue returns the group of an entry in the table view
+func (m *DashboardModel) tableGroupValue(entry LogEntry) string {
+	if m.tableGroupBy == tableGroupSeverity {
+		return normalizeSeverityLevel(entry.Severity)
+	}
+	return entry.Attributes[m.tableGroupBy]
+}
+
+// layoutTableRows lays out the sorted entries, under a header per group
+// when grouped. Groups come in the order of their first entry, so the
+// group holding the top entry by the sort column comes first.
+func (m *DashboardModel) layoutTableRows() {
+	m.tableRows = m.tableRows[:0]
+	if m.tableGroupBy == "" {
+		for _, entry := range m.tableEntries {
+			m.tableRows = append(m.tableRows, tableRow{entry: entry})
+		}
+		return
+	}
+
+	var order []string
+	groups := make(map[string][]LogEntry)
+	for _, entry := range m.tableEntries {
+		value := m.tableGroupValue(entry)
+		if _, ok := groups[value]; !ok {
+			order = append(order, value)
+		}
+		groups[value] = append(groups[value], entry)
+	}
+	for _, value := range order {
+		header := tableRow{group: value, header: true, count: len(groups[value])}
+		for _, entry := range groups[value] {
+			if isErrorEntry(entry) {
+				header.errors++
+			}
+		}
+		m.tableRows = append(m.tableRows, header)
+		if m.tableCollapsed[value] {
+			continue
+		}
+		for _, entry := range groups[value] {
+			m.tableRows = append(m.tableRows, tableRow{group: value, entry: entry})
+		}
+	}
+}
+
+// tableSortColumns returns the columns the table can be sorted by: all but
+// the numeric field while none is chosen
+func (m *DashboardModel) tableSortColumns() []int {
+	var columns []int
+	for column := range tableColumnCount {
+		if column != tableColField || m.tableField != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// cycleTableSortColumn moves the sort column by delta among the columns
+func (m *DashboardModel) cycleTableSortColumn(delta int) {
+	columns := m.tableSortColumns()
+	index := 0
+	for i, column := range columns {
+		if column == m.tableSortColumn {
+			index = i
+		}
+	}
+	m.tableSortColumn = columns[(index+delta+len(columns))%len(columns)]
+	m.sortTableEntries()
+}
+
+// cycleChoice returns the choice after current, "" following the last one
+func cycleChoice(choices []string, current string) string {
+	for i, choice := range choices {
+		if choice == current {
+			if i+1 < len(choices) {
+				return choices[i+1]
+			}
+			return ""
+		}
+	}
+	if len(choices) > 0 && current == "" {
+		return choices[0]
+	}
+	return ""
+}
+
+// cycleTableField shows the next numeric field and sorts by it, largest
+// first, or removes the field column after the last one
+func (m *DashboardModel) cycleTableField() {
+	m.tableField = cycleChoice(m.tableFields, m.tableField)
+	switch {
+	case m.tableField != "":
+		m.tableSortColumn = tableColField
+		m.tableSortDesc = true
+	case m.tableSortColumn == tableColField:
+		m.tableSortColumn = tableColTime
+		m.tableSortDesc = false
+	}
+	m.sortTableEntries()
+}
+
+// cycleTableGroup groups by the next attribute, ungrouping after the last one
+func (m *DashboardModel) cycleTableGroup() {
+	m.tableGroupBy = cycleChoice(m.tableGroups, m.tableGroupBy)
+	m.tableCollapsed = make(map[string]bool)
+	m.tableSelected = 0
+	m.layoutTableRows()
+}
+
+// toggleTableGroup collapses or expands the group of the selected row,
+// keeping its header selected
+func (m *DashboardModel) toggleTableGroup() {
+	if m.tableGroupBy == "" || m.tableSelected >= len(m.tableRows) {
+		return
+	}
+	group := m.tableRows[m.tableSelected].group
+	m.tableCollapsed[group] = !m.tableCollapsed[group]
+	m.layoutTableRows()
+	m.selectTableHeader(group)
+}
+
+// setAllTableGroupsCollapsed collapses or expands every group, keeping the
+// selected row's group selected
+func (m *DashboardModel) setAllTableGroupsCollapsed(collapsed bool) {
+	if m.tableGroupBy == "" || m.tableSelected >= len(m.tableRows) {
+		return
+	}
+	group := m.tableRows[m.tableSelected].group
+	for _, row := range m.tableRows {
+		if row.header {
+			m.tableCollapsed[row.group] = collapsed
+		}
+	}
+	m.layoutTableRows()
+	m.selectTableHeader(group)
+}
+
+// selectTableHeader selects the header of a group
+func (m *DashboardModel) selectTableHeader(group string) {
+	for i, row := range m.tableRows {
+		if row.header && row.group == group {
+			m.tableSelected = i
+			return
+		}
+	}
+	m.tableSelected = 0
+}
+
+// handleTableModalKeys processes keyboard input for the table view
+func (m *DashboardModel) handleTableModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := max(0, len(m.tableRows)-1)
+	switch msg.String() {
+	case "escape", "esc", "J":
+		m.showTableModal = false
+	case "right", "l", "tab":
+		m.cycleTableSortColumn(1)
+	case "left", "h", "shift+tab":
+		m.cycleTableSortColumn(-1)
+	case "s":
+		// Reverse the sort order
+		m.tableSortDesc = !m.tableSortDesc
+		m.sortTableEntries()
+	case "f":
+		m.cycleTableField()
+	case "g":
+		m.cycleTableGroup()
+	case " ":
+		m.toggleTableGroup()
+	case "c":
+		m.setAllTableGroupsCollapsed(true)
+	case "e":
+		m.setAllTableGroupsCollapsed(false)
+	case "r":
+		// Take the entries in the view again
+		m.refreshTableEntries()
+		m.tableSelected = min(m.tableSelected, max(0, len(m.tableRows)-1))
+	case "up", "k":
+		m.tableSelected = max(0, m.tableSelected-1)
+	case "down", "j":
+		m.tableSelected = min(last, m.tableSelected+1)
+	case "pgup":
+		m.tableSelected = max(0, m.tableSelected-10)
+	case "pgdown":
+		m.tableSelected = min(last, m.tableSelected+10)
+	case "home":
+		m.tableSelected = 0
+	case "end":
+		m.tableSelected = last
+	case "enter":
+		if m.tableSelected >= len(m.tableRows) {
+			break
+		}
+		row := m.tableRows[m.tableSelected]
+		if row.header {
+			m.toggleTableGroup()
+			break
+		}
+		// Show details of the selected entry
+		entry := row.entry
+		m.currentLogEntry = &entry
+		m.modalContent = m.formatLogDetails(entry, 60)
+		m.showModal = true
+		m.modalReady = false
+		m.modalActiveSection = "info"
+		m.aiAnalysisResult = ""
+		m.showTableModal = false
+	}
+	return m, nil
+}
+
+// handleTableModalMouseEvent processes mouse wheel scrolling in the table view
+func (m *DashboardModel) handleTableModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = -1
+	case tea.MouseButtonWheelDown:
+		delta = 1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.tableSelected = max(0, min(len(m.tableRows)-1, m.tableSelected+delta))
+	return m, nil
+}
+
+// tableColumnTitle titles a column, marking the sort column with its order
+func (m *DashboardModel) tableColumnTitle(column int) string {
+	title := tableColumnNames[column]
+	if column == tableColField {
+		title = m.tableField
+	}
+	if column == m.tableSortColumn {
+		if m.tableSortDesc {
+			return title + " ▼"
+		}
+		return title + " ▲"
+	}
+	return title
+}
+
+// renderTableModal renders the entries in the view sorted by a column and
+// optionally grouped by an attribute
+func (m *DashboardModel) renderTableModal() string {
+	modalWidth := min(m.width-4, 180)
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header, column titles and status bar take one line each
+	listHeight := max(1, contentHeight-3)
+
+	summary := fmt.Sprintf("Table: %s entries • sorted by %s", formatCount(int64(len(m.tableEntries))), m.tableColumnTitle(m.tableSortColumn))
+	if m.tableGroupBy != "" {
+		groups := 0
+		for _, row := range m.tableRows {
+			if row.header {
+				groups++
+			}
+		}
+		summary += fmt.Sprintf(" • grouped by %s (%d groups)", m.tableGroupBy, groups)
+	}
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(summary)
+
+	// Fixed widths of the time, severity, service, host and field columns
+	const timeWidth, severityWidth, serviceWidth, hostWidth = 14, 8, 16, 12
+	fieldWidth := 0
+	if m.tableField != "" {
+		fieldWidth = max(10, min(lipgloss.Width(m.tableColumnTitle(tableColField)), 24))
+	}
+	messageWidth := max(10, contentWidth-4-timeWidth-severityWidth-serviceWidth-hostWidth-fieldWidth-5)
+
+	titleStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	sortStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	title := func(column, width int, right bool) string {
+		text := truncateToWidth(m.tableColumnTitle(column), width)
+		if right {
+			text = strings.Repeat(" ", max(0, width-lipgloss.Width(text))) + text
+		} else {
+			text = padToWidth(text, width)
+		}
+		if column == m.tableSortColumn {
+			return sortStyle.Render(text)
+		}
+		return titleStyle.Render(text)
+	}
+	// Grouped rows are indented under their group's header
+	indent := "  "
+	if m.tableGroupBy != "" {
+		indent = "    "
+	}
+	columns := indent + title(tableColTime, timeWidth, false) + " " +
+		title(tableColSeverity, severityWidth, false) + " " +
+		title(tableColService, serviceWidth, false) + " " +
+		title(tableColHost, hostWidth, false) + " "
+	if fieldWidth > 0 {
+		columns += title(tableColField, fieldWidth, true) + " "
+	}
+	columns += title(tableColMessage, messageWidth, false)
+
+	var lines []string
+	if len(m.tableRows) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorGray).Render("  No entries in the view"))
+	}
+
+	start := listWindow(m.tableSelected, listHeight, len(m.tableRows))
+
+	selected := lipgloss.NewStyle().Background(ColorBlue).Foreground(ColorWhite)
+	groupStyle := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+	styles := getRowStyles()
+	for i := start; i < len(m.tableRows) && i < start+listHeight; i++ {
+		row := m.tableRows[i]
+		if row.header {
+			arrow := "▼ "
+			if m.tableCollapsed[row.group] {
+				arrow = "▶ "
+			}
+			value := row.group
+			if value == "" {
+				value = "(none)"
+			}
+			name := truncateToWidth(m.tableGroupBy+"="+value, max(10, contentWidth-40))
+			counts := fmt.Sprintf("  %s entries", formatCount(int64(row.count)))
+			errors := ""
+			if row.errors > 0 {
+				errors = fmt.Sprintf("  %s errors", formatCount(int64(row.errors)))
+			}
+			if i == m.tableSelected {
+				lines = append(lines, selected.Render(padToWidth(arrow+name+counts+errors, contentWidth)))
+				continue
+			}
+			lines = append(lines, groupStyle.Render(arrow+name)+counts+errorStyle.Render(errors))
+			continue
+		}
+
+		entry := row.entry
+		timestamp := padToWidth(truncateToWidth(m.formatListTimestamp(entry), timeWidth), timeWidth)
+		severity := padToWidth(truncateToWidth(entry.Severity, severityWidth), severityWidth)
+		service := padToWidth(truncateToWidth(entry.Attributes["service.name"], serviceWidth), serviceWidth)
+		host := padToWidth(truncateToWidth(entry.Attributes["host.name"], hostWidth), hostWidth)
+		field := ""
+		if fieldWidth > 0 {
+			value := truncateToWidth(entry.Attributes[m.tableField], fieldWidth)
+			field = strings.Repeat(" ", max(0, fieldWidth-lipgloss.Width(value))) + value + " "
+		}
+		message := truncateToWidth(entry.Message, messageWidth)
+
+		if i == m.tableSelected {
+			line := indent + timestamp + " " + severity + " " + service + " " + host + " " + field + message
+			lines = append(lines, selected.Render(padToWidth(line, contentWidth)))
+			continue
+		}
+		lines = append(lines, indent+styles.timestamp.Render(timestamp)+" "+
+			styles.severityStyle(entry.Severity).Render(severity)+" "+
+			styles.col2.Render(service)+" "+styles.col1.Render(host)+" "+field+message)
+	}
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(strings.Join(lines, "\n"))
+
+	status := "←→: Sort column • s: Reverse • f: Numeric field • g: Group by • ↑↓: Navigate • Enter: Details • r: Refresh • ESC: Close"
+	if m.tableGroupBy != "" {
+		status = "←→/s: Sort • f: Field • g: Group by • Space: Collapse • c/e: Collapse/Expand all • Enter: Details • r: Refresh • ESC: Close"
+	}
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(status)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, columns, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
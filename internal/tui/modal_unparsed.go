@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// UnparsedLine is a line its format failed to parse, kept for the unparsed
+// view
+type UnparsedLine struct {
+	Time   time.Time
+	Source string
+	Error  string
+	Raw    string // The line, or its first bytes when longer than kept
+	Size   int    // Of the whole line, in bytes
+}
+
+// SetUnparsed sets the function reporting the latest lines that failed to
+// parse, oldest first, and how many did in all, shown in the unparsed view
+func (m *DashboardModel) SetUnparsed(lines func() ([]UnparsedLine, int64)) {
+	m.unparsed = lines
+}
+
+// openUnparsedModal shows the lines that failed to parse, newest at the
+// bottom
+func (m *DashboardModel) openUnparsedModal() {
+	m.showUnparsedModal = true
+	m.unparsedOffset = 0
+}
+
+// escapeRaw shows the bytes of a line that a terminal would hide or act on:
+// control characters and invalid UTF-8 become escapes such as \t or \x00
+func escapeRaw(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			fmt.Fprintf(&b, `\x%02x`, text[i])
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			b.WriteString(text[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// unparsedLines renders each line that failed to parse under its time,
+// source and error, wrapped to width, oldest first
+func (m *DashboardModel) unparsedLines(lines []UnparsedLine, width int) []string {
+	timeStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	sourceStyle := lipgloss.NewStyle().Foreground(ColorBlue)
+	errorStyle := lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+	noteStyle := lipgloss.NewStyle().Foreground(ColorGray).Italic(true)
+
+	const indent = "  "
+	var rendered []string
+	for _, line := range lines {
+		header := timeStyle.Render(m.inDisplayZone(line.Time).Format("15:04:05.000")) + " " +
+			sourceStyle.Render(line.Source) + " " + errorStyle.Render(line.Error)
+		rendered = append(rendered, strings.Split(ansi.Wrap(header, width, ""), "\n")...)
+		for _, raw := range strings.Split(ansi.Hardwrap(escapeRaw(line.Raw), max(10, width-len(indent)), true), "\n") {
+			rendered = append(rendered, indent+raw)
+		}
+		if line.Size > len(line.Raw) {
+			rendered = append(rendered, indent+noteStyle.Render(fmt.Sprintf("first %s of %s shown", m.formatBytes(int64(len(line.Raw))), m.formatBytes(int64(line.Size)))))
+		}
+		rendered = append(rendered, "")
+	}
+	return rendered
+}
+
+// handleUnparsedModalKeys processes keyboard input for the unparsed view
+func (m *DashboardModel) handleUnparsedModalKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc", "Q":
+		m.showUnparsedModal = false
+	case "up", "k":
+		m.unparsedOffset++
+	case "down", "j":
+		m.unparsedOffset = max(0, m.unparsedOffset-1)
+	case "pgup":
+		m.unparsedOffset += 10
+	case "pgdown":
+		m.unparsedOffset = max(0, m.unparsedOffset-10)
+	case "home":
+		m.unparsedOffset = int(^uint(0) >> 1)
+	case "end":
+		m.unparsedOffset = 0
+	}
+	return m, nil
+}
+
+// handleUnparsedModalMouseEvent processes mouse wheel scrolling in the unparsed view
+func (m *DashboardModel) handleUnparsedModalMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	delta := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		delta = 1
+	case tea.MouseButtonWheelDown:
+		delta = -1
+	}
+	if m.reverseScrollWheel {
+		delta = -delta
+	}
+	m.unparsedOffset = max(0, m.unparsedOffset+delta)
+	return m, nil
+}
+
+// renderUnparsedModal renders the lines that failed to parse with their raw
+// bytes and error, following the newest unless scrolled back
+func (m *DashboardModel) renderUnparsedModal() string {
+	modalWidth := min(m.width-4, 160)
+	modalHeight := m.height - 2
+	contentWidth := modalWidth - 2
+	contentHeight := modalHeight - 2
+
+	// Header and status bar take one line each
+	listHeight := max(1, contentHeight-2)
+
+	var kept []UnparsedLine
+	var total int64
+	if m.unparsed != nil {
+		kept, total = m.unparsed()
+	}
+	summary := fmt.Sprintf("Unparsed Lines: %s since start", formatCount(total))
+	if int64(len(kept)) < total {
+		summary += fmt.Sprintf(" • latest %s shown", formatCount(int64(len(kept))))
+	}
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(summary)
+
+	lines := m.unparsedLines(kept, contentWidth)
+	if len(lines) == 0 {
+		lines = []string{lipgloss.NewStyle().Foreground(ColorGray).Render("  Every line parsed")}
+	}
+
+	// The offset counts lines back from the newest
+	m.unparsedOffset = min(m.unparsedOffset, max(0, len(lines)-listHeight))
+	end := len(lines) - m.unparsedOffset
+	start := max(0, end-listHeight)
+	list := lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(listHeight).
+		Render(strings.Join(lines[start:end], "\n"))
+
+	status := "↑↓/PgUp/PgDn: Scroll • Home/End: Oldest/Newest • ESC: Close"
+	if m.unparsedOffset > 0 {
+		status = fmt.Sprintf("%d newer lines below • ", m.unparsedOffset) + status
+	}
+	statusBar := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Width(contentWidth).
+		MaxWidth(contentWidth).
+		Render(status)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, list, statusBar)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.DoubleBorder()).
+		BorderForeground(ColorBlue).
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
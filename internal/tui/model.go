@@ -1,12 +1,22 @@
 package tui
 
 import (
+	"context"
 	"regexp"
 	"sort"
 	"time"
 
 	"github.com/control-theory/gonzo/internal/ai"
+	"github.com/control-theory/gonzo/internal/analyzer"
+	"github.com/control-theory/gonzo/internal/alerts"
+	"github.com/control-theory/gonzo/internal/diaglog"
+	"github.com/control-theory/gonzo/internal/filterexpr"
 	"github.com/control-theory/gonzo/internal/memory"
+	"github.com/control-theory/gonzo/internal/metrics"
+	"github.com/control-theory/gonzo/internal/query"
+	"github.com/control-theory/gonzo/internal/redact"
+	"github.com/control-theory/gonzo/internal/slo"
+	"github.com/control-theory/gonzo/internal/spill"
 	versioncheck "github.com/control-theory/gonzo/internal/version"
 
 	"github.com/charmbracelet/bubbles/textarea"
@@ -21,6 +31,9 @@ type K8sSourceInterface interface {
 	ListNamespaces() (map[string]bool, error)
 	ListPods(selectedNamespaces map[string]bool) (map[string]bool, error)
 	UpdateFilter(namespaces []string, selector string, podNames []string) error
+	FollowPod(namespace, pod string) error
+	Unfollow() error
+	GetActiveStreams() int
 }
 
 // Section represents different dashboard sections
@@ -43,6 +56,14 @@ type LogEntry struct {
 	Message       string
 	RawLine       string
 	Attributes    map[string]string
+	Seq           int64    // Sequence number in arrival order, stable for the session
+	Outlier       string   // Numeric attribute that was an outlier on arrival, e.g. "duration_ms=5300 (median 120)"
+	Source        string   // Input the entry was read from, e.g. "k8s" or "stdin"
+	ResourceKeys  []string // Keys of Attributes from the OTLP resource, shared between entries
+	ScopeKeys     []string // Keys of Attributes from the OTLP instrumentation scope
+	// RawLine holds an OTLP body of key/values or an array as JSON, which
+	// Message only summarizes
+	StructuredBody bool
 }
 
 // HeatmapMinute represents severity counts for one minute in the heatmap
@@ -74,6 +95,7 @@ type DashboardModel struct {
 	showHelp           bool
 	showPatternsModal  bool
 	showStatsModal     bool
+	statsDiagnostics   bool // The statistics modal shows gonzo's own diagnostics
 	showCountsModal    bool
 	showLogViewerModal    bool
 	showSeverityFilterModal bool
@@ -82,15 +104,77 @@ type DashboardModel struct {
 	snapshot      *memory.FrequencySnapshot
 	logEntries    []LogEntry       // Filtered view for display
 	allLogEntries []LogEntry       // Complete unfiltered log buffer
-	countsHistory []SeverityCounts // Line counts per interval by severity
+	searchIndex   *searchIndex     // Inverted token index over allLogEntries
+	columns       *columnStore     // Frequently filtered fields of allLogEntries
+	pinnedEntries []LogEntry       // Entries pinned to the always-visible pane
+
+	// Related entries view (entries sharing an attribute value)
+	showRelatedModal bool
+	relatedAnchor    LogEntry   // Entry the view was opened from
+	relatedKeys      []string   // Anchor attribute keys available in the picker
+	relatedKeyIndex  int        // Currently selected attribute key
+	relatedEntries   []LogEntry // Matching entries ordered by time
+	relatedSelected  int        // Selected entry in the related list
+
+	// Drill-down from a frequency chart into the entries behind a value
+	drill               *drillDown
+	podZoom             *podZoom // Pod followed from the log list, nil when not zoomed
+	showAttrValuesModal bool
+	attrValuesKey       string
+	attrValuesTotal     int64
+	attrValues          []attributeValueCount // Most common first
+	attrValuesSelected  int
+
+	// Attribute values most associated with the errors in the view
+	showCorrelationModal bool
+	correlationRows      []errorCorrelation
+	correlationErrors    int // Error entries analyzed
+	correlationOthers    int // Other entries analyzed
+	correlationSelected  int
+
+	// Sessions reconstructed from the entries sharing a correlation key
+	showSessionsModal  bool
+	sessionKeys        []string // Correlation attributes, in order of preference
+	sessions           []logSession
+	sessionsFailedOnly bool // List only the sessions with errors
+	sessionsSelected   int
+
+	// Table view of the entries, sorted by a column and grouped by an attribute
+	showTableModal  bool
+	tableEntries    []LogEntry // Entries in the view when opened, sorted
+	tableRows       []tableRow
+	tableSortColumn int
+	tableSortDesc   bool
+	tableField      string   // Numeric attribute shown as a column, "" for none
+	tableFields     []string // Numeric attributes the field column cycles through
+	tableGroupBy    string   // Attribute the rows are grouped by, "" for none
+	tableGroups     []string // Attributes the grouping cycles through
+	tableCollapsed  map[string]bool
+	tableSelected   int
+
+	// Errors of each service in the view per time bucket
+	showSvcHeatmapModal bool
+	svcHeatmap          *serviceHeatmap
+	svcHeatmapRow       int // Selected service
+	svcHeatmapCol       int // Selected time bucket
+
+	countsHistory     []SeverityCounts // Line counts per interval by severity
+	countsIntervals   int64            // Intervals added to countsHistory since the start
+	countsIntervalEnd time.Time        // When the latest counts interval ended
+	spike             *logSpike        // Latest volume spike on the Counts chart
+	spikeAge          int              // Intervals since the spike ended
 
 	// Log Counts Modal Data
 	heatmapData        []HeatmapMinute           // Minute-by-minute severity counts for heatmap (60 minute rolling window)
+	heatmapIndex       map[int64]int             // Position in heatmapData by minute in Unix nanoseconds
+	heatmapPruned      time.Time                 // When minutes past the window were last dropped
 	drain3BySeverity   map[string]*Drain3Manager // Separate drain3 instance for each severity
 	servicesBySeverity map[string][]ServiceCount // Top services by severity level
 
 	// Configuration
 	maxLogBuffer       int
+	bufferMaxBytes     int64  // Memory budget of the buffer, 0 for none
+	bufferPolicy       string // What happens when the buffer is full
 	updateInterval     time.Duration
 	reverseScrollWheel bool
 	useLogTime         bool // Use OrigTimestamp instead of Timestamp for heatmap/display
@@ -104,12 +188,171 @@ type DashboardModel struct {
 	searchInput  textinput.Model
 	searchActive bool
 	searchTerm   string // For 's' command - highlights just the term
+	fuzzySearch  bool   // Use fuzzy subsequence matching for search
+
+	// Include/exclude filters built from a selected entry
+	attributeFilters     []attributeFilter
+	showFilterPicker     bool
+	filterPickerOptions  []attributeFilter
+	filterPickerSelected int
+
+	// URL templates opened in the browser from the selected entry (--link)
+	linkTemplates      []LinkTemplate
+	showLinkPicker     bool
+	linkPickerOptions  []entryLink
+	linkPickerSelected int
+
+	// Export of the current view or selection to a file
+	showExportPrompt bool
+	exportInput      textinput.Model
+	exportEntries    []LogEntry // Entries being exported, copied when the prompt opened
+	exportScreen     []string   // Rendered screen lines when exporting the screen
+	exportAnalysis   *analysisExport // Analytics panels snapshot when exporting the analysis
+	exportLabel      string     // What is being exported, for the prompt title
+	exportFormat     string     // Selected export format
+	exportJob        *exportJob // Running background export, if any
+	exportRedactor   *redact.Redactor // Applied to exported entries and analyses
+
+	// Panel layout
+	hiddenPanels     map[string]bool // Panels hidden by configuration
+	logsMaximized    bool            // Hide every panel except the log list
+	chartsLinesLimit int             // Max content lines per chart row, 0 = fit content
+
+	// Guided tour
+	showTutorial bool
+	tutorialStep int
+
+	// Status line template
+	statusLineTemplate string       // Custom status info with {variables}, empty for the default
+	droppedCounter     func() int64            // Entries dropped by the input sources
+	droppedBySource    func() map[string]int64 // The same, for each source
+	queueDepths        func() []QueueDepth     // Channels between the sources and the dashboard
+	bufferBytes        int64                   // Estimated memory of the buffered entries
+	bufferEvicted      int64                   // Entries evicted to stay within the buffer budget
+	bufferRejected     int64                   // Entries discarded because the buffer was full
+
+	// Evicted entries kept on disk (--log-spill), and those read back while
+	// scrolled past the buffer: spill entries coldStart up to coldEnd
+	spill        *spill.Store
+	spillFailed  bool
+	cold         []LogEntry
+	coldStart    int
+	coldEnd      int
+	coldAdjacent bool // The loaded scrollback ends where the buffer starts
+
+	// Entries from before where the files' tail started (--tail-bytes,
+	// --tail-lines), read back when scrolling past the first entry received
+	olderEntries func(n int) []LogEntry
+	history      []LogEntry
+	historyShown bool
+
+	// Entry sequence numbers and "go to #N"
+	lastSeq         int64 // Highest sequence number assigned so far
+	showLineNumbers bool
+	showGotoPrompt  bool
+	gotoInput       textinput.Model
+	gotoError       string
+
+	// Aggregate query typed at the ":" prompt, with its answer
+	showQueryModal bool
+	query          *query.Query
+	queryResult    query.Result
+	querySelected  int
+
+	// Filter expressions, typed or translated from a request by the AI
+	filterExpr     *filterexpr.Expr
+	showExprPrompt bool
+	exprInput      textinput.Model
+	exprAskAI      bool   // The prompt holds a request for the AI, not an expression
+	exprPending    string // Translated expression awaiting confirmation
+	exprError      string
+	exprWorking    bool
+	exprRequestID  int // Identifies the translation the prompt is waiting for
+
+	// Alert rules and the alerts currently above their thresholds
+	alertEngine  *alerts.Engine
+	activeAlerts []alerts.Alert
+
+	// gonzo's own log messages and the modal showing them
+	diagLog          *diaglog.Log
+	showDiagLogModal bool
+	diagLogLevel     diaglog.Level // Least severe level shown
+	diagLogOffset    int           // Lines scrolled back from the newest
+
+	// What each input source delivered and the modal showing it
+	sourceStats      func() []SourceStats
+	showSourcesModal bool
+	sourcesOffset    int
+
+	// Lines that failed to parse and the view showing them
+	unparsed          func() ([]UnparsedLine, int64)
+	showUnparsedModal bool
+	unparsedOffset    int // Lines scrolled back from the newest
+
+	// Reported in the status line after the config file was reloaded
+	configNotice      string
+	configNoticeUntil time.Time
+
+	// Metrics extracted from the stream by the configured rules
+	metricSet *metrics.Set
+
+	// Service level objectives and their latest status
+	sloTracker  *slo.Tracker
+	sloStatuses []slo.Status
+
+	// Watch expressions counted in the watches pane, and the prompt adding them
+	watches         []*watchCounter
+	showWatchPrompt bool
+	watchInput      textinput.Model
+	watchError      string
+
+	// Markers on the counts chart, such as deploys, and the prompt adding them
+	markers          []marker
+	showMarkerPrompt bool
+	markerInput      textinput.Model
+
+	// Badges naming the source of each entry in the log list
+	sourceBadgesMode string
+	sourceBadges     map[string]SourceBadge // Configured badges by source
+	sourcesSeen      map[string]bool
+	sourceBadgeWidth int // Widest label of the sources seen
+
+	// Patterns first seen recently, for the new patterns pane
+	novelty *patternNovelty
+
+	// Outliers among numeric attribute values, and whether only they are shown
+	outliers     *outlierDetector
+	outliersOnly bool
+
+	// Whether only the entries of sampled traces are shown
+	sampledOnly bool
+
+	// HTTP requests by status class and endpoint, for the HTTP pane, and
+	// its 5xx and slow request toggles
+	httpFacet        *httpFacet
+	serverErrorsOnly bool
+	slowOnlyMs       float64 // Shortest duration shown, zero when the slow toggle is off
+
+	// Latency percentiles per service/endpoint and the slow entries drill-down
+	latency         *latencyTracker
+	latencySelected int // Group selected in the statistics modal
+	showSlowModal   bool
+	slowKey         latencyKey
+	slowSamples     []latencySample // Slowest entries of the group, slowest first
+	slowSelected    int
+
+	// User-defined highlight rules (from config or added at runtime)
+	highlightRules        []HighlightRule
+	runtimeHighlightCount int // Number of rules added from the TUI, for color cycling
+	highlightGeneration   int // Bumped when the rules change, invalidating cached rows
+	rowCache              rowCache
 
 	// Severity Filter
 	severityFilter         map[string]bool // Which severity levels are enabled (true = show, false = hide)
 	severityFilterSelected int             // Selected index in severity filter modal
 	severityFilterActive   bool            // Whether severity filtering is active (any severity disabled)
 	severityFilterOriginal map[string]bool // Original state when modal opened (for ESC cancellation)
+	severityFloor          string          // Least severe level shown when set with V, "" for none
 
 	// Kubernetes Filter (requires integration with k8s log source)
 	showK8sFilterModal     bool                // Whether to show K8s filter modal
@@ -131,6 +374,16 @@ type DashboardModel struct {
 	selectedLogIndex int  // For log section navigation
 	viewPaused       bool // Pause view updates when navigating logs
 	logAutoScroll    bool // Auto-scroll to latest logs in log viewer
+	unreadLogCount   int  // New entries arrived while auto-scroll was paused
+	resumeAnchor     time.Time // Log time to select once reached, restored with --resume
+	useUTC           bool   // Show timestamps in UTC instead of local time
+	timeFormat       string // Timestamp layout for entries from today
+	dateTimeFormat   string // Timestamp layout for entries older than today
+	timestampMode    string    // absolute, relative or delta timestamps
+	relativeBase     time.Time // Timestamp of the previous listed entry, for delta mode
+	visualMode       bool // Visual multi-select mode in the log list
+	selectionAnchor  int  // Log index where the visual selection started
+	rowInSelection   bool // Whether the row being rendered is inside the selection
 	instructionsScrollOffset int // Scroll position for instructions/filter status screen
 
 	// Modal display options
@@ -146,6 +399,11 @@ type DashboardModel struct {
 	currentLogEntry  *LogEntry // Track current log entry being viewed for AI analysis
 	aiAnalysisResult string    // Store the AI analysis result for display
 	aiSpinnerFrame   int       // Animation frame for AI spinner
+	aiBatchPrompt    string             // Prompt template for selection and filtered view analysis
+	aiStreamID       int                // Identifies the streamed analysis shown in the modal
+	aiStreamCancel   context.CancelFunc // Cancels the streamed analysis, nil when none is running
+	aiStreamTitle    string             // Heading of the streamed analysis
+	aiStreamText     string             // Reply received so far
 
 	// AI Status tracking
 	aiConfigured   bool   // Whether AI is properly configured
@@ -175,7 +433,9 @@ type DashboardModel struct {
 	chatSpinnerFrame int      // Animation frame for chat spinner
 
 	// Column display
-	showColumns bool // Toggle Host and Service columns in log view
+	showColumns  bool // Toggle Host and Service columns in log view
+	scopeColumns bool // The columns show the instrumentation scope instead
+	scopesSeen   bool // Some entry had an OTLP instrumentation scope
 
 	// Drain3 pattern extraction
 	drain3Manager       *Drain3Manager
@@ -200,6 +460,7 @@ type DashboardModel struct {
 	lifetimeWordCounts     map[string]int64            // Total count per word (for charts)
 	lifetimeAttrKeyCounts  map[string]map[string]int64 // Per attribute key: value -> count (for charts)
 	stopWords              map[string]bool             // Stop words to filter from word counting
+	wordAnalyzer           *analyzer.TextAnalyzer      // Word counting rules, replacing stopWords when set
 
 	// Version checking
 	versionChecker *versioncheck.Checker // Version checker for update notifications
@@ -245,7 +506,7 @@ func initializeDrain3BySeverity() map[string]*Drain3Manager {
 }
 
 // NewDashboardModel creates a new dashboard model with stop words
-func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel string, stopWords map[string]bool, reverseScrollWheel bool, useLogTime bool) *DashboardModel {
+func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiClient *ai.OpenAIClient, stopWords map[string]bool, reverseScrollWheel bool, useLogTime bool) *DashboardModel {
 	filterInput := textinput.New()
 	filterInput.Placeholder = "Filter logs by message or attributes (regex supported)..."
 	filterInput.CharLimit = 200
@@ -254,6 +515,26 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 	searchInput.Placeholder = "Search and highlight text..."
 	searchInput.CharLimit = 200
 
+	exportInput := textinput.New()
+	exportInput.Placeholder = "Path to export file..."
+	exportInput.CharLimit = 500
+
+	gotoInput := textinput.New()
+	gotoInput.Placeholder = "Entry number or query..."
+	gotoInput.CharLimit = 256
+
+	exprInput := textinput.New()
+	exprInput.Placeholder = "Expression or request..."
+	exprInput.CharLimit = 500
+
+	watchInput := textinput.New()
+	watchInput.Placeholder = "OOMKilled, or name: severity=ERROR service.name=api..."
+	watchInput.CharLimit = 500
+
+	markerInput := textinput.New()
+	markerInput.Placeholder = "deploy checkout v1.4.2..."
+	markerInput.CharLimit = 200
+
 	chatInput := textarea.New()
 	chatInput.Prompt = "> "
 	chatInput.Placeholder = "Ask a follow-up question about this log..."
@@ -288,18 +569,30 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 		reverseScrollWheel:  reverseScrollWheel,
 		useLogTime:          useLogTime,
 		filterInput:         filterInput,
+		exportInput:         exportInput,
+		gotoInput:           gotoInput,
+		exprInput:           exprInput,
+		watchInput:          watchInput,
+		markerInput:         markerInput,
 		searchInput:         searchInput,
 		chatInput:           chatInput,
 		selectedIndex:       make(map[Section]int),
 		logEntries:          make([]LogEntry, 0, maxLogBuffer),
 		allLogEntries:       make([]LogEntry, 0, maxLogBuffer),
+		searchIndex:         newSearchIndex(),
+		columns:             newColumnStore(),
+		latency:             newLatencyTracker("", DefaultLatencyWindow),
+		novelty:             newPatternNovelty(DefaultNewPatternWindow),
+		httpFacet:           &httpFacet{},
+		outliers:            newOutlierDetector(DefaultOutlierThreshold),
+		sessionKeys:         DefaultSessionKeys,
 		countsHistory:       make([]SeverityCounts, 0),
 		heatmapData:         make([]HeatmapMinute, 0),
 		drain3BySeverity:    initializeDrain3BySeverity(),
 		servicesBySeverity:  make(map[string][]ServiceCount),
 		availableIntervals:  availableIntervals,
 		currentIntervalIdx:  currentIdx,
-		aiClient:            ai.NewOpenAIClient(aiModel), // Initialize AI client with configurable model
+		aiClient:            aiClient,
 		infoViewport:        viewport.New(80, 20),        // Will be resized later
 		chatViewport:        viewport.New(30, 20),        // Will be resized later
 		modalActiveSection:  "info",                      // Start with info section active
@@ -308,6 +601,9 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 		drain3Manager:       NewDrain3Manager(), // Initialize drain3 manager
 		drain3LastProcessed: 0,                  // Initialize drain3 tracking
 		logAutoScroll:       true,               // Start with auto-scroll enabled
+		timeFormat:          DefaultTimeFormat,
+		dateTimeFormat:      DefaultDateTimeFormat,
+		timestampMode:       TimestampModeAbsolute,
 		showColumns:         true,               // Show Host/Service columns by default
 		instructionsScrollOffset: 0,             // Start at top of instructions
 		attributeWrappingEnabled: false,         // Default to truncating (not wrapping)
@@ -345,6 +641,7 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 		severityFilterActive:   false,
 		severityFilterOriginal: make(map[string]bool), // Initialize empty map for modal state backup
 	}
+	m.columns.measure = m.measureField
 
 	// Initialize AI status based on client validation
 	if m.aiClient != nil {
@@ -357,7 +654,7 @@ func NewDashboardModel(maxLogBuffer int, updateInterval time.Duration, aiModel s
 		m.aiConfigured = false
 		m.aiServiceName = "None"
 		m.aiModelName = ""
-		m.aiErrorMessage = "No API key configured"
+		m.aiErrorMessage = "No AI endpoint configured"
 	}
 
 	return m
@@ -445,6 +742,10 @@ func (m *DashboardModel) SetVersionChecker(checker *versioncheck.Checker) {
 // SetK8sSource sets the Kubernetes log source for the dashboard
 func (m *DashboardModel) SetK8sSource(source K8sSourceInterface) {
 	m.k8sSource = source
+	// A selection restored from the last session is streamed from the start
+	if m.k8sFilterActive {
+		m.applyK8sFilterToSource()
+	}
 }
 
 // isK8sMode returns true if any logs have k8s attributes (namespace or pod)
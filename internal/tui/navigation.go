@@ -12,6 +12,11 @@ import (
 
 // handleKeyPress processes keyboard input
 func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The guided tour takes every key until it is finished or skipped
+	if m.showTutorial {
+		return m.handleTutorialKeys(msg)
+	}
+
 	// HIGHEST PRIORITY: Filter input (must come before ANY other handlers)
 	if m.filterActive {
 		switch msg.String() {
@@ -26,7 +31,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.updateFilteredView()
 			// Reset to a valid section for navigation
 			if m.activeSection == SectionFilter {
-				m.activeSection = SectionWords
+				m.activeSection = m.firstSection()
 			}
 			return m, nil
 		case "enter":
@@ -76,7 +81,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchTerm = ""
 			// Reset to a valid section for navigation
 			if m.activeSection == SectionFilter {
-				m.activeSection = SectionWords
+				m.activeSection = m.firstSection()
 			}
 			return m, nil
 		case "enter":
@@ -88,6 +93,10 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Switch to log viewer to allow navigation
 			m.activeSection = SectionLogs
 			return m, nil
+		case "tab":
+			// Toggle between substring and fuzzy matching
+			m.fuzzySearch = !m.fuzzySearch
+			return m, nil
 		default:
 			// ALL other keys (including 'q') go to search input
 			var cmd tea.Cmd
@@ -118,6 +127,9 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "?", "h", "escape", "esc":
 			m.showHelp = false
 			return m, nil
+		case "t":
+			m.openTutorial()
+			return m, nil
 		}
 
 		// Update viewport with any other keys
@@ -195,6 +207,150 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Filter picker captures all keys while open
+	if m.showFilterPicker {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleFilterPickerKeys(msg)
+	}
+
+	// Link picker captures all keys while open
+	if m.showLinkPicker {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleLinkPickerKeys(msg)
+	}
+
+	// Error correlation modal captures all keys while open
+	if m.showCorrelationModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleCorrelationModalKeys(msg)
+	}
+
+	// Slow entries modal captures all keys while open
+	if m.showSlowModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleSlowModalKeys(msg)
+	}
+
+	// Related entries modal captures all keys while open
+	if m.showRelatedModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleRelatedModalKeys(msg)
+	}
+
+	// Sessions modal captures all keys while open
+	if m.showSessionsModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleSessionsModalKeys(msg)
+	}
+
+	// Attribute values modal captures all keys while open
+	if m.showAttrValuesModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleAttributeValuesModalKeys(msg)
+	}
+
+	// Table view captures all keys while open
+	if m.showTableModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleTableModalKeys(msg)
+	}
+
+	// Service heatmap captures all keys while open
+	if m.showSvcHeatmapModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleServiceHeatmapModalKeys(msg)
+	}
+
+	// Query results capture all keys while open
+	if m.showQueryModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleQueryModalKeys(msg)
+	}
+
+	// Gonzo logs modal captures all keys while open
+	if m.showDiagLogModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleDiagLogModalKeys(msg)
+	}
+
+	// Sources modal captures all keys while open
+	if m.showSourcesModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleSourcesModalKeys(msg)
+	}
+
+	// Unparsed view captures all keys while open
+	if m.showUnparsedModal {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleUnparsedModalKeys(msg)
+	}
+
+	// Export prompt captures all keys while open
+	if m.showExportPrompt {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleExportPromptKeys(msg)
+	}
+
+	// Go to entry prompt captures all keys while open
+	if m.showGotoPrompt {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleGotoPromptKeys(msg)
+	}
+
+	// Marker prompt captures all keys while open
+	if m.showMarkerPrompt {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleMarkerPromptKeys(msg)
+	}
+
+	// Watch prompt captures all keys while open
+	if m.showWatchPrompt {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleWatchPromptKeys(msg)
+	}
+
+	// Filter expression prompt captures all keys while open
+	if m.showExprPrompt {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m.handleExprPromptKeys(msg)
+	}
+
 	// Critical keys that always work
 	switch msg.String() {
 	case "ctrl+c":
@@ -225,6 +381,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			for k, v := range m.k8sPodsOriginal {
 				m.k8sPods[k] = v
 			}
+			m.updateFilteredView()
 			m.showK8sFilterModal = false
 			return m, nil
 		}
@@ -238,6 +395,10 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.showSeverityFilterModal = false
 			return m, nil
 		}
+		if m.visualMode && !m.showModal {
+			m.clearVisualSelection()
+			return m, nil
+		}
 		if m.showLogViewerModal {
 			m.showLogViewerModal = false
 			return m, nil
@@ -245,6 +406,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.showModal {
 			m.showModal = false
 			m.modalContent = ""
+			m.stopAIStream()
 			// Reset viewport scroll position for next modal
 			m.infoViewport.GotoTop()
 			m.chatViewport.GotoTop()
@@ -261,7 +423,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.updateFilteredView()
 			// Reset to a valid section for navigation
 			if m.activeSection == SectionFilter {
-				m.activeSection = SectionWords
+				m.activeSection = m.firstSection()
 			}
 			return m, nil
 		}
@@ -273,12 +435,17 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchTerm = ""
 			// Reset to a valid section for navigation
 			if m.activeSection == SectionFilter {
-				m.activeSection = SectionWords
+				m.activeSection = m.firstSection()
 			}
 			return m, nil
 		}
+		// Leave a drill-down from the frequency charts first
+		if m.drill != nil {
+			m.exitDrillDown()
+			return m, nil
+		}
 		// Clear applied filter/search even when not in input mode
-		if m.filterRegex != nil || m.filterInput.Value() != "" || m.searchTerm != "" || m.searchInput.Value() != "" {
+		if m.filterRegex != nil || m.filterInput.Value() != "" || m.searchTerm != "" || m.searchInput.Value() != "" || len(m.attributeFilters) > 0 || m.filterExpr != nil || m.outliersOnly || m.sampledOnly || m.serverErrorsOnly || m.slowOnlyMs > 0 {
 			// Clear all filter and search state
 			m.filterActive = false
 			m.searchActive = false
@@ -288,10 +455,16 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchInput.SetValue("")
 			m.filterRegex = nil
 			m.searchTerm = ""
+			m.attributeFilters = nil
+			m.filterExpr = nil
+			m.outliersOnly = false
+			m.sampledOnly = false
+			m.serverErrorsOnly = false
+			m.slowOnlyMs = 0
 			m.updateFilteredView()
 			// Reset to a valid section for navigation
 			if m.activeSection == SectionFilter {
-				m.activeSection = SectionWords
+				m.activeSection = m.firstSection()
 			}
 			return m, nil
 		}
@@ -357,9 +530,9 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "c":
-		// Toggle Host/Service columns in log view
+		// Cycle Host/Service, Scope/Version and no columns in log view
 		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
-			m.showColumns = !m.showColumns
+			m.cycleColumns()
 			return m, nil
 		}
 
@@ -371,13 +544,213 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// Remove the numbered filter chip
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showK8sFilterModal {
+			m.removeFilterChip(int(msg.String()[0] - '1'))
+			return m, nil
+		}
+
+	case "Z":
+		// Toggle timestamp display between local time and UTC
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.useUTC = !m.useUTC
+			return m, nil
+		}
+
+	case "D":
+		// Cycle timestamp display: absolute, relative to now, delta from previous
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.cycleTimestampMode()
+			return m, nil
+		}
+
+	case "!":
+		// Show only the entries flagged as numeric outliers
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.toggleOutliersOnly()
+			return m, nil
+		}
+
+	case "@":
+		// Show only the entries of sampled traces
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.toggleSampledOnly()
+			return m, nil
+		}
+
+	case "%":
+		// Show only the requests that failed with a 5xx status
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.toggleServerErrorsOnly()
+			return m, nil
+		}
+
+	case ">":
+		// Show only the requests at or above the p90 duration
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.toggleSlowOnly()
+			return m, nil
+		}
+
+	case "M":
+		// Maximize the log list by hiding the charts and pinned pane
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.toggleLogsMaximized()
+			return m, nil
+		}
+
+	case "[", "]":
+		// Shrink or grow the chart rows to trade chart detail for log lines
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			if msg.String() == "[" {
+				m.resizeCharts(-1)
+			} else {
+				m.resizeCharts(1)
+			}
+			return m, nil
+		}
+
+	case "#":
+		// Toggle entry sequence numbers in the log list
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.showLineNumbers = !m.showLineNumbers
+			return m, nil
+		}
+
+	case ":":
+		// Jump to an entry by its sequence number, or run a query
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.showLogViewerModal = false
+			m.openGotoPrompt()
+			return m, nil
+		}
+
+	case "e":
+		// Export the visual selection, or all entries in the filtered view
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.exportSelectionOrView()
+			return m, nil
+		}
+
+	case "X":
+		// Export the analytics panels (patterns, frequencies, severities) to JSON or CSV
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openAnalysisExport()
+			return m, nil
+		}
+
+	case "E":
+		// Export the screen as currently rendered to HTML or ANSI text
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openScreenExport()
+			return m, nil
+		}
+
+	case "H":
+		// Toggle the current search term as a persistent highlight rule
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal {
+			m.toggleSearchHighlightRule()
+			return m, nil
+		}
+
 	case "i":
-		// Toggle statistics modal
-		if !m.showModal && !m.filterActive && !m.searchActive && !m.showHelp && !m.showPatternsModal && !m.showModelSelectionModal && !m.showSeverityFilterModal {
+		// Toggle statistics modal (a visual selection uses 'i' for AI analysis)
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showHelp && !m.showPatternsModal && !m.showModelSelectionModal && !m.showSeverityFilterModal && !m.visualMode {
 			m.showStatsModal = !m.showStatsModal
 			return m, nil
 		}
 
+	case "A":
+		// AI analysis of the visual selection or the filtered view
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			return m.analyzeView()
+		}
+
+	case "F":
+		// Filter expression prompt, or ask the AI to write one
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.showLogViewerModal = false
+			m.openExprPrompt()
+			return m, nil
+		}
+
+	case "C":
+		// Rank the attribute values most associated with the errors in view
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openCorrelationModal()
+			return m, nil
+		}
+
+	case "G":
+		// Group the buffer into sessions by correlation key
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openSessionsModal()
+			return m, nil
+		}
+
+	case "J":
+		// Show the view as a table sorted by any column
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openTableModal()
+			return m, nil
+		}
+
+	case "Y":
+		// Show the errors of each service over time
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openServiceHeatmapModal()
+			return m, nil
+		}
+
+	case "V":
+		// Raise the least severe level shown: INFO, WARN, ERROR, then all
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.cycleSeverityFloor()
+			return m, nil
+		}
+
+	case "W":
+		// Add or remove a watch counted in the watches pane
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openWatchPrompt()
+			return m, nil
+		}
+
+	case "I":
+		// Mark the counts chart now, e.g. for a deploy
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openMarkerPrompt()
+			return m, nil
+		}
+
+	case "L":
+		// gonzo's own log messages
+		if m.diagLog != nil && !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openDiagLogModal()
+			return m, nil
+		}
+
+	case "B":
+		// What each input source delivered
+		if m.sourceStats != nil && !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openSourcesModal()
+			return m, nil
+		}
+
+	case "Q":
+		// Lines that failed to parse
+		if m.unparsed != nil && !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			m.openUnparsedModal()
+			return m, nil
+		}
+
+	case "S":
+		// AI summary of the latest spike on the Counts chart
+		if !m.showModal && !m.filterActive && !m.searchActive && !m.showSeverityFilterModal && !m.showHelp && !m.showPatternsModal && !m.showStatsModal && !m.showCountsModal && !m.showModelSelectionModal && !m.showK8sFilterModal {
+			return m.summarizeSpike()
+		}
+
 	case "f":
 		// Toggle log viewer modal (fullscreen view of logs)
 		if !m.showModal && !m.filterActive && !m.searchActive && !m.showHelp && !m.showPatternsModal && !m.showModelSelectionModal && !m.showStatsModal && !m.showCountsModal && !m.showSeverityFilterModal {
@@ -389,6 +762,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				} else {
 					m.selectedLogIndex = 0
 				}
+				m.syncLogFollow()
 			}
 			m.showLogViewerModal = !m.showLogViewerModal
 			return m, nil
@@ -556,6 +930,28 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case "escape", "esc":
 			m.showStatsModal = false
 			return m, nil
+		case "d":
+			// Switch between the log statistics and gonzo's own diagnostics
+			m.statsDiagnostics = !m.statsDiagnostics
+			m.infoViewport.GotoTop()
+			return m, nil
+		case "tab", "shift+tab":
+			// Select the latency row to drill into
+			if m.statsDiagnostics {
+				return m, nil
+			}
+			if msg.String() == "tab" {
+				m.selectLatencyRow(1)
+			} else {
+				m.selectLatencyRow(-1)
+			}
+			return m, nil
+		case "enter":
+			// Drill down to the slowest entries of the selected latency row
+			if !m.statsDiagnostics {
+				m.openSlowEntries()
+			}
+			return m, nil
 		}
 
 		// Update statistics modal viewport with scroll messages
@@ -597,33 +993,87 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.activeSection = SectionLogs
 		
 		switch msg.String() {
-		case "up", "k":
-			// Navigate up in log list
+		case "up", "k", "shift+up":
+			// Navigate up in log list, extending the selection with shift
+			if msg.String() == "shift+up" && !m.visualMode {
+				m.startVisualSelection()
+			}
 			if m.selectedLogIndex > 0 {
 				m.selectedLogIndex--
 			}
+			m.syncLogFollow()
 			m.activeSection = previousSection
 			return m, nil
-		case "down", "j":
-			// Navigate down in log list
+		case "down", "j", "shift+down":
+			// Navigate down in log list, extending the selection with shift
+			if msg.String() == "shift+down" && !m.visualMode {
+				m.startVisualSelection()
+			}
 			if m.selectedLogIndex < len(m.logEntries)-1 {
 				m.selectedLogIndex++
 			}
+			m.syncLogFollow()
+			m.activeSection = previousSection
+			return m, nil
+		case "o", "O":
+			// Open the selected entry in $PAGER (o) or $EDITOR (O)
+			m.activeSection = previousSection
+			if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+				return m.openInExternalViewer(m.logEntries[m.selectedLogIndex], msg.String() == "O")
+			}
+			return m, nil
+		case "b":
+			// Open a link for the selected entry in the browser
+			m.activeSection = previousSection
+			if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+				return m.openEntryLinks(m.logEntries[m.selectedLogIndex])
+			}
+			return m, nil
+		case "v":
+			// Toggle visual multi-select mode
+			if m.visualMode {
+				m.clearVisualSelection()
+			} else {
+				m.startVisualSelection()
+			}
+			m.activeSection = previousSection
+			return m, nil
+		case "y":
+			// Copy the selected entries to the clipboard
+			m.copySelection()
+			m.activeSection = previousSection
+			return m, nil
+		case "p", "i":
+			// Bulk actions on a visual selection
+			if m.visualMode {
+				m.showLogViewerModal = false
+				m.activeSection = previousSection
+				switch msg.String() {
+				case "p":
+					m.pinSelection()
+				case "i":
+					return m.analyzeSelection()
+				}
+				return m, nil
+			}
 			m.activeSection = previousSection
 			return m, nil
 		case "pgup":
 			// Page up
 			m.selectedLogIndex = max(0, m.selectedLogIndex-10)
+			m.syncLogFollow()
 			m.activeSection = previousSection
 			return m, nil
 		case "pgdown":
 			// Page down
-			m.selectedLogIndex = min(len(m.logEntries)-1, m.selectedLogIndex+10)
+			m.selectedLogIndex = max(0, min(len(m.logEntries)-1, m.selectedLogIndex+10))
+			m.syncLogFollow()
 			m.activeSection = previousSection
 			return m, nil
 		case "home":
 			// Go to top
 			m.selectedLogIndex = 0
+			m.syncLogFollow()
 			m.activeSection = previousSection
 			return m, nil
 		case "end":
@@ -631,6 +1081,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if len(m.logEntries) > 0 {
 				m.selectedLogIndex = len(m.logEntries) - 1
 			}
+			m.syncLogFollow()
 			m.activeSection = previousSection
 			return m, nil
 		case "enter":
@@ -662,8 +1113,23 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.searchInput.Focus()
 			return m, nil
 		case "c":
-			// Toggle columns
-			m.showColumns = !m.showColumns
+			// Cycle columns
+			m.cycleColumns()
+			m.activeSection = previousSection
+			return m, nil
+		case "+", "=", "-":
+			// Build an include/exclude filter from the selected log
+			if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+				m.openFilterPicker(m.logEntries[m.selectedLogIndex], msg.String() == "-")
+			}
+			m.activeSection = previousSection
+			return m, nil
+		case "R":
+			// Open related entries for the selected log
+			if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+				m.showLogViewerModal = false
+				m.openRelatedModal(m.logEntries[m.selectedLogIndex])
+			}
 			m.activeSection = previousSection
 			return m, nil
 		case "escape", "esc", "f":
@@ -829,34 +1295,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.k8sFilterActive = true
 
 			// Update the actual K8s source to stream only from selected namespaces and pods
-			if m.k8sSource != nil {
-				// Build list of selected namespaces
-				var selectedNamespaces []string
-				for ns, selected := range m.k8sNamespaces {
-					if selected {
-						selectedNamespaces = append(selectedNamespaces, ns)
-					}
-				}
-
-				// If no namespaces selected, use empty string to mean "all"
-				if len(selectedNamespaces) == 0 {
-					selectedNamespaces = []string{""}
-				}
-
-				// Build list of selected pods (format: namespace/podname or just podname)
-				var selectedPods []string
-				for pod, selected := range m.k8sPods {
-					if selected {
-						selectedPods = append(selectedPods, pod)
-					}
-				}
-
-				// Update K8s source filter (both namespace and pod filtering at source)
-				if err := m.k8sSource.UpdateFilter(selectedNamespaces, "", selectedPods); err != nil {
-					// Log error but don't block
-					// Note: In production, you might want to show this error to the user
-				}
-			}
+			m.applyK8sFilterToSource()
 
 			// Refresh filtered view
 			m.updateFilteredView()
@@ -962,7 +1401,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					// Check if AI is configured before enabling chat
 					if !m.aiConfigured {
 						// Show error in chat area instead of enabling chat
-						chatError := fmt.Sprintf("AI Chat Not Available\n\nError: %s\n\nTo configure AI:\n• Set OPENAI_API_KEY environment variable\n• For local AI: Set OPENAI_API_BASE\n• Use --ai-model flag to specify model", m.aiErrorMessage)
+						chatError := fmt.Sprintf("AI Chat Not Available\n\nError: %s\n\nTo configure AI:\n• Set OPENAI_API_KEY environment variable\n• For local AI (Ollama, vLLM, LM Studio): use --ai-base-url, no key needed\n• Use --ai-model flag to specify model", m.aiErrorMessage)
 						m.chatHistory = []string{fmt.Sprintf("System: %s", chatError)}
 						m.chatAutoScroll = true  // Enable auto-scroll for error message
 						return m, nil
@@ -1052,6 +1491,16 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					}
 					return m, nil
 				}
+			case "o", "O":
+				// Open the entry in $PAGER (o) or $EDITOR (O) - only when not in chat mode
+				if !m.chatActive {
+					return m.openInExternalViewer(*m.currentLogEntry, msg.String() == "O")
+				}
+			case "b":
+				// Open a link for the entry in the browser - only when not in chat mode
+				if !m.chatActive && m.currentLogEntry != nil {
+					return m.openEntryLinks(*m.currentLogEntry)
+				}
 			case "w":
 				// Toggle attribute wrapping - only when not in chat mode
 				if !m.chatActive {
@@ -1111,6 +1560,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case "escape", "esc":
 				m.showModal = false
 				m.modalContent = ""
+				m.stopAIStream()
 				return m, nil
 			}
 
@@ -1132,6 +1582,38 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.prevSection()
 		return m, nil
 
+	case "shift+up", "shift+down":
+		// Extend a visual selection from the current log entry
+		if m.activeSection == SectionLogs {
+			if !m.visualMode {
+				m.startVisualSelection()
+			}
+			if msg.String() == "shift+up" {
+				m.moveSelection(-1)
+			} else {
+				m.moveSelection(1)
+			}
+			return m, nil
+		}
+
+	case "v":
+		// Toggle visual multi-select mode in the log list
+		if m.activeSection == SectionLogs {
+			if m.visualMode {
+				m.clearVisualSelection()
+			} else {
+				m.startVisualSelection()
+			}
+			return m, nil
+		}
+
+	case "y":
+		// Copy the selected entry, or all entries of a visual selection
+		if m.activeSection == SectionLogs && len(m.logEntries) > 0 {
+			m.copySelection()
+			return m, nil
+		}
+
 	case "up", "k":
 		// Special handling for instructions scrolling when in logs section but no logs are shown
 		if m.activeSection == SectionLogs && len(m.logEntries) <= 0 {
@@ -1162,7 +1644,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.selectedLogIndex = 0
-			m.logAutoScroll = false // Stop auto-scrolling when at top
+			m.syncLogFollow() // Stop auto-scrolling when leaving the bottom
 			return m, nil
 		}
 
@@ -1175,7 +1657,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.selectedLogIndex = max(0, len(m.logEntries)-1)
-			m.logAutoScroll = true // Resume auto-scrolling
+			m.syncLogFollow() // Resume auto-scrolling
 			return m, nil
 		}
 
@@ -1190,9 +1672,7 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			pageSize := 10 // Move by 10 entries
 			m.selectedLogIndex = max(0, m.selectedLogIndex-pageSize)
-			if m.selectedLogIndex == 0 {
-				m.logAutoScroll = false // Stop auto-scroll when at top
-			}
+			m.syncLogFollow() // Stop auto-scroll when scrolling up
 			return m, nil
 		}
 
@@ -1209,15 +1689,16 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			pageSize := 10 // Move by 10 entries
 			maxIndex := max(0, len(m.logEntries)-1)
 			m.selectedLogIndex = min(maxIndex, m.selectedLogIndex+pageSize)
-			if m.selectedLogIndex == maxIndex {
-				m.logAutoScroll = true // Resume auto-scroll when at bottom
-			}
+			m.syncLogFollow() // Resume auto-scroll when at bottom
 			return m, nil
 		}
 
 	case "i":
 		// If in log section, open modal and handle AI analysis
 		if m.activeSection == SectionLogs {
+			if m.visualMode {
+				return m.analyzeSelection()
+			}
 			if m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
 				entry := m.logEntries[m.selectedLogIndex]
 				m.currentLogEntry = &entry
@@ -1234,6 +1715,86 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "p":
+		// Pin or unpin the selected log entry, or pin a whole visual selection
+		if m.activeSection == SectionLogs && m.visualMode {
+			m.pinSelection()
+			return m, nil
+		}
+		if m.activeSection == SectionLogs && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			m.togglePinnedEntry(m.logEntries[m.selectedLogIndex])
+			return m, nil
+		}
+
+	case "+", "=":
+		// Include only entries sharing a field value with the selected entry
+		if m.activeSection == SectionLogs && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			m.openFilterPicker(m.logEntries[m.selectedLogIndex], false)
+			return m, nil
+		}
+
+	case "-":
+		// Exclude entries sharing a field value with the selected entry
+		if m.activeSection == SectionLogs && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			m.openFilterPicker(m.logEntries[m.selectedLogIndex], true)
+			return m, nil
+		}
+
+	case "R":
+		// Show all entries related to the selected one by a shared attribute
+		if m.activeSection == SectionLogs && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			m.openRelatedModal(m.logEntries[m.selectedLogIndex])
+			return m, nil
+		}
+
+	case "o", "O":
+		// Open the selected entry in $PAGER (o) or $EDITOR (O)
+		if m.activeSection == SectionLogs && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			return m.openInExternalViewer(m.logEntries[m.selectedLogIndex], msg.String() == "O")
+		}
+
+	case "b":
+		// Open a link for the selected entry in the browser
+		if m.activeSection == SectionLogs && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			return m.openEntryLinks(m.logEntries[m.selectedLogIndex])
+		}
+
+	case "z":
+		// Follow only the pod of the selected entry, with more of its history
+		if m.activeSection == SectionLogs && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+			if m.zoomIntoPod(m.logEntries[m.selectedLogIndex]) {
+				return m, nil
+			}
+		}
+
+	case "x":
+		// Zoom back out to the selection from before following a pod
+		if m.podZoom != nil {
+			m.exitPodZoom()
+			return m, nil
+		}
+
+	case "P":
+		// Clear all pinned entries
+		if m.activeSection == SectionLogs {
+			m.pinnedEntries = nil
+			return m, nil
+		}
+
+	case "n":
+		// Jump to next log entry matching the search term
+		if m.activeSection == SectionLogs && m.searchTerm != "" {
+			m.jumpToSearchMatch(1)
+			return m, nil
+		}
+
+	case "N":
+		// Jump to previous log entry matching the search term
+		if m.activeSection == SectionLogs && m.searchTerm != "" {
+			m.jumpToSearchMatch(-1)
+			return m, nil
+		}
+
 	case "enter":
 		return m.showDetails()
 	}
@@ -1243,11 +1804,11 @@ func (m *DashboardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // nextSection moves to the next section
 func (m *DashboardModel) nextSection() {
-	sections := []Section{SectionWords, SectionAttributes, SectionDistribution, SectionCounts, SectionLogs}
+	sections := m.navigableSections()
 
 	// If current section is not in the list (e.g., SectionFilter), start from the first section
 	if m.activeSection == SectionFilter {
-		m.activeSection = SectionWords
+		m.activeSection = sections[0]
 		return
 	}
 
@@ -1263,7 +1824,7 @@ func (m *DashboardModel) nextSection() {
 
 // prevSection moves to the previous section
 func (m *DashboardModel) prevSection() {
-	sections := []Section{SectionWords, SectionAttributes, SectionDistribution, SectionCounts, SectionLogs}
+	sections := m.navigableSections()
 
 	// If current section is not in the list (e.g., SectionFilter), start from the last section
 	if m.activeSection == SectionFilter {
@@ -1281,6 +1842,21 @@ func (m *DashboardModel) prevSection() {
 	}
 }
 
+// syncLogFollow updates auto-scroll from the log selection: tailing pauses
+// as soon as the selection leaves the latest entry and resumes (clearing the
+// unread counter) once it is back at the bottom. A visual selection keeps
+// tailing paused.
+func (m *DashboardModel) syncLogFollow() {
+	// Reaching either end of the loaded entries pages in scrollback from disk
+	m.pageScrollback()
+	atNewest := m.cold == nil || m.coldAdjacent
+	m.logAutoScroll = !m.visualMode && atNewest && (len(m.logEntries) == 0 || m.selectedLogIndex >= len(m.logEntries)-1)
+	if m.logAutoScroll {
+		m.unreadLogCount = 0
+		m.dropScrollback()
+	}
+}
+
 // moveSelection moves the selection within the active section
 func (m *DashboardModel) moveSelection(delta int) {
 	// Special handling for log section
@@ -1301,16 +1877,9 @@ func (m *DashboardModel) moveSelection(delta int) {
 
 		m.selectedLogIndex = newIndex
 
-		// Update auto-scroll based on position
-		if m.selectedLogIndex == 0 {
-			// At top - disable auto-scroll
-			m.logAutoScroll = false
-		} else if m.selectedLogIndex == maxItems-1 {
-			// At bottom - enable auto-scroll
-			m.logAutoScroll = true
-		}
-		// For positions in between, keep current auto-scroll state
-		
+		// Scrolling away from the bottom pauses auto-scroll, reaching it resumes
+		m.syncLogFollow()
+
 		return
 	}
 
@@ -1365,6 +1934,41 @@ func (m *DashboardModel) updateSeverityFilterActiveStatus() {
 	}
 }
 
+// applyK8sFilterToSource updates the K8s source to stream only from the
+// selected namespaces and pods
+func (m *DashboardModel) applyK8sFilterToSource() {
+	// A new selection replaces a pod zoom
+	m.podZoom = nil
+	if m.k8sSource != nil {
+		// Build list of selected namespaces
+		var selectedNamespaces []string
+		for ns, selected := range m.k8sNamespaces {
+			if selected {
+				selectedNamespaces = append(selectedNamespaces, ns)
+			}
+		}
+
+		// If no namespaces selected, use empty string to mean "all"
+		if len(selectedNamespaces) == 0 {
+			selectedNamespaces = []string{""}
+		}
+
+		// Build list of selected pods (format: namespace/podname or just podname)
+		var selectedPods []string
+		for pod, selected := range m.k8sPods {
+			if selected {
+				selectedPods = append(selectedPods, pod)
+			}
+		}
+
+		// Update K8s source filter (both namespace and pod filtering at source)
+		if err := m.k8sSource.UpdateFilter(selectedNamespaces, "", selectedPods); err != nil {
+			// Log error but don't block
+			// Note: In production, you might want to show this error to the user
+		}
+	}
+}
+
 // showDetails shows details for the selected item
 func (m *DashboardModel) showDetails() (tea.Model, tea.Cmd) {
 	// Special handling for log details
@@ -1394,31 +1998,17 @@ func (m *DashboardModel) showDetails() (tea.Model, tea.Cmd) {
 	case SectionWords:
 		lifetimeWords := m.getLifetimeWordEntries()
 		if selectedIdx < len(lifetimeWords) {
-			entry := lifetimeWords[selectedIdx]
-			// Toggle word highlighting: if same word is already being searched, clear it; otherwise apply it
-			if m.searchTerm == entry.Term {
-				// Clear search highlighting if same word is already being searched
-				m.searchTerm = ""
-			} else {
-				// Apply the selected word for search highlighting (like 's' command)
-				m.searchTerm = entry.Term
-			}
-			// Don't show modal, just return
+			// Show only the entries with the word, highlighted; ESC restores the view
+			m.drillIntoWord(lifetimeWords[selectedIdx].Term)
 			return m, nil
 		}
 
 	case SectionAttributes:
 		lifetimeAttrs := m.getLifetimeAttributeEntries()
 		if selectedIdx < len(lifetimeAttrs) {
-			entry := lifetimeAttrs[selectedIdx]
-			// Use full available content width for the modal
-			contentWidth := m.width - 16 // Modal margins and borders
-			if contentWidth < 60 {
-				contentWidth = 60 // Minimum reasonable width
-			}
-			content = m.formatAttributeValuesModal(entry, contentWidth)
-			// Clear log entry to ensure single modal layout for attributes
-			m.currentLogEntry = nil
+			// Pick one of the attribute's values to drill into
+			m.openAttributeValuesModal(lifetimeAttrs[selectedIdx])
+			return m, nil
 		}
 
 	case SectionDistribution:
@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/drain3"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// New pattern ("first seen") feed limits
+const (
+	DefaultNewPatternWindow = 10 * time.Minute
+	newPatternWarmup        = time.Minute // Patterns seen this soon after the first entry are the baseline
+	maxNewPatterns          = 50
+	maxNewPatternRows       = 3 // Rows shown in the pane
+)
+
+// newPattern is a message template first seen within the window
+type newPattern struct {
+	template  string
+	firstSeen time.Time
+	lastSeen  time.Time
+	count     int
+	severity  string // Severity and service of the first entry
+	service   string
+}
+
+// patternNovelty clusters every message into templates for as long as gonzo
+// runs, independently of the periodically reset Patterns chart, and records
+// the templates that appear after the warm-up.
+type patternNovelty struct {
+	drain    *drain3.Drain
+	window   time.Duration
+	started  time.Time
+	patterns map[int64]*newPattern // By cluster ID
+}
+
+func newPatternNovelty(window time.Duration) *patternNovelty {
+	if window <= 0 {
+		window = DefaultNewPatternWindow
+	}
+	return &patternNovelty{
+		drain: drain3.New(&drain3.Config{
+			Depth:        4,
+			SimilarityTh: 0.5,
+			MaxChildren:  100,
+			MaxClusters:  5000, // Large, so old templates are not forgotten and seen again as new
+		}),
+		window:   window,
+		patterns: make(map[int64]*newPattern),
+	}
+}
+
+// SetNewPatternWindow sets how long a pattern stays in the new patterns feed
+// after it was first seen
+func (m *DashboardModel) SetNewPatternWindow(window time.Duration) {
+	m.novelty = newPatternNovelty(window)
+}
+
+// observe clusters an entry's message, recording it if its template is new
+func (n *patternNovelty) observe(entry LogEntry, now time.Time) {
+	if n.drain == nil || strings.TrimSpace(entry.Message) == "" {
+		return
+	}
+	if n.started.IsZero() {
+		n.started = now
+	}
+	cluster, created, err := n.drain.AddLogMessageCluster(entry.Message)
+	if err != nil || cluster == nil {
+		return
+	}
+
+	if pattern, ok := n.patterns[cluster.ClusterId]; ok {
+		pattern.count++
+		pattern.lastSeen = now
+		pattern.template = strings.Join(cluster.LogTemplateTokens, " ")
+		return
+	}
+	if !created || now.Sub(n.started) < newPatternWarmup {
+		return
+	}
+	n.prune(now)
+	if len(n.patterns) >= maxNewPatterns {
+		return
+	}
+	n.patterns[cluster.ClusterId] = &newPattern{
+		template:  strings.Join(cluster.LogTemplateTokens, " "),
+		firstSeen: now,
+		lastSeen:  now,
+		count:     1,
+		severity:  normalizeSeverityLevel(entry.Severity),
+		service:   ServiceName(entry),
+	}
+}
+
+// prune drops patterns first seen before the window
+func (n *patternNovelty) prune(now time.Time) {
+	for id, pattern := range n.patterns {
+		if now.Sub(pattern.firstSeen) > n.window {
+			delete(n.patterns, id)
+		}
+	}
+}
+
+// recent returns the patterns first seen within the window, newest first
+func (n *patternNovelty) recent(now time.Time) []*newPattern {
+	n.prune(now)
+	patterns := make([]*newPattern, 0, len(n.patterns))
+	for _, pattern := range n.patterns {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if !patterns[i].firstSeen.Equal(patterns[j].firstSeen) {
+			return patterns[i].firstSeen.After(patterns[j].firstSeen)
+		}
+		return patterns[i].template < patterns[j].template
+	})
+	return patterns
+}
+
+// newPatternsPaneVisible reports whether the new patterns pane is shown
+func (m *DashboardModel) newPatternsPaneVisible() bool {
+	if m.logsMaximized || m.hiddenPanels[PanelNewPatterns] {
+		return false
+	}
+	m.novelty.prune(time.Now())
+	return len(m.novelty.patterns) > 0
+}
+
+// newPatternsPaneHeight returns the number of lines the new patterns pane occupies
+func (m *DashboardModel) newPatternsPaneHeight() int {
+	if !m.newPatternsPaneVisible() {
+		return 0
+	}
+	return 1 + min(len(m.novelty.patterns), maxNewPatternRows)
+}
+
+// renderNewPatternsPane renders the patterns first seen within the window
+// above the log list, newest first
+func (m *DashboardModel) renderNewPatternsPane() string {
+	now := time.Now()
+	patterns := m.novelty.recent(now)
+	width := max(m.width-2, 40)
+
+	title := fmt.Sprintf("🆕 New Patterns (%d first seen in the last %s)", len(patterns), formatWindowDuration(m.novelty.window))
+	if len(patterns) > maxNewPatternRows {
+		title += fmt.Sprintf(" • showing latest %d", maxNewPatternRows)
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(ColorYellow).Bold(true).Padding(0, 1).Render(title),
+	}
+
+	grayStyle := lipgloss.NewStyle().Foreground(ColorGray)
+	for _, pattern := range patterns[:min(len(patterns), maxNewPatternRows)] {
+		severity := lipgloss.NewStyle().Foreground(GetSeverityColor(pattern.severity)).Bold(true).Render(padToWidth(pattern.severity, 8))
+		line := fmt.Sprintf("%s %s %s %s",
+			grayStyle.Render(padToWidth(formatAgo(now.Sub(pattern.firstSeen)), 9)),
+			severity,
+			padToWidth("×"+formatCount(int64(pattern.count)), 7),
+			strings.ReplaceAll(pattern.template, "<*>", "***"))
+		if pattern.service != "" {
+			line += grayStyle.Render(" • " + pattern.service)
+		}
+		lines = append(lines, " "+truncateToWidth(line, width))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
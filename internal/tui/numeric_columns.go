@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"math"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+)
+
+// maxNumberColumns caps the numeric fields kept as columns; the least
+// recently filtered one makes room for a new one
+const maxNumberColumns = 8
+
+// sizeAttributeKeys are the attributes an entry's size in bytes is read
+// from, as OTLP, JSON and the access-log formats name it
+var sizeAttributeKeys = []string{"http.response.body.size", "http.response_content_length", "response_bytes", "bytes_sent", "body_bytes_sent", "content_length", "bytes", "size"}
+
+// entryByteSize reads an entry's size in bytes; bare numbers are bytes and
+// values such as 1.5MB are converted
+func entryByteSize(entry LogEntry) (float64, bool) {
+	for _, key := range sizeAttributeKeys {
+		value, ok := entry.Attributes[key]
+		if !ok {
+			continue
+		}
+		if bytes, unit, ok := filterexpr.ParseQuantity(value); ok && unit != filterexpr.UnitDuration {
+			return bytes, true
+		}
+	}
+	return 0, false
+}
+
+// numberColumn holds a numeric field of every buffered entry, NaN where the
+// entry has none
+type numberColumn struct {
+	values  []float64
+	units   []filterexpr.Unit
+	lastUse uint64 // Scan that last read the column
+}
+
+// measureField reads a numeric field of an entry as filter expressions do
+func (m *DashboardModel) measureField(entry LogEntry, field string) (float64, filterexpr.Unit, bool) {
+	return filterexpr.Bound{Field: field}.Measure(m.filterRecord(entry))
+}
+
+// appendNumber adds an entry's value to a numeric column
+func (c *columnStore) appendNumber(col *numberColumn, field string, entry LogEntry) {
+	value, unit, ok := c.measure(entry, field)
+	if !ok {
+		value = math.NaN()
+	}
+	col.values = append(col.values, value)
+	col.units = append(col.units, unit)
+}
+
+// numberColumn returns the column of a numeric field, reading it from the
+// buffered entries the first time the field is filtered on
+func (c *columnStore) numberColumn(field string, entries []LogEntry) *numberColumn {
+	c.scans++
+	if col, ok := c.numbers[field]; ok {
+		col.lastUse = c.scans
+		return col
+	}
+	if len(c.numbers) >= maxNumberColumns {
+		var oldest string
+		for name, col := range c.numbers {
+			if oldest == "" || col.lastUse < c.numbers[oldest].lastUse {
+				oldest = name
+			}
+		}
+		delete(c.numbers, oldest)
+	}
+	col := &numberColumn{
+		values:  make([]float64, 0, len(entries)),
+		units:   make([]filterexpr.Unit, 0, len(entries)),
+		lastUse: c.scans,
+	}
+	for _, entry := range entries {
+		c.appendNumber(col, field, entry)
+	}
+	c.numbers[field] = col
+	return col
+}
+
+// scanBounds narrows keep down to the rows whose numeric fields fall in all
+// bounds, reading each field from its column
+func (c *columnStore) scanBounds(keep rowMask, bounds []filterexpr.Bound, entries []LogEntry) rowMask {
+	if len(bounds) == 0 || c.measure == nil {
+		return keep
+	}
+	if keep == nil {
+		keep = make(rowMask, (len(c.severity)+63)/64)
+		for i := range keep {
+			keep[i] = math.MaxUint64
+		}
+	}
+	for _, bound := range bounds {
+		col := c.numberColumn(bound.Field, entries)
+		for i, value := range col.values {
+			if keep.keeps(i) && (math.IsNaN(value) || !bound.Contains(value, col.units[i])) {
+				keep[i/64] &^= 1 << (i % 64)
+			}
+		}
+	}
+	return keep
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/control-theory/gonzo/internal/metrics"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Outlier detection limits
+const (
+	DefaultOutlierThreshold = 3.5 // Modified z-score an outlier exceeds
+	outlierSamples          = 500 // Latest values kept per attribute
+	outlierMinSamples       = 30  // Values an attribute needs before outliers are flagged
+	outlierRefresh          = 50  // Values between recomputing the median and spread
+	maxOutlierFields        = 100 // Numeric attributes tracked
+)
+
+// outlierSkipNames are attribute names whose numbers are identifiers or
+// codes rather than measurements
+var outlierSkipNames = []string{"id", "port", "pid", "tid", "line", "lineno", "seq", "code", "status", "version", "time", "timestamp", "ts", "year"}
+
+// outlierField is the rolling distribution of one numeric attribute
+type outlierField struct {
+	values    []float64 // Ring of the latest values
+	next      int
+	durations bool // Values were durations, converted to milliseconds
+	median    float64
+	spread    float64 // Scaled median absolute deviation
+	stale     int     // Values added since the median and spread were computed
+}
+
+// outlierDetector flags entries whose numeric attributes lie far outside the
+// rolling distribution of their earlier values
+type outlierDetector struct {
+	threshold float64
+	fields    map[string]*outlierField
+}
+
+func newOutlierDetector(threshold float64) *outlierDetector {
+	return &outlierDetector{threshold: threshold, fields: make(map[string]*outlierField)}
+}
+
+// SetOutlierThreshold sets the modified z-score above which a numeric
+// attribute value is flagged as an outlier; 0 disables outlier detection
+func (m *DashboardModel) SetOutlierThreshold(threshold float64) {
+	m.outliers = newOutlierDetector(threshold)
+}
+
+// observe adds an entry's numeric attributes to their distributions and
+// describes the most extreme one that is an outlier, e.g.
+// "duration_ms=5300 (median 120)", or returns "" when none is
+func (d *outlierDetector) observe(entry LogEntry) string {
+	if d.threshold <= 0 || len(entry.Attributes) == 0 {
+		return ""
+	}
+
+	label, worst := "", 0.0
+	for key, value := range entry.Attributes {
+		if isOutlierSkipped(key) {
+			continue
+		}
+		v, isDuration, ok := metrics.ParseValue(value)
+		if !ok {
+			continue
+		}
+		field := d.fields[key]
+		if field == nil {
+			if len(d.fields) >= maxOutlierFields {
+				continue
+			}
+			field = &outlierField{values: make([]float64, 0, outlierSamples)}
+			d.fields[key] = field
+		}
+		if isDuration {
+			field.durations = true
+		}
+
+		if score := field.score(v); math.Abs(score) > d.threshold && math.Abs(score) > worst {
+			worst = math.Abs(score)
+			label = fmt.Sprintf("%s=%s (median %s)", key, value, formatMetricValue(field.median, field.durations))
+		}
+		field.add(v)
+	}
+	return label
+}
+
+// isOutlierSkipped reports whether an attribute holds identifiers or codes
+// by the last part of its name, e.g. user_id or http.status_code
+func isOutlierSkipped(key string) bool {
+	name := strings.ToLower(key[strings.LastIndexByte(key, '.')+1:])
+	for _, skip := range outlierSkipNames {
+		if name == skip || strings.HasSuffix(name, "_"+skip) || strings.HasSuffix(name, "-"+skip) {
+			return true
+		}
+	}
+	// Also camelCase identifiers such as userId, and created_at times
+	return strings.HasSuffix(name, "id") || strings.HasSuffix(name, "_at")
+}
+
+// score is the modified z-score of a value against the distribution, or 0
+// while there are too few values. Any change from values that never varied
+// is an outlier.
+func (f *outlierField) score(v float64) float64 {
+	if len(f.values) < outlierMinSamples {
+		return 0
+	}
+	if f.stale >= outlierRefresh || f.stale == len(f.values) {
+		f.recompute()
+	}
+	if f.spread == 0 {
+		if v == f.median {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return (v - f.median) / f.spread
+}
+
+// add records a value, replacing the oldest once the ring is full
+func (f *outlierField) add(v float64) {
+	if len(f.values) < outlierSamples {
+		f.values = append(f.values, v)
+	} else {
+		f.values[f.next] = v
+		f.next = (f.next + 1) % outlierSamples
+	}
+	f.stale++
+}
+
+// recompute updates the median and the median absolute deviation, scaled to
+// match a standard deviation for normally distributed values. When most
+// values are equal the mean absolute deviation is used instead, so a queue
+// that is nearly always empty still flags a sudden backlog.
+func (f *outlierField) recompute() {
+	sorted := append([]float64(nil), f.values...)
+	sort.Float64s(sorted)
+	f.median = medianOf(sorted)
+
+	deviations := make([]float64, len(sorted))
+	total := 0.0
+	for i, v := range sorted {
+		deviations[i] = math.Abs(v - f.median)
+		total += deviations[i]
+	}
+	sort.Float64s(deviations)
+	f.spread = 1.4826 * medianOf(deviations)
+	if f.spread == 0 {
+		f.spread = 1.2533 * total / float64(len(deviations))
+	}
+	f.stale = 0
+}
+
+// medianOf returns the median of sorted values
+func medianOf(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// outlierMarker is the mark shown before the message of an outlier entry
+func outlierMarker(entry LogEntry) string {
+	if entry.Outlier == "" {
+		return ""
+	}
+	return "▲ "
+}
+
+// outlierMarkerWidth is the width outlierMarker takes in the list
+func outlierMarkerWidth(entry LogEntry) int {
+	return lipgloss.Width(outlierMarker(entry))
+}
+
+// toggleOutliersOnly shows only the entries flagged as outliers, or all again
+func (m *DashboardModel) toggleOutliersOnly() {
+	m.outliersOnly = !m.outliersOnly
+	m.updateFilteredView()
+}
@@ -35,6 +35,9 @@ func (m *DashboardModel) renderDrain3Chart(width, height int) string {
 		content = helpStyle.Render("Extracting patterns")
 	}
 
+	// Fit the content to the chart height chosen in the layout
+	content = clipLines(content, height-1, false)
+
 	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
 }
 
@@ -88,10 +91,7 @@ func (m *DashboardModel) renderDrain3Content(chartWidth int) string {
 			percentage := fmt.Sprintf("%5.1f%%", pattern.Percentage)
 
 			// Truncate template if needed
-			template := pattern.Template
-			if len(template) > templateWidth {
-				template = template[:templateWidth-3] + "..."
-			}
+			template := truncateToWidth(pattern.Template, templateWidth)
 
 			// Color code based on frequency (high frequency = more important)
 			var barColor lipgloss.Style
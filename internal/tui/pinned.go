@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxPinnedEntries caps how many entries can be pinned at once
+const maxPinnedEntries = 5
+
+// maxPinnedRows is the number of pinned entries shown in the pane
+const maxPinnedRows = 3
+
+// samePinnedEntry reports whether two entries are the same log line
+func samePinnedEntry(a, b LogEntry) bool {
+	return a.Timestamp.Equal(b.Timestamp) && a.RawLine == b.RawLine && a.Message == b.Message
+}
+
+// togglePinnedEntry pins an entry, or unpins it if it is already pinned.
+// Pinned entries are copies, so they survive buffer eviction.
+// When the pane is full the oldest pin is dropped.
+func (m *DashboardModel) togglePinnedEntry(entry LogEntry) {
+	for i, pinned := range m.pinnedEntries {
+		if samePinnedEntry(pinned, entry) {
+			m.pinnedEntries = append(m.pinnedEntries[:i], m.pinnedEntries[i+1:]...)
+			return
+		}
+	}
+
+	m.pinnedEntries = append(m.pinnedEntries, entry)
+	if len(m.pinnedEntries) > maxPinnedEntries {
+		m.pinnedEntries = m.pinnedEntries[1:]
+	}
+}
+
+// pinnedPaneHeight returns the number of lines the pinned pane occupies
+func (m *DashboardModel) pinnedPaneHeight() int {
+	if !m.pinnedPaneVisible() {
+		return 0
+	}
+	return 1 + min(len(m.pinnedEntries), maxPinnedRows)
+}
+
+// renderPinnedPane renders the pinned entries above the log list.
+// The most recently pinned entries are shown when there are more pins than rows.
+func (m *DashboardModel) renderPinnedPane() string {
+	if len(m.pinnedEntries) == 0 {
+		return ""
+	}
+
+	width := m.width - 2
+	if width < 40 {
+		width = 40
+	}
+
+	title := fmt.Sprintf("📌 Pinned (%d)", len(m.pinnedEntries))
+	if len(m.pinnedEntries) > maxPinnedRows {
+		title += fmt.Sprintf(" • showing latest %d", maxPinnedRows)
+	}
+	title += " • p: pin/unpin selected • P: clear pins"
+
+	lines := []string{
+		lipgloss.NewStyle().Foreground(ColorOrange).Bold(true).Padding(0, 1).Render(title),
+	}
+
+	start := max(0, len(m.pinnedEntries)-maxPinnedRows)
+	for _, entry := range m.pinnedEntries[start:] {
+		lines = append(lines, " "+m.formatLogEntry(entry, width, false))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"log"
+)
+
+// podZoom follows one pod picked in the log list: the view shows only its
+// entries and the Kubernetes source streams only its containers, reading
+// more of their history, until zooming out restores the view from before
+type podZoom struct {
+	namespace, pod string
+
+	// View from before the zoom
+	savedFollow     bool
+	savedSelection  int
+	savedSelectedAt *LogEntry
+}
+
+// zoomIntoPod zooms into the pod an entry came from, reporting false when
+// it has no Kubernetes attributes. Zooming into another pod replaces the
+// zoom but keeps the view from before the first one.
+func (m *DashboardModel) zoomIntoPod(entry LogEntry) bool {
+	namespace, pod := entry.Attributes["k8s.namespace"], entry.Attributes["k8s.pod"]
+	if namespace == "" || pod == "" {
+		return false
+	}
+	zoom := &podZoom{namespace: namespace, pod: pod}
+	if m.podZoom != nil {
+		zoom.savedFollow = m.podZoom.savedFollow
+		zoom.savedSelection = m.podZoom.savedSelection
+		zoom.savedSelectedAt = m.podZoom.savedSelectedAt
+	} else {
+		zoom.savedFollow = m.logAutoScroll
+		zoom.savedSelection = m.selectedLogIndex
+		zoom.savedSelectedAt = &entry
+	}
+	m.podZoom = zoom
+
+	if m.k8sSource != nil {
+		if err := m.k8sSource.FollowPod(namespace, pod); err != nil {
+			log.Printf("Warning: failed to follow pod %s/%s: %v", namespace, pod, err)
+		}
+	}
+	m.logAutoScroll = true
+	m.updateFilteredView()
+	return true
+}
+
+// endPodZoom returns the Kubernetes source to the selection from before the
+// zoom, leaving the view to be rebuilt by the caller
+func (m *DashboardModel) endPodZoom() {
+	if m.podZoom == nil {
+		return
+	}
+	m.podZoom = nil
+	if m.k8sSource != nil {
+		if err := m.k8sSource.Unfollow(); err != nil {
+			log.Printf("Warning: failed to stop following the pod: %v", err)
+		}
+	}
+}
+
+// exitPodZoom zooms out, restoring the view from before the zoom and
+// selecting the entry that was selected then
+func (m *DashboardModel) exitPodZoom() {
+	zoom := m.podZoom
+	if zoom == nil {
+		return
+	}
+	m.endPodZoom()
+	m.logAutoScroll = zoom.savedFollow
+	m.updateFilteredView()
+	m.restoreLogSelection(zoom.savedSelection, zoom.savedSelectedAt)
+}
+
+// passesPodZoom reports whether an entry came from the pod zoomed into
+func (m *DashboardModel) passesPodZoom(entry LogEntry) bool {
+	return m.podZoom == nil ||
+		entry.Attributes["k8s.pod"] == m.podZoom.pod && entry.Attributes["k8s.namespace"] == m.podZoom.namespace
+}
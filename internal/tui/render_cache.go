@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// rowStyles holds the styles of log rows, built once per skin instead of
+// for every row of every frame
+type rowStyles struct {
+	timestamp lipgloss.Style
+	col1      lipgloss.Style
+	col2      lipgloss.Style
+	outlier   lipgloss.Style
+	match     lipgloss.Style                    // Search matches
+	severity  map[string]lipgloss.Style         // By severity as shown
+	row       map[lipgloss.Color]lipgloss.Style // Whole rows by background
+}
+
+// currentRowStyles is built on first use and dropped when the skin changes
+var currentRowStyles *rowStyles
+
+// getRowStyles returns the row styles of the current skin
+func getRowStyles() *rowStyles {
+	if currentRowStyles == nil {
+		currentRowStyles = &rowStyles{
+			timestamp: lipgloss.NewStyle().Foreground(ColorGray),
+			col1:      lipgloss.NewStyle().Foreground(ColorGreen),
+			col2:      lipgloss.NewStyle().Foreground(ColorBlue),
+			outlier:   lipgloss.NewStyle().Foreground(ColorOrange).Bold(true),
+			match:     lipgloss.NewStyle().Background(ColorYellow).Foreground(ColorBlack).Bold(true),
+			severity:  make(map[string]lipgloss.Style),
+			row:       make(map[lipgloss.Color]lipgloss.Style),
+		}
+	}
+	return currentRowStyles
+}
+
+// severityStyle returns the style of a severity column
+func (s *rowStyles) severityStyle(severity string) lipgloss.Style {
+	style, ok := s.severity[severity]
+	if !ok {
+		style = lipgloss.NewStyle().Foreground(GetSeverityColor(severity)).Bold(true)
+		s.severity[severity] = style
+	}
+	return style
+}
+
+// rowStyle returns the style of a row colored as a whole, for the selection
+// and row highlight rules
+func (s *rowStyles) rowStyle(background lipgloss.Color) lipgloss.Style {
+	style, ok := s.row[background]
+	if !ok {
+		style = lipgloss.NewStyle().Background(background).Foreground(ColorWhite)
+		s.row[background] = style
+	}
+	return style
+}
+
+// maxCachedRows bounds the rendered rows kept; the cache is emptied beyond
+const maxCachedRows = 4096
+
+// rowKey identifies a rendered log row. The timestamp is part of it as
+// shown, so relative timestamps render again as they change.
+type rowKey struct {
+	seq         int64
+	width       int
+	selected    bool
+	inSelection bool
+	timestamp   string
+}
+
+// rowRenderState is the view state every row's rendering depends on; when it
+// changes, the cached rows are dropped
+type rowRenderState struct {
+	searchTerm  string
+	fuzzy       bool
+	columns     bool
+	scope       bool // The columns show the instrumentation scope
+	highlighted int  // Generation of the highlight rules
+}
+
+// rowCache keeps rendered log rows so scrolling and refreshing only render
+// the rows that changed
+type rowCache struct {
+	state rowRenderState
+	rows  map[rowKey]string
+}
+
+// lookup returns the cached row for key under state
+func (c *rowCache) lookup(state rowRenderState, key rowKey) (string, bool) {
+	if c.rows == nil || c.state != state {
+		c.state = state
+		c.rows = make(map[rowKey]string)
+		return "", false
+	}
+	row, ok := c.rows[key]
+	return row, ok
+}
+
+// store caches a rendered row
+func (c *rowCache) store(key rowKey, row string) {
+	if len(c.rows) >= maxCachedRows {
+		c.rows = make(map[rowKey]string)
+	}
+	c.rows[key] = row
+}
+
+// rowRenderState returns the current view state rows depend on
+func (m *DashboardModel) rowRenderState() rowRenderState {
+	return rowRenderState{
+		searchTerm:  m.searchTerm,
+		fuzzy:       m.fuzzySearch,
+		columns:     m.showColumns,
+		scope:       m.scopeColumns,
+		highlighted: m.highlightGeneration,
+	}
+}
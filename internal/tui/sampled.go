@@ -0,0 +1,23 @@
+package tui
+
+import "strconv"
+
+// TraceFlagsAttribute holds the W3C trace flags of an entry in hex, as an
+// OTLP record's trace context has them
+const TraceFlagsAttribute = "trace_flags"
+
+// traceFlagSampled is the trace flag of a sampled trace
+const traceFlagSampled = 0x01
+
+// isSampled reports whether an entry belongs to a sampled trace, whose
+// spans the tracing backend keeps
+func isSampled(entry LogEntry) bool {
+	flags, err := strconv.ParseUint(entry.Attributes[TraceFlagsAttribute], 16, 8)
+	return err == nil && flags&traceFlagSampled != 0
+}
+
+// toggleSampledOnly shows only the entries of sampled traces, or all again
+func (m *DashboardModel) toggleSampledOnly() {
+	m.sampledOnly = !m.sampledOnly
+	m.updateFilteredView()
+}
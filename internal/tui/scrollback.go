@@ -0,0 +1,223 @@
+package tui
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/control-theory/gonzo/internal/spill"
+)
+
+// Scrollback paging limits
+const (
+	spillPage      = 1000  // Entries read from disk when scrolling past the loaded ones
+	spillMaxPages  = 20    // Pages read at most for one scroll when filters hide them
+	maxColdEntries = 50000 // Entries read back from disk held in memory at once
+)
+
+// SetSpill keeps the entries evicted from the buffer in store, so scrolling
+// past the oldest entry in memory pages older ones back in from disk
+func (m *DashboardModel) SetSpill(store *spill.Store) {
+	m.spill = store
+}
+
+// spillEntry writes an entry evicted from the buffer to disk. While the
+// loaded scrollback ends where the buffer starts, the entry joins it, so
+// scrolling back never shows a gap.
+func (m *DashboardModel) spillEntry(entry LogEntry) {
+	if m.spill == nil {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = m.spill.Append(data)
+	}
+	if err != nil {
+		if !m.spillFailed {
+			log.Printf("Warning: scrollback spill disabled: %v", err)
+			m.spillFailed = true
+		}
+		return
+	}
+	if m.cold != nil && m.coldAdjacent {
+		m.cold = append(m.cold, entry)
+		m.coldEnd = m.spill.Len()
+		if len(m.cold) > maxColdEntries {
+			trimmed := len(m.cold) - maxColdEntries
+			m.cold = m.cold[trimmed:]
+			m.coldStart += trimmed
+		}
+	}
+}
+
+// spilledCount returns how many evicted entries are still on disk
+func (m *DashboardModel) spilledCount() int {
+	if m.spill == nil || m.spillFailed {
+		return 0
+	}
+	return m.spill.Len() - m.spill.First()
+}
+
+// readSpill decodes entries from start up to end of the spill
+func (m *DashboardModel) readSpill(start, end int) []LogEntry {
+	records, err := m.spill.Read(start, end)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return nil
+	}
+	entries := make([]LogEntry, 0, len(records))
+	for _, data := range records {
+		var entry LogEntry
+		if json.Unmarshal(data, &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// SetOlderEntries sets where to read the entries from before the first one
+// received, a page of up to n at a time oldest first, when scrolling past it
+func (m *DashboardModel) SetOlderEntries(load func(n int) []LogEntry) {
+	m.olderEntries = load
+}
+
+// pageScrollback loads entries from disk when the selection reaches either
+// end of the loaded scrollback, so scrolling continues into the entries
+// evicted from memory and then into those never read
+func (m *DashboardModel) pageScrollback() {
+	if len(m.logEntries) == 0 {
+		return
+	}
+	spilling := m.spill != nil && !m.spillFailed
+	switch {
+	case m.selectedLogIndex <= 0 && spilling && m.spillHasOlder():
+		m.loadOlderScrollback()
+	case m.selectedLogIndex <= 0:
+		m.loadOlderEntries()
+	case spilling && m.selectedLogIndex >= len(m.logEntries)-1 && m.cold != nil && !m.coldAdjacent:
+		m.loadNewerScrollback()
+	}
+}
+
+// spillHasOlder reports whether the spill holds entries older than those
+// loaded
+func (m *DashboardModel) spillHasOlder() bool {
+	if m.cold == nil {
+		return m.spill.Len() > m.spill.First()
+	}
+	return m.coldStart > m.spill.First()
+}
+
+// atFirstEntry reports whether the oldest entry loaded is the first one
+// received, so the older entries read back join it without a gap
+func (m *DashboardModel) atFirstEntry() bool {
+	switch {
+	case m.cold != nil:
+		return len(m.cold) > 0 && m.cold[0].Seq == 1
+	case len(m.allLogEntries) > 0:
+		return m.allLogEntries[0].Seq == 1
+	}
+	return false
+}
+
+// loadOlderEntries shows the older entries read back so far, then prepends
+// a page of those before them, reading on while the filters hide every
+// entry of a page
+func (m *DashboardModel) loadOlderEntries() {
+	if m.olderEntries == nil || !m.atFirstEntry() {
+		return
+	}
+	shown := len(m.logEntries)
+	if m.history != nil && !m.historyShown {
+		m.historyShown = true
+		m.updateFilteredView()
+		if len(m.logEntries) > shown {
+			return
+		}
+	}
+	for range spillMaxPages {
+		if len(m.history) >= maxColdEntries {
+			return
+		}
+		older := m.olderEntries(spillPage)
+		if len(older) == 0 {
+			return
+		}
+		m.history = append(older, m.history...)
+		m.historyShown = true
+
+		m.updateFilteredView()
+		if len(m.logEntries) > shown {
+			return
+		}
+	}
+}
+
+// loadOlderScrollback prepends the page before the loaded scrollback,
+// reading on while the filters hide every entry of a page
+func (m *DashboardModel) loadOlderScrollback() {
+	shown := len(m.logEntries)
+	for range spillMaxPages {
+		start := m.spill.Len()
+		if m.cold != nil {
+			start = m.coldStart
+		}
+		from := max(m.spill.First(), start-spillPage)
+		if from >= start {
+			return
+		}
+		if m.cold == nil {
+			m.coldEnd = start
+			m.coldAdjacent = true
+		}
+		m.cold = append(m.readSpill(from, start), m.cold...)
+		m.coldStart = from
+
+		// Let go of the newest loaded entries; scrolling down reads them again
+		if len(m.cold) > maxColdEntries {
+			m.cold = m.cold[:maxColdEntries]
+			m.coldEnd = m.coldStart + maxColdEntries
+			m.coldAdjacent = false
+		}
+
+		m.updateFilteredView()
+		if len(m.logEntries) > shown {
+			return
+		}
+	}
+}
+
+// loadNewerScrollback appends the page after the loaded scrollback, until
+// it meets the entries in memory again
+func (m *DashboardModel) loadNewerScrollback() {
+	shown := len(m.logEntries)
+	for range spillMaxPages {
+		to := min(m.spill.Len(), m.coldEnd+spillPage)
+		m.cold = append(m.cold, m.readSpill(m.coldEnd, to)...)
+		m.coldEnd = to
+		m.coldAdjacent = to >= m.spill.Len()
+
+		if len(m.cold) > maxColdEntries {
+			trimmed := len(m.cold) - maxColdEntries
+			m.cold = m.cold[trimmed:]
+			m.coldStart += trimmed
+		}
+
+		m.updateFilteredView()
+		if len(m.logEntries) > shown || m.coldAdjacent {
+			return
+		}
+	}
+}
+
+// dropScrollback forgets the entries read back from disk once the view
+// follows the newest entries again. The older entries read back from the
+// files are only hidden, as they cannot be read again.
+func (m *DashboardModel) dropScrollback() {
+	if m.cold == nil && !m.historyShown {
+		return
+	}
+	m.cold = nil
+	m.coldAdjacent = false
+	m.historyShown = false
+	m.updateFilteredView()
+}
@@ -0,0 +1,211 @@
+package tui
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// searchIndex is an incremental inverted index over the log buffer.
+// Every buffered entry gets a monotonically increasing sequence number and
+// each lower-cased alphanumeric token found in its raw line, message and
+// attributes maps to the ascending list of sequence numbers containing it.
+// The index only narrows down candidates; callers still verify matches.
+type searchIndex struct {
+	postings map[string][]uint64 // token -> ascending sequence numbers
+	vocab    []string            // tokens in first-seen order
+	baseSeq  uint64              // sequence number of allLogEntries[0]
+	nextSeq  uint64              // sequence number assigned to the next entry
+	evicted  int                 // evictions since the last compaction
+
+	// Per-term cache of vocabulary tokens containing the term, so repeated
+	// queries only need to look at tokens added since the last lookup
+	termTokens map[string]*termMatch
+}
+
+// termMatch caches the vocabulary tokens that contain a query term
+type termMatch struct {
+	tokens  []string
+	scanned int // number of vocab entries already checked
+}
+
+// newSearchIndex creates an empty search index
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		postings:   make(map[string][]uint64),
+		termTokens: make(map[string]*termMatch),
+	}
+}
+
+// add indexes a newly buffered entry
+func (idx *searchIndex) add(entry LogEntry) {
+	seq := idx.nextSeq
+	idx.nextSeq++
+
+	seen := make(map[string]struct{})
+	addTokens := func(text string) {
+		for _, token := range tokenizeForIndex(text) {
+			if _, ok := seen[token]; ok {
+				continue
+			}
+			seen[token] = struct{}{}
+			if _, exists := idx.postings[token]; !exists {
+				idx.vocab = append(idx.vocab, token)
+			}
+			idx.postings[token] = append(idx.postings[token], seq)
+		}
+	}
+
+	addTokens(entry.RawLine)
+	if entry.Message != entry.RawLine {
+		addTokens(entry.Message)
+	}
+	for key, value := range entry.Attributes {
+		addTokens(key)
+		addTokens(value)
+	}
+}
+
+// evictOldest records that the oldest buffered entry has been dropped.
+// Postings are pruned lazily, with a full compaction once as many entries
+// have been evicted as remain live so the cost stays amortised.
+func (idx *searchIndex) evictOldest() {
+	if idx.baseSeq >= idx.nextSeq {
+		return
+	}
+	idx.baseSeq++
+	idx.evicted++
+
+	if idx.evicted >= idx.size() {
+		idx.compact()
+	}
+}
+
+// size returns the number of live entries tracked by the index
+func (idx *searchIndex) size() int {
+	return int(idx.nextSeq - idx.baseSeq)
+}
+
+// compact drops evicted sequence numbers and empty tokens
+func (idx *searchIndex) compact() {
+	vocab := make([]string, 0, len(idx.vocab))
+	for _, token := range idx.vocab {
+		trimmed := idx.trim(idx.postings[token])
+		if len(trimmed) == 0 {
+			delete(idx.postings, token)
+			continue
+		}
+		// Copy so the evicted prefix can be garbage collected
+		idx.postings[token] = append([]uint64(nil), trimmed...)
+		vocab = append(vocab, token)
+	}
+	idx.vocab = vocab
+	idx.termTokens = make(map[string]*termMatch)
+	idx.evicted = 0
+}
+
+// tokensContaining returns all vocabulary tokens that contain term
+func (idx *searchIndex) tokensContaining(term string) []string {
+	match, ok := idx.termTokens[term]
+	if !ok {
+		match = &termMatch{}
+		idx.termTokens[term] = match
+	}
+	for _, token := range idx.vocab[match.scanned:] {
+		if strings.Contains(token, term) {
+			match.tokens = append(match.tokens, token)
+		}
+	}
+	match.scanned = len(idx.vocab)
+	return match.tokens
+}
+
+// trim returns the part of a posting list that is still live
+func (idx *searchIndex) trim(list []uint64) []uint64 {
+	i := 0
+	for i < len(list) && list[i] < idx.baseSeq {
+		i++
+	}
+	return list[i:]
+}
+
+// candidates returns buffer positions of entries that may match the regex.
+// ok is false when the pattern has no literal the index can use, in which
+// case the caller must fall back to scanning the whole buffer.
+func (idx *searchIndex) candidates(re *regexp.Regexp) (positions []int, ok bool) {
+	terms := requiredIndexTerms(re.String())
+	if len(terms) == 0 {
+		return nil, false
+	}
+
+	live := idx.size()
+	var matched []uint64 // bitset over buffer positions
+	for i, term := range terms {
+		current := make([]uint64, (live+63)/64)
+		for _, token := range idx.tokensContaining(term) {
+			for _, seq := range idx.trim(idx.postings[token]) {
+				pos := int(seq - idx.baseSeq)
+				current[pos/64] |= 1 << (pos % 64)
+			}
+		}
+
+		if i == 0 {
+			matched = current
+		} else {
+			for w := range matched {
+				matched[w] &= current[w]
+			}
+		}
+	}
+
+	positions = make([]int, 0)
+	for w, word := range matched {
+		if word == 0 {
+			continue
+		}
+		for b := 0; b < 64; b++ {
+			if word&(1<<b) != 0 {
+				positions = append(positions, w*64+b)
+			}
+		}
+	}
+	return positions, true
+}
+
+// tokenizeForIndex splits text into lower-cased alphanumeric tokens
+func tokenizeForIndex(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// requiredIndexTerms extracts lower-cased alphanumeric runs from literals
+// that every match of the pattern must contain. Any run of letters and digits
+// inside a required literal is guaranteed to appear inside a single indexed
+// token, so entries lacking a token containing it cannot match.
+func requiredIndexTerms(pattern string) []string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	re = re.Simplify()
+
+	var literals []string
+	switch re.Op {
+	case syntax.OpLiteral:
+		literals = append(literals, string(re.Rune))
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				literals = append(literals, string(sub.Rune))
+			}
+		}
+	}
+
+	var terms []string
+	for _, literal := range literals {
+		terms = append(terms, tokenizeForIndex(literal)...)
+	}
+	return terms
+}
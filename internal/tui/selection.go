@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxBulkAnalysisEntries caps how many selected entries are sent for AI analysis
+const maxBulkAnalysisEntries = 200
+
+// startVisualSelection begins a multi-entry selection at the current log.
+// Tailing is paused so the selected range stays in place.
+func (m *DashboardModel) startVisualSelection() {
+	if len(m.logEntries) == 0 {
+		return
+	}
+	m.visualMode = true
+	m.selectionAnchor = m.selectedLogIndex
+	m.logAutoScroll = false
+}
+
+// clearVisualSelection leaves visual mode and resumes tailing if at the bottom
+func (m *DashboardModel) clearVisualSelection() {
+	m.visualMode = false
+	m.syncLogFollow()
+}
+
+// selectionRange returns the inclusive bounds of the selected log entries.
+// Without visual mode this is just the entry under the cursor.
+func (m *DashboardModel) selectionRange() (int, int) {
+	if !m.visualMode {
+		return m.selectedLogIndex, m.selectedLogIndex
+	}
+	return min(m.selectionAnchor, m.selectedLogIndex), max(m.selectionAnchor, m.selectedLogIndex)
+}
+
+// inVisualSelection reports whether the log at index is part of the visual selection
+func (m *DashboardModel) inVisualSelection(index int) bool {
+	if !m.visualMode {
+		return false
+	}
+	start, end := m.selectionRange()
+	return index >= start && index <= end
+}
+
+// selectedEntries returns copies of the selected log entries in display order
+func (m *DashboardModel) selectedEntries() []LogEntry {
+	start, end := m.selectionRange()
+	start = max(0, start)
+	end = min(len(m.logEntries)-1, end)
+	if start > end {
+		return nil
+	}
+	entries := make([]LogEntry, end-start+1)
+	copy(entries, m.logEntries[start:end+1])
+	return entries
+}
+
+// showBulkResult reports the outcome of a bulk action in the info modal
+func (m *DashboardModel) showBulkResult(content string) {
+	m.currentLogEntry = nil
+	m.modalContent = content
+	m.showModal = true
+	m.modalReady = false
+}
+
+// copySelection copies the selected entries to the system clipboard
+func (m *DashboardModel) copySelection() {
+	entries := m.selectedEntries()
+	if len(entries) == 0 {
+		return
+	}
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = PlainLogLine(entry)
+	}
+
+	if err := clipboard.WriteAll(strings.Join(lines, "\n")); err != nil {
+		m.showBulkResult(fmt.Sprintf("Copy Failed\n\nCould not access the clipboard: %v", err))
+		return
+	}
+	m.showBulkResult(fmt.Sprintf("Copied %d log line(s) to the clipboard", len(entries)))
+	m.clearVisualSelection()
+}
+
+// exportSelectionOrView opens the export prompt for the visual selection,
+// or for the whole filtered view when nothing is selected
+func (m *DashboardModel) exportSelectionOrView() {
+	m.showLogViewerModal = false
+	if m.visualMode {
+		m.openExportPrompt(m.selectedEntries(), "selection")
+		m.clearVisualSelection()
+		return
+	}
+	m.openExportPrompt(m.logEntries, "current view")
+}
+
+// pinSelection pins every selected entry that is not pinned yet
+func (m *DashboardModel) pinSelection() {
+	for _, entry := range m.selectedEntries() {
+		pinned := false
+		for _, existing := range m.pinnedEntries {
+			if samePinnedEntry(existing, entry) {
+				pinned = true
+				break
+			}
+		}
+		if !pinned {
+			m.togglePinnedEntry(entry)
+		}
+	}
+	m.clearVisualSelection()
+}
+
+// selectionIndicator renders the visual mode badge with the bulk action keys
+func (m *DashboardModel) selectionIndicator() string {
+	if !m.visualMode {
+		return ""
+	}
+	start, end := m.selectionRange()
+	return lipgloss.NewStyle().
+		Background(ColorBlue).
+		Foreground(ColorWhite).
+		Bold(true).
+		Render(fmt.Sprintf(" VISUAL %d selected • y: copy  e: export  p: pin  i: AI  Esc: cancel ", end-start+1))
+}
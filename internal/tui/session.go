@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Session is the view state saved when gonzo exits, to pick up where it
+// left off with --resume
+type Session struct {
+	SavedAt time.Time `json:"saved_at"`
+
+	// Filters
+	Filter           string            `json:"filter,omitempty"` // Regex filter
+	Search           string            `json:"search,omitempty"`
+	FuzzySearch      bool              `json:"fuzzy_search,omitempty"`
+	FilterExpression string            `json:"filter_expression,omitempty"`
+	AttributeFilters []attributeFilter `json:"attribute_filters,omitempty"`
+	Severities       map[string]bool   `json:"severities,omitempty"` // Only while some are hidden
+	OutliersOnly     bool              `json:"outliers_only,omitempty"`
+	SampledOnly      bool              `json:"sampled_only,omitempty"`
+	ServerErrorsOnly bool              `json:"server_errors_only,omitempty"`
+	SlowOnlyMs       float64           `json:"slow_only_ms,omitempty"` // Shortest request duration shown
+
+	// Column layout
+	Columns       bool   `json:"columns,omitempty"`
+	ScopeColumns  bool   `json:"scope_columns,omitempty"`
+	LineNumbers   bool   `json:"line_numbers,omitempty"`
+	TimestampMode string `json:"timestamp_mode,omitempty"`
+	LogsMaximized bool   `json:"logs_maximized,omitempty"`
+
+	// Log timestamp of the entry selected while tailing was paused
+	Anchor *time.Time `json:"anchor,omitempty"`
+
+	// Kubernetes namespaces and pods selected in the filter modal
+	K8sNamespaces map[string]bool `json:"k8s_namespaces,omitempty"`
+	K8sPods       map[string]bool `json:"k8s_pods,omitempty"`
+
+	Pinned []LogEntry `json:"pinned,omitempty"`
+
+	// Watches added with W, as typed
+	Watches []string `json:"watches,omitempty"`
+}
+
+// Session returns the view state to save on exit
+func (m *DashboardModel) Session() Session {
+	s := Session{
+		SavedAt:          time.Now(),
+		Filter:           m.filterInput.Value(),
+		Search:           m.searchTerm,
+		FuzzySearch:      m.fuzzySearch,
+		AttributeFilters: m.attributeFilters,
+		OutliersOnly:     m.outliersOnly,
+		SampledOnly:      m.sampledOnly,
+		ServerErrorsOnly: m.serverErrorsOnly,
+		SlowOnlyMs:       m.slowOnlyMs,
+		Columns:          m.showColumns,
+		ScopeColumns:     m.scopeColumns,
+		LineNumbers:      m.showLineNumbers,
+		TimestampMode:    m.timestampMode,
+		LogsMaximized:    m.logsMaximized,
+		Pinned:           m.pinnedEntries,
+		Watches:          m.addedWatches(),
+	}
+	if m.filterExpr != nil {
+		s.FilterExpression = m.filterExpr.String()
+	}
+	if m.drill != nil {
+		// A drill-down is not saved, only the search from before it
+		s.Search = m.drill.savedSearch
+	}
+	if m.severityFilterActive {
+		s.Severities = m.severityFilter
+	}
+	if m.k8sFilterActive {
+		s.K8sNamespaces = m.k8sNamespaces
+		s.K8sPods = m.k8sPods
+	}
+
+	// Only the log's own time finds the entry again when the logs are read
+	// anew; the receive time would not
+	if !m.logAutoScroll && m.selectedLogIndex >= 0 && m.selectedLogIndex < len(m.logEntries) {
+		if anchor := m.logEntries[m.selectedLogIndex].OrigTimestamp; !anchor.IsZero() {
+			s.Anchor = &anchor
+		}
+	}
+	return s
+}
+
+// RestoreSession applies a saved view state. Filters that no longer parse
+// are skipped and returned; everything else is restored.
+func (m *DashboardModel) RestoreSession(s Session) []error {
+	var errs []error
+
+	if s.Filter != "" {
+		if regex, err := regexp.Compile(s.Filter); err != nil {
+			errs = append(errs, fmt.Errorf("invalid saved filter %q: %w", s.Filter, err))
+		} else {
+			m.filterInput.SetValue(s.Filter)
+			m.filterRegex = regex
+		}
+	}
+	m.searchInput.SetValue(s.Search)
+	m.searchTerm = s.Search
+	m.fuzzySearch = s.FuzzySearch
+	if err := m.SetFilterExpression(s.FilterExpression); err != nil {
+		errs = append(errs, fmt.Errorf("saved filter expression: %w", err))
+	}
+	m.attributeFilters = s.AttributeFilters
+	if len(s.Severities) > 0 {
+		for severity, enabled := range s.Severities {
+			m.severityFilter[severity] = enabled
+		}
+		m.updateSeverityFilterActiveStatus()
+	}
+	m.outliersOnly = s.OutliersOnly
+	m.sampledOnly = s.SampledOnly
+	m.serverErrorsOnly = s.ServerErrorsOnly
+	m.slowOnlyMs = max(0, s.SlowOnlyMs)
+
+	m.showColumns = s.Columns
+	m.scopeColumns = s.ScopeColumns
+	m.showLineNumbers = s.LineNumbers
+	m.SetTimestampMode(s.TimestampMode)
+	m.logsMaximized = s.LogsMaximized
+
+	if s.Anchor != nil {
+		m.resumeAnchor = *s.Anchor
+	}
+	if len(s.K8sNamespaces) > 0 || len(s.K8sPods) > 0 {
+		m.k8sNamespaces = s.K8sNamespaces
+		m.k8sPods = s.K8sPods
+		m.k8sFilterActive = true
+	}
+
+	m.pinnedEntries = s.Pinned
+	if len(m.pinnedEntries) > maxPinnedEntries {
+		m.pinnedEntries = m.pinnedEntries[len(m.pinnedEntries)-maxPinnedEntries:]
+	}
+	for _, spec := range s.Watches {
+		watch, err := parseAddedWatch(spec)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("saved watch: %w", err))
+			continue
+		}
+		// A watch configured since with --watch is kept once
+		if !m.hasWatch(watch.name) {
+			m.watches = append(m.watches, watch)
+		}
+	}
+	m.updateFilteredView()
+	return errs
+}
+
+// seekResumeAnchor selects the first arriving entry at or after the restored
+// scroll position, with tailing paused there, once the logs reach it
+func (m *DashboardModel) seekResumeAnchor(added []LogEntry) {
+	if m.resumeAnchor.IsZero() {
+		return
+	}
+	for _, entry := range added {
+		if entry.OrigTimestamp.IsZero() || entry.OrigTimestamp.Before(m.resumeAnchor) || !m.passesFilters(entry) {
+			continue
+		}
+		for i := len(m.logEntries) - 1; i >= 0; i-- {
+			if m.logEntries[i].Seq == entry.Seq {
+				m.selectedLogIndex = i
+				m.logAutoScroll = false
+				m.resumeAnchor = time.Time{}
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// severityRanks orders the severities a floor compares, least severe first.
+// UNKNOWN has no rank: a line without a level passes every floor.
+var severityRanks = map[string]int{
+	"TRACE":    0,
+	"DEBUG":    1,
+	"INFO":     2,
+	"WARN":     3,
+	"ERROR":    4,
+	"FATAL":    5,
+	"CRITICAL": 5,
+}
+
+// severityFloors are the floors V cycles through, "" showing every severity
+var severityFloors = []string{"", "INFO", "WARN", "ERROR"}
+
+// ParseSeverityFloor normalizes a severity floor such as warn or ERROR. An
+// empty name is no floor.
+func ParseSeverityFloor(name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", nil
+	}
+	floor := normalizeSeverityLevel(name)
+	if _, ok := severityRanks[floor]; !ok {
+		return "", fmt.Errorf("invalid severity %q (use trace, debug, info, warn, error or fatal)", name)
+	}
+	return floor, nil
+}
+
+// SeverityAtLeast reports whether severity is at or above floor. Every
+// severity passes an empty floor, and unknown severities pass any floor.
+func SeverityAtLeast(severity, floor string) bool {
+	if floor == "" {
+		return true
+	}
+	rank, ok := severityRanks[normalizeSeverityLevel(severity)]
+	return !ok || rank >= severityRanks[floor]
+}
+
+// SetSeverityFloor shows only the entries at or above floor, as a severity
+// filter that the Ctrl+f modal can refine
+func (m *DashboardModel) SetSeverityFloor(floor string) {
+	m.severityFloor = floor
+	for severity := range m.severityFilter {
+		m.severityFilter[severity] = SeverityAtLeast(severity, floor)
+	}
+	m.updateSeverityFilterActiveStatus()
+	m.updateFilteredView()
+}
+
+// cycleSeverityFloor raises the severity floor one step, wrapping around
+// to showing every severity
+func (m *DashboardModel) cycleSeverityFloor() {
+	next := 1
+	if m.hasSeverityFloor() {
+		for i, floor := range severityFloors {
+			if floor == m.severityFloor {
+				next = (i + 1) % len(severityFloors)
+			}
+		}
+	}
+	m.SetSeverityFloor(severityFloors[next])
+}
+
+// hasSeverityFloor reports whether the severity filter is still the floor
+// last set, rather than edited since in the modal
+func (m *DashboardModel) hasSeverityFloor() bool {
+	if m.severityFloor == "" {
+		return false
+	}
+	for severity, enabled := range m.severityFilter {
+		if enabled != SeverityAtLeast(severity, m.severityFloor) {
+			return false
+		}
+	}
+	return true
+}
@@ -317,6 +317,8 @@ func updateStyles() {
 		Foreground(lipgloss.Color(CurrentSkin.Colors.ChartTitle)).
 		Bold(true).
 		Align(lipgloss.Center)
+
+	currentRowStyles = nil
 }
 
 // GetSeverityColor returns the color for a given severity level
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/slo"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSLORows is the number of objectives shown in the SLO pane
+const maxSLORows = 3
+
+// sloBudgetBarWidth is the width of the error budget bar
+const sloBudgetBarWidth = 10
+
+// SetObjectives sets the SLOs tracked in the SLO pane
+func (m *DashboardModel) SetObjectives(objectives []slo.Objective) {
+	if len(objectives) == 0 {
+		m.sloTracker = nil
+		m.sloStatuses = nil
+		return
+	}
+	m.sloTracker = slo.NewTracker(objectives)
+	m.sloStatuses = m.sloTracker.Evaluate(time.Now())
+}
+
+// observeSLOs counts a new entry against the objectives. Like the alert
+// rules, every arrival is counted whatever the view's filters.
+func (m *DashboardModel) observeSLOs(entry LogEntry) {
+	if m.sloTracker == nil {
+		return
+	}
+	m.sloTracker.Observe(m.filterRecord(entry), time.Now())
+}
+
+// checkSLOs updates the status of the objectives
+func (m *DashboardModel) checkSLOs() {
+	if m.sloTracker == nil {
+		return
+	}
+	m.sloStatuses = m.sloTracker.Evaluate(time.Now())
+}
+
+// sloBanner summarizes the objectives that are breached or projected to be,
+// for the status line, e.g. "checkout SLO breach in ~6m"
+func (m *DashboardModel) sloBanner() string {
+	var summaries []string
+	for _, status := range m.sloStatuses {
+		switch {
+		case status.Breached:
+			summaries = append(summaries, fmt.Sprintf("%s SLO breached (%.2f%%, objective %s)", status.Objective.Name, status.Rate*100, status.Objective.Condition()))
+		case status.Projected():
+			summaries = append(summaries, fmt.Sprintf("%s SLO breach in ~%s", status.Objective.Name, formatBreachIn(status.BreachIn)))
+		}
+	}
+	return strings.Join(summaries, " • ")
+}
+
+// sloPaneVisible reports whether the SLO pane is shown
+func (m *DashboardModel) sloPaneVisible() bool {
+	return m.sloTracker != nil && !m.logsMaximized && !m.hiddenPanels[PanelSLO]
+}
+
+// sloPaneHeight returns the number of lines the SLO pane occupies
+func (m *DashboardModel) sloPaneHeight() int {
+	if !m.sloPaneVisible() {
+		return 0
+	}
+	return 1 + min(len(m.sloStatuses), maxSLORows)
+}
+
+// renderSLOPane renders the error budget of each objective above the log
+// list. The title turns red while any objective is breached or projected to
+// be.
+func (m *DashboardModel) renderSLOPane() string {
+	width := max(m.width-2, 40)
+
+	titleColor := ColorBlue
+	for _, status := range m.sloStatuses {
+		if status.Breached || status.Projected() {
+			titleColor = ColorRed
+		}
+	}
+	title := fmt.Sprintf("🎯 SLOs (%d)", len(m.sloStatuses))
+	if len(m.sloStatuses) > maxSLORows {
+		title += fmt.Sprintf(" • showing first %d", maxSLORows)
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(titleColor).Bold(true).Padding(0, 1).Render(title),
+	}
+
+	for _, status := range m.sloStatuses[:min(len(m.sloStatuses), maxSLORows)] {
+		lines = append(lines, " "+truncateToWidth(formatSLOStatus(status), width))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// formatSLOStatus renders one objective, e.g.
+// "checkout 0.42% of 1,203 (< 1% over 30m) [████░░░░░░] 42% of budget • burn 3.1×"
+func formatSLOStatus(status slo.Status) string {
+	o := status.Objective
+	name := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(o.Name)
+	if status.Total == 0 {
+		return fmt.Sprintf("%s no entries in the last %s (%s)", name, formatWindowDuration(o.Window), o.Condition())
+	}
+
+	color := ColorGreen
+	switch {
+	case status.Breached:
+		color = ColorRed
+	case status.Projected():
+		color = ColorOrange
+	case status.BudgetUsed >= 0.5:
+		color = ColorYellow
+	}
+	colored := lipgloss.NewStyle().Foreground(color).Bold(true)
+
+	filled := min(sloBudgetBarWidth, int(status.BudgetUsed*sloBudgetBarWidth+0.5))
+	bar := colored.Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(ColorGray).Render(strings.Repeat("░", sloBudgetBarWidth-filled))
+
+	line := fmt.Sprintf("%s %s of %s (%s) [%s] %s of budget",
+		name,
+		colored.Render(fmt.Sprintf("%.2f%%", status.Rate*100)),
+		formatCount(int64(status.Total)),
+		o.Condition(),
+		bar,
+		colored.Render(fmt.Sprintf("%.0f%%", status.BudgetUsed*100)))
+	if status.BurnRate > 0 {
+		line += fmt.Sprintf(" • burn %.1f×", status.BurnRate)
+	}
+	switch {
+	case status.Breached:
+		line += " • " + colored.Render("⚠ BREACHED")
+	case status.Projected():
+		line += " • " + colored.Render("⚠ breach in ~"+formatBreachIn(status.BreachIn))
+	}
+	return line
+}
+
+// formatBreachIn renders the time left until a projected breach, e.g. "6m"
+func formatBreachIn(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return formatWindowDuration(d.Round(time.Minute))
+}
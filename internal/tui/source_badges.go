@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Source badge modes for the log list
+const (
+	SourceBadgesAuto   = "auto" // Once entries from two sources are buffered
+	SourceBadgesAlways = "always"
+	SourceBadgesNever  = "never"
+)
+
+// maxSourceBadgeWidth is the widest badge label allowed
+const maxSourceBadgeWidth = 8
+
+// SourceBadge labels the entries of one source in the log list
+type SourceBadge struct {
+	Label string
+	Color lipgloss.Color
+}
+
+// defaultSourceBadges label the inputs gonzo reads
+var defaultSourceBadges = map[string]SourceBadge{
+	"k8s":    {"K8S", lipgloss.Color("39")},
+	"otlp":   {"OTL", lipgloss.Color("42")},
+	"file":   {"FIL", lipgloss.Color("220")},
+	"stdin":  {"STD", lipgloss.Color("250")},
+	"vmlogs": {"VML", lipgloss.Color("208")},
+	"replay": {"RPL", lipgloss.Color("141")},
+	"agent":  {"AGT", lipgloss.Color("81")},
+	"attach": {"ATT", lipgloss.Color("117")},
+}
+
+// sourceBadgePalette colors the badges of sources without a default
+var sourceBadgePalette = []lipgloss.Color{"39", "42", "220", "208", "141", "81", "204", "117"}
+
+// ParseSourceBadge parses a badge written as SOURCE=LABEL[:COLOR], where
+// COLOR is a hex color such as #326ce5 or an ANSI color number
+func ParseSourceBadge(spec string) (string, SourceBadge, error) {
+	source, rest, ok := strings.Cut(spec, "=")
+	source = strings.TrimSpace(source)
+	if !ok || source == "" {
+		return "", SourceBadge{}, fmt.Errorf("invalid source badge %q: expected SOURCE=LABEL[:COLOR]", spec)
+	}
+
+	var badge SourceBadge
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		badge.Color = lipgloss.Color(strings.TrimSpace(rest[i+1:]))
+		rest = rest[:i]
+	}
+	badge.Label = strings.TrimSpace(rest)
+	if badge.Label == "" {
+		return "", SourceBadge{}, fmt.Errorf("invalid source badge %q: the label is empty", spec)
+	}
+	if lipgloss.Width(badge.Label) > maxSourceBadgeWidth {
+		return "", SourceBadge{}, fmt.Errorf("invalid source badge %q: labels are at most %d characters", spec, maxSourceBadgeWidth)
+	}
+	return source, badge, nil
+}
+
+// SetSourceBadges sets when the log list shows a badge naming each entry's
+// source (auto, always or never) and the badges that replace the defaults.
+// Badges without a color keep the default color of their source.
+func (m *DashboardModel) SetSourceBadges(mode string, badges map[string]SourceBadge) error {
+	switch mode = strings.ToLower(strings.TrimSpace(mode)); mode {
+	case "":
+		mode = SourceBadgesAuto
+	case SourceBadgesAuto, SourceBadgesAlways, SourceBadgesNever:
+	default:
+		return fmt.Errorf("invalid source badges mode %q (use auto, always or never)", mode)
+	}
+	m.sourceBadgesMode = mode
+	m.sourceBadges = badges
+	return nil
+}
+
+// observeSource records the source of a new entry, widening the badge
+// column when its label is wider than those seen before
+func (m *DashboardModel) observeSource(entry LogEntry) {
+	if entry.Source == "" || m.sourcesSeen[entry.Source] {
+		return
+	}
+	if m.sourcesSeen == nil {
+		m.sourcesSeen = make(map[string]bool)
+	}
+	m.sourcesSeen[entry.Source] = true
+	m.sourceBadgeWidth = max(m.sourceBadgeWidth, lipgloss.Width(m.sourceBadge(entry.Source).Label))
+}
+
+// sourceBadgesShown reports whether the log list shows source badges
+func (m *DashboardModel) sourceBadgesShown() bool {
+	switch m.sourceBadgesMode {
+	case SourceBadgesAlways:
+		return len(m.sourcesSeen) > 0
+	case SourceBadgesNever:
+		return false
+	}
+	return len(m.sourcesSeen) > 1
+}
+
+// sourceBadge returns the badge of a source: the configured one, the
+// default, or the source's first letters in a color picked from its name.
+// Sources such as "file:app.log" fall back to the badge of their kind.
+func (m *DashboardModel) sourceBadge(source string) SourceBadge {
+	kind := source
+	if i := strings.IndexAny(kind, ":/"); i > 0 {
+		kind = kind[:i]
+	}
+	badge, ok := defaultSourceBadges[source]
+	if !ok {
+		badge, ok = defaultSourceBadges[kind]
+	}
+	if !ok {
+		label := []rune(strings.ToUpper(kind))
+		badge.Label = string(label[:min(len(label), 3)])
+		h := fnv.New32a()
+		h.Write([]byte(source))
+		badge.Color = sourceBadgePalette[h.Sum32()%uint32(len(sourceBadgePalette))]
+	}
+	configured, ok := m.sourceBadges[source]
+	if !ok {
+		configured, ok = m.sourceBadges[kind]
+	}
+	if ok {
+		badge.Label = configured.Label
+		if configured.Color != "" {
+			badge.Color = configured.Color
+		}
+	}
+	return badge
+}
+
+// sourceBadgePrefix returns the badge column for an entry, padded so rows
+// stay aligned, or "" while badges are not shown. Plain badges go in rows
+// that are colored as a whole.
+func (m *DashboardModel) sourceBadgePrefix(entry LogEntry, plain bool) string {
+	if !m.sourceBadgesShown() {
+		return ""
+	}
+	label := ""
+	badge := SourceBadge{}
+	if entry.Source != "" {
+		badge = m.sourceBadge(entry.Source)
+		label = badge.Label
+	}
+	label += strings.Repeat(" ", max(0, m.sourceBadgeWidth-lipgloss.Width(label)))
+	if plain || badge.Color == "" {
+		return label + " "
+	}
+	return lipgloss.NewStyle().Foreground(badge.Color).Bold(true).Render(label) + " "
+}
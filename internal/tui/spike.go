@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/ai"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Spike detection on the Counts chart: an interval is a spike when it has at
+// least spikeMinLines lines, spikeFactor times the average of the intervals
+// before it and three standard deviations above that average
+const (
+	spikeBaselineIntervals    = 10 // Intervals averaged for the baseline
+	spikeMinBaselineIntervals = 5  // Intervals needed before spikes are detected
+	spikeMinLines             = 20
+	spikeFactor               = 3.0
+	spikeOfferIntervals       = 20 // Intervals the spike badge stays on the chart
+	spikeMaxPatterns          = 15
+	spikeMaxSamples           = 30
+)
+
+// logSpike is a burst of log volume seen on the Counts chart. Consecutive
+// spike intervals are merged into one.
+type logSpike struct {
+	start     time.Time
+	end       time.Time
+	total     int // Lines over all spike intervals
+	intervals int
+	baseline  float64
+	ongoing   bool // The latest interval is still part of the spike
+}
+
+// detectSpike checks the interval just added to the counts history, which
+// covers the receive times from the previous interval end up to now
+func (m *DashboardModel) detectSpike(now time.Time) {
+	start := m.countsIntervalEnd
+	m.countsIntervalEnd = now
+	if start.IsZero() {
+		return
+	}
+	latest := m.countsHistory[len(m.countsHistory)-1]
+
+	if m.spike != nil && m.spike.ongoing {
+		if float64(latest.Total) >= spikeFactor*m.spike.baseline && latest.Total >= spikeMinLines {
+			m.spike.end = now
+			m.spike.total += latest.Total
+			m.spike.intervals++
+			return
+		}
+		m.spike.ongoing = false
+	}
+	if m.spike != nil {
+		m.spikeAge++
+	}
+
+	previous := m.countsHistory[:len(m.countsHistory)-1]
+	if len(previous) < spikeMinBaselineIntervals {
+		return
+	}
+	previous = previous[max(0, len(previous)-spikeBaselineIntervals):]
+	mean, stddev := 0.0, 0.0
+	for _, counts := range previous {
+		mean += float64(counts.Total)
+	}
+	mean /= float64(len(previous))
+	for _, counts := range previous {
+		stddev += (float64(counts.Total) - mean) * (float64(counts.Total) - mean)
+	}
+	stddev = math.Sqrt(stddev / float64(len(previous)))
+
+	total := float64(latest.Total)
+	if latest.Total < spikeMinLines || total < spikeFactor*mean || total <= mean+3*stddev {
+		return
+	}
+	m.spike = &logSpike{
+		start:     start,
+		end:       now,
+		total:     latest.Total,
+		intervals: 1,
+		baseline:  max(mean, 1),
+		ongoing:   true,
+	}
+	m.spikeAge = 0
+}
+
+// spikeBadge is shown in the Counts chart title while a recent spike can be
+// summarized
+func (m *DashboardModel) spikeBadge() string {
+	if m.spike == nil || m.spikeAge >= spikeOfferIntervals {
+		return ""
+	}
+	return fmt.Sprintf(" ⚡%.1f× S:summarize", m.spike.factor())
+}
+
+// factor is how many times the baseline the spike intervals averaged
+func (s *logSpike) factor() float64 {
+	return float64(s.total) / float64(s.intervals) / s.baseline
+}
+
+// summarizeSpike clusters the entries of the last spike and asks the AI for
+// a short incident summary with probable causes
+func (m *DashboardModel) summarizeSpike() (tea.Model, tea.Cmd) {
+	if m.spike == nil {
+		m.showBulkResult(fmt.Sprintf("No Spike Detected\n\nA spike is an interval on the Counts chart with at least %d lines and %.0f× the recent average. Press S after one appears (⚡ in the chart title).", spikeMinLines, spikeFactor))
+		return m, nil
+	}
+	if m.aiClient == nil {
+		m.showBulkResult(fmt.Sprintf("AI Analysis Not Available\n\nError: %s", m.aiErrorMessage))
+		return m, nil
+	}
+	if m.aiAnalyzing {
+		return m, nil
+	}
+
+	var entries []LogEntry
+	for _, entry := range m.allLogEntries {
+		if entry.Timestamp.After(m.spike.start) && !entry.Timestamp.After(m.spike.end) {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		m.showBulkResult("Spike No Longer Buffered\n\nThe entries of the last spike have left the log buffer. Raise --log-buffer to keep them longer.")
+		return m, nil
+	}
+
+	drain := NewDrain3Manager()
+	counts := &SeverityCounts{}
+	for _, entry := range entries {
+		drain.AddLogMessage(entry.Message)
+		counts.AddCount(entry.Severity)
+	}
+	var patterns []string
+	for _, pattern := range drain.GetTopPatterns(spikeMaxPatterns) {
+		patterns = append(patterns, fmt.Sprintf("%d× %s", pattern.Count, pattern.Template))
+	}
+	samples, _ := sampleForAnalysis(entries, spikeMaxSamples)
+
+	window := fmt.Sprintf("%s and %s", m.spike.start.Format("15:04:05"), m.spike.end.Format("15:04:05"))
+	prompt := ai.BuildSpikePrompt(ai.SpikePromptData{
+		Window:     window,
+		Total:      m.spike.total,
+		Intervals:  m.spike.intervals,
+		Baseline:   m.spike.baseline,
+		Severities: formatSeverityBreakdown(counts),
+		Patterns:   patterns,
+		Attributes: dominantAttributes(entries),
+		Samples:    samples,
+	})
+
+	title := fmt.Sprintf("Spike Summary: %d lines between %s (%.1f× baseline)", m.spike.total, window, m.spike.factor())
+	return m, m.streamAnalysis(title, prompt)
+}
+
+// formatSeverityBreakdown lists the non-zero severity counts, highest
+// severity first
+func formatSeverityBreakdown(counts *SeverityCounts) string {
+	var parts []string
+	for _, sev := range []struct {
+		name  string
+		count int
+	}{
+		{"FATAL", counts.Fatal + counts.Critical},
+		{"ERROR", counts.Error},
+		{"WARN", counts.Warn},
+		{"INFO", counts.Info},
+		{"DEBUG", counts.Debug},
+		{"TRACE", counts.Trace},
+		{"UNKNOWN", counts.Unknown},
+	} {
+		if sev.count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", sev.name, sev.count))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dominantAttributes returns the attribute values carried by most of the
+// entries, such as the one service or pod a spike comes from
+func dominantAttributes(entries []LogEntry) []string {
+	valueCounts := make(map[string]map[string]int)
+	for _, entry := range entries {
+		for key, value := range entry.Attributes {
+			if valueCounts[key] == nil {
+				valueCounts[key] = make(map[string]int)
+			}
+			valueCounts[key][value]++
+		}
+	}
+
+	type dominant struct {
+		text  string
+		count int
+	}
+	var found []dominant
+	for key, values := range valueCounts {
+		// Values that differ on nearly every entry (ids, timings) say nothing
+		if len(values) > len(entries)/2 && len(entries) > 1 {
+			continue
+		}
+		for value, count := range values {
+			if count*10 >= len(entries)*6 {
+				found = append(found, dominant{fmt.Sprintf("%s=%s (%d of %d)", key, value, count, len(entries)), count})
+			}
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].count != found[j].count {
+			return found[i].count > found[j].count
+		}
+		return found[i].text < found[j].text
+	})
+
+	texts := make([]string, 0, min(len(found), 8))
+	for _, d := range found[:min(len(found), 8)] {
+		texts = append(texts, d.text)
+	}
+	return texts
+}
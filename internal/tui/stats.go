@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // renderStatsContent renders the detailed statistics content
@@ -29,7 +30,9 @@ func (m *DashboardModel) renderStatsContent(contentWidth int) string {
 	// Row 1: General Statistics | Severity Distribution (side by side)
 	generalStats := m.renderStatsSection("General Statistics", []StatItem{
 		{"Total Logs Processed", fmt.Sprintf("%d", m.statsTotalLogsEver)},
-		{"Logs in Buffer", fmt.Sprintf("%d", len(m.allLogEntries))},
+		{"Logs in Buffer", fmt.Sprintf("%d (%d%%, %s)", len(m.allLogEntries), m.bufferFillPercent(), m.formatBytes(m.bufferBytes))},
+		{"Evicted from Buffer", m.bufferEvictedText()},
+		{"Dropped by Sources", m.droppedText()},
 		{"Filtered Logs Displayed", fmt.Sprintf("%d", len(m.logEntries))},
 		{"Total Bytes Processed", m.formatBytes(m.statsTotalBytes)},
 		{"Uptime", m.formatUptime()},
@@ -45,6 +48,12 @@ func (m *DashboardModel) renderStatsContent(contentWidth int) string {
 	row1 := m.combineSideBySide(generalStats, severitySection)
 	sections = append(sections, row1)
 
+	// Latency percentiles, when entries carry durations
+	if latencyStats := m.latency.stats(time.Now()); len(latencyStats) > 0 {
+		m.latencySelected = min(m.latencySelected, len(latencyStats)-1)
+		sections = append(sections, m.renderLatencySection(latencyStats, contentWidth))
+	}
+
 	// Host Statistics Section
 	hostStats := m.calculateHostStats()
 	if len(hostStats) > 0 {
@@ -103,8 +112,8 @@ func (m *DashboardModel) renderStatsSection(title string, items []StatItem, widt
 	// Calculate the maximum key length for alignment
 	maxKeyLen := 0
 	for _, item := range items {
-		if len(item.Key) > maxKeyLen {
-			maxKeyLen = len(item.Key)
+		if ansi.StringWidth(item.Key) > maxKeyLen {
+			maxKeyLen = ansi.StringWidth(item.Key)
 		}
 	}
 
@@ -244,9 +253,14 @@ func (m *DashboardModel) formatUptime() string {
 }
 
 func (m *DashboardModel) formatCurrentRate() string {
-	// If no historical data yet, show current second rate
+	return fmt.Sprintf("%.1f logs/sec", m.currentRate())
+}
+
+// currentRate returns the average logs per second over the last few seconds
+func (m *DashboardModel) currentRate() float64 {
+	// If no historical data yet, use the current second
 	if len(m.statsRecentCounts) == 0 {
-		return fmt.Sprintf("%.1f logs/sec", float64(m.statsLogsThisSecond))
+		return float64(m.statsLogsThisSecond)
 	}
 
 	// Calculate average over recent window (last 5 seconds for more responsive rate)
@@ -266,13 +280,8 @@ func (m *DashboardModel) formatCurrentRate() string {
 	totalLogs += m.statsLogsThisSecond
 	validSeconds++
 
-	if validSeconds == 0 {
-		return "0.0 logs/sec"
-	}
-
 	// Calculate rate over the window
-	rate := float64(totalLogs) / float64(validSeconds)
-	return fmt.Sprintf("%.1f logs/sec", rate)
+	return float64(totalLogs) / float64(validSeconds)
 }
 
 // combineSideBySide combines two sections side by side (using lipgloss like the main dashboard)
@@ -291,8 +300,8 @@ func (m *DashboardModel) renderSeveritySection(stats []StatItem, width int) stri
 	// Calculate the maximum key length for alignment
 	maxKeyLen := 0
 	for _, item := range stats {
-		if len(item.Key) > maxKeyLen {
-			maxKeyLen = len(item.Key)
+		if ansi.StringWidth(item.Key) > maxKeyLen {
+			maxKeyLen = ansi.StringWidth(item.Key)
 		}
 	}
 
@@ -460,11 +469,11 @@ func (m *DashboardModel) renderAttributeSection(stats []AttributeStatFormatted,
 	actualMaxKeyLen := 0
 	actualMaxValueLen := 0
 	for _, stat := range stats {
-		if len(stat.Key) > actualMaxKeyLen {
-			actualMaxKeyLen = len(stat.Key)
+		if ansi.StringWidth(stat.Key) > actualMaxKeyLen {
+			actualMaxKeyLen = ansi.StringWidth(stat.Key)
 		}
-		if len(stat.Value) > actualMaxValueLen {
-			actualMaxValueLen = len(stat.Value)
+		if ansi.StringWidth(stat.Value) > actualMaxValueLen {
+			actualMaxValueLen = ansi.StringWidth(stat.Value)
 		}
 	}
 	
@@ -508,23 +517,17 @@ func (m *DashboardModel) renderAttributeSection(stats []AttributeStatFormatted,
 
 	// Render each attribute
 	for _, stat := range stats {
-		key := stat.Key
-		if len(key) > maxKeyLen {
-			key = key[:maxKeyLen-3] + "..."
-		}
+		key := truncateToWidth(stat.Key, maxKeyLen)
 
-		value := stat.Value
-		if len(value) > maxValueLen {
-			value = value[:maxValueLen-3] + "..."
-		}
+		value := truncateToWidth(stat.Value, maxValueLen)
 
 		keyStyle := lipgloss.NewStyle().Foreground(ColorWhite)
 		valueStyle := lipgloss.NewStyle().Foreground(ColorBlue)
 		countStyle := lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
 
 		line := fmt.Sprintf("%s │ %s │ %s",
-			keyStyle.Render(fmt.Sprintf("%-*s", maxKeyLen, key)),
-			valueStyle.Render(fmt.Sprintf("%-*s", maxValueLen, value)),
+			keyStyle.Render(padToWidth(key, maxKeyLen)),
+			valueStyle.Render(padToWidth(value, maxValueLen)),
 			countStyle.Render(fmt.Sprintf("%d (%.1f%%)", stat.Count, stat.Percentage)))
 
 		contentLines = append(contentLines, line)
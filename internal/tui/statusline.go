@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// statusLineVarRegex matches {name} placeholders in a status line template
+var statusLineVarRegex = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// statusLineVars lists the template variables and how to compute them
+var statusLineVars = map[string]func(m *DashboardModel) string{
+	"rate": func(m *DashboardModel) string {
+		return fmt.Sprintf("%.1f/s", m.currentRate())
+	},
+	"peak": func(m *DashboardModel) string {
+		return fmt.Sprintf("%.1f/s", m.statsPeakLogsPerSec)
+	},
+	"buffer": func(m *DashboardModel) string {
+		return fmt.Sprintf("%d/%d", len(m.allLogEntries), m.maxLogBuffer)
+	},
+	"buffer_pct": func(m *DashboardModel) string {
+		return fmt.Sprintf("%d%%", m.bufferFillPercent())
+	},
+	"buffer_bytes": func(m *DashboardModel) string {
+		if m.bufferMaxBytes > 0 {
+			return fmt.Sprintf("%s/%s", m.formatBytes(m.bufferBytes), m.formatBytes(m.bufferMaxBytes))
+		}
+		return m.formatBytes(m.bufferBytes)
+	},
+	"evicted": func(m *DashboardModel) string {
+		return fmt.Sprintf("%d", m.bufferEvicted)
+	},
+	"discarded": func(m *DashboardModel) string {
+		return fmt.Sprintf("%d", m.bufferRejected)
+	},
+	"shown": func(m *DashboardModel) string {
+		return fmt.Sprintf("%d", len(m.logEntries))
+	},
+	"total": func(m *DashboardModel) string {
+		return fmt.Sprintf("%d", m.statsTotalLogsEver)
+	},
+	"filters": func(m *DashboardModel) string {
+		return fmt.Sprintf("%d", len(m.activeFilterChips()))
+	},
+	"streams": func(m *DashboardModel) string {
+		if m.k8sSource == nil {
+			return "0"
+		}
+		return fmt.Sprintf("%d", m.k8sSource.GetActiveStreams())
+	},
+	"dropped": func(m *DashboardModel) string {
+		if m.droppedCounter == nil {
+			return "0"
+		}
+		return fmt.Sprintf("%d", m.droppedCounter())
+	},
+	"interval": func(m *DashboardModel) string {
+		return m.formatDuration(m.updateInterval)
+	},
+	"uptime": func(m *DashboardModel) string {
+		return m.formatUptime()
+	},
+}
+
+// StatusLineVariables returns the names of the status line template variables
+func StatusLineVariables() []string {
+	names := make([]string, 0, len(statusLineVars))
+	for name := range statusLineVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetStatusLineTemplate replaces the update interval in the status bar with
+// a template such as "{rate} • buf {buffer_pct} • dropped {dropped}". An
+// empty template restores the default.
+func (m *DashboardModel) SetStatusLineTemplate(tmpl string) error {
+	var unknown []string
+	for _, match := range statusLineVarRegex.FindAllStringSubmatch(tmpl, -1) {
+		if _, ok := statusLineVars[match[1]]; !ok {
+			unknown = append(unknown, "{"+match[1]+"}")
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown status line variables %s (available: %s)", strings.Join(unknown, ", "), strings.Join(StatusLineVariables(), ", "))
+	}
+	m.statusLineTemplate = tmpl
+	return nil
+}
+
+// SetDroppedCounter sets the function reporting how many entries the input
+// sources dropped, shown by the {dropped} status line variable
+func (m *DashboardModel) SetDroppedCounter(counter func() int64) {
+	m.droppedCounter = counter
+}
+
+// SetDroppedBySource sets the function reporting the dropped entries of each
+// input source, listed in the statistics modal
+func (m *DashboardModel) SetDroppedBySource(counter func() map[string]int64) {
+	m.droppedBySource = counter
+}
+
+// droppedStatus reports the entries the input sources dropped for the
+// status bar, e.g. "1,234 dropped", or "" when none were
+func (m *DashboardModel) droppedStatus() string {
+	if m.droppedCounter == nil {
+		return ""
+	}
+	if dropped := m.droppedCounter(); dropped > 0 {
+		return fmt.Sprintf("%s dropped", formatCount(dropped))
+	}
+	return ""
+}
+
+// droppedText lists the dropped entries by source for the statistics
+// modal, the most dropped first, e.g. "1,234 (k8s 1,200, otlp 34)"
+func (m *DashboardModel) droppedText() string {
+	if m.droppedCounter == nil {
+		return "0"
+	}
+	total := m.droppedCounter()
+	if total == 0 || m.droppedBySource == nil {
+		return fmt.Sprintf("%d", total)
+	}
+	counts := m.droppedBySource()
+	sources := make([]string, 0, len(counts))
+	for source, count := range counts {
+		if count > 0 {
+			sources = append(sources, source)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if counts[sources[i]] != counts[sources[j]] {
+			return counts[sources[i]] > counts[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+	parts := make([]string, len(sources))
+	for i, source := range sources {
+		parts[i] = fmt.Sprintf("%s %s", source, formatCount(counts[source]))
+	}
+	return fmt.Sprintf("%d (%s)", total, strings.Join(parts, ", "))
+}
+
+// renderStatusLineTemplate expands the status line template
+func (m *DashboardModel) renderStatusLineTemplate() string {
+	return statusLineVarRegex.ReplaceAllStringFunc(m.statusLineTemplate, func(match string) string {
+		return statusLineVars[match[1:len(match)-1]](m)
+	})
+}
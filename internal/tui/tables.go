@@ -6,10 +6,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/control-theory/gonzo/internal/memory"
-
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // formatAttributesTable formats attributes using Bubbles table component
@@ -49,13 +48,10 @@ func (m *DashboardModel) formatAttributesTable(attributes map[string]string, max
 		value := attributes[key]
 
 		// Always truncate long keys to fit (keys are less important to see in full)
-		displayKey := key
-		if len(displayKey) > keyWidth-3 {
-			displayKey = displayKey[:keyWidth-3] + "..."
-		}
+		displayKey := truncateToWidth(key, keyWidth)
 
 		// Handle value display based on wrapping setting
-		if m.attributeWrappingEnabled && len(value) > valueWidth {
+		if m.attributeWrappingEnabled && ansi.StringWidth(value) > valueWidth {
 			// Wrap long values across multiple rows
 			wrappedLines := wrapText(value, valueWidth)
 			for i, line := range wrappedLines {
@@ -70,9 +66,7 @@ func (m *DashboardModel) formatAttributesTable(attributes map[string]string, max
 			}
 		} else {
 			// Truncate long values to fit (default behavior)
-			if len(value) > valueWidth-3 {
-				value = value[:valueWidth-3] + "..."
-			}
+			value = truncateToWidth(value, valueWidth)
 			rows = append(rows, table.Row{displayKey, value})
 			totalRows++
 		}
@@ -139,25 +133,57 @@ func (m *DashboardModel) formatLogDetails(entry LogEntry, maxWidth int) string {
 	// Header
 	details.WriteString(headerStyle.Render("Log Entry Details") + "\n\n")
 
+	if entry.Seq > 0 {
+		details.WriteString(labelStyle.Render("Entry:") + " " +
+			valueStyle.Render(fmt.Sprintf("#%d", entry.Seq)) + "\n")
+	}
+
 	// Basic information - show both timestamps
 	details.WriteString(labelStyle.Render("Received:") + " " +
-		valueStyle.Render(entry.Timestamp.Format("2006-01-02 15:04:05.000")) + "\n")
+		valueStyle.Render(m.formatFullTimestamp(entry.Timestamp)) + "\n")
 
 	// Show original timestamp if available and different from receive time
 	if !entry.OrigTimestamp.IsZero() {
 		details.WriteString(labelStyle.Render("Log Time:") + " " +
-			valueStyle.Render(entry.OrigTimestamp.Format("2006-01-02 15:04:05.000")) + "\n")
+			valueStyle.Render(m.formatFullTimestamp(entry.OrigTimestamp)) + "\n")
 	}
 
 	details.WriteString(labelStyle.Render("Severity:") + " " +
 		severityStyle.Render(entry.Severity) + "\n")
+	if entry.Source != "" {
+		details.WriteString(labelStyle.Render("Source:") + " " +
+			valueStyle.Render(entry.Source) + "\n")
+	}
+	if entry.Outlier != "" {
+		details.WriteString(labelStyle.Render("Outlier:") + " " +
+			lipgloss.NewStyle().Foreground(ColorOrange).Render(entry.Outlier) + "\n")
+	}
 	details.WriteString(labelStyle.Render("Message:") + "\n" +
 		valueStyle.Render(entry.Message) + "\n")
+	if entry.StructuredBody {
+		if body, ok := prettyJSON(entry.RawLine); ok {
+			details.WriteString("\n" + headerStyle.Render("Body") + "\n" +
+				valueStyle.Render(body) + "\n")
+		}
+	}
 
-	// Attributes table
-	if len(entry.Attributes) > 0 {
-		details.WriteString("\n" + headerStyle.Render("Attributes") + "\n")
-		details.WriteString(m.formatAttributesTable(entry.Attributes, maxWidth))
+	// Attributes tables, OTLP resource and scope attributes apart
+	resource, scope, record := splitAttributes(entry)
+	if len(resource) > 0 {
+		details.WriteString("\n" + headerStyle.Render("Resource Attributes") + "\n")
+		details.WriteString(m.formatAttributesTable(resource, maxWidth))
+	}
+	if len(scope) > 0 {
+		details.WriteString("\n" + headerStyle.Render("Scope Attributes") + "\n")
+		details.WriteString(m.formatAttributesTable(scope, maxWidth))
+	}
+	if len(record) > 0 {
+		header := "Attributes"
+		if len(resource) > 0 || len(scope) > 0 {
+			header = "Record Attributes"
+		}
+		details.WriteString("\n" + headerStyle.Render(header) + "\n")
+		details.WriteString(m.formatAttributesTable(record, maxWidth))
 	}
 
 	// AI Analysis section
@@ -175,140 +201,42 @@ func (m *DashboardModel) formatLogDetails(entry LogEntry, maxWidth int) string {
 	return details.String()
 }
 
-// wrapText wraps text to fit within the specified width
-func wrapText(text string, width int) []string {
-	if len(text) <= width {
-		return []string{text}
+// splitAttributes separates the attributes of an entry from its OTLP
+// resource and scope from its own
+func splitAttributes(entry LogEntry) (resource, scope, record map[string]string) {
+	if len(entry.ResourceKeys) == 0 && len(entry.ScopeKeys) == 0 {
+		return nil, nil, entry.Attributes
 	}
-
-	var lines []string
-	for len(text) > width {
-		// Find the best break point (prefer spaces)
-		breakPoint := width
-
-		// Look for a space near the end to break on word boundary
-		for i := width - 1; i > width/2 && i < len(text); i-- {
-			if text[i] == ' ' {
-				breakPoint = i
-				break
+	pick := func(keys []string) map[string]string {
+		picked := make(map[string]string, len(keys))
+		for _, key := range keys {
+			if value, ok := entry.Attributes[key]; ok {
+				picked[key] = value
 			}
 		}
-
-		// Add the line (excluding the space if we broke on one)
-		if breakPoint < len(text) && text[breakPoint] == ' ' {
-			lines = append(lines, text[:breakPoint])
-			text = text[breakPoint+1:] // Skip the space
-		} else {
-			lines = append(lines, text[:breakPoint])
-			text = text[breakPoint:]
-		}
-	}
-
-	// Add the remaining text
-	if len(text) > 0 {
-		lines = append(lines, text)
-	}
-
-	return lines
-}
-
-// formatAttributeValuesModal formats the attribute values modal showing individual values and their counts with full width layout
-func (m *DashboardModel) formatAttributeValuesModal(entry *memory.AttributeStatsEntry, maxWidth int) string {
-	var modal strings.Builder
-
-	// Title section
-	titleStyle := lipgloss.NewStyle().
-		Foreground(ColorWhite).
-		Bold(true)
-
-	modal.WriteString(titleStyle.Render(fmt.Sprintf("Attribute Values for \"%s\"", entry.Key)) + "\n\n")
-
-	if len(entry.Values) == 0 {
-		helpStyle := lipgloss.NewStyle().Foreground(ColorGray).Italic(true)
-		modal.WriteString(helpStyle.Render("No values recorded for this attribute.") + "\n")
-		return modal.String()
-	}
-
-	// Add summary section at the top
-	summaryStyle := lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
-	modal.WriteString(summaryStyle.Render("Summary:") + "\n")
-
-	summaryDetailStyle := lipgloss.NewStyle().Foreground(ColorWhite)
-	modal.WriteString(summaryDetailStyle.Render(fmt.Sprintf("Total occurrences: %d", entry.TotalCount)) + "\n")
-	modal.WriteString(summaryDetailStyle.Render(fmt.Sprintf("Unique values: %d", entry.UniqueValueCount)) + "\n\n")
-
-	// Convert map to sorted slice for consistent display
-	type ValueCount struct {
-		Value string
-		Count int64
+		return picked
 	}
-
-	values := make([]ValueCount, 0, len(entry.Values))
-	for value, count := range entry.Values {
-		values = append(values, ValueCount{Value: value, Count: count})
-	}
-
-	// Sort by count (descending), then by value name for ties
-	sort.Slice(values, func(i, j int) bool {
-		if values[i].Count == values[j].Count {
-			return values[i].Value < values[j].Value
+	resource, scope = pick(entry.ResourceKeys), pick(entry.ScopeKeys)
+	record = make(map[string]string, len(entry.Attributes))
+	for key, value := range entry.Attributes {
+		if _, ok := resource[key]; ok {
+			continue
 		}
-		return values[i].Count > values[j].Count
-	})
-
-	// Calculate optimal column widths using full available width
-	availableWidth := maxWidth
-
-	// Reserve space for count column and separators
-	countColumnWidth := 18 // Fixed width for "Count" column including percentage
-	separatorWidth := 3    // " │ " separator
-
-	// Use remaining space for value column
-	valueColumnWidth := availableWidth - countColumnWidth - separatorWidth
-
-	// Find actual max value length in data
-	actualMaxValueLen := 0
-	for _, vc := range values {
-		if len(vc.Value) > actualMaxValueLen {
-			actualMaxValueLen = len(vc.Value)
+		if _, ok := scope[key]; ok {
+			continue
 		}
+		record[key] = value
 	}
+	return resource, scope, record
+}
 
-	// Use full available space for values, but ensure minimum readable width
-	maxValueLength := max(valueColumnWidth, 20) // Use full space available, minimum 20
-
-	// Table header
-	headerStyle := lipgloss.NewStyle().Foreground(ColorWhite).Bold(true)
-	header := fmt.Sprintf("%-*s │ %s", maxValueLength, "Value", "Count")
-	modal.WriteString(headerStyle.Render(header) + "\n")
-
-	// Divider line
-	dividerStyle := lipgloss.NewStyle().Foreground(ColorGray)
-	modal.WriteString(dividerStyle.Render(strings.Repeat("─", len(header))) + "\n")
-
-	// Display ALL values with counts in table format (no artificial limit - let scrolling handle it)
-	for _, vc := range values {
-		displayValue := vc.Value
-		if len(displayValue) > maxValueLength {
-			displayValue = displayValue[:maxValueLength-3] + "..."
-		}
-
-		// Calculate percentage
-		percentage := float64(vc.Count) * 100.0 / float64(entry.TotalCount)
-
-		// Style the value and count
-		valueStyle := lipgloss.NewStyle().Foreground(ColorBlue)
-		countStyle := lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
-
-		// Format with proper table alignment
-		line := fmt.Sprintf("%s │ %s",
-			valueStyle.Render(fmt.Sprintf("%-*s", maxValueLength, displayValue)),
-			countStyle.Render(fmt.Sprintf("%d (%.1f%%)", vc.Count, percentage)))
-
-		modal.WriteString(line + "\n")
+// wrapText wraps text to fit within the specified width, preferring to
+// break on spaces. Widths are measured per grapheme.
+func wrapText(text string, width int) []string {
+	if ansi.StringWidth(text) <= width {
+		return []string{text}
 	}
-
-	return modal.String()
+	return strings.Split(ansi.Wrap(text, width, ""), "\n")
 }
 
 // formatDuration formats a duration for user display
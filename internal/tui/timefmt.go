@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Default layouts for the log list timestamp column
+const (
+	DefaultTimeFormat     = "15:04:05"       // Entries from today
+	DefaultDateTimeFormat = "01-02 15:04:05" // Entries older than today
+)
+
+// Timestamp display modes for the log list
+const (
+	TimestampModeAbsolute = "absolute" // Wall-clock time
+	TimestampModeRelative = "relative" // Age relative to now ("2.3s ago")
+	TimestampModeDelta    = "delta"    // Offset from the previous entry ("+120ms")
+)
+
+// timestampModes is the cycle order for the timestamp mode toggle
+var timestampModes = []string{TimestampModeAbsolute, TimestampModeRelative, TimestampModeDelta}
+
+// SetTimestampMode selects how timestamps are shown in the log list.
+// Unknown modes fall back to absolute timestamps.
+func (m *DashboardModel) SetTimestampMode(mode string) {
+	m.timestampMode = TimestampModeAbsolute
+	for _, known := range timestampModes {
+		if strings.EqualFold(mode, known) {
+			m.timestampMode = known
+		}
+	}
+}
+
+// cycleTimestampMode switches to the next timestamp display mode
+func (m *DashboardModel) cycleTimestampMode() {
+	for i, mode := range timestampModes {
+		if mode == m.timestampMode {
+			m.timestampMode = timestampModes[(i+1)%len(timestampModes)]
+			return
+		}
+	}
+	m.timestampMode = TimestampModeAbsolute
+}
+
+// SetTimeDisplay configures the timezone and layouts used for log timestamps.
+// Empty layouts keep the defaults.
+func (m *DashboardModel) SetTimeDisplay(useUTC bool, timeFormat, dateTimeFormat string) {
+	m.useUTC = useUTC
+	if timeFormat != "" {
+		m.timeFormat = timeFormat
+	}
+	if dateTimeFormat != "" {
+		m.dateTimeFormat = dateTimeFormat
+	}
+}
+
+// inDisplayZone converts a time to the selected display timezone
+func (m *DashboardModel) inDisplayZone(t time.Time) time.Time {
+	if m.useUTC {
+		return t.UTC()
+	}
+	return t.Local()
+}
+
+// setRelativeBase records the timestamp of the entry preceding index i in a
+// list so delta mode can show the gap between consecutive lines
+func (m *DashboardModel) setRelativeBase(entries []LogEntry, i int) {
+	m.relativeBase = time.Time{}
+	if i > 0 && i <= len(entries) {
+		m.relativeBase = m.getDisplayTimestamp(entries[i-1])
+	}
+}
+
+// formatListTimestamp formats an entry's timestamp for the log list. Entries
+// from before today (in the display timezone) include the date, since a bare
+// time of day is ambiguous across midnight; today's are padded to the same
+// width so the columns after it stay aligned when a view spans days. In the
+// relative and delta modes the result is padded to the width of the default
+// layout.
+func (m *DashboardModel) formatListTimestamp(entry LogEntry) string {
+	switch m.timestampMode {
+	case TimestampModeRelative:
+		return fmt.Sprintf("%8s", formatAgo(time.Since(m.getDisplayTimestamp(entry))))
+	case TimestampModeDelta:
+		if m.relativeBase.IsZero() {
+			return fmt.Sprintf("%8s", "+0")
+		}
+		return fmt.Sprintf("%8s", formatDelta(m.getDisplayTimestamp(entry).Sub(m.relativeBase)))
+	}
+
+	t := m.inDisplayZone(m.getDisplayTimestamp(entry))
+	now := m.inDisplayZone(time.Now())
+
+	ty, tm, td := t.Date()
+	ny, nm, nd := now.Date()
+	dated := t.Format(m.dateTimeFormat)
+	if ty == ny && tm == nm && td == nd {
+		return padToWidth(t.Format(m.timeFormat), ansi.StringWidth(dated))
+	}
+	return dated
+}
+
+// formatFullTimestamp formats a timestamp with date in the display timezone
+func (m *DashboardModel) formatFullTimestamp(t time.Time) string {
+	return m.inDisplayZone(t).Format("2006-01-02 15:04:05.000 MST")
+}
+
+// formatAgo renders an age compactly, e.g. "2.3s ago" or "5h ago"
+func formatAgo(d time.Duration) string {
+	if d < 0 {
+		return "now"
+	}
+	switch {
+	case d < 10*time.Second:
+		return fmt.Sprintf("%.1fs ago", d.Seconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// formatDelta renders the gap to the previous entry, e.g. "+120ms" or "+1m05s".
+// Out-of-order entries get a "-" sign.
+func formatDelta(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%s%dms", sign, d.Milliseconds())
+	case d < 10*time.Second:
+		return fmt.Sprintf("%s%.2fs", sign, d.Seconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%s%.1fs", sign, d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%s%dm%02ds", sign, int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%s%dh%02dm", sign, int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%s%dd%02dh", sign, int(d.Hours()/24), int(d.Hours())%24)
+	}
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// tutorialStep is one card of the guided tour. When focus is set the
+// dashboard moves to that section so the card points at a live panel.
+type tutorialStep struct {
+	title string
+	body  string
+	focus *Section
+}
+
+// sectionRef returns a pointer to a section constant for tutorial steps
+func sectionRef(s Section) *Section {
+	return &s
+}
+
+// tutorialSteps walks through the panels, filtering, the Kubernetes modal
+// and the analysis views
+var tutorialSteps = []tutorialStep{
+	{
+		title: "Welcome to Gonzo!",
+		body: "Logs stream into a live dashboard: charts on top, the log list below. " +
+			"This short tour shows where everything is.",
+	},
+	{
+		title: "Panels",
+		body: "Tab / Shift+Tab moves between Words, Attributes, Log Patterns, Counts and Logs, " +
+			"or click a panel. ↑/↓ selects an item and Enter opens its details. " +
+			"M maximizes the log list and [ / ] resize the charts.",
+		focus: sectionRef(SectionWords),
+	},
+	{
+		title: "Filtering",
+		body: "/ filters with a regex and s highlights a search term (Tab toggles fuzzy). " +
+			"Ctrl+f picks severities, and + / - include or exclude by a field of the selected entry. " +
+			"Applied filters appear as chips: 1-9 removes one, ESC clears them all.",
+	},
+	{
+		title: "Kubernetes",
+		body: "With --k8s-enabled, Ctrl+k opens the namespace and pod picker. " +
+			"Streams follow your selection live, and c switches the log columns to namespace/pod.",
+	},
+	{
+		title: "Analysis",
+		body: "Enter on the Counts panel shows the heatmap with patterns and services by severity, " +
+			"Enter on Log Patterns lists every Drain3 pattern, and i opens the statistics. " +
+			"In an entry's details, i asks the AI model about it, and A asks it about the whole filtered view.",
+		focus: sectionRef(SectionCounts),
+	},
+	{
+		title: "Log List",
+		body: "Scrolling up pauses auto-scroll and End resumes it. Space pauses the whole dashboard, " +
+			"f opens the fullscreen viewer, p pins an entry and R finds related entries.",
+		focus: sectionRef(SectionLogs),
+	},
+	{
+		title: "You're All Set",
+		body:  "Press ? at any time for every shortcut, and t in the help screen to take this tour again.",
+	},
+}
+
+// SetShowTutorial sets whether the guided tour is shown on startup
+func (m *DashboardModel) SetShowTutorial(show bool) {
+	if show {
+		m.openTutorial()
+	} else {
+		m.showTutorial = false
+	}
+}
+
+// openTutorial starts the guided tour from the first step
+func (m *DashboardModel) openTutorial() {
+	m.showHelp = false
+	m.showTutorial = true
+	m.tutorialStep = 0
+	m.focusTutorialStep()
+}
+
+// focusTutorialStep moves to the section the current step is about, when
+// that section is shown
+func (m *DashboardModel) focusTutorialStep() {
+	focus := tutorialSteps[m.tutorialStep].focus
+	if focus == nil {
+		return
+	}
+	for _, section := range m.navigableSections() {
+		if section == *focus {
+			m.activeSection = section
+			return
+		}
+	}
+}
+
+// handleTutorialKeys processes keyboard input while the tour is shown
+func (m *DashboardModel) handleTutorialKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "escape", "esc", "q":
+		m.showTutorial = false
+	case "right", "l", "enter", " ", "tab":
+		if m.tutorialStep == len(tutorialSteps)-1 {
+			m.showTutorial = false
+			return m, nil
+		}
+		m.tutorialStep++
+		m.focusTutorialStep()
+	case "left", "h", "backspace", "shift+tab":
+		if m.tutorialStep > 0 {
+			m.tutorialStep--
+			m.focusTutorialStep()
+		}
+	}
+	return m, nil
+}
+
+// renderTutorialOverlay draws the current tour card over the dashboard
+func (m *DashboardModel) renderTutorialOverlay() string {
+	step := tutorialSteps[m.tutorialStep]
+	cardWidth := min(m.width-4, 60)
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Render(step.title)
+	progress := lipgloss.NewStyle().
+		Foreground(ColorGray).
+		Render(fmt.Sprintf("%d/%d", m.tutorialStep+1, len(tutorialSteps)))
+	titleGap := max(1, cardWidth-4-lipgloss.Width(header)-lipgloss.Width(progress))
+
+	hint := "→/Enter: Next • ←: Back • ESC: Skip tour"
+	if m.tutorialStep == len(tutorialSteps)-1 {
+		hint = "Enter: Start exploring • ←: Back"
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		header+strings.Repeat(" ", titleGap)+progress,
+		"",
+		lipgloss.NewStyle().Width(cardWidth-4).Render(step.body),
+		"",
+		lipgloss.NewStyle().Foreground(ColorGray).Render(hint),
+	)
+
+	card := lipgloss.NewStyle().
+		Width(cardWidth).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(content)
+
+	x := (m.width - lipgloss.Width(card)) / 2
+	y := (m.height - lipgloss.Height(card)) / 2
+	return overlayAt(m.renderDashboard(), card, x, y)
+}
+
+// overlayAt draws foreground over background with its top-left corner at
+// column x, line y, keeping the background visible around it
+func overlayAt(background, foreground string, x, y int) string {
+	bgLines := strings.Split(background, "\n")
+	for i, fgLine := range strings.Split(foreground, "\n") {
+		row := y + i
+		if row < 0 || row >= len(bgLines) {
+			continue
+		}
+		bgLine := bgLines[row]
+		left := ansi.Truncate(bgLine, x, "")
+		left += strings.Repeat(" ", max(0, x-ansi.StringWidth(left)))
+		right := ansi.TruncateLeft(bgLine, x+ansi.StringWidth(fgLine), "")
+		bgLines[row] = left + "\x1b[0m" + fgLine + "\x1b[0m" + right
+	}
+	return strings.Join(bgLines, "\n")
+}
@@ -65,10 +65,36 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Continue periodic ticks
-		return m, tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
+		// Continue periodic ticks, running the actions of alerts that fired
+		m.checkSLOs()
+		tick := tea.Tick(m.updateInterval, func(t time.Time) tea.Msg {
 			return TickMsg(t)
 		})
+		if alertCmd := m.checkAlerts(); alertCmd != nil {
+			return m, tea.Batch(tick, alertCmd)
+		}
+		return m, tick
+
+	case ExportDoneMsg:
+		return m.handleExportDone(msg)
+
+	case ExternalViewerDoneMsg:
+		if msg.Err != nil {
+			m.showBulkResult(fmt.Sprintf("External Viewer Failed\n\n%v\n\nSet $PAGER or $EDITOR to choose the program.", msg.Err))
+		}
+		return m, nil
+
+	case LinkOpenedMsg:
+		if msg.Err != nil {
+			m.showBulkResult(fmt.Sprintf("Open Link Failed\n\n%s\n\n%v\n\nSet $BROWSER to choose the program.", msg.URL, msg.Err))
+		}
+		return m, nil
+
+	case aiFilterMsg:
+		return m.handleAIFilter(msg)
+
+	case aiStreamMsg:
+		return m.handleAIStream(msg)
 
 	case AIAnalysisMsg:
 		if msg.IsChat {
@@ -117,6 +143,11 @@ func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleMouseEvent processes mouse interactions
 func (m *DashboardModel) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	// Ignore the mouse while the guided tour is shown
+	if m.showTutorial {
+		return m, nil
+	}
+
 	// Handle mouse events in modals
 	if m.showModal {
 		return m.handleModalMouseEvent(msg)
@@ -137,6 +168,11 @@ func (m *DashboardModel) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd)
 		return m.handlePatternsModalMouseEvent(msg)
 	}
 	
+	// Handle mouse events in the slow entries modal
+	if m.showSlowModal {
+		return m.handleSlowModalMouseEvent(msg)
+	}
+
 	// Handle mouse events in statistics modal
 	if m.showStatsModal {
 		return m.handleStatsModalMouseEvent(msg)
@@ -147,6 +183,61 @@ func (m *DashboardModel) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd)
 		return m.handleCountsModalMouseEvent(msg)
 	}
 	
+	// Handle mouse events in error correlation modal
+	if m.showCorrelationModal {
+		return m.handleCorrelationModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in related entries modal
+	if m.showRelatedModal {
+		return m.handleRelatedModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in sessions modal
+	if m.showSessionsModal {
+		return m.handleSessionsModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in attribute values modal
+	if m.showAttrValuesModal {
+		return m.handleAttributeValuesModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in table view
+	if m.showTableModal {
+		return m.handleTableModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in service heatmap
+	if m.showSvcHeatmapModal {
+		return m.handleServiceHeatmapModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in query results
+	if m.showQueryModal {
+		return m.handleQueryModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in gonzo logs modal
+	if m.showDiagLogModal {
+		return m.handleDiagLogModalMouseEvent(msg)
+	}
+
+	// Handle mouse events in the unparsed view
+	if m.showUnparsedModal {
+		return m.handleUnparsedModalMouseEvent(msg)
+	}
+
+	// The sources modal does not scroll with the mouse
+	if m.showSourcesModal {
+		return m, nil
+	}
+
+	// Ignore mouse events while the export or go to prompt is open
+	if m.showExportPrompt || m.showGotoPrompt || m.showExprPrompt || m.showWatchPrompt || m.showMarkerPrompt {
+		return m, nil
+	}
+
 	// Handle mouse events in log viewer modal
 	if m.showLogViewerModal {
 		return m.handleLogViewerModalMouseEvent(msg)
@@ -309,7 +400,7 @@ func (m *DashboardModel) handleModalClick(x, _ int) (tea.Model, tea.Cmd) {
 			// Check if AI is configured before enabling chat
 			if !m.aiConfigured {
 				// Show error in chat area instead of enabling chat
-				chatError := fmt.Sprintf("AI Chat Not Available\n\nError: %s\n\nTo configure AI:\n• Set OPENAI_API_KEY environment variable\n• For local AI: Set OPENAI_API_BASE\n• Use --ai-model flag to specify model", m.aiErrorMessage)
+				chatError := fmt.Sprintf("AI Chat Not Available\n\nError: %s\n\nTo configure AI:\n• Set OPENAI_API_KEY environment variable\n• For local AI (Ollama, vLLM, LM Studio): use --ai-base-url, no key needed\n• Use --ai-model flag to specify model", m.aiErrorMessage)
 				m.chatHistory = []string{fmt.Sprintf("System: %s", chatError)}
 				m.chatAutoScroll = true  // Enable auto-scroll for error message
 				return m, nil
@@ -478,11 +569,13 @@ func (m *DashboardModel) handleLogViewerModalMouseEvent(msg tea.MouseMsg) (tea.M
 				if m.selectedLogIndex > 0 {
 					m.selectedLogIndex--
 				}
+				m.syncLogFollow()
 			} else {
 				// Normal: wheel up goes to later logs (like scrolling list up)
 				if m.selectedLogIndex < len(m.logEntries)-1 {
 					m.selectedLogIndex++
 				}
+				m.syncLogFollow()
 			}
 			return m, nil
 
@@ -493,11 +586,13 @@ func (m *DashboardModel) handleLogViewerModalMouseEvent(msg tea.MouseMsg) (tea.M
 				if m.selectedLogIndex < len(m.logEntries)-1 {
 					m.selectedLogIndex++
 				}
+				m.syncLogFollow()
 			} else {
 				// Normal: wheel down goes to earlier logs (like scrolling list down)
 				if m.selectedLogIndex > 0 {
 					m.selectedLogIndex--
 				}
+				m.syncLogFollow()
 			}
 			return m, nil
 		}
@@ -510,36 +605,24 @@ func (m *DashboardModel) handleLogViewerModalMouseEvent(msg tea.MouseMsg) (tea.M
 // handleMouseClick processes mouse clicks to switch between sections
 func (m *DashboardModel) handleMouseClick(x, y int) (tea.Model, tea.Cmd) {
 	// Calculate section boundaries based on screen layout
-	// The dashboard uses a 2x2 grid layout with logs at the bottom
+	// The dashboard shows rows of charts with logs at the bottom
 
 	if m.width <= 0 || m.height <= 0 {
 		return m, nil
 	}
 
-	// Calculate grid dimensions
-	gridWidth := m.width / 2
-	gridHeight := m.calculateRequiredChartsHeight() / 2
-
-	// Define section boundaries (approximate)
-	switch {
-	case x < gridWidth && y < gridHeight:
-		// Top-left: Words section
-		m.activeSection = SectionWords
-
-	case x >= gridWidth && y < gridHeight:
-		// Top-right: Attributes section
-		m.activeSection = SectionAttributes
-
-	case x < gridWidth && y >= gridHeight && y < gridHeight*2:
-		// Bottom-left: Distribution/Patterns section
-		m.activeSection = SectionDistribution
-
-	case x >= gridWidth && y >= gridHeight && y < gridHeight*2:
-		// Bottom-right: Counts section
-		m.activeSection = SectionCounts
+	// Clicking a filter chip (rendered directly below the charts) removes it
+	if y == m.calculateRequiredChartsHeight() && len(m.activeFilterChips()) > 0 {
+		if idx := m.filterChipAt(x); idx >= 0 {
+			m.removeFilterChip(idx)
+		}
+		return m, nil
+	}
 
-	case y >= gridHeight*2:
-		// Bottom area: Logs section
+	// Charts are laid out in rows; everything below them is the log area
+	if section, ok := m.chartSectionAt(x, y); ok {
+		m.activeSection = section
+	} else {
 		m.activeSection = SectionLogs
 	}
 
@@ -555,11 +638,7 @@ func (m *DashboardModel) handleUpdate(msg UpdateMsg) (tea.Model, tea.Cmd) {
 
 	// Handle batch log entries
 	if len(msg.NewLogBatch) > 0 {
-		for _, entry := range msg.NewLogBatch {
-			if entry != nil {
-				m.addLogEntry(*entry)
-			}
-		}
+		m.addLogBatch(msg.NewLogBatch)
 	}
 
 	// Only update dashboard data when not paused
@@ -576,10 +655,12 @@ func (m *DashboardModel) handleUpdate(msg UpdateMsg) (tea.Model, tea.Cmd) {
 				counts = &SeverityCounts{}
 			}
 			m.countsHistory = append(m.countsHistory, *counts)
+			m.countsIntervals++
 			// Keep only last 50 data points
 			if len(m.countsHistory) > 50 {
 				m.countsHistory = m.countsHistory[1:]
 			}
+			m.detectSpike(time.Now())
 			// Chart data updated in view rendering
 		}
 	}
@@ -602,8 +683,69 @@ func (m *DashboardModel) handleUpdate(msg UpdateMsg) (tea.Model, tea.Cmd) {
 
 // addLogEntry adds a new log entry to the buffer
 func (m *DashboardModel) addLogEntry(entry LogEntry) {
+	m.addLogBatch([]*LogEntry{&entry})
+}
+
+// addLogBatch adds entries to the buffer as one step, updating the filtered
+// view once for the whole batch
+func (m *DashboardModel) addLogBatch(entries []*LogEntry) {
+	added := make([]LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		if buffered, ok := m.bufferLogEntry(*entry); ok {
+			added = append(added, buffered)
+		}
+	}
+	if len(added) == 0 || m.viewPaused {
+		return
+	}
+	// A batch larger than the buffer evicts its own first entries
+	if len(added) > len(m.allLogEntries) {
+		added = added[len(added)-len(m.allLogEntries):]
+	}
+
+	// Update filtered view with the new entries only
+	m.appendFilteredView(added)
+
+	// Count arrivals the user hasn't scrolled to while tailing is paused
+	if !m.logAutoScroll {
+		for _, entry := range added {
+			if m.passesFilters(entry) {
+				m.unreadLogCount++
+			}
+		}
+	}
+	m.seekResumeAnchor(added)
+}
+
+// bufferLogEntry appends an entry to the complete buffer and updates the
+// statistics, returning the entry as buffered and whether the buffer took it
+func (m *DashboardModel) bufferLogEntry(entry LogEntry) (LogEntry, bool) {
+	// A full buffer under the stop policy takes no more entries
+	if m.rejectEntry(entry) {
+		return entry, false
+	}
+
+	// Number entries in arrival order; attached TUIs keep the server's numbers
+	if entry.Seq == 0 {
+		m.lastSeq++
+		entry.Seq = m.lastSeq
+	} else {
+		m.lastSeq = max(m.lastSeq, entry.Seq)
+	}
+
+	// Flag numeric attributes far outside their rolling distribution
+	entry.Outlier = m.outliers.observe(entry)
+
 	// Always add to the complete unfiltered buffer
 	m.allLogEntries = append(m.allLogEntries, entry)
+	m.bufferBytes += entrySize(entry)
+	m.searchIndex.add(entry)
+	m.columns.add(entry)
+	m.observeSource(entry)
+	m.scopesSeen = m.scopesSeen || entry.Attributes[ScopeNameAttribute] != ""
 	
 	// Update statistics tracking
 	m.statsTotalLogsEver++  // Track total logs processed (unlimited)
@@ -617,93 +759,200 @@ func (m *DashboardModel) addLogEntry(entry LogEntry) {
 	
 	// Update services data for counts modal (patterns will be derived from drain3)
 	m.updateCountsModalServices(entry)
+
+	// Count the entry against the alert rules, SLOs and watches
+	m.observeAlerts(entry)
+	m.observeSLOs(entry)
+	m.observeWatches(entry)
+
+	// Track its duration for the latency percentiles, its pattern for the
+	// new patterns feed and its status for the HTTP pane
+	m.latency.observe(entry, time.Now())
+	m.novelty.observe(entry, time.Now())
+	m.httpFacet.observe(entry, m.latency, time.Now())
 	
 	// Track logs for the current second
 	m.statsLogsThisSecond++
 
 	// Maintain buffer size for complete buffer
-	if len(m.allLogEntries) > m.maxLogBuffer {
-		m.allLogEntries = m.allLogEntries[1:]
-		// Adjust drain3 tracking if we removed an entry
-		if m.drain3LastProcessed > 0 {
-			m.drain3LastProcessed--
-		}
+	m.trimBuffer()
+
+	// Only process through drain3 when not paused
+	if !m.viewPaused && m.drain3Manager != nil {
+		m.drain3Manager.AddLogMessage(entry.Message)
+		m.drain3LastProcessed = len(m.allLogEntries) // Track that we've processed up to here
 	}
+	return entry, true
+}
 
-	// Only process through drain3 and update view when not paused
-	if !m.viewPaused {
-		// Process through drain3 for pattern extraction
-		if m.drain3Manager != nil {
-			m.drain3Manager.AddLogMessage(entry.Message)
-			m.drain3LastProcessed = len(m.allLogEntries) // Track that we've processed up to here
-		}
+// viewSelection remembers the selected entries of the filtered view so they
+// stay selected while it changes
+type viewSelection struct {
+	index    int
+	entry    *LogEntry // Selected entry while tailing is paused
+	anchor   int
+	anchored *LogEntry // Anchor entry of the visual selection
+}
+
+// saveSelection remembers the selection of the filtered view
+func (m *DashboardModel) saveSelection() viewSelection {
+	sel := viewSelection{index: m.selectedLogIndex, anchor: m.selectionAnchor}
+	if !m.logAutoScroll && sel.index >= 0 && sel.index < len(m.logEntries) {
+		entry := m.logEntries[sel.index]
+		sel.entry = &entry
+	}
+	if m.visualMode && sel.anchor >= 0 && sel.anchor < len(m.logEntries) {
+		entry := m.logEntries[sel.anchor]
+		sel.anchored = &entry
+	}
+	return sel
+}
 
-		// Update filtered view
+// restoreSelection selects the remembered entries in the changed view
+func (m *DashboardModel) restoreSelection(sel viewSelection) {
+	m.restoreLogSelection(sel.index, sel.entry)
+	if m.visualMode {
+		m.restoreSelectionAnchor(sel.anchor, sel.index, sel.anchored)
+	}
+}
+
+// appendFilteredView brings the filtered view up to date after entries were
+// buffered, without rescanning the buffer: evicted entries leave its front
+// and the new ones passing the filters join its end. Rows read back from
+// disk need the full rebuild.
+func (m *DashboardModel) appendFilteredView(added []LogEntry) {
+	if m.cold != nil || m.historyShown || len(m.allLogEntries) == 0 {
 		m.updateFilteredView()
+		return
+	}
+	sel := m.saveSelection()
+
+	oldest := m.allLogEntries[0].Seq
+	evicted := 0
+	for evicted < len(m.logEntries) && m.logEntries[evicted].Seq < oldest {
+		evicted++
 	}
+	m.logEntries = m.logEntries[evicted:]
+
+	for _, entry := range added {
+		if entry.Seq >= oldest && m.passesFilters(entry) {
+			m.logEntries = append(m.logEntries, entry)
+		}
+	}
+
+	m.restoreSelection(sel)
 }
 
 // updateFilteredView regenerates the filtered log entries view
 func (m *DashboardModel) updateFilteredView() {
-	oldSelection := m.selectedLogIndex
+	sel := m.saveSelection()
 
 	// Clear current filtered view
 	m.logEntries = m.logEntries[:0]
 
-	// Apply filter to all entries
-	for _, entry := range m.allLogEntries {
-		// Check regex filter (if any) - search in message, attributes keys, and attribute values
-		passesRegexFilter := m.filterRegex == nil || m.matchesFilter(entry)
-
-		// Check severity filter (if active)
-		// Normalize severity to match filter keys
-		normalizedSeverity := normalizeSeverityLevel(entry.Severity)
-		passesSeverityFilter := !m.severityFilterActive || m.severityFilter[normalizedSeverity]
-
-		// Check K8s filter (if active)
-		// Note: When using K8s source, filtering is applied at the source level,
-		// so logs from unselected pods won't arrive here at all.
-		// This display-side filter is kept as a fallback for edge cases.
-		passesK8sFilter := true
-		if m.k8sFilterActive && m.k8sSource == nil {
-			// Only apply display-side filtering if we don't have a K8s source
-			// (e.g., when reading k8s logs from stdin/file)
-			ns, hasNs := entry.Attributes["k8s.namespace"]
-			pod, hasPod := entry.Attributes["k8s.pod"]
-
-			// If entry has K8s attributes, apply filtering
-			if hasNs || hasPod {
-				// Default to not passing if we have K8s attributes
-				passesK8sFilter = false
-
-				// Check namespace filter - must be selected
-				if hasNs && m.k8sNamespaces[ns] {
-					passesK8sFilter = true
-
-					// Also check pod filter if pod attribute exists
-					if hasPod {
-						// Build namespace/pod key for lookup
-						podKey := ns + "/" + pod
-						// Check if this specific pod is selected
-						passesK8sFilter = m.k8sPods[podKey]
-					}
-				}
+	// Entries read back from disk come first, after those from before the
+	// first entry received while they lead up to it; past the newest of
+	// them, the buffer only follows when they lead up to it
+	if m.historyShown && m.atFirstEntry() {
+		for _, entry := range m.history {
+			if m.passesFilters(entry) {
+				m.logEntries = append(m.logEntries, entry)
 			}
-			// If no K8s attributes, let it pass (non-K8s logs)
 		}
-
-		// Include entry only if it passes all filters
-		if passesRegexFilter && passesSeverityFilter && passesK8sFilter {
+	}
+	for _, entry := range m.cold {
+		if m.passesFilters(entry) {
 			m.logEntries = append(m.logEntries, entry)
 		}
 	}
+	if m.cold != nil && !m.coldAdjacent {
+		m.restoreSelection(sel)
+		return
+	}
+
+	// Narrow the regex filter down through the search index when possible
+	// so only candidate entries are checked, otherwise scan the whole buffer
+	var positions []int
+	indexed := false
+	if m.filterRegex != nil {
+		positions, indexed = m.searchIndex.candidates(m.filterRegex)
+	}
+
+	// Severity and Kubernetes filters are a scan over the typed columns, so
+	// only the entries they keep are looked at; so are the numeric
+	// comparisons a filter expression requires
+	keep := m.columns.scan(m.columnFilter())
+	if m.filterExpr != nil {
+		keep = m.columns.scanBounds(keep, m.filterExpr.Bounds(), m.allLogEntries)
+	}
+
+	if indexed {
+		for _, pos := range positions {
+			if keep.keeps(pos) && m.passesEntryFilters(m.allLogEntries[pos]) {
+				m.logEntries = append(m.logEntries, m.allLogEntries[pos])
+			}
+		}
+	} else {
+		for pos, entry := range m.allLogEntries {
+			if keep.keeps(pos) && m.passesEntryFilters(entry) {
+				m.logEntries = append(m.logEntries, entry)
+			}
+		}
+	}
+
+	m.restoreSelection(sel)
+}
 
+// restoreSelectionAnchor keeps the visual selection anchored on the same entry.
+// An anchor that is no longer visible was usually trimmed from the front of
+// the buffer, so the selection then extends to the first entry.
+func (m *DashboardModel) restoreSelectionAnchor(oldAnchor, oldSelection int, anchor *LogEntry) {
+	if anchor != nil {
+		if i := m.findEntryNear(*anchor, oldAnchor); i >= 0 {
+			m.selectionAnchor = i
+			return
+		}
+	}
+	if oldAnchor <= oldSelection {
+		m.selectionAnchor = 0
+	} else {
+		m.selectionAnchor = m.selectedLogIndex
+	}
+}
+
+// findEntryNear returns the index of entry in the filtered view, searching
+// backwards from hint first since entries only move towards the front as
+// the buffer trims. It returns -1 when the entry is not visible.
+func (m *DashboardModel) findEntryNear(entry LogEntry, hint int) int {
+	for i := min(hint, len(m.logEntries)-1); i >= 0; i-- {
+		if samePinnedEntry(m.logEntries[i], entry) {
+			return i
+		}
+	}
+	// A changed filter may have moved it further down
+	for i := hint + 1; i < len(m.logEntries); i++ {
+		if samePinnedEntry(m.logEntries[i], entry) {
+			return i
+		}
+	}
+	return -1
+}
+
+// restoreLogSelection updates the selection after the filtered view changed
+func (m *DashboardModel) restoreLogSelection(oldSelection int, selected *LogEntry) {
 	// Update selection based on auto-scroll setting
 	if m.logAutoScroll {
 		// Auto-scroll enabled: always go to latest entry
 		m.selectedLogIndex = max(0, len(m.logEntries)-1)
 	} else {
-		// Auto-scroll disabled: try to maintain current position
+		// Auto-scroll disabled: keep the same entry selected
+		if selected != nil {
+			if i := m.findEntryNear(*selected, oldSelection); i >= 0 {
+				m.selectedLogIndex = i
+				return
+			}
+		}
+		// Fall back to maintaining the current position
 		if oldSelection < len(m.logEntries) {
 			m.selectedLogIndex = oldSelection
 		} else {
@@ -712,6 +961,79 @@ func (m *DashboardModel) updateFilteredView() {
 	}
 }
 
+// passesFilters reports whether an entry passes all active view filters
+func (m *DashboardModel) passesFilters(entry LogEntry) bool {
+	return m.passesColumnFilters(entry) && m.passesEntryFilters(entry)
+}
+
+// passesColumnFilters reports whether an entry passes the severity and
+// Kubernetes filters, which columnStore.scan evaluates for the buffer
+func (m *DashboardModel) passesColumnFilters(entry LogEntry) bool {
+	// Check severity filter (if active)
+	// Normalize severity to match filter keys
+	normalizedSeverity := normalizeSeverityLevel(entry.Severity)
+	passesSeverityFilter := !m.severityFilterActive || m.severityFilter[normalizedSeverity]
+
+	// Check K8s filter (if active)
+	// Note: When using K8s source, filtering is applied at the source level,
+	// so logs from unselected pods won't arrive here at all.
+	// This display-side filter is kept as a fallback for edge cases.
+	passesK8sFilter := true
+	if m.k8sFilterActive && m.k8sSource == nil {
+		// Only apply display-side filtering if we don't have a K8s source
+		// (e.g., when reading k8s logs from stdin/file)
+		ns, hasNs := entry.Attributes["k8s.namespace"]
+		pod, hasPod := entry.Attributes["k8s.pod"]
+
+		// If entry has K8s attributes, apply filtering
+		if hasNs || hasPod {
+			// Default to not passing if we have K8s attributes
+			passesK8sFilter = false
+
+			// Check namespace filter - must be selected
+			if hasNs && m.k8sNamespaces[ns] {
+				passesK8sFilter = true
+
+				// Also check pod filter if pod attribute exists
+				if hasPod {
+					// Build namespace/pod key for lookup
+					podKey := ns + "/" + pod
+					// Check if this specific pod is selected
+					passesK8sFilter = m.k8sPods[podKey]
+				}
+			}
+		}
+		// If no K8s attributes, let it pass (non-K8s logs)
+	}
+
+	return passesSeverityFilter && passesK8sFilter
+}
+
+// passesEntryFilters reports whether an entry passes the filters that need
+// the whole entry
+func (m *DashboardModel) passesEntryFilters(entry LogEntry) bool {
+	// Check regex filter (if any) - search in message, attributes keys, and attribute values
+	passesRegexFilter := m.filterRegex == nil || m.matchesFilter(entry)
+
+	// Check include/exclude attribute filters (if any)
+	passesAttributeFilter := m.passesAttributeFilters(entry)
+
+	// Check filter expression (if any)
+	passesExprFilter := m.filterExpr == nil || m.filterExpr.Match(m.filterRecord(entry))
+
+	// Check outlier toggle (if on)
+	passesOutlierFilter := !m.outliersOnly || entry.Outlier != ""
+
+	// Check sampled traces toggle (if on)
+	passesSampledFilter := !m.sampledOnly || isSampled(entry)
+
+	// Check 5xx and slow request toggles (if on)
+	passesHTTPFilter := m.passesHTTPFilters(entry)
+
+	// Include entry only if it passes all filters
+	return passesRegexFilter && passesAttributeFilter && passesExprFilter && passesOutlierFilter && passesSampledFilter && passesHTTPFilter && m.passesDrillDown(entry) && m.passesPodZoom(entry)
+}
+
 // initializeCharts sets up the charts based on current dimensions
 func (m *DashboardModel) initializeCharts() {
 	if m.width <= 0 || m.height <= 0 {
@@ -767,8 +1089,8 @@ func (m *DashboardModel) updateLifetimeStats(entry LogEntry) {
 			// Remove common punctuation
 			word = strings.Trim(word, ".,!?;:()[]{}\"'")
 			// Check minimum length and stopwords filter
-			if len(word) >= 3 && !m.stopWords[word] {
-				m.lifetimeWordCounts[word]++
+			if counted, ok := m.countedWord(word); ok {
+				m.lifetimeWordCounts[counted]++
 			}
 		}
 	}
@@ -859,10 +1181,8 @@ func (m *DashboardModel) getDisplayTimestamp(entry LogEntry) time.Time {
 // rebuildHeatmap clears and rebuilds the heatmap from all log entries
 // Used when toggling timestamp mode to recalculate with the new timestamp source
 func (m *DashboardModel) rebuildHeatmap() {
-	m.heatmapData = make([]HeatmapMinute, 0)
-	for _, entry := range m.allLogEntries {
-		m.updateHeatmapData(entry)
-	}
+	m.heatmapData = m.columns.heatmap(m.useLogTime, time.Now().Add(-6*time.Hour))
+	m.heatmapIndex = nil
 }
 
 // updateHeatmapData updates the minute-by-minute heatmap data for the counts modal
@@ -870,63 +1190,48 @@ func (m *DashboardModel) updateHeatmapData(entry LogEntry) {
 	// Use getDisplayTimestamp to respect the useLogTime setting
 	// This allows users to choose between log time and receive time
 	entryTime := m.getDisplayTimestamp(entry).Truncate(time.Minute)
-	
+
+	// Keep a larger window of data (6 hours) to accommodate logs with older timestamps
+	// The actual 60-minute window filtering will be done during display
+	now := time.Now()
+	m.pruneHeatmap(now)
+
 	// Find or create the heatmap minute entry
-	var targetMinute *HeatmapMinute
-	
-	// Look for existing minute entry
-	for i := range m.heatmapData {
-		if m.heatmapData[i].Timestamp.Equal(entryTime) {
-			targetMinute = &m.heatmapData[i]
-			break
-		}
-	}
-	
-	// If not found, create new minute entry
-	if targetMinute == nil {
-		newMinute := HeatmapMinute{
-			Timestamp: entryTime,
-			Counts:    SeverityCounts{},
+	pos, ok := m.heatmapIndex[entryTime.UnixNano()]
+	if !ok {
+		if !entryTime.After(now.Add(-6 * time.Hour)) {
+			return
 		}
-		m.heatmapData = append(m.heatmapData, newMinute)
-		targetMinute = &m.heatmapData[len(m.heatmapData)-1]
+		pos = len(m.heatmapData)
+		m.heatmapData = append(m.heatmapData, HeatmapMinute{Timestamp: entryTime})
+		m.heatmapIndex[entryTime.UnixNano()] = pos
 	}
-	
-	// Update the severity count for this minute
-	switch entry.Severity {
-	case "TRACE":
-		targetMinute.Counts.Trace++
-	case "DEBUG":
-		targetMinute.Counts.Debug++
-	case "INFO":
-		targetMinute.Counts.Info++
-	case "WARN", "WARNING":
-		targetMinute.Counts.Warn++
-	case "ERROR":
-		targetMinute.Counts.Error++
-	case "FATAL":
-		targetMinute.Counts.Fatal++
-	case "CRITICAL":
-		targetMinute.Counts.Critical++
-	default:
-		targetMinute.Counts.Unknown++
+
+	// Update the severity and total counts for this minute
+	m.heatmapData[pos].Counts.AddCount(entry.Severity)
+}
+
+// pruneHeatmap drops the minutes that left the 6 hour window, at most once a
+// minute, and indexes the rest by minute
+func (m *DashboardModel) pruneHeatmap(now time.Time) {
+	if m.heatmapIndex != nil && now.Sub(m.heatmapPruned) < time.Minute {
+		return
 	}
-	
-	// Update total count
-	targetMinute.Counts.Total++
-	
-	// Keep a larger window of data (6 hours) to accommodate logs with older timestamps
-	// The actual 60-minute window filtering will be done during display
-	cutoffTime := time.Now().Add(-6 * time.Hour)
-	filteredData := make([]HeatmapMinute, 0)
-	
+	m.heatmapPruned = now
+
+	cutoffTime := now.Add(-6 * time.Hour)
+	kept := m.heatmapData[:0]
 	for _, minute := range m.heatmapData {
 		if minute.Timestamp.After(cutoffTime) {
-			filteredData = append(filteredData, minute)
+			kept = append(kept, minute)
 		}
 	}
-	
-	m.heatmapData = filteredData
+	m.heatmapData = kept
+
+	m.heatmapIndex = make(map[int64]int, len(m.heatmapData))
+	for i, minute := range m.heatmapData {
+		m.heatmapIndex[minute.Timestamp.UnixNano()] = i
+	}
 }
 
 // updateCountsModalServices updates services data grouped by severity
@@ -937,7 +1242,7 @@ func (m *DashboardModel) updateCountsModalServices(entry LogEntry) {
 	}
 	
 	// Update service counts by severity
-	serviceName := getServiceName(entry)
+	serviceName := ServiceName(entry)
 	if serviceName != "" {
 		if m.servicesBySeverity[severity] == nil {
 			m.servicesBySeverity[severity] = make([]ServiceCount, 0)
@@ -970,8 +1275,8 @@ func (m *DashboardModel) updateCountsModalServices(entry LogEntry) {
 	}
 }
 
-// getServiceName extracts service name from log entry attributes
-func getServiceName(entry LogEntry) string {
+// ServiceName extracts service name from log entry attributes
+func ServiceName(entry LogEntry) string {
 	// Try different common service attribute names
 	if service, ok := entry.Attributes["service"]; ok {
 		return service
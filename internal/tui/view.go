@@ -4,11 +4,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// hasFilterOrSearch returns true if a filter or search is active or applied
-func (m *DashboardModel) hasFilterOrSearch() bool {
-	return m.filterActive || m.searchActive || 
-		m.filterRegex != nil || m.filterInput.Value() != "" || 
-		m.searchTerm != "" || m.searchInput.Value() != ""
+// isEditingFilterOrSearch returns true if the filter or search input is being edited
+func (m *DashboardModel) isEditingFilterOrSearch() bool {
+	return m.filterActive || m.searchActive
 }
 
 // View renders the dashboard
@@ -17,6 +15,11 @@ func (m *DashboardModel) View() string {
 		return "Initializing dashboard..."
 	}
 
+	// Show the guided tour over the dashboard
+	if m.showTutorial {
+		return m.renderTutorialOverlay()
+	}
+
 	// Show help modal
 	if m.showHelp {
 		return m.renderHelpModal()
@@ -27,6 +30,11 @@ func (m *DashboardModel) View() string {
 		return m.renderPatternsModal()
 	}
 	
+	// Show the slow entries of a latency row (opened from the statistics modal)
+	if m.showSlowModal {
+		return m.renderSlowModal()
+	}
+
 	// Show statistics modal
 	if m.showStatsModal {
 		return m.renderStatsModal()
@@ -47,6 +55,91 @@ func (m *DashboardModel) View() string {
 		return m.renderSeverityFilterModal()
 	}
 
+	// Show include/exclude filter picker
+	if m.showFilterPicker {
+		return m.renderFilterPickerModal()
+	}
+
+	// Show link picker
+	if m.showLinkPicker {
+		return m.renderLinkPickerModal()
+	}
+
+	// Show error correlation modal
+	if m.showCorrelationModal {
+		return m.renderCorrelationModal()
+	}
+
+	// Show related entries modal
+	if m.showRelatedModal {
+		return m.renderRelatedModal()
+	}
+
+	// Show sessions modal (after related entries, which drill down from it)
+	if m.showSessionsModal {
+		return m.renderSessionsModal()
+	}
+
+	// Show the values of an attribute from the attributes chart
+	if m.showAttrValuesModal {
+		return m.renderAttributeValuesModal()
+	}
+
+	// Show table view
+	if m.showTableModal {
+		return m.renderTableModal()
+	}
+
+	// Show service heatmap
+	if m.showSvcHeatmapModal {
+		return m.renderServiceHeatmapModal()
+	}
+
+	// Show query results
+	if m.showQueryModal {
+		return m.renderQueryModal()
+	}
+
+	// Show gonzo logs modal
+	if m.showDiagLogModal {
+		return m.renderDiagLogModal()
+	}
+
+	// Show sources modal
+	if m.showSourcesModal {
+		return m.renderSourcesModal()
+	}
+
+	// Show unparsed view
+	if m.showUnparsedModal {
+		return m.renderUnparsedModal()
+	}
+
+	// Show export prompt
+	if m.showExportPrompt {
+		return m.renderExportPrompt()
+	}
+
+	// Show go to entry prompt
+	if m.showGotoPrompt {
+		return m.renderGotoPrompt()
+	}
+
+	// Show marker prompt
+	if m.showMarkerPrompt {
+		return m.renderMarkerPrompt()
+	}
+
+	// Show watch prompt
+	if m.showWatchPrompt {
+		return m.renderWatchPrompt()
+	}
+
+	// Show filter expression prompt
+	if m.showExprPrompt {
+		return m.renderExprPrompt()
+	}
+
 	// Show log viewer modal (fullscreen log viewer)
 	if m.showLogViewerModal {
 		return m.renderLogViewerModal()
@@ -76,10 +169,14 @@ func (m *DashboardModel) renderDashboard() string {
 	// Calculate required space for charts dynamically
 	requiredChartsHeight := m.calculateRequiredChartsHeight()
 
-	// Filter/Search height depends on whether filter or search is applied (or being edited)
+	// Filter/Search height depends on whether filters are applied and/or being edited
 	filterHeight := 0 // No space when inactive
-	if m.hasFilterOrSearch() {
-		filterHeight = 1 // Single row for filter/search
+	hasChips := len(m.activeFilterChips()) > 0
+	if hasChips {
+		filterHeight++ // Single row for filter chips
+	}
+	if m.isEditingFilterOrSearch() {
+		filterHeight++ // Single row for filter/search input
 	}
 
 	// Reserve space for status line at bottom
@@ -87,7 +184,7 @@ func (m *DashboardModel) renderDashboard() string {
 
 	// Use full height for proper layout
 	usableHeight := m.height - statusLineHeight - 2 // Use full height minus status line (minus 2 because.. I have no idea why)
-	logsHeight := usableHeight - requiredChartsHeight - filterHeight
+	logsHeight := usableHeight - requiredChartsHeight - filterHeight - m.metricsPaneHeight() - m.sloPaneHeight() - m.watchesPaneHeight() - m.httpPaneHeight() - m.newPatternsPaneHeight() - m.pinnedPaneHeight()
 
 	// Final allocation - trust the math
 	chartsHeight := requiredChartsHeight
@@ -99,18 +196,54 @@ func (m *DashboardModel) renderDashboard() string {
 
 	// Layout calculations complete
 
-	// Top section: 2x2 grid of charts (VERY constrained height)
-	topSection := m.renderChartsGrid(chartsHeight)
+	// Top section: grid of the visible charts (VERY constrained height)
+	var sections []string
+	if chartsHeight > 0 {
+		sections = append(sections, m.renderChartsGrid(chartsHeight))
+	}
 
 	// Middle section: Filter (only when active)
-	var sections []string
-	sections = append(sections, topSection)
 
-	if m.hasFilterOrSearch() {
+	// Applied filters as removable chips directly under the charts
+	if hasChips {
+		sections = append(sections, m.renderFilterChips())
+	}
+
+	if m.isEditingFilterOrSearch() {
 		filterSection := m.renderFilter()
 		sections = append(sections, filterSection)
 	}
 
+	// Extracted metrics (only when metric rules are configured)
+	if m.metricsPaneVisible() {
+		sections = append(sections, m.renderMetricsPane())
+	}
+
+	// Error budgets (only when SLOs are configured)
+	if m.sloPaneVisible() {
+		sections = append(sections, m.renderSLOPane())
+	}
+
+	// Watch counters (only when watches are set)
+	if m.watchesPaneVisible() {
+		sections = append(sections, m.renderWatchesPane())
+	}
+
+	// HTTP status classes and top paths (only when requests were logged)
+	if m.httpPaneVisible() {
+		sections = append(sections, m.renderHTTPPane())
+	}
+
+	// Patterns first seen recently (only when there are any)
+	if m.newPatternsPaneVisible() {
+		sections = append(sections, m.renderNewPatternsPane())
+	}
+
+	// Pinned entries pane (only when something is pinned)
+	if m.pinnedPaneVisible() {
+		sections = append(sections, m.renderPinnedPane())
+	}
+
 	// Bottom section: Log scroll
 	logsSection := m.renderLogScroll(logsHeight)
 	sections = append(sections, logsSection)
@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+	"github.com/control-theory/gonzo/internal/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxWatchRows is the number of watches shown in the watches pane
+const maxWatchRows = 4
+
+// maxWatchNameWidth is the widest watch name shown before truncating
+const maxWatchNameWidth = 30
+
+// watchRecent is how long a watch stays highlighted after a match
+const watchRecent = 10 * time.Second
+
+// watchCounter counts the entries matching a watch expression
+type watchCounter struct {
+	name      string
+	filter    *filterexpr.Expr
+	spec      string // As typed, for the saved session; empty for configured watches
+	count     int64
+	last      time.Time // Timestamp of the latest match
+	matchedAt time.Time // When the latest match arrived
+}
+
+// SetWatches sets the watch expressions counted in the watches pane
+func (m *DashboardModel) SetWatches(watches []notify.Watch) {
+	m.watches = nil
+	for _, watch := range watches {
+		m.watches = append(m.watches, &watchCounter{name: watch.Name, filter: watch.Filter})
+	}
+}
+
+// observeWatches counts a new entry against the watches. Like the alert
+// rules, every arrival is counted whatever the view's filters.
+func (m *DashboardModel) observeWatches(entry LogEntry) {
+	if len(m.watches) == 0 {
+		return
+	}
+	rec := m.filterRecord(entry)
+	for _, watch := range m.watches {
+		if !watch.filter.Match(rec) {
+			continue
+		}
+		watch.count++
+		if rec.Time.After(watch.last) {
+			watch.last = rec.Time
+		}
+		watch.matchedAt = time.Now()
+	}
+}
+
+// parseAddedWatch parses a watch typed in the prompt, written as
+// [NAME:] FILTER
+func parseAddedWatch(spec string) (*watchCounter, error) {
+	watch, err := notify.ParseWatch(spec, "")
+	if err != nil {
+		return nil, err
+	}
+	if watch.URL != "" {
+		return nil, fmt.Errorf("invalid watch %q: webhooks can only be given with --watch", spec)
+	}
+	return &watchCounter{name: watch.Name, filter: watch.Filter, spec: strings.TrimSpace(spec)}, nil
+}
+
+// toggleWatch adds a watch written as [NAME:] FILTER, or removes the watch
+// with the same name
+func (m *DashboardModel) toggleWatch(spec string) error {
+	watch, err := parseAddedWatch(spec)
+	if err != nil {
+		return err
+	}
+	if !m.removeWatch(watch.name) {
+		m.watches = append(m.watches, watch)
+	}
+	return nil
+}
+
+// hasWatch reports whether a watch has the name
+func (m *DashboardModel) hasWatch(name string) bool {
+	for _, watch := range m.watches {
+		if watch.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// removeWatch removes the watch with the name, reporting whether there was one
+func (m *DashboardModel) removeWatch(name string) bool {
+	for i, watch := range m.watches {
+		if watch.name == name {
+			m.watches = append(m.watches[:i], m.watches[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// addedWatches returns the watches added with the prompt, as typed
+func (m *DashboardModel) addedWatches() []string {
+	var specs []string
+	for _, watch := range m.watches {
+		if watch.spec != "" {
+			specs = append(specs, watch.spec)
+		}
+	}
+	return specs
+}
+
+// openWatchPrompt asks for a watch to add or remove
+func (m *DashboardModel) openWatchPrompt() {
+	m.watchError = ""
+	m.watchInput.SetValue("")
+	m.watchInput.Focus()
+	m.showWatchPrompt = true
+}
+
+// closeWatchPrompt hides the watch prompt
+func (m *DashboardModel) closeWatchPrompt() {
+	m.showWatchPrompt = false
+	m.watchInput.Blur()
+}
+
+// handleWatchPromptKeys processes keyboard input for the watch prompt
+func (m *DashboardModel) handleWatchPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "escape", "esc":
+		m.closeWatchPrompt()
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.watchInput.Value())
+		if value == "" {
+			m.closeWatchPrompt()
+			return m, nil
+		}
+		if err := m.toggleWatch(value); err != nil {
+			m.watchError = err.Error()
+			return m, nil
+		}
+		m.closeWatchPrompt()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.watchInput, cmd = m.watchInput.Update(msg)
+	m.watchError = ""
+	return m, cmd
+}
+
+// renderWatchPrompt renders the watch prompt, listing the current watches
+func (m *DashboardModel) renderWatchPrompt() string {
+	modalWidth := min(m.width-8, 80)
+	contentWidth := modalWidth - 4
+
+	header := lipgloss.NewStyle().
+		Foreground(ColorBlue).
+		Bold(true).
+		Render("Watch: [NAME:] FILTER")
+
+	const label = "Watch: "
+	m.watchInput.Width = contentWidth - len(label) - 2
+	lines := []string{header, "", label + m.watchInput.View()}
+	if m.watchError != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorRed).Render(truncateToWidth(m.watchError, contentWidth)))
+	}
+	if len(m.watches) > 0 {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(ColorGray).Render("Watching:"))
+		for _, watch := range m.watches {
+			lines = append(lines, "  "+truncateToWidth(watch.name, contentWidth-2))
+		}
+	}
+	lines = append(lines, "",
+		lipgloss.NewStyle().Foreground(ColorGray).Render("Enter: Add, or remove a watch of the same name • ESC: Cancel"))
+
+	modal := lipgloss.NewStyle().
+		Width(modalWidth).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBlue).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// watchesPaneVisible reports whether the watches pane is shown
+func (m *DashboardModel) watchesPaneVisible() bool {
+	return len(m.watches) > 0 && !m.logsMaximized && !m.hiddenPanels[PanelWatches]
+}
+
+// watchesPaneHeight returns the number of lines the watches pane occupies
+func (m *DashboardModel) watchesPaneHeight() int {
+	if !m.watchesPaneVisible() {
+		return 0
+	}
+	return 1 + min(len(m.watches), maxWatchRows)
+}
+
+// renderWatchesPane renders the match count and latest match of each watch
+// above the log list. Watches that matched in the last few seconds stand
+// out.
+func (m *DashboardModel) renderWatchesPane() string {
+	width := max(m.width-2, 40)
+
+	title := fmt.Sprintf("👁 Watches (%d)", len(m.watches))
+	if len(m.watches) > maxWatchRows {
+		title += fmt.Sprintf(" • showing first %d", maxWatchRows)
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Padding(0, 1).Render(title),
+	}
+
+	shown := m.watches[:min(len(m.watches), maxWatchRows)]
+	nameWidth := 0
+	for _, watch := range shown {
+		nameWidth = max(nameWidth, min(lipgloss.Width(watch.name), maxWatchNameWidth))
+	}
+	for _, watch := range shown {
+		lines = append(lines, " "+truncateToWidth(m.formatWatch(watch, nameWidth), width))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// formatWatch renders one watch, e.g. "OOMKilled  12  last 14:02:11 (3m ago)"
+func (m *DashboardModel) formatWatch(watch *watchCounter, nameWidth int) string {
+	name := truncateToWidth(watch.name, nameWidth)
+	name += strings.Repeat(" ", max(0, nameWidth-lipgloss.Width(name)))
+	name = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(name)
+	if watch.count == 0 {
+		return name + lipgloss.NewStyle().Foreground(ColorGray).Render(fmt.Sprintf("  %8s  no matches", "0"))
+	}
+
+	countStyle := lipgloss.NewStyle().Foreground(ColorWhite)
+	if time.Since(watch.matchedAt) < watchRecent {
+		countStyle = lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
+	}
+	return fmt.Sprintf("%s  %s  last %s (%s)",
+		name,
+		countStyle.Render(fmt.Sprintf("%8s", formatCount(watch.count))),
+		m.inDisplayZone(watch.last).Format("15:04:05"),
+		formatAgo(time.Since(watch.last)))
+}
@@ -4,11 +4,27 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/control-theory/gonzo/internal/analyzer"
 	"github.com/control-theory/gonzo/internal/memory"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// SetWordAnalyzer sets the stop words, minimum length and collapsed tokens
+// the words chart counts words with
+func (m *DashboardModel) SetWordAnalyzer(ta *analyzer.TextAnalyzer) {
+	m.wordAnalyzer = ta
+}
+
+// countedWord returns the form a message word is counted as in the words
+// chart, or false when it is not counted
+func (m *DashboardModel) countedWord(word string) (string, bool) {
+	if m.wordAnalyzer != nil {
+		return m.wordAnalyzer.CountedWord(word)
+	}
+	return word, len(word) >= 3 && !m.stopWords[word]
+}
+
 // calculateWordsContentLines calculates lines needed for words chart content
 func (m *DashboardModel) calculateWordsContentLines() int {
 	// Always use a consistent minimum height regardless of data availability
@@ -50,6 +66,9 @@ func (m *DashboardModel) renderWordsChart(width, height int) string {
 		content = helpStyle.Render("No data available")
 	}
 
+	// Fit the content to the chart height chosen in the layout
+	content = clipLines(content, height-1, false)
+
 	return style.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
 }
 
@@ -105,9 +124,12 @@ func (m *DashboardModel) renderWordsContent(chartWidth int, words []*memory.Freq
 
 		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 
-		// Dynamic format string with calculated label width and count field width
-		formatStr := fmt.Sprintf("%%2d. %%-%ds %%%dd |%%s|", labelWidth, countFieldWidth)
-		line := fmt.Sprintf(formatStr, i+1, entry.Term, entry.Count, bar)
+		// Truncate long words to fit dynamic label width
+		term := padToWidth(truncateToWidth(entry.Term, labelWidth), labelWidth)
+
+		// Dynamic format string with calculated count field width
+		formatStr := fmt.Sprintf("%%2d. %%s %%%dd |%%s|", countFieldWidth)
+		line := fmt.Sprintf(formatStr, i+1, term, entry.Count, bar)
 
 		if i == selectedIdx && m.activeSection == SectionWords {
 			line = lipgloss.NewStyle().
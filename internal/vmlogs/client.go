@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/control-theory/gonzo/internal/linelimit"
 )
 
 // Client represents a Victoria Logs client for streaming logs
@@ -16,6 +18,8 @@ type Client struct {
 	User     string
 	Password string
 	Client   *http.Client
+
+	lineLimit int // See Receiver.SetMaxLineSize
 }
 
 // NewClient creates a new Victoria Logs client
@@ -66,17 +70,20 @@ func (c *Client) Tail(ctx context.Context, logsQL string, params map[string]stri
 		return errors.New("tail failed: " + resp.Status + " - " + string(b))
 	}
 
-	return scanNDJSON(resp.Body, onLine)
+	return scanNDJSON(resp.Body, c.lineLimit, onLine)
 }
 
-// scanNDJSON scans newline-delimited JSON from the response body
-func scanNDJSON(r io.Reader, onLine func(string) error) error {
+// scanNDJSON scans newline-delimited JSON from the response body, cutting
+// the lines longer than lineLimit, zero for linelimit.Default
+func scanNDJSON(r io.Reader, lineLimit int, onLine func(string) error) error {
 	scanner := bufio.NewScanner(r)
 
-	// Set larger buffer size to handle long JSON lines
-	const maxScanTokenSize = 1024 * 1024 // 1MB
-	buf := make([]byte, maxScanTokenSize)
-	scanner.Buffer(buf, maxScanTokenSize)
+	// Cut long JSON lines rather than stop at them
+	if lineLimit <= 0 {
+		lineLimit = linelimit.Default
+	}
+	linelimit.Buffer(scanner, lineLimit)
+	scanner.Split(linelimit.Split(lineLimit))
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -119,6 +126,12 @@ func NewReceiver(baseURL, user, password, query string, params map[string]string
 	}
 }
 
+// SetMaxLineSize sets the longest line sent whole; longer lines are cut and
+// marked with the size they had, see linelimit. Call it before Start.
+func (r *Receiver) SetMaxLineSize(max int) {
+	r.client.lineLimit = max
+}
+
 // Start begins streaming logs from Victoria Logs
 func (r *Receiver) Start() error {
 	go func() {
@@ -0,0 +1,269 @@
+// Package webui serves a read-only browser view of a gonzo session: the
+// buffered entries followed by a live tail over a websocket, narrowed by a
+// filter expression typed in the page. Teammates can follow the same session
+// from a browser without a shell on the machine running gonzo.
+package webui
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/control-theory/gonzo/internal/filterexpr"
+
+	"golang.org/x/net/websocket"
+)
+
+// Server limits
+const (
+	clientBuffer = 1000             // Messages queued for one browser before it is disconnected
+	maxBacklog   = 5000             // Buffered entries sent when a browser connects or changes its filter
+	writeTimeout = 10 * time.Second // Longest a browser may take to accept a message
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// item is a buffered entry: the record filters match against and its JSON
+type item struct {
+	rec  *filterexpr.Record
+	data json.RawMessage
+}
+
+// message is sent to the browser
+type message struct {
+	Type    string          `json:"type"` // reset, entry or error
+	Entry   json.RawMessage `json:"entry,omitempty"`
+	Filter  string          `json:"filter,omitempty"`
+	Matched int             `json:"matched,omitempty"` // Buffered entries matching the filter, on reset
+	Error   string          `json:"error,omitempty"`
+}
+
+// request is sent by the browser to change its filter
+type request struct {
+	Filter string `json:"filter"`
+}
+
+// client is one connected browser
+type client struct {
+	mu     sync.Mutex
+	filter *filterexpr.Expr
+	send   chan message
+}
+
+// matches reports whether an entry passes the browser's filter
+func (c *client) matches(rec *filterexpr.Record) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.filter == nil || c.filter.Match(rec)
+}
+
+// Server keeps the most recent entries and streams new ones to the browsers.
+// It is safe for concurrent use.
+type Server struct {
+	mu       sync.Mutex
+	items    []item
+	capacity int
+	clients  map[*client]struct{}
+
+	http *http.Server
+}
+
+// NewServer creates a server that keeps up to capacity entries
+func NewServer(capacity int) *Server {
+	return &Server{
+		capacity: max(1, capacity),
+		clients:  make(map[*client]struct{}),
+	}
+}
+
+// Start serves the page and its websocket on addr
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for the web UI on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(indexHTML)
+	})
+	mux.Handle("GET /ws", websocket.Server{Handshake: checkSameOrigin, Handler: s.handle})
+	s.http = &http.Server{Handler: mux, ReadHeaderTimeout: writeTimeout}
+
+	go func() {
+		if err := s.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Warning: web UI stopped: %v", err)
+		}
+	}()
+	log.Printf("Serving the web UI on http://%s/", listener.Addr())
+	return nil
+}
+
+// Stop closes the server and disconnects the browsers
+func (s *Server) Stop() {
+	if s.http != nil {
+		s.http.Close()
+	}
+}
+
+// checkSameOrigin accepts websockets opened by the page itself, so other
+// sites a teammate visits cannot read the session
+func checkSameOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := url.Parse(r.Header.Get("Origin"))
+	if err != nil || origin.Host == "" {
+		return fmt.Errorf("missing websocket origin")
+	}
+	if !strings.EqualFold(origin.Host, r.Host) {
+		return fmt.Errorf("websocket origin %s does not match host %s", origin.Host, r.Host)
+	}
+	return nil
+}
+
+// Add records an entry, given as its filter record and JSON, and sends it to
+// every browser whose filter it matches
+func (s *Server) Add(rec *filterexpr.Record, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = append(s.items, item{rec: rec, data: data})
+	if len(s.items) > s.capacity {
+		s.items = s.items[len(s.items)-s.capacity:]
+	}
+
+	for c := range s.clients {
+		if !c.matches(rec) {
+			continue
+		}
+		select {
+		case c.send <- message{Type: "entry", Entry: data}:
+		default:
+			// Drop browsers that cannot keep up rather than stalling ingestion
+			s.removeLocked(c)
+		}
+	}
+}
+
+// removeLocked disconnects a browser; s.mu must be held
+func (s *Server) removeLocked(c *client) {
+	if _, ok := s.clients[c]; !ok {
+		return
+	}
+	delete(s.clients, c)
+	close(c.send)
+}
+
+// reset applies a browser's filter and queues the buffered entries matching
+// it, under the lock so no live entry is missed or duplicated
+func (s *Server) reset(c *client, filter *filterexpr.Expr, source string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.clients[c]; !ok {
+		return
+	}
+
+	c.mu.Lock()
+	c.filter = filter
+	c.mu.Unlock()
+
+	var backlog []json.RawMessage
+	for _, it := range s.items {
+		if filter == nil || filter.Match(it.rec) {
+			backlog = append(backlog, it.data)
+		}
+	}
+	matched := len(backlog)
+	backlog = backlog[max(0, len(backlog)-maxBacklog):]
+
+	// The queue is drained by the writer, so make room by dropping what the
+	// browser has not received yet; reset replaces it anyway. The writer may
+	// take the last message first, so the receive must not block.
+drain:
+	for {
+		select {
+		case <-c.send:
+		default:
+			break drain
+		}
+	}
+	c.send <- message{Type: "reset", Filter: source, Matched: matched}
+	for _, data := range backlog {
+		select {
+		case c.send <- message{Type: "entry", Entry: data}:
+		default:
+			return
+		}
+	}
+}
+
+// sendError tells a browser its filter is invalid
+func (s *Server) sendError(c *client, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.clients[c]; !ok {
+		return
+	}
+	select {
+	case c.send <- message{Type: "error", Error: err.Error()}:
+	default:
+	}
+}
+
+// handle streams entries to one browser and applies the filters it sends
+func (s *Server) handle(ws *websocket.Conn) {
+	defer ws.Close()
+
+	// The queue holds the backlog too, so it is sized to fit it
+	c := &client{send: make(chan message, maxBacklog+clientBuffer)}
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.removeLocked(c)
+		s.mu.Unlock()
+	}()
+	s.reset(c, nil, "")
+
+	// Read filter changes until the browser goes away, then stop writing
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			s.removeLocked(c)
+			s.mu.Unlock()
+		}()
+		for {
+			var req request
+			if err := websocket.JSON.Receive(ws, &req); err != nil {
+				return
+			}
+			source := strings.TrimSpace(req.Filter)
+			var filter *filterexpr.Expr
+			if source != "" {
+				expr, err := filterexpr.Parse(source)
+				if err != nil {
+					s.sendError(c, err)
+					continue
+				}
+				filter = expr
+			}
+			s.reset(c, filter, source)
+		}
+	}()
+
+	for msg := range c.send {
+		ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := websocket.JSON.Send(ws, msg); err != nil {
+			return
+		}
+	}
+}